@@ -0,0 +1,207 @@
+package conns
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+)
+
+// AssumeRole is a single role in the chain of roles assumed prior to making API calls.
+type AssumeRole struct {
+	DurationSeconds   int
+	ExternalID        string
+	Policy            string
+	PolicyARNs        []string
+	RoleARN           string
+	SessionName       string
+	Tags              map[string]string
+	TransitiveTagKeys []string
+}
+
+// AssumeRoleWithWebIdentity is a role assumed using an OpenID Connect (OIDC) web identity token.
+// The resulting credentials are the base credentials of any assume role chain.
+type AssumeRoleWithWebIdentity struct {
+	DurationSeconds      int
+	Policy               string
+	PolicyARNs           []string
+	RoleARN              string
+	SessionName          string
+	WebIdentityToken     string
+	WebIdentityTokenFile string
+}
+
+// provider returns an SDK credentials provider that assumes the role using the specified STS client.
+func (r AssumeRole) provider(client stsiface.STSAPI) *stscreds.AssumeRoleProvider {
+	p := &stscreds.AssumeRoleProvider{
+		Client:  client,
+		RoleARN: r.RoleARN,
+	}
+
+	if r.DurationSeconds > 0 {
+		p.Duration = time.Duration(r.DurationSeconds) * time.Second
+	}
+
+	if r.ExternalID != "" {
+		p.ExternalID = aws.String(r.ExternalID)
+	}
+
+	if r.Policy != "" {
+		p.Policy = aws.String(r.Policy)
+	}
+
+	if len(r.PolicyARNs) > 0 {
+		p.PolicyArns = policyDescriptorTypes(r.PolicyARNs)
+	}
+
+	if r.SessionName != "" {
+		p.RoleSessionName = r.SessionName
+	}
+
+	for k, v := range r.Tags {
+		p.Tags = append(p.Tags, &sts.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v),
+		})
+	}
+
+	if len(r.TransitiveTagKeys) > 0 {
+		p.TransitiveTagKeys = aws.StringSlice(r.TransitiveTagKeys)
+	}
+
+	return p
+}
+
+func (r *AssumeRoleWithWebIdentity) tokenFetcher() stscreds.TokenFetcher {
+	if r.WebIdentityToken != "" {
+		return webIdentityToken(r.WebIdentityToken)
+	}
+
+	return stscreds.FetchTokenPath(r.WebIdentityTokenFile)
+}
+
+// webIdentityExpiryWindow is the time before expiry at which web identity credentials are refreshed.
+const webIdentityExpiryWindow = 1 * time.Minute
+
+// webIdentityToken is a web identity token specified directly in configuration.
+type webIdentityToken string
+
+func (t webIdentityToken) FetchToken(credentials.Context) ([]byte, error) {
+	return []byte(t), nil
+}
+
+// webIdentityRoleProvider retrieves credentials using an OIDC web identity token.
+// The SDK's stscreds.WebIdentityRoleProvider does not support session policies.
+type webIdentityRoleProvider struct {
+	credentials.Expiry
+
+	client stsiface.STSAPI
+	role   *AssumeRoleWithWebIdentity
+}
+
+func (p *webIdentityRoleProvider) Retrieve() (credentials.Value, error) {
+	token, err := p.role.tokenFetcher().FetchToken(aws.BackgroundContext())
+
+	if err != nil {
+		return credentials.Value{}, fmt.Errorf("error reading web identity token: %w", err)
+	}
+
+	sessionName := p.role.SessionName
+
+	if sessionName == "" {
+		sessionName = fmt.Sprintf("terraform-provider-aws-%d", time.Now().UnixNano())
+	}
+
+	input := &sts.AssumeRoleWithWebIdentityInput{
+		RoleArn:          aws.String(p.role.RoleARN),
+		RoleSessionName:  aws.String(sessionName),
+		WebIdentityToken: aws.String(string(token)),
+	}
+
+	if p.role.DurationSeconds > 0 {
+		input.DurationSeconds = aws.Int64(int64(p.role.DurationSeconds))
+	}
+
+	if p.role.Policy != "" {
+		input.Policy = aws.String(p.role.Policy)
+	}
+
+	if len(p.role.PolicyARNs) > 0 {
+		input.PolicyArns = policyDescriptorTypes(p.role.PolicyARNs)
+	}
+
+	output, err := p.client.AssumeRoleWithWebIdentity(input)
+
+	if err != nil {
+		return credentials.Value{}, err
+	}
+
+	if output == nil || output.Credentials == nil {
+		return credentials.Value{}, fmt.Errorf("empty result")
+	}
+
+	p.SetExpiration(aws.TimeValue(output.Credentials.Expiration), webIdentityExpiryWindow)
+
+	return credentials.Value{
+		AccessKeyID:     aws.StringValue(output.Credentials.AccessKeyId),
+		SecretAccessKey: aws.StringValue(output.Credentials.SecretAccessKey),
+		SessionToken:    aws.StringValue(output.Credentials.SessionToken),
+		ProviderName:    stscreds.WebIdentityProviderName,
+	}, nil
+}
+
+// webIdentityCredentials returns validated credentials for the role assumed with a web identity token.
+// AssumeRoleWithWebIdentity requests are not signed, so the STS client needs no credentials.
+func webIdentityCredentials(client stsiface.STSAPI, role *AssumeRoleWithWebIdentity) (*credentials.Credentials, error) {
+	log.Printf("[INFO] Attempting to AssumeRoleWithWebIdentity %s (SessionName: %q)", role.RoleARN, role.SessionName)
+
+	creds := credentials.NewCredentials(&webIdentityRoleProvider{
+		client: client,
+		role:   role,
+	})
+
+	if _, err := creds.Get(); err != nil {
+		return nil, fmt.Errorf("error assuming role (%s) with web identity: %w", role.RoleARN, err)
+	}
+
+	return creds, nil
+}
+
+// assumeRoleChainCredentials returns validated credentials for the last role in the chain.
+// Each role is assumed using the credentials of the previous role, starting with the specified credentials.
+func assumeRoleChainCredentials(sess *session.Session, stsEndpoint string, creds *credentials.Credentials, roles []AssumeRole) (*credentials.Credentials, error) {
+	for i, role := range roles {
+		log.Printf("[INFO] Attempting to AssumeRole %s (SessionName: %q, ExternalId: %q)", role.RoleARN, role.SessionName, role.ExternalID)
+
+		client := sts.New(sess.Copy(&aws.Config{
+			Credentials: creds,
+			Endpoint:    aws.String(stsEndpoint),
+		}))
+
+		creds = credentials.NewCredentials(role.provider(client))
+
+		if _, err := creds.Get(); err != nil {
+			return nil, fmt.Errorf("error assuming role %d (%s): %w", i+1, role.RoleARN, err)
+		}
+	}
+
+	return creds, nil
+}
+
+func policyDescriptorTypes(policyARNs []string) []*sts.PolicyDescriptorType {
+	var apiObjects []*sts.PolicyDescriptorType
+
+	for _, policyARN := range policyARNs {
+		apiObjects = append(apiObjects, &sts.PolicyDescriptorType{
+			Arn: aws.String(policyARN),
+		})
+	}
+
+	return apiObjects
+}
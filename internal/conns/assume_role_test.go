@@ -0,0 +1,236 @@
+package conns
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// stsStandIn is a local stand-in for the STS API that records the role assumptions made.
+// The credentials returned for a role have the role name as access key ID.
+type stsStandIn struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+var stsStandInAccessKeyIDRegexp = regexp.MustCompile(`Credential=([^/]+)/`)
+
+func (s *stsStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	action, roleARN := r.Form.Get("Action"), r.Form.Get("RoleArn")
+	signer := "unsigned"
+
+	if m := stsStandInAccessKeyIDRegexp.FindStringSubmatch(r.Header.Get("Authorization")); m != nil {
+		signer = m[1]
+	}
+
+	s.mu.Lock()
+	s.calls = append(s.calls, fmt.Sprintf("%s %s %s", action, roleARN, signer))
+	s.mu.Unlock()
+
+	switch action {
+	case "AssumeRole":
+		if r.Form.Get("ExternalId") == "denied" {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprintf(w, stsStandInErrorResponse, "AccessDenied", "not authorized to perform sts:AssumeRole")
+			return
+		}
+	case "AssumeRoleWithWebIdentity":
+		if r.Form.Get("WebIdentityToken") != "test-token" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, stsStandInErrorResponse, "InvalidIdentityToken", "invalid token")
+			return
+		}
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, stsStandInErrorResponse, "InvalidAction", action)
+		return
+	}
+
+	roleName := roleARN[strings.LastIndex(roleARN, "/")+1:]
+
+	fmt.Fprintf(w, stsStandInResponse, action, roleName, roleARN)
+}
+
+func (s *stsStandIn) Calls() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls
+}
+
+const stsStandInResponse = `<%[1]sResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <%[1]sResult>
+    <Credentials>
+      <AccessKeyId>%[2]s</AccessKeyId>
+      <SecretAccessKey>secret</SecretAccessKey>
+      <SessionToken>token</SessionToken>
+      <Expiration>2099-01-01T00:00:00Z</Expiration>
+    </Credentials>
+    <AssumedRoleUser>
+      <Arn>%[3]s</Arn>
+      <AssumedRoleId>ARO123EXAMPLE123:test</AssumedRoleId>
+    </AssumedRoleUser>
+  </%[1]sResult>
+  <ResponseMetadata>
+    <RequestId>01234567-89ab-cdef-0123-456789abcdef</RequestId>
+  </ResponseMetadata>
+</%[1]sResponse>`
+
+const stsStandInErrorResponse = `<ErrorResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <Error>
+    <Type>Sender</Type>
+    <Code>%s</Code>
+    <Message>%s</Message>
+  </Error>
+  <RequestId>01234567-89ab-cdef-0123-456789abcdef</RequestId>
+</ErrorResponse>`
+
+func TestConfigClientAssumeRole(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+
+	if err := os.WriteFile(tokenFile, []byte("test-token"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		Name                      string
+		AssumeRole                []AssumeRole
+		AssumeRoleWithWebIdentity *AssumeRoleWithWebIdentity
+		ExpectedAccessKeyID       string
+		ExpectedAccountID         string
+		ExpectedCalls             []string
+		ExpectedError             *regexp.Regexp
+	}{
+		{
+			Name: "chain",
+			AssumeRole: []AssumeRole{
+				{RoleARN: "arn:aws:iam::111111111111:role/hub", ExternalID: "hub"},                                         //lintignore:AWSAT005
+				{RoleARN: "arn:aws:iam::222222222222:role/spoke", Tags: map[string]string{"k": "v"}, SessionName: "spoke"}, //lintignore:AWSAT005
+			},
+			ExpectedAccessKeyID: "spoke",
+			ExpectedAccountID:   "222222222222",
+			ExpectedCalls: []string{
+				"AssumeRole arn:aws:iam::111111111111:role/hub base",  //lintignore:AWSAT005
+				"AssumeRole arn:aws:iam::222222222222:role/spoke hub", //lintignore:AWSAT005
+			},
+		},
+		{
+			Name: "web identity token",
+			AssumeRoleWithWebIdentity: &AssumeRoleWithWebIdentity{
+				Policy:           `{"Version":"2012-10-17","Statement":[]}`,
+				RoleARN:          "arn:aws:iam::111111111111:role/oidc", //lintignore:AWSAT005
+				WebIdentityToken: "test-token",
+			},
+			ExpectedAccessKeyID: "oidc",
+			ExpectedAccountID:   "111111111111",
+			ExpectedCalls: []string{
+				"AssumeRoleWithWebIdentity arn:aws:iam::111111111111:role/oidc unsigned", //lintignore:AWSAT005
+			},
+		},
+		{
+			Name: "web identity token file and chain",
+			AssumeRole: []AssumeRole{
+				{RoleARN: "arn:aws:iam::222222222222:role/spoke"}, //lintignore:AWSAT005
+			},
+			AssumeRoleWithWebIdentity: &AssumeRoleWithWebIdentity{
+				RoleARN:              "arn:aws:iam::111111111111:role/oidc", //lintignore:AWSAT005
+				SessionName:          "ci",
+				WebIdentityTokenFile: tokenFile,
+			},
+			ExpectedAccessKeyID: "spoke",
+			ExpectedAccountID:   "222222222222",
+			ExpectedCalls: []string{
+				"AssumeRoleWithWebIdentity arn:aws:iam::111111111111:role/oidc unsigned", //lintignore:AWSAT005
+				"AssumeRole arn:aws:iam::222222222222:role/spoke oidc",                   //lintignore:AWSAT005
+			},
+		},
+		{
+			Name: "invalid web identity token",
+			AssumeRoleWithWebIdentity: &AssumeRoleWithWebIdentity{
+				RoleARN:          "arn:aws:iam::111111111111:role/oidc", //lintignore:AWSAT005
+				WebIdentityToken: "invalid",
+			},
+			ExpectedError: regexp.MustCompile(`with web identity: InvalidIdentityToken`),
+		},
+		{
+			Name: "chain access denied",
+			AssumeRole: []AssumeRole{
+				{RoleARN: "arn:aws:iam::111111111111:role/hub"},                         //lintignore:AWSAT005
+				{RoleARN: "arn:aws:iam::222222222222:role/spoke", ExternalID: "denied"}, //lintignore:AWSAT005
+			},
+			ExpectedError: regexp.MustCompile(`error assuming role 2 \(arn:aws:iam::222222222222:role/spoke\): AccessDenied`), //lintignore:AWSAT005
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			standIn := &stsStandIn{}
+			server := httptest.NewServer(standIn)
+			defer server.Close()
+
+			config := &Config{
+				AccessKey:                 "base",
+				AssumeRole:                testCase.AssumeRole,
+				AssumeRoleWithWebIdentity: testCase.AssumeRoleWithWebIdentity,
+				Endpoints:                 map[string]string{STS: server.URL},
+				MaxRetries:                1,
+				Region:                    "us-east-1", //lintignore:AWSAT003
+				SecretKey:                 "secret",
+				SkipCredsValidation:       true,
+				SkipGetEC2Platforms:       true,
+				SkipMetadataApiCheck:      true,
+				SkipRequestingAccountId:   true,
+			}
+
+			raw, err := config.Client()
+
+			if testCase.ExpectedError != nil {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+
+				if !testCase.ExpectedError.MatchString(err.Error()) {
+					t.Fatalf("expected error matching %q, got: %s", testCase.ExpectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			client := raw.(*AWSClient)
+
+			if got, expected := client.AccountID, testCase.ExpectedAccountID; got != expected {
+				t.Errorf("got account ID %s, expected %s", got, expected)
+			}
+
+			v, err := client.STSConn.Config.Credentials.Get()
+
+			if err != nil {
+				t.Fatalf("unexpected error getting credentials: %s", err)
+			}
+
+			if got, expected := v.AccessKeyID, testCase.ExpectedAccessKeyID; got != expected {
+				t.Errorf("got access key ID %s, expected %s", got, expected)
+			}
+
+			if got, expected := standIn.Calls(), testCase.ExpectedCalls; !reflect.DeepEqual(got, expected) {
+				t.Errorf("got calls %q, expected %q", got, expected)
+			}
+		})
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"sync"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -854,14 +857,10 @@ type Config struct {
 	Region        string
 	MaxRetries    int
 
-	AssumeRoleARN               string
-	AssumeRoleDurationSeconds   int
-	AssumeRoleExternalID        string
-	AssumeRolePolicy            string
-	AssumeRolePolicyARNs        []string
-	AssumeRoleSessionName       string
-	AssumeRoleTags              map[string]string
-	AssumeRoleTransitiveTagKeys []string
+	// AssumeRole is the ordered chain of roles to assume.
+	// Each role is assumed using the credentials of the previous role.
+	AssumeRole                []AssumeRole
+	AssumeRoleWithWebIdentity *AssumeRoleWithWebIdentity
 
 	AllowedAccountIds   []string
 	ForbiddenAccountIds []string
@@ -871,6 +870,13 @@ type Config struct {
 	IgnoreTagsConfig  *tftags.IgnoreConfig
 	Insecure          bool
 	HTTPProxy         string
+	NoProxy           string
+
+	// CustomCABundle is the path of a PEM file of certificate authorities trusted in place of the system's.
+	CustomCABundle string
+
+	EC2MetadataServiceEndpoint     string
+	EC2MetadataServiceEndpointMode string
 
 	SkipCredsValidation     bool
 	SkipGetEC2Platforms     bool
@@ -879,6 +885,9 @@ type Config struct {
 	SkipMetadataApiCheck    bool
 	S3ForcePathStyle        bool
 
+	UseDualStackEndpoint bool
+	UseFIPSEndpoint      bool
+
 	TerraformVersion string
 }
 
@@ -1163,6 +1172,17 @@ type AWSClient struct {
 	WorkMailMessageFlowConn           *workmailmessageflow.WorkMailMessageFlow
 	WorkSpacesConn                    *workspaces.WorkSpaces
 	XRayConn                          *xray.XRay
+
+	config          *Config
+	regionalClients *regionalClients
+	session         *session.Session
+}
+
+// regionalClients caches the clients for the regions that resources are managed in, by region.
+// It is shared by the provider's client and all of its regional clients.
+type regionalClients struct {
+	mu      sync.Mutex
+	clients map[string]*AWSClient
 }
 
 // PartitionHostname returns a hostname with the provider domain suffix for the partition
@@ -1179,6 +1199,45 @@ func (client *AWSClient) RegionalHostname(prefix string) string {
 	return fmt.Sprintf("%s.%s.%s", prefix, client.Region, client.DNSSuffix)
 }
 
+// RegionalClient returns a client whose service connections are made to the specified region.
+// The client itself is returned if the region is empty or is the client's region.
+// Clients for other regions share the client's credentials and are created once per region.
+func (client *AWSClient) RegionalClient(region string) (*AWSClient, error) {
+	if region == "" || region == client.Region {
+		return client, nil
+	}
+
+	if client.config == nil || client.regionalClients == nil {
+		return nil, fmt.Errorf("error configuring client for region (%s): provider client not configured", region)
+	}
+
+	client.regionalClients.mu.Lock()
+	defer client.regionalClients.mu.Unlock()
+
+	if v, ok := client.regionalClients.clients[region]; ok {
+		return v, nil
+	}
+
+	config := *client.config
+	config.Region = region
+
+	if !config.SkipRegionValidation {
+		if err := awsbase.ValidateRegion(region); err != nil {
+			return nil, err
+		}
+	}
+
+	// Unlike NewSessionForRegion, copying the provider's session keeps its credentials, retry, logging and endpoint variant handlers.
+	sess := client.session.Copy(&aws.Config{Region: aws.String(region)})
+
+	v := config.awsClient(sess, client.AccountID, client.Partition)
+	v.regionalClients = client.regionalClients
+
+	client.regionalClients.clients[region] = v
+
+	return v, nil
+}
+
 // Client configures and returns a fully initialized AWSClient
 func (c *Config) Client() (interface{}, error) {
 	// Get the auth and region. This can fail if keys/regions were not
@@ -1189,33 +1248,100 @@ func (c *Config) Client() (interface{}, error) {
 		}
 	}
 
+	// The credential and account ID lookups made while configuring the session use the same endpoint variant as all other requests.
+	iamEndpoint, stsEndpoint := c.credentialEndpoint(IAM, iam.EndpointsID), c.credentialEndpoint(STS, sts.EndpointsID)
+
 	awsbaseConfig := &awsbase.Config{
-		AccessKey:                   c.AccessKey,
-		AssumeRoleARN:               c.AssumeRoleARN,
-		AssumeRoleDurationSeconds:   c.AssumeRoleDurationSeconds,
-		AssumeRoleExternalID:        c.AssumeRoleExternalID,
-		AssumeRolePolicy:            c.AssumeRolePolicy,
-		AssumeRolePolicyARNs:        c.AssumeRolePolicyARNs,
-		AssumeRoleSessionName:       c.AssumeRoleSessionName,
-		AssumeRoleTags:              c.AssumeRoleTags,
-		AssumeRoleTransitiveTagKeys: c.AssumeRoleTransitiveTagKeys,
-		CallerDocumentationURL:      "https://registry.terraform.io/providers/hashicorp/aws",
-		CallerName:                  "Terraform AWS Provider",
-		CredsFilename:               c.CredsFilename,
-		DebugLogging:                logging.IsDebugOrHigher(),
-		IamEndpoint:                 c.Endpoints[IAM],
-		Insecure:                    c.Insecure,
-		HTTPProxy:                   c.HTTPProxy,
-		MaxRetries:                  c.MaxRetries,
-		Profile:                     c.Profile,
-		Region:                      c.Region,
-		SecretKey:                   c.SecretKey,
-		SkipCredsValidation:         c.SkipCredsValidation,
-		SkipMetadataApiCheck:        c.SkipMetadataApiCheck,
-		SkipRequestingAccountId:     c.SkipRequestingAccountId,
-		StsEndpoint:                 c.Endpoints[STS],
-		Token:                       c.Token,
-		UserAgentProducts:           StdUserAgentProducts(c.TerraformVersion),
+		AccessKey:               c.AccessKey,
+		CallerDocumentationURL:  "https://registry.terraform.io/providers/hashicorp/aws",
+		CallerName:              "Terraform AWS Provider",
+		CredsFilename:           c.CredsFilename,
+		DebugLogging:            logging.IsDebugOrHigher(),
+		IamEndpoint:             iamEndpoint,
+		Insecure:                c.Insecure,
+		HTTPProxy:               c.HTTPProxy,
+		MaxRetries:              c.MaxRetries,
+		Profile:                 c.Profile,
+		Region:                  c.Region,
+		SecretKey:               c.SecretKey,
+		SkipCredsValidation:     c.SkipCredsValidation,
+		SkipMetadataApiCheck:    c.SkipMetadataApiCheck,
+		SkipRequestingAccountId: c.SkipRequestingAccountId,
+		StsEndpoint:             stsEndpoint,
+		Token:                   c.Token,
+		UserAgentProducts:       StdUserAgentProducts(c.TerraformVersion),
+	}
+
+	// The custom CA bundle and EC2 metadata service endpoint are passed to awsbase's sessions through the environment.
+	sdkEnv, err := c.sdkEnvironment()
+
+	if err != nil {
+		return nil, fmt.Errorf("error configuring Terraform AWS Provider: %w", err)
+	}
+
+	restoreEnv, err := setEnvironment(sdkEnv)
+
+	if err != nil {
+		return nil, fmt.Errorf("error configuring Terraform AWS Provider: %w", err)
+	}
+
+	defer restoreEnv()
+
+	// A single role assumed using the base credentials is handled by the session library.
+	// Web identity and chained role credentials are obtained here.
+	chainRoles := c.AssumeRole
+
+	if c.AssumeRoleWithWebIdentity == nil && len(chainRoles) == 1 {
+		role := chainRoles[0]
+		chainRoles = nil
+
+		awsbaseConfig.AssumeRoleARN = role.RoleARN
+		awsbaseConfig.AssumeRoleDurationSeconds = role.DurationSeconds
+		awsbaseConfig.AssumeRoleExternalID = role.ExternalID
+		awsbaseConfig.AssumeRolePolicy = role.Policy
+		awsbaseConfig.AssumeRolePolicyARNs = role.PolicyARNs
+		awsbaseConfig.AssumeRoleSessionName = role.SessionName
+		awsbaseConfig.AssumeRoleTags = role.Tags
+		awsbaseConfig.AssumeRoleTransitiveTagKeys = role.TransitiveTagKeys
+	}
+
+	var webIdentityCreds *credentials.Credentials
+
+	if c.AssumeRoleWithWebIdentity != nil {
+		httpClient, err := c.httpClient()
+
+		if err != nil {
+			return nil, fmt.Errorf("error configuring Terraform AWS Provider: %w", err)
+		}
+
+		webIdentitySess, err := session.NewSession(&aws.Config{
+			Credentials: credentials.AnonymousCredentials,
+			Endpoint:    aws.String(stsEndpoint),
+			HTTPClient:  httpClient,
+			MaxRetries:  aws.Int(c.MaxRetries),
+			Region:      aws.String(c.Region),
+		})
+
+		if err != nil {
+			return nil, fmt.Errorf("error configuring Terraform AWS Provider: error creating web identity session: %w", err)
+		}
+
+		webIdentityCreds, err = webIdentityCredentials(sts.New(webIdentitySess), c.AssumeRoleWithWebIdentity)
+
+		if err != nil {
+			return nil, fmt.Errorf("error configuring Terraform AWS Provider: %w", err)
+		}
+
+		// The session library only accepts static base credentials; the refreshing credentials replace them below.
+		v, err := webIdentityCreds.Get()
+
+		if err != nil {
+			return nil, fmt.Errorf("error configuring Terraform AWS Provider: %w", err)
+		}
+
+		awsbaseConfig.AccessKey = v.AccessKeyID
+		awsbaseConfig.SecretKey = v.SecretAccessKey
+		awsbaseConfig.Token = v.SessionToken
 	}
 
 	sess, accountID, Partition, err := awsbase.GetSessionWithAccountIDAndPartition(awsbaseConfig)
@@ -1223,6 +1349,37 @@ func (c *Config) Client() (interface{}, error) {
 		return nil, fmt.Errorf("error configuring Terraform AWS Provider: %w", err)
 	}
 
+	if err := c.configureNoProxy(sess.Config.HTTPClient); err != nil {
+		return nil, fmt.Errorf("error configuring Terraform AWS Provider: %w", err)
+	}
+
+	if webIdentityCreds != nil || len(chainRoles) > 0 {
+		creds := webIdentityCreds
+		roleARN := ""
+
+		if creds == nil {
+			creds = sess.Config.Credentials
+		} else {
+			roleARN = c.AssumeRoleWithWebIdentity.RoleARN
+		}
+
+		creds, err = assumeRoleChainCredentials(sess, stsEndpoint, creds, chainRoles)
+
+		if err != nil {
+			return nil, fmt.Errorf("error configuring Terraform AWS Provider: %w", err)
+		}
+
+		if n := len(chainRoles); n > 0 {
+			roleARN = chainRoles[n-1].RoleARN
+		}
+
+		sess = sess.Copy(&aws.Config{Credentials: creds})
+
+		if v, err := arn.Parse(roleARN); err == nil {
+			accountID, Partition = v.AccountID, v.Partition
+		}
+	}
+
 	if accountID == "" {
 		log.Printf("[WARN] AWS account ID not found for provider. See https://www.terraform.io/docs/providers/aws/index.html#skip_requesting_account_id for implications.")
 	}
@@ -1231,12 +1388,31 @@ func (c *Config) Client() (interface{}, error) {
 		return nil, err
 	}
 
+	if c.UseFIPSEndpoint || c.UseDualStackEndpoint {
+		sess = sess.Copy(c.endpointVariantConfig())
+		sess.Handlers.Validate.PushFrontNamed(c.endpointVariantValidationHandler())
+	}
+
+	client := c.awsClient(sess, accountID, Partition)
+	client.regionalClients = &regionalClients{
+		clients: map[string]*AWSClient{c.Region: client},
+	}
+
+	return client, nil
+}
+
+// awsClient returns a client whose service connections use the specified session.
+func (c *Config) awsClient(sess *session.Session, accountID, Partition string) *AWSClient {
+	// The DNS suffix is that of the partition's standard endpoints, regardless of the endpoint variant,
+	// as it is used to construct the hostnames of resources (see PartitionHostname and RegionalHostname).
 	DNSSuffix := "amazonaws.com"
 	if p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), c.Region); ok {
 		DNSSuffix = p.DNSSuffix()
 	}
 
 	client := &AWSClient{
+		config:                            c,
+		session:                           sess,
 		AccessAnalyzerConn:                accessanalyzer.New(sess.Copy(&aws.Config{Endpoint: aws.String(c.Endpoints[AccessAnalyzer])})),
 		AccountConn:                       account.New(sess.Copy(&aws.Config{Endpoint: aws.String(c.Endpoints[Account])})),
 		AccountID:                         accountID,
@@ -1355,7 +1531,7 @@ func (c *Config) Client() (interface{}, error) {
 		HealthConn:                        health.New(sess.Copy(&aws.Config{Endpoint: aws.String(c.Endpoints[Health])})),
 		HealthLakeConn:                    healthlake.New(sess.Copy(&aws.Config{Endpoint: aws.String(c.Endpoints[HealthLake])})),
 		HoneycodeConn:                     honeycode.New(sess.Copy(&aws.Config{Endpoint: aws.String(c.Endpoints[Honeycode])})),
-		IAMConn:                           iam.New(sess.Copy(&aws.Config{Endpoint: aws.String(c.Endpoints[IAM])})),
+		IAMConn:                           iam.New(sess.Copy(&aws.Config{Endpoint: aws.String(c.credentialEndpoint(IAM, iam.EndpointsID))})),
 		IdentityStoreConn:                 identitystore.New(sess.Copy(&aws.Config{Endpoint: aws.String(c.Endpoints[IdentityStore])})),
 		IgnoreTagsConfig:                  c.IgnoreTagsConfig,
 		ImageBuilderConn:                  imagebuilder.New(sess.Copy(&aws.Config{Endpoint: aws.String(c.Endpoints[ImageBuilder])})),
@@ -1488,7 +1664,7 @@ func (c *Config) Client() (interface{}, error) {
 		SSOConn:                           sso.New(sess.Copy(&aws.Config{Endpoint: aws.String(c.Endpoints[SSO])})),
 		SSOOIDCConn:                       ssooidc.New(sess.Copy(&aws.Config{Endpoint: aws.String(c.Endpoints[SSOOIDC])})),
 		StorageGatewayConn:                storagegateway.New(sess.Copy(&aws.Config{Endpoint: aws.String(c.Endpoints[StorageGateway])})),
-		STSConn:                           sts.New(sess.Copy(&aws.Config{Endpoint: aws.String(c.Endpoints[STS])})),
+		STSConn:                           sts.New(sess.Copy(&aws.Config{Endpoint: aws.String(c.credentialEndpoint(STS, sts.EndpointsID))})),
 		SupportConn:                       support.New(sess.Copy(&aws.Config{Endpoint: aws.String(c.Endpoints[Support])})),
 		SWFConn:                           swf.New(sess.Copy(&aws.Config{Endpoint: aws.String(c.Endpoints[SWF])})),
 		SyntheticsConn:                    synthetics.New(sess.Copy(&aws.Config{Endpoint: aws.String(c.Endpoints[Synthetics])})),
@@ -1641,7 +1817,7 @@ func (c *Config) Client() (interface{}, error) {
 			}
 		case "DeleteOrganizationConformancePack", "DescribeOrganizationConformancePacks", "DescribeOrganizationConformancePackStatuses", "PutOrganizationConformancePack":
 			if !tfawserr.ErrCodeEquals(r.Error, configservice.ErrCodeOrganizationAccessDeniedException) {
-				if r.Operation.Name == "DeleteOrganizationConformancePack" && tfawserr.ErrCodeEquals(err, configservice.ErrCodeResourceInUseException) {
+				if r.Operation.Name == "DeleteOrganizationConformancePack" && tfawserr.ErrCodeEquals(r.Error, configservice.ErrCodeResourceInUseException) {
 					r.Retryable = aws.Bool(true)
 				}
 				return
@@ -1792,7 +1968,7 @@ func (c *Config) Client() (interface{}, error) {
 			if tfawserr.ErrMessageContains(r.Error, wafv2.ErrCodeWAFTagOperationException, "Retry your request") {
 				r.Retryable = aws.Bool(true)
 			}
-			if tfawserr.ErrMessageContains(err, wafv2.ErrCodeWAFTagOperationInternalErrorException, "Retry your request") {
+			if tfawserr.ErrMessageContains(r.Error, wafv2.ErrCodeWAFTagOperationInternalErrorException, "Retry your request") {
 				r.Retryable = aws.Bool(true)
 			}
 		}
@@ -1809,7 +1985,7 @@ func (c *Config) Client() (interface{}, error) {
 		}
 	}
 
-	return client, nil
+	return client
 }
 
 func StdUserAgentProducts(terraformVersion string) []*awsbase.UserAgentProduct {
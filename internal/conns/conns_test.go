@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	awsbase "github.com/hashicorp/aws-sdk-go-base"
 )
@@ -82,6 +83,84 @@ func TestAWSClientRegionalHostname(t *testing.T) {
 	}
 }
 
+func TestAWSClientRegionalClient(t *testing.T) {
+	config := &Config{
+		AccessKey:               "test",
+		MaxRetries:              1,
+		Region:                  "us-west-2", //lintignore:AWSAT003
+		SecretKey:               "test",
+		SkipCredsValidation:     true,
+		SkipGetEC2Platforms:     true,
+		SkipMetadataApiCheck:    true,
+		SkipRequestingAccountId: true,
+	}
+
+	raw, err := config.Client()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	client := raw.(*AWSClient)
+
+	for _, region := range []string{"", "us-west-2"} { //lintignore:AWSAT003
+		got, err := client.RegionalClient(region)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got != client {
+			t.Errorf("region %q: got new client, expected provider client", region)
+		}
+	}
+
+	regional, err := client.RegionalClient("eu-west-1") //lintignore:AWSAT003
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, expected := regional.Region, "eu-west-1"; got != expected { //lintignore:AWSAT003
+		t.Errorf("got region %s, expected %s", got, expected)
+	}
+
+	if got, expected := aws.StringValue(regional.EC2Conn.Config.Region), "eu-west-1"; got != expected { //lintignore:AWSAT003
+		t.Errorf("got EC2 connection region %s, expected %s", got, expected)
+	}
+
+	if got, expected := regional.RegionalHostname("test"), "test.eu-west-1.amazonaws.com"; got != expected { //lintignore:AWSAT003
+		t.Errorf("got regional hostname %s, expected %s", got, expected)
+	}
+
+	if got, expected := aws.StringValue(client.EC2Conn.Config.Region), "us-west-2"; got != expected { //lintignore:AWSAT003
+		t.Errorf("got provider EC2 connection region %s, expected %s", got, expected)
+	}
+
+	// Clients are created once per region, whichever client they are requested from.
+	for _, c := range []*AWSClient{client, regional} {
+		got, err := c.RegionalClient("eu-west-1") //lintignore:AWSAT003
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got != regional {
+			t.Errorf("got new client, expected cached client")
+		}
+	}
+
+	if got, err := regional.RegionalClient("us-west-2"); err != nil { //lintignore:AWSAT003
+		t.Fatalf("unexpected error: %s", err)
+	} else if got != client {
+		t.Errorf("got new client, expected provider client")
+	}
+
+	if _, err := client.RegionalClient("not-a-region"); err == nil {
+		t.Errorf("expected error for invalid region, got none")
+	}
+}
+
 func TestGetSupportedEC2Platforms(t *testing.T) {
 	ec2Endpoints := []*awsbase.MockEndpoint{
 		{
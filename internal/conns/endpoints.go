@@ -0,0 +1,140 @@
+package conns
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+const (
+	// ErrCodeEndpointVariantNotFound is returned for requests to a service that has no endpoint of the
+	// configured FIPS and/or dual-stack variant in the request's region.
+	ErrCodeEndpointVariantNotFound = "EndpointVariantNotFound"
+
+	endpointVariantHandlerName = "terraform-provider-aws/ValidateEndpointVariant"
+)
+
+// endpointVariantConfig returns the SDK configuration that selects the FIPS and/or dual-stack endpoint variants.
+// When a flag is not set the SDK's own configuration (environment variables or shared configuration file) applies.
+func (c *Config) endpointVariantConfig() *aws.Config {
+	config := &aws.Config{}
+
+	if c.UseFIPSEndpoint {
+		config.UseFIPSEndpoint = endpoints.FIPSEndpointStateEnabled
+	}
+
+	if c.UseDualStackEndpoint {
+		config.UseDualStackEndpoint = endpoints.DualStackEndpointStateEnabled
+	}
+
+	return config
+}
+
+func (c *Config) endpointVariantOptions(o *endpoints.Options) {
+	if c.UseFIPSEndpoint {
+		o.UseFIPSEndpoint = endpoints.FIPSEndpointStateEnabled
+	}
+
+	if c.UseDualStackEndpoint {
+		o.UseDualStackEndpoint = endpoints.DualStackEndpointStateEnabled
+	}
+}
+
+func (c *Config) endpointVariantName() string {
+	switch {
+	case c.UseFIPSEndpoint && c.UseDualStackEndpoint:
+		return "FIPS and dual-stack"
+	case c.UseFIPSEndpoint:
+		return "FIPS"
+	default:
+		return "dual-stack"
+	}
+}
+
+// ResolveEndpointVariant returns the URL of the configured endpoint variant of a service in a region.
+// Unlike the SDK's default resolution, which constructs a hostname from the partition's variant defaults,
+// an error is returned if the endpoints model does not list the variant for the service and region.
+// ok is false if the service and region are not listed in the endpoints model at all.
+func (c *Config) ResolveEndpointVariant(endpointsID, region string) (url string, ok bool, err error) {
+	resolver := endpoints.DefaultResolver()
+
+	// Only services and regions known to the endpoints model can be checked for variants.
+	if _, err := resolver.EndpointFor(endpointsID, region, endpoints.StrictMatchingOption); err != nil {
+		return "", false, nil
+	}
+
+	resolved, err := resolver.EndpointFor(endpointsID, region, endpoints.StrictMatchingOption, c.endpointVariantOptions)
+
+	if err != nil {
+		return "", true, fmt.Errorf("%s has no %s endpoint in region %s; use the provider endpoints configuration block to specify one", endpointsID, c.endpointVariantName(), region)
+	}
+
+	return resolved.URL, true, nil
+}
+
+// credentialEndpoint returns the endpoint of IAM or STS, which are used to resolve credentials and the account ID:
+// the configured endpoint, else the configured endpoint variant, else the standard endpoint, so that the provider
+// can be configured in regions where the service has no endpoint of the configured variant.
+// An empty string is returned if the SDK's default endpoint resolution applies.
+func (c *Config) credentialEndpoint(name, endpointsID string) string {
+	if v := c.Endpoints[name]; v != "" {
+		return v
+	}
+
+	if !c.UseFIPSEndpoint && !c.UseDualStackEndpoint {
+		return ""
+	}
+
+	url, ok, err := c.ResolveEndpointVariant(endpointsID, c.Region)
+
+	if !ok {
+		return ""
+	}
+
+	if err == nil {
+		return url
+	}
+
+	resolved, err := endpoints.DefaultResolver().EndpointFor(endpointsID, c.Region, endpoints.StrictMatchingOption)
+
+	if err != nil {
+		return ""
+	}
+
+	log.Printf("[WARN] %s has no %s endpoint in region %s, using %s", endpointsID, c.endpointVariantName(), c.Region, resolved.URL)
+
+	return resolved.URL
+}
+
+// endpointVariantValidationHandler fails requests to services whose endpoint variant could not be resolved.
+// Requests to explicitly configured endpoints are not checked.
+func (c *Config) endpointVariantValidationHandler() request.NamedHandler {
+	endpointsIDs := make(map[string]string, len(serviceData))
+
+	for _, v := range serviceData {
+		endpointsIDs[v.AWSServiceID] = v.AWSEndpointsID
+	}
+
+	return request.NamedHandler{
+		Name: endpointVariantHandlerName,
+		Fn: func(r *request.Request) {
+			if aws.StringValue(r.Config.Endpoint) != "" {
+				return
+			}
+
+			endpointsID, ok := endpointsIDs[r.ClientInfo.ServiceID]
+
+			if !ok {
+				return
+			}
+
+			if _, _, err := c.ResolveEndpointVariant(endpointsID, aws.StringValue(r.Config.Region)); err != nil {
+				r.Error = awserr.New(ErrCodeEndpointVariantNotFound, err.Error(), nil)
+			}
+		},
+	}
+}
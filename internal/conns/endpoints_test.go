@@ -0,0 +1,205 @@
+package conns
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/amplify"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+)
+
+func TestConfigResolveEndpointVariant(t *testing.T) {
+	testCases := []struct {
+		Name                 string
+		UseDualStackEndpoint bool
+		UseFIPSEndpoint      bool
+		EndpointsID          string
+		Region               string
+		ExpectedURL          string
+		ExpectedOK           bool
+		ExpectedError        bool
+	}{
+		{
+			Name:            "FIPS",
+			UseFIPSEndpoint: true,
+			EndpointsID:     "ec2",
+			Region:          "us-east-1",                                //lintignore:AWSAT003
+			ExpectedURL:     "https://ec2-fips.us-east-1.amazonaws.com", //lintignore:AWSAT003
+			ExpectedOK:      true,
+		},
+		{
+			Name:                 "dual-stack",
+			UseDualStackEndpoint: true,
+			EndpointsID:          "s3",
+			Region:               "us-east-1",                                    //lintignore:AWSAT003
+			ExpectedURL:          "https://s3.dualstack.us-east-1.amazonaws.com", //lintignore:AWSAT003
+			ExpectedOK:           true,
+		},
+		{
+			Name:                 "FIPS and dual-stack",
+			UseDualStackEndpoint: true,
+			UseFIPSEndpoint:      true,
+			EndpointsID:          "s3",
+			Region:               "us-east-1",                                         //lintignore:AWSAT003
+			ExpectedURL:          "https://s3-fips.dualstack.us-east-1.amazonaws.com", //lintignore:AWSAT003
+			ExpectedOK:           true,
+		},
+		{
+			Name:            "no FIPS variant",
+			UseFIPSEndpoint: true,
+			EndpointsID:     "amplify",
+			Region:          "us-east-1", //lintignore:AWSAT003
+			ExpectedOK:      true,
+			ExpectedError:   true,
+		},
+		{
+			Name:            "unknown region",
+			UseFIPSEndpoint: true,
+			EndpointsID:     "ec2",
+			Region:          "xx-test-1",
+		},
+		{
+			Name:            "unknown service",
+			UseFIPSEndpoint: true,
+			EndpointsID:     "test",
+			Region:          "us-east-1", //lintignore:AWSAT003
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			config := &Config{
+				UseDualStackEndpoint: testCase.UseDualStackEndpoint,
+				UseFIPSEndpoint:      testCase.UseFIPSEndpoint,
+			}
+
+			url, ok, err := config.ResolveEndpointVariant(testCase.EndpointsID, testCase.Region)
+
+			if testCase.ExpectedError && err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if !testCase.ExpectedError && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if ok != testCase.ExpectedOK {
+				t.Errorf("got ok %t, expected %t", ok, testCase.ExpectedOK)
+			}
+
+			if url != testCase.ExpectedURL {
+				t.Errorf("got %s, expected %s", url, testCase.ExpectedURL)
+			}
+		})
+	}
+}
+
+func TestConfigEndpointVariantValidationHandler(t *testing.T) {
+	config := &Config{
+		UseFIPSEndpoint: true,
+	}
+
+	sess, err := session.NewSession(&aws.Config{
+		Credentials: credentials.AnonymousCredentials,
+		Region:      aws.String("us-east-1"), //lintignore:AWSAT003
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	sess = sess.Copy(config.endpointVariantConfig())
+	sess.Handlers.Validate.PushFrontNamed(config.endpointVariantValidationHandler())
+
+	// Requests are built, but not sent.
+	ec2Request, _ := ec2.New(sess).DescribeRegionsRequest(&ec2.DescribeRegionsInput{})
+
+	if err := ec2Request.Build(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	if got, expected := ec2Request.HTTPRequest.URL.Host, "ec2-fips.us-east-1.amazonaws.com"; got != expected { //lintignore:AWSAT003
+		t.Errorf("got host %s, expected %s", got, expected)
+	}
+
+	amplifyRequest, _ := amplify.New(sess).ListAppsRequest(&amplify.ListAppsInput{})
+
+	if err := amplifyRequest.Build(); !tfawserr.ErrCodeEquals(err, ErrCodeEndpointVariantNotFound) {
+		t.Errorf("expected %s error, got %v", ErrCodeEndpointVariantNotFound, err)
+	}
+
+	amplifyRequest, _ = amplify.New(sess, &aws.Config{Endpoint: aws.String("https://amplify.example.com")}).ListAppsRequest(&amplify.ListAppsInput{})
+
+	if err := amplifyRequest.Build(); err != nil {
+		t.Errorf("unexpected error with explicit endpoint: %s", err)
+	}
+}
+
+func TestConfigClientCredentialEndpointFallback(t *testing.T) {
+	testCases := []struct {
+		Name                 string
+		UseDualStackEndpoint bool
+		UseFIPSEndpoint      bool
+		Region               string
+		ExpectedSTSEndpoint  string
+	}{
+		{
+			Name:                 "dual-stack",
+			UseDualStackEndpoint: true,
+			Region:               "us-east-1", //lintignore:AWSAT003
+			ExpectedSTSEndpoint:  "https://sts.amazonaws.com",
+		},
+		{
+			Name:                "no FIPS variant",
+			UseFIPSEndpoint:     true,
+			Region:              "eu-west-1", //lintignore:AWSAT003
+			ExpectedSTSEndpoint: "https://sts.amazonaws.com",
+		},
+		{
+			Name:                "FIPS",
+			UseFIPSEndpoint:     true,
+			Region:              "us-east-1",                                //lintignore:AWSAT003
+			ExpectedSTSEndpoint: "https://sts-fips.us-east-1.amazonaws.com", //lintignore:AWSAT003
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			config := &Config{
+				AccessKey:               "test",
+				MaxRetries:              1,
+				Region:                  testCase.Region,
+				SecretKey:               "test",
+				SkipCredsValidation:     true,
+				SkipGetEC2Platforms:     true,
+				SkipMetadataApiCheck:    true,
+				SkipRequestingAccountId: true,
+				UseDualStackEndpoint:    testCase.UseDualStackEndpoint,
+				UseFIPSEndpoint:         testCase.UseFIPSEndpoint,
+			}
+
+			raw, err := config.Client()
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			client := raw.(*AWSClient)
+
+			if got := client.STSConn.Endpoint; got != testCase.ExpectedSTSEndpoint {
+				t.Errorf("got STS endpoint %s, expected %s", got, testCase.ExpectedSTSEndpoint)
+			}
+
+			// Requests are built, but not sent.
+			request, _ := client.STSConn.GetCallerIdentityRequest(&sts.GetCallerIdentityInput{})
+
+			if err := request.Build(); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
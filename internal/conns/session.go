@@ -0,0 +1,141 @@
+package conns
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/hashicorp/go-cleanhttp"
+	homedir "github.com/mitchellh/go-homedir"
+	"golang.org/x/net/http/httpproxy"
+)
+
+const (
+	EC2MetadataServiceEndpointModeIPv4 = "IPv4"
+	EC2MetadataServiceEndpointModeIPv6 = "IPv6"
+)
+
+func EC2MetadataServiceEndpointMode_Values() []string {
+	return []string{
+		EC2MetadataServiceEndpointModeIPv4,
+		EC2MetadataServiceEndpointModeIPv6,
+	}
+}
+
+// sdkEnvironment returns the AWS Go SDK environment variables that apply the provider's custom CA bundle
+// and EC2 metadata service endpoint to every session created while configuring the provider, including
+// those created by awsbase to obtain credentials and the account ID.
+func (c *Config) sdkEnvironment() (map[string]string, error) {
+	env := make(map[string]string)
+
+	if c.CustomCABundle != "" {
+		filename, err := homedir.Expand(c.CustomCABundle)
+
+		if err != nil {
+			return nil, fmt.Errorf("error expanding custom CA bundle filename: %w", err)
+		}
+
+		env["AWS_CA_BUNDLE"] = filename
+	}
+
+	if c.EC2MetadataServiceEndpoint != "" {
+		env["AWS_EC2_METADATA_SERVICE_ENDPOINT"] = c.EC2MetadataServiceEndpoint
+	}
+
+	if c.EC2MetadataServiceEndpointMode != "" {
+		env["AWS_EC2_METADATA_SERVICE_ENDPOINT_MODE"] = c.EC2MetadataServiceEndpointMode
+	}
+
+	return env, nil
+}
+
+// setEnvironment sets the environment variables and returns a function that restores their previous values.
+func setEnvironment(env map[string]string) (func(), error) {
+	previous := make(map[string]*string, len(env))
+
+	restore := func() {
+		for k, v := range previous {
+			if v == nil {
+				os.Unsetenv(k)
+			} else {
+				os.Setenv(k, *v)
+			}
+		}
+	}
+
+	for k, v := range env {
+		if old, ok := os.LookupEnv(k); ok {
+			previous[k] = &old
+		} else {
+			previous[k] = nil
+		}
+
+		if err := os.Setenv(k, v); err != nil {
+			restore()
+
+			return nil, err
+		}
+	}
+
+	return restore, nil
+}
+
+// httpClient returns an HTTP client configured as the session library configures that of the provider's session.
+// It is used for requests made before that session is available.
+func (c *Config) httpClient() (*http.Client, error) {
+	client := cleanhttp.DefaultClient()
+	transport := client.Transport.(*http.Transport)
+
+	if c.Insecure {
+		transport.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true,
+		}
+	}
+
+	if c.HTTPProxy != "" {
+		proxyURL, err := url.Parse(c.HTTPProxy)
+
+		if err != nil {
+			return nil, fmt.Errorf("error parsing HTTP proxy URL: %w", err)
+		}
+
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if err := c.configureNoProxy(client); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// configureNoProxy excludes the hosts listed in NoProxy from the HTTP client's proxy.
+// The session library only supports a single proxy for all hosts, so it is applied to the session's HTTP client afterwards.
+func (c *Config) configureNoProxy(client *http.Client) error {
+	if c.NoProxy == "" {
+		return nil
+	}
+
+	transport, ok := client.Transport.(*http.Transport)
+
+	if !ok {
+		return fmt.Errorf("error configuring no_proxy: unsupported HTTP transport (%T)", client.Transport)
+	}
+
+	proxyConfig := httpproxy.FromEnvironment()
+	proxyConfig.NoProxy = c.NoProxy
+
+	if c.HTTPProxy != "" {
+		proxyConfig.HTTPProxy = c.HTTPProxy
+		proxyConfig.HTTPSProxy = c.HTTPProxy
+	}
+
+	proxyFunc := proxyConfig.ProxyFunc()
+	transport.Proxy = func(r *http.Request) (*url.URL, error) {
+		return proxyFunc(r.URL)
+	}
+
+	return nil
+}
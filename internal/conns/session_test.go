@@ -0,0 +1,304 @@
+package conns
+
+import (
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestConfigHTTPClientProxy(t *testing.T) {
+	t.Setenv("HTTP_PROXY", "")
+	t.Setenv("HTTPS_PROXY", "")
+	t.Setenv("NO_PROXY", "")
+
+	config := &Config{
+		AccessKey:               "base",
+		HTTPProxy:               "http://proxy.example.com:3128",
+		MaxRetries:              1,
+		NoProxy:                 "internal.example.com,.vpce.amazonaws.com",
+		Region:                  "us-east-1", //lintignore:AWSAT003
+		SecretKey:               "secret",
+		SkipCredsValidation:     true,
+		SkipGetEC2Platforms:     true,
+		SkipMetadataApiCheck:    true,
+		SkipRequestingAccountId: true,
+	}
+
+	httpClient, err := config.httpClient()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	raw, err := config.Client()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	clients := map[string]*http.Client{
+		"web identity": httpClient,
+		"session":      raw.(*AWSClient).STSConn.Config.HTTPClient,
+	}
+
+	testCases := []struct {
+		URL      string
+		Expected string
+	}{
+		{
+			URL:      "https://sts.amazonaws.com/",
+			Expected: "http://proxy.example.com:3128",
+		},
+		{
+			URL: "https://internal.example.com/",
+		},
+		{
+			URL: "https://vpce-0123456789abcdef0.sts.vpce.amazonaws.com/",
+		},
+	}
+
+	for name, client := range clients {
+		for _, testCase := range testCases {
+			t.Run(name+" "+testCase.URL, func(t *testing.T) {
+				req, err := http.NewRequest(http.MethodGet, testCase.URL, nil)
+
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				proxyURL, err := client.Transport.(*http.Transport).Proxy(req)
+
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				got := ""
+
+				if proxyURL != nil {
+					got = proxyURL.String()
+				}
+
+				if got != testCase.Expected {
+					t.Errorf("got proxy %q, expected %q", got, testCase.Expected)
+				}
+			})
+		}
+	}
+}
+
+func TestConfigClientCustomCABundle(t *testing.T) {
+	t.Setenv("AWS_CA_BUNDLE", "")
+
+	server := httptest.NewTLSServer(&stsStandIn{})
+	defer server.Close()
+
+	bundle := filepath.Join(t.TempDir(), "ca-bundle.pem")
+
+	if err := os.WriteFile(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		Name           string
+		CustomCABundle string
+		ExpectedError  *regexp.Regexp
+	}{
+		{
+			Name:          "system certificate authorities",
+			ExpectedError: regexp.MustCompile(`cannot be assumed`),
+		},
+		{
+			Name:           "custom CA bundle",
+			CustomCABundle: bundle,
+		},
+		{
+			Name:           "missing custom CA bundle",
+			CustomCABundle: filepath.Join(t.TempDir(), "missing.pem"),
+			ExpectedError:  regexp.MustCompile(`custom CA bundle`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			config := &Config{
+				AccessKey: "base",
+				AssumeRole: []AssumeRole{
+					{RoleARN: "arn:aws:iam::111111111111:role/test"}, //lintignore:AWSAT005
+				},
+				CustomCABundle:          testCase.CustomCABundle,
+				Endpoints:               map[string]string{STS: server.URL},
+				MaxRetries:              1,
+				Region:                  "us-east-1", //lintignore:AWSAT003
+				SecretKey:               "secret",
+				SkipCredsValidation:     true,
+				SkipGetEC2Platforms:     true,
+				SkipMetadataApiCheck:    true,
+				SkipRequestingAccountId: true,
+			}
+
+			_, err := config.Client()
+
+			if got := os.Getenv("AWS_CA_BUNDLE"); got != "" {
+				t.Errorf("AWS_CA_BUNDLE not restored, got %q", got)
+			}
+
+			if testCase.ExpectedError != nil {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+
+				if !testCase.ExpectedError.MatchString(err.Error()) {
+					t.Fatalf("expected error matching %q, got: %s", testCase.ExpectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestConfigClientCustomCABundleContainerCredentials(t *testing.T) {
+	for _, k := range []string{
+		"AWS_ACCESS_KEY_ID",
+		"AWS_ACCESS_KEY",
+		"AWS_CONTAINER_AUTHORIZATION_TOKEN",
+		"AWS_CONTAINER_CREDENTIALS_RELATIVE_URI",
+		"AWS_PROFILE",
+		"AWS_ROLE_ARN",
+		"AWS_SECRET_ACCESS_KEY",
+		"AWS_SECRET_KEY",
+		"AWS_WEB_IDENTITY_TOKEN_FILE",
+	} {
+		t.Setenv(k, "")
+	}
+
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+
+	// A stand-in for the ECS container credentials endpoint behind a TLS-intercepting proxy.
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"AccessKeyId":"container","SecretAccessKey":"secret","Token":"token","Expiration":"2099-01-01T00:00:00Z"}`)
+	}))
+	defer server.Close()
+
+	t.Setenv("AWS_CONTAINER_CREDENTIALS_FULL_URI", server.URL+"/credentials")
+
+	bundle := filepath.Join(t.TempDir(), "ca-bundle.pem")
+
+	if err := os.WriteFile(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &Config{
+		CredsFilename:           filepath.Join(t.TempDir(), "credentials"),
+		CustomCABundle:          bundle,
+		MaxRetries:              1,
+		Region:                  "us-east-1", //lintignore:AWSAT003
+		SkipCredsValidation:     true,
+		SkipGetEC2Platforms:     true,
+		SkipMetadataApiCheck:    true,
+		SkipRequestingAccountId: true,
+	}
+
+	raw, err := config.Client()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	v, err := raw.(*AWSClient).STSConn.Config.Credentials.Get()
+
+	if err != nil {
+		t.Fatalf("unexpected error getting credentials: %s", err)
+	}
+
+	if got, expected := v.AccessKeyID, "container"; got != expected {
+		t.Errorf("got access key ID %s, expected %s", got, expected)
+	}
+}
+
+func TestConfigClientEC2MetadataServiceEndpoint(t *testing.T) {
+	for _, k := range []string{
+		"AWS_ACCESS_KEY_ID",
+		"AWS_ACCESS_KEY",
+		"AWS_CONTAINER_CREDENTIALS_FULL_URI",
+		"AWS_CONTAINER_CREDENTIALS_RELATIVE_URI",
+		"AWS_EC2_METADATA_DISABLED",
+		"AWS_PROFILE",
+		"AWS_ROLE_ARN",
+		"AWS_SECRET_ACCESS_KEY",
+		"AWS_SECRET_KEY",
+		"AWS_WEB_IDENTITY_TOKEN_FILE",
+	} {
+		t.Setenv(k, "")
+	}
+
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+
+	// A stand-in for the EC2 instance metadata service with an instance profile role.
+	mux := http.NewServeMux()
+	mux.HandleFunc("/latest/api/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Aws-Ec2-Metadata-Token-Ttl-Seconds", "21600")
+		fmt.Fprint(w, "token")
+	})
+	mux.HandleFunc("/latest/meta-data/iam/security-credentials/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "test")
+	})
+	mux.HandleFunc("/latest/meta-data/iam/security-credentials/test", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"Code":"Success","Type":"AWS-HMAC","AccessKeyId":"imds","SecretAccessKey":"secret","Token":"token","Expiration":"2099-01-01T00:00:00Z"}`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	config := &Config{
+		CredsFilename:                  filepath.Join(t.TempDir(), "credentials"),
+		EC2MetadataServiceEndpoint:     server.URL,
+		EC2MetadataServiceEndpointMode: EC2MetadataServiceEndpointModeIPv4,
+		MaxRetries:                     1,
+		Region:                         "us-east-1", //lintignore:AWSAT003
+		SkipCredsValidation:            true,
+		SkipGetEC2Platforms:            true,
+		SkipRequestingAccountId:        true,
+	}
+
+	raw, err := config.Client()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	v, err := raw.(*AWSClient).STSConn.Config.Credentials.Get()
+
+	if err != nil {
+		t.Fatalf("unexpected error getting credentials: %s", err)
+	}
+
+	if got, expected := v.AccessKeyID, "imds"; got != expected {
+		t.Errorf("got access key ID %s, expected %s", got, expected)
+	}
+}
+
+func TestConfigClientEC2MetadataServiceEndpointModeInvalid(t *testing.T) {
+	config := &Config{
+		AccessKey:                      "base",
+		EC2MetadataServiceEndpointMode: "IPv5",
+		Region:                         "us-east-1", //lintignore:AWSAT003
+		SecretKey:                      "secret",
+		SkipCredsValidation:            true,
+		SkipGetEC2Platforms:            true,
+		SkipRequestingAccountId:        true,
+	}
+
+	if _, err := config.Client(); err == nil {
+		t.Fatal("expected error, got none")
+	}
+}
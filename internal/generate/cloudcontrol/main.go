@@ -0,0 +1,110 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-aws/internal/generate/cloudcontrol"
+)
+
+var (
+	schemaFile   = flag.String("Schema", "", "path to the CloudFormation resource schema JSON file")
+	resourceName = flag.String("Resource", "", "Terraform resource type name, e.g. aws_logs_log_group")
+	functionName = flag.String("Function", "", "name of the function returning the resource (default derived from -Resource)")
+	subcategory  = flag.String("Subcategory", "", "documentation subcategory")
+	docsDir      = flag.String("Docs", "../../../website/docs/r", "documentation directory; empty to skip documentation")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage:\n")
+	fmt.Fprintf(os.Stderr, "\tmain.go [flags]\n\n")
+	fmt.Fprintf(os.Stderr, "Flags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	log.SetFlags(0)
+	flag.Usage = usage
+	flag.Parse()
+
+	if *schemaFile == "" || *resourceName == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	servicePackage := os.Getenv("GOPACKAGE")
+
+	if servicePackage == "" {
+		log.Fatal("GOPACKAGE environment variable is not set; run via go generate")
+	}
+
+	document, err := os.ReadFile(*schemaFile)
+
+	if err != nil {
+		log.Fatalf("error reading CloudFormation resource schema (%s): %s", *schemaFile, err)
+	}
+
+	function := *functionName
+
+	if function == "" {
+		function = cloudcontrol.DefaultFunctionName(*resourceName, servicePackage)
+	}
+
+	resource, err := cloudcontrol.NewResource(string(document), *resourceName, function)
+
+	if err != nil {
+		log.Fatalf("error generating %s: %s", *resourceName, err)
+	}
+
+	base := strings.TrimPrefix(*resourceName, "aws_")
+	base = strings.TrimPrefix(base, servicePackage+"_")
+
+	source, err := resource.GenerateSource(servicePackage)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := writeFile(base+"_gen.go", source); err != nil {
+		log.Fatal(err)
+	}
+
+	testSource, err := resource.GenerateTestSource(servicePackage)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := writeFile(base+"_gen_test.go", testSource); err != nil {
+		log.Fatal(err)
+	}
+
+	if *docsDir == "" {
+		return
+	}
+
+	docs, err := resource.GenerateDocs(servicePackage, *subcategory)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := writeFile(filepath.Join(*docsDir, strings.TrimPrefix(*resourceName, "aws_")+".html.markdown"), docs); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func writeFile(filename string, contents []byte) error {
+	if err := os.WriteFile(filename, contents, 0644); err != nil {
+		return fmt.Errorf("error writing file (%s): %w", filename, err)
+	}
+
+	return nil
+}
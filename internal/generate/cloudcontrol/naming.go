@@ -0,0 +1,65 @@
+package cloudcontrol
+
+import (
+	"strings"
+	"unicode"
+)
+
+// mixedCaseAcronyms are acronyms whose case would otherwise split them into words.
+var mixedCaseAcronyms = strings.NewReplacer(
+	"IPv4", "Ipv4",
+	"IPv6", "Ipv6",
+)
+
+// SnakeCase returns the Terraform attribute name of a CloudFormation property name, e.g. db_instance_identifier for DBInstanceIdentifier.
+func SnakeCase(s string) string {
+	runes := []rune(mixedCaseAcronyms.Replace(s))
+
+	var sb strings.Builder
+
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			previous := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower) {
+				sb.WriteRune('_')
+			}
+		}
+
+		if r == '-' || r == '.' || r == ' ' {
+			r = '_'
+		}
+
+		sb.WriteRune(unicode.ToLower(r))
+	}
+
+	return sb.String()
+}
+
+// PascalCase returns the Go identifier for a snake case name, e.g. LogGroup for log_group.
+func PascalCase(s string) string {
+	var sb strings.Builder
+
+	for _, word := range strings.Split(s, "_") {
+		if word == "" {
+			continue
+		}
+
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+
+		sb.WriteString(string(runes))
+	}
+
+	return sb.String()
+}
+
+// DefaultFunctionName returns the name of the function returning a resource in a service package,
+// e.g. ResourceLogGroup for aws_logs_log_group in the logs package.
+func DefaultFunctionName(resourceName, packageName string) string {
+	name := strings.TrimPrefix(resourceName, "aws_")
+	name = strings.TrimPrefix(name, packageName+"_")
+
+	return "Resource" + PascalCase(name)
+}
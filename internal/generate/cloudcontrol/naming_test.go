@@ -0,0 +1,68 @@
+package cloudcontrol
+
+import (
+	"testing"
+)
+
+func TestSnakeCase(t *testing.T) {
+	testCases := []struct {
+		Input    string
+		Expected string
+	}{
+		{Input: "Name", Expected: "name"},
+		{Input: "LogGroupName", Expected: "log_group_name"},
+		{Input: "DBInstanceIdentifier", Expected: "db_instance_identifier"},
+		{Input: "KmsKeyId", Expected: "kms_key_id"},
+		{Input: "S3Bucket", Expected: "s3_bucket"},
+		{Input: "EC2InstanceType", Expected: "ec2_instance_type"},
+		{Input: "IPv6CidrBlock", Expected: "ipv6_cidr_block"},
+		{Input: "AssignIPv6AddressOnCreation", Expected: "assign_ipv6_address_on_creation"},
+		{Input: "ARN", Expected: "arn"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Input, func(t *testing.T) {
+			if got := SnakeCase(testCase.Input); got != testCase.Expected {
+				t.Errorf("got %q, expected %q", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestPascalCase(t *testing.T) {
+	testCases := []struct {
+		Input    string
+		Expected string
+	}{
+		{Input: "log_group", Expected: "LogGroup"},
+		{Input: "logs", Expected: "Logs"},
+		{Input: "ipv6_cidr_block", Expected: "Ipv6CidrBlock"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Input, func(t *testing.T) {
+			if got := PascalCase(testCase.Input); got != testCase.Expected {
+				t.Errorf("got %q, expected %q", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestDefaultFunctionName(t *testing.T) {
+	testCases := []struct {
+		ResourceName string
+		PackageName  string
+		Expected     string
+	}{
+		{ResourceName: "aws_logs_log_group", PackageName: "logs", Expected: "ResourceLogGroup"},
+		{ResourceName: "aws_example_widget", PackageName: "cloudcontrol", Expected: "ResourceExampleWidget"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.ResourceName, func(t *testing.T) {
+			if got := DefaultFunctionName(testCase.ResourceName, testCase.PackageName); got != testCase.Expected {
+				t.Errorf("got %q, expected %q", got, testCase.Expected)
+			}
+		})
+	}
+}
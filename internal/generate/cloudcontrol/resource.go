@@ -0,0 +1,477 @@
+package cloudcontrol
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+// maxNestingDepth is the depth of nested objects below which properties are represented as JSON strings.
+// It also bounds the expansion of recursive definitions.
+const maxNestingDepth = 6
+
+// reservedAttributeNames cannot be used as top-level attribute names.
+var reservedAttributeNames = map[string]bool{
+	"connection":  true,
+	"count":       true,
+	"depends_on":  true,
+	"for_each":    true,
+	"id":          true,
+	"lifecycle":   true,
+	"provider":    true,
+	"provisioner": true,
+	"timeouts":    true,
+}
+
+// Resource is a Terraform resource generated from a CloudFormation resource schema.
+type Resource struct {
+	// TypeName is the CloudFormation resource type name, e.g. AWS::Logs::LogGroup.
+	TypeName string
+
+	// Name is the Terraform resource type name, e.g. aws_logs_log_group.
+	Name string
+
+	// FunctionName is the name of the function returning the resource, e.g. ResourceLogGroup.
+	FunctionName string
+
+	Description string
+	Attributes  []*Attribute
+}
+
+// Attribute is a resource attribute or a nested block generated from a CloudFormation property.
+type Attribute struct {
+	Name         string
+	PropertyName string
+	Description  string
+
+	// Type is the name of the attribute's schema.ValueType, e.g. TypeString.
+	Type string
+
+	// ElemType is the name of the schema.ValueType of the elements of a list, set or map of primitives.
+	ElemType string
+
+	// Attributes are the attributes of a nested block.
+	Attributes []*Attribute
+
+	Object    bool
+	JSON      bool
+	Required  bool
+	Optional  bool
+	Computed  bool
+	ForceNew  bool
+	ReadOnly  bool
+	WriteOnly bool
+	Sensitive bool
+	MinItems  int
+	MaxItems  int
+
+	// Enum is the list of valid values of a string attribute.
+	Enum []string
+
+	// ValidateFunc is the Go expression of the attribute's validation function, if any.
+	ValidateFunc string
+}
+
+// IsBlock returns whether the attribute is a nested block.
+func (a *Attribute) IsBlock() bool {
+	return a.Attributes != nil
+}
+
+// NewResource returns the resource generated from a CloudFormation resource schema document.
+func NewResource(document, name, functionName string) (*Resource, error) {
+	resourceSchema, err := cfschema.NewResourceJsonSchemaDocument(cfschema.Sanitize(document))
+
+	if err != nil {
+		return nil, fmt.Errorf("error parsing CloudFormation resource schema: %w", err)
+	}
+
+	cfResource, err := resourceSchema.Resource()
+
+	if err != nil {
+		return nil, fmt.Errorf("error parsing CloudFormation resource schema: %w", err)
+	}
+
+	typeName := stringValue(cfResource.TypeName)
+
+	if typeName == "" {
+		return nil, fmt.Errorf("CloudFormation resource schema has no typeName")
+	}
+
+	b := &builder{resource: cfResource}
+
+	r := &Resource{
+		TypeName:     typeName,
+		Name:         name,
+		FunctionName: functionName,
+		Description:  cleanDescription(stringValue(cfResource.Description)),
+	}
+
+	for _, propertyName := range sortedPropertyNames(cfResource.Properties) {
+		attribute, err := b.attribute([]string{propertyName}, propertyName, cfResource.Properties[propertyName], cfResource.IsRequired(propertyName), attributeFlags{}, 0)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if reservedAttributeNames[attribute.Name] {
+			attribute.Name = SnakeCase(typeNameResource(typeName)) + "_" + attribute.Name
+		}
+
+		r.Attributes = append(r.Attributes, attribute)
+	}
+
+	sortAttributes(r.Attributes)
+
+	return r, nil
+}
+
+// attributeFlags are the flags that nested attributes inherit from their parent.
+type attributeFlags struct {
+	forceNew bool
+	readOnly bool
+}
+
+type builder struct {
+	resource *cfschema.Resource
+}
+
+func (b *builder) attribute(path []string, propertyName string, property *cfschema.Property, required bool, parent attributeFlags, depth int) (*Attribute, error) {
+	property, err := b.resolve(property)
+
+	if err != nil {
+		return nil, fmt.Errorf("error resolving %s: %w", strings.Join(path, "/"), err)
+	}
+
+	flags := attributeFlags{
+		forceNew: parent.forceNew || b.matches(b.resource.CreateOnlyProperties, path),
+		readOnly: parent.readOnly || b.matches(b.resource.ReadOnlyProperties, path),
+	}
+
+	a := &Attribute{
+		Name:         SnakeCase(propertyName),
+		PropertyName: propertyName,
+		Description:  cleanDescription(stringValue(property.Description)),
+		ReadOnly:     flags.readOnly,
+		WriteOnly:    b.matches(b.resource.WriteOnlyProperties, path),
+	}
+
+	// Write-only properties are typically secrets, such as passwords.
+	a.Sensitive = a.WriteOnly
+
+	switch {
+	case a.ReadOnly:
+		a.Computed = true
+	case required:
+		a.Required = true
+		a.ForceNew = flags.forceNew
+	default:
+		a.Optional = true
+		a.Computed = true
+		a.ForceNew = flags.forceNew
+	}
+
+	switch propertyType(property) {
+	case cfschema.PropertyTypeString:
+		a.Type = "TypeString"
+		a.Enum = enumStrings(property.Enum)
+	case cfschema.PropertyTypeInteger:
+		a.Type = "TypeInt"
+	case cfschema.PropertyTypeNumber:
+		a.Type = "TypeFloat"
+	case cfschema.PropertyTypeBoolean:
+		a.Type = "TypeBool"
+	case cfschema.PropertyTypeArray:
+		items, err := b.resolve(property.Items)
+
+		if err != nil {
+			return nil, fmt.Errorf("error resolving %s items: %w", strings.Join(path, "/"), err)
+		}
+
+		a.Type = "TypeList"
+
+		if property.InsertionOrder != nil && !*property.InsertionOrder {
+			a.Type = "TypeSet"
+		}
+
+		if v := property.MinItems; v != nil && !a.ReadOnly {
+			a.MinItems = *v
+		}
+
+		if v := property.MaxItems; v != nil && !a.ReadOnly {
+			a.MaxItems = *v
+		}
+
+		switch itemsType := propertyType(items); {
+		case itemsType == cfschema.PropertyTypeObject && len(items.Properties) > 0 && depth < maxNestingDepth:
+			if err := b.nestedAttributes(a, path, items, flags, depth); err != nil {
+				return nil, err
+			}
+		case isPrimitive(itemsType):
+			a.ElemType = primitiveValueType(itemsType)
+		default:
+			b.json(a)
+		}
+	case cfschema.PropertyTypeObject:
+		switch {
+		case len(property.Properties) > 0 && depth < maxNestingDepth:
+			a.Type = "TypeList"
+			a.Object = true
+			a.MaxItems = 1
+
+			if err := b.nestedAttributes(a, path, property, flags, depth); err != nil {
+				return nil, err
+			}
+		case len(property.Properties) == 0 && isStringMap(b, property):
+			a.Type = "TypeMap"
+			a.ElemType = "TypeString"
+		default:
+			b.json(a)
+		}
+	default:
+		b.json(a)
+	}
+
+	a.ValidateFunc = validateFunc(a, property)
+
+	return a, nil
+}
+
+func (b *builder) nestedAttributes(a *Attribute, path []string, property *cfschema.Property, flags attributeFlags, depth int) error {
+	a.Attributes = []*Attribute{}
+
+	for _, propertyName := range sortedPropertyNames(property.Properties) {
+		nested, err := b.attribute(append(path[:len(path):len(path)], propertyName), propertyName, property.Properties[propertyName], property.IsRequired(propertyName), flags, depth+1)
+
+		if err != nil {
+			return err
+		}
+
+		a.Attributes = append(a.Attributes, nested)
+	}
+
+	sortAttributes(a.Attributes)
+
+	return nil
+}
+
+// json makes an attribute an arbitrary JSON value represented by a JSON string.
+func (b *builder) json(a *Attribute) {
+	a.Type = "TypeString"
+	a.ElemType = ""
+	a.JSON = true
+	a.MinItems = 0
+	a.MaxItems = 0
+}
+
+// resolve returns a copy of a property with any reference resolved and any oneOf properties unwrapped.
+func (b *builder) resolve(property *cfschema.Property) (*cfschema.Property, error) {
+	if property == nil {
+		return &cfschema.Property{}, nil
+	}
+
+	resolved := *property
+
+	for i := 0; resolved.Ref != nil; i++ {
+		if i > maxNestingDepth {
+			return nil, fmt.Errorf("too many references (%s)", resolved.Ref)
+		}
+
+		target, err := b.resource.ResolveReference(*resolved.Ref)
+
+		if err != nil {
+			return nil, err
+		}
+
+		description := resolved.Description
+		resolved = *target
+
+		if description != nil && *description != "" {
+			resolved.Description = description
+		}
+	}
+
+	if err := b.resource.UnwrapOneOfProperties(&resolved); err != nil {
+		return nil, err
+	}
+
+	return &resolved, nil
+}
+
+// matches returns whether a property path matches any of the JSON pointers.
+// The elements of arrays can be referenced with or without a wildcard segment.
+func (b *builder) matches(pointers cfschema.PropertyJsonPointers, path []string) bool {
+	for _, pointer := range pointers {
+		var segments []string
+
+		for _, segment := range pointer.Path() {
+			if segment != "*" {
+				segments = append(segments, segment)
+			}
+		}
+
+		if len(segments) != len(path) {
+			continue
+		}
+
+		match := true
+
+		for i, segment := range segments {
+			if segment != path[i] {
+				match = false
+				break
+			}
+		}
+
+		if match {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isStringMap(b *builder, property *cfschema.Property) bool {
+	if len(property.PatternProperties) != 1 {
+		return false
+	}
+
+	for _, v := range property.PatternProperties {
+		v, err := b.resolve(v)
+
+		if err != nil {
+			return false
+		}
+
+		return propertyType(v) == cfschema.PropertyTypeString
+	}
+
+	return false
+}
+
+func propertyType(property *cfschema.Property) string {
+	if v := property.Type.String(); v != "" {
+		return v
+	}
+
+	if len(property.Properties) > 0 {
+		return cfschema.PropertyTypeObject
+	}
+
+	return ""
+}
+
+func isPrimitive(propertyType string) bool {
+	switch propertyType {
+	case cfschema.PropertyTypeBoolean, cfschema.PropertyTypeInteger, cfschema.PropertyTypeNumber, cfschema.PropertyTypeString:
+		return true
+	}
+
+	return false
+}
+
+func primitiveValueType(propertyType string) string {
+	switch propertyType {
+	case cfschema.PropertyTypeBoolean:
+		return "TypeBool"
+	case cfschema.PropertyTypeInteger:
+		return "TypeInt"
+	case cfschema.PropertyTypeNumber:
+		return "TypeFloat"
+	default:
+		return "TypeString"
+	}
+}
+
+func validateFunc(a *Attribute, property *cfschema.Property) string {
+	if a.ReadOnly {
+		return ""
+	}
+
+	switch {
+	case a.JSON:
+		return "validation.StringIsJSON"
+	case len(a.Enum) > 0:
+		return fmt.Sprintf("validation.StringInSlice(%s, false)", goStringSlice(a.Enum))
+	case a.Type == "TypeString" && property.MaxLength != nil:
+		min := 0
+
+		if property.MinLength != nil {
+			min = *property.MinLength
+		}
+
+		return fmt.Sprintf("validation.StringLenBetween(%d, %d)", min, *property.MaxLength)
+	case a.Type == "TypeInt" && property.Minimum != nil && property.Maximum != nil:
+		return fmt.Sprintf("validation.IntBetween(%d, %d)", *property.Minimum, *property.Maximum)
+	case a.Type == "TypeInt" && property.Minimum != nil:
+		return fmt.Sprintf("validation.IntAtLeast(%d)", *property.Minimum)
+	case a.Type == "TypeInt" && property.Maximum != nil:
+		return fmt.Sprintf("validation.IntAtMost(%d)", *property.Maximum)
+	}
+
+	return ""
+}
+
+func enumStrings(values []interface{}) []string {
+	var enum []string
+
+	for _, v := range values {
+		s, ok := v.(string)
+
+		if !ok {
+			return nil
+		}
+
+		enum = append(enum, s)
+	}
+
+	return enum
+}
+
+func goStringSlice(values []string) string {
+	quoted := make([]string, 0, len(values))
+
+	for _, v := range values {
+		quoted = append(quoted, fmt.Sprintf("%q", v))
+	}
+
+	return "[]string{" + strings.Join(quoted, ", ") + "}"
+}
+
+func sortedPropertyNames(properties map[string]*cfschema.Property) []string {
+	names := make([]string, 0, len(properties))
+
+	for name := range properties {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func sortAttributes(attributes []*Attribute) {
+	sort.Slice(attributes, func(i, j int) bool {
+		return attributes[i].Name < attributes[j].Name
+	})
+}
+
+// typeNameResource returns the resource segment of a CloudFormation resource type name, e.g. LogGroup.
+func typeNameResource(typeName string) string {
+	parts := strings.Split(typeName, "::")
+
+	return parts[len(parts)-1]
+}
+
+func cleanDescription(description string) string {
+	return strings.Join(strings.Fields(description), " ")
+}
+
+func stringValue(v *string) string {
+	if v == nil {
+		return ""
+	}
+
+	return *v
+}
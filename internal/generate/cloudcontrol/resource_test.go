@@ -0,0 +1,215 @@
+package cloudcontrol
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func testResource(t *testing.T) *Resource {
+	document, err := os.ReadFile("testdata/AWS_Example_Widget.json")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewResource(string(document), "aws_example_widget", "ResourceWidget")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r
+}
+
+func testAttribute(t *testing.T, attributes []*Attribute, name string) *Attribute {
+	for _, a := range attributes {
+		if a.Name == name {
+			return a
+		}
+	}
+
+	t.Fatalf("attribute %s not found", name)
+
+	return nil
+}
+
+func TestNewResource(t *testing.T) {
+	r := testResource(t)
+
+	if got, expected := r.TypeName, "AWS::Example::Widget"; got != expected {
+		t.Errorf("TypeName: got %q, expected %q", got, expected)
+	}
+
+	testCases := []struct {
+		Name     string
+		Expected Attribute
+	}{
+		{
+			Name:     "arn",
+			Expected: Attribute{PropertyName: "Arn", Type: "TypeString", Computed: true, ReadOnly: true},
+		},
+		{
+			Name:     "labels",
+			Expected: Attribute{PropertyName: "Labels", Type: "TypeMap", ElemType: "TypeString", Optional: true, Computed: true},
+		},
+		{
+			Name:     "name",
+			Expected: Attribute{PropertyName: "Name", Type: "TypeString", Required: true, ForceNew: true},
+		},
+		{
+			Name:     "password",
+			Expected: Attribute{PropertyName: "Password", Type: "TypeString", Optional: true, Computed: true, WriteOnly: true, Sensitive: true},
+		},
+		{
+			Name:     "policy_document",
+			Expected: Attribute{PropertyName: "PolicyDocument", Type: "TypeString", JSON: true, Optional: true, Computed: true, ValidateFunc: "validation.StringIsJSON"},
+		},
+		{
+			Name:     "ports",
+			Expected: Attribute{PropertyName: "Ports", Type: "TypeSet", ElemType: "TypeInt", Optional: true, Computed: true},
+		},
+		{
+			Name:     "size",
+			Expected: Attribute{PropertyName: "Size", Type: "TypeString", Optional: true, Computed: true, Enum: []string{"SMALL", "LARGE"}, ValidateFunc: `validation.StringInSlice([]string{"SMALL", "LARGE"}, false)`},
+		},
+		{
+			Name:     "widget_id",
+			Expected: Attribute{PropertyName: "Id", Type: "TypeString", Computed: true, ReadOnly: true},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got := testAttribute(t, r.Attributes, testCase.Name)
+			expected := testCase.Expected
+			expected.Name = testCase.Name
+			expected.Description = got.Description
+
+			if got.PropertyName != expected.PropertyName || got.Type != expected.Type || got.ElemType != expected.ElemType ||
+				got.JSON != expected.JSON || got.Required != expected.Required || got.Optional != expected.Optional ||
+				got.Computed != expected.Computed || got.ForceNew != expected.ForceNew || got.ReadOnly != expected.ReadOnly ||
+				got.WriteOnly != expected.WriteOnly || got.Sensitive != expected.Sensitive || got.ValidateFunc != expected.ValidateFunc ||
+				strings.Join(got.Enum, ",") != strings.Join(expected.Enum, ",") || got.IsBlock() {
+				t.Errorf("got %+v, expected %+v", *got, expected)
+			}
+		})
+	}
+}
+
+func TestNewResourceNestedBlocks(t *testing.T) {
+	r := testResource(t)
+
+	settings := testAttribute(t, r.Attributes, "settings")
+
+	if !settings.Object || settings.Type != "TypeList" || settings.MaxItems != 1 || !settings.ForceNew {
+		t.Errorf("settings: got %+v", *settings)
+	}
+
+	if secret := testAttribute(t, settings.Attributes, "secret"); !secret.WriteOnly || !secret.Sensitive || !secret.ForceNew {
+		t.Errorf("settings.secret: got %+v", *secret)
+	}
+
+	if weight := testAttribute(t, settings.Attributes, "weight"); weight.ValidateFunc != "validation.IntBetween(0, 100)" {
+		t.Errorf("settings.weight: got %+v", *weight)
+	}
+
+	tags := testAttribute(t, r.Attributes, "tags")
+
+	if tags.Object || tags.Type != "TypeList" || tags.MaxItems != 0 || tags.ForceNew {
+		t.Errorf("tags: got %+v", *tags)
+	}
+
+	if key := testAttribute(t, tags.Attributes, "key"); !key.Required || key.ValidateFunc != "validation.StringLenBetween(1, 128)" {
+		t.Errorf("tags.key: got %+v", *key)
+	}
+}
+
+func TestResourceGenerateSource(t *testing.T) {
+	r := testResource(t)
+
+	for _, packageName := range []string{"example", "cloudcontrol"} {
+		t.Run(packageName, func(t *testing.T) {
+			b, err := r.GenerateSource(packageName)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			source := string(b)
+
+			expected := []string{
+				"package " + packageName + "\n",
+				"func ResourceWidget() *schema.Resource {",
+				"DiffSuppressFunc: verify.SuppressEquivalentJSONDiffs,",
+				`TypeName: "AWS::Example::Widget",`,
+				"WriteOnly:    true,",
+				"Sensitive: true,",
+			}
+
+			if packageName == "cloudcontrol" {
+				expected = append(expected, "return NewResource(resourceWidgetType,")
+			} else {
+				expected = append(expected, "return tfcloudcontrol.NewResource(resourceWidgetType,")
+			}
+
+			for _, s := range expected {
+				if !strings.Contains(source, s) {
+					t.Errorf("generated source does not contain %q:\n%s", s, source)
+				}
+			}
+
+			if packageName == "cloudcontrol" && strings.Contains(source, "tfcloudcontrol") {
+				t.Errorf("generated source qualifies its own package:\n%s", source)
+			}
+		})
+	}
+}
+
+func TestResourceGenerateTestSource(t *testing.T) {
+	b, err := testResource(t).GenerateTestSource("example")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source := string(b)
+
+	for _, s := range []string{
+		"package example_test\n",
+		"func TestAccExampleWidget_basic(t *testing.T) {",
+		`ImportStateVerifyIgnore: []string{"password", "settings.0.secret"},`,
+		"  name = %[1]q\n",
+	} {
+		if !strings.Contains(source, s) {
+			t.Errorf("generated test source does not contain %q:\n%s", s, source)
+		}
+	}
+
+	if strings.Contains(source, "t.Skip") {
+		t.Errorf("generated test source is skipped:\n%s", source)
+	}
+}
+
+func TestResourceGenerateDocs(t *testing.T) {
+	b, err := testResource(t).GenerateDocs("example", "Example")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs := string(b)
+
+	for _, s := range []string{
+		`subcategory: "Example"`,
+		"# Resource: aws_example_widget",
+		"* `name` - (Required) The name of the widget. Changing this value forces a new resource.",
+		"### settings",
+		"* `arn` - The ARN of the widget.",
+		"$ terraform import aws_example_widget.example example",
+	} {
+		if !strings.Contains(docs, s) {
+			t.Errorf("generated docs do not contain %q:\n%s", s, docs)
+		}
+	}
+}
@@ -0,0 +1,621 @@
+package cloudcontrol
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"text/template"
+)
+
+// runtimePackage is the package implementing the lifecycle of generated resources.
+const (
+	runtimePackage      = "cloudcontrol"
+	runtimePackageAlias = "tfcloudcontrol"
+)
+
+type templateData struct {
+	*Resource
+
+	PackageName  string
+	Qualifier    string
+	Subcategory  string
+	TestFunction string
+	TestName     string
+	TypeVariable string
+
+	Schema     string
+	Attributes string
+
+	ImportRuntime    bool
+	ImportValidation bool
+	ImportVerify     bool
+
+	TestConfig         string
+	TestConfigUsesName bool
+	TestSkipReason     string
+	ImportIgnore       []string
+	ExampleConfig      string
+	RequiredDocs       []string
+	OptionalDocs       []string
+	BlockDocs          []blockDoc
+	ComputedDocs       []string
+	HasWriteOnly       bool
+	ResourceDocName    string
+}
+
+type blockDoc struct {
+	Name       string
+	Attributes []string
+}
+
+func (r *Resource) templateData(packageName, subcategory string) *templateData {
+	data := &templateData{
+		Resource:        r,
+		PackageName:     packageName,
+		Subcategory:     subcategory,
+		TypeVariable:    lowerFirst(r.FunctionName) + "Type",
+		ResourceDocName: strings.TrimPrefix(r.Name, "aws_"),
+	}
+
+	if packageName != runtimePackage {
+		data.Qualifier = runtimePackageAlias + "."
+		data.ImportRuntime = true
+	}
+
+	data.TestName = strings.TrimPrefix(r.FunctionName, "Resource")
+	data.TestFunction = "TestAcc" + PascalCase(packageName) + data.TestName
+
+	var sb strings.Builder
+	writeSchema(&sb, r.Attributes, 2, data)
+	data.Schema = sb.String()
+
+	sb.Reset()
+	writeAttributes(&sb, r.Attributes, 2, data.Qualifier)
+	data.Attributes = sb.String()
+
+	data.TestConfig, data.TestSkipReason = testConfig(r)
+	data.TestConfigUsesName = strings.Contains(data.TestConfig, testConfigName)
+
+	if !data.TestConfigUsesName {
+		data.TestConfig = strings.ReplaceAll(data.TestConfig, "%%", "%")
+	}
+	data.ExampleConfig = exampleConfig(r)
+
+	data.ImportIgnore = writeOnlyPaths(r.Attributes, "")
+	data.HasWriteOnly = len(data.ImportIgnore) > 0
+
+	data.RequiredDocs, data.OptionalDocs, data.ComputedDocs, data.BlockDocs = attributeDocs(r.Attributes)
+
+	return data
+}
+
+// GenerateSource returns the Go source of the resource.
+func (r *Resource) GenerateSource(packageName string) ([]byte, error) {
+	return generateGo(sourceTemplateBody, r.templateData(packageName, ""))
+}
+
+// GenerateTestSource returns the Go source of the resource's acceptance tests.
+func (r *Resource) GenerateTestSource(packageName string) ([]byte, error) {
+	data := r.templateData(packageName, "")
+	data.Qualifier = runtimePackageAlias + "."
+
+	return generateGo(testTemplateBody, data)
+}
+
+// GenerateDocs returns the resource's documentation.
+func (r *Resource) GenerateDocs(packageName, subcategory string) ([]byte, error) {
+	return execute(docsTemplateBody, r.templateData(packageName, subcategory))
+}
+
+func generateGo(body string, data *templateData) ([]byte, error) {
+	b, err := execute(body, data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	formatted, err := format.Source(b)
+
+	if err != nil {
+		return nil, fmt.Errorf("error formatting generated source: %w", err)
+	}
+
+	return formatted, nil
+}
+
+func execute(body string, data *templateData) ([]byte, error) {
+	tmpl, err := template.New("").Parse(body)
+
+	if err != nil {
+		return nil, fmt.Errorf("error parsing template: %w", err)
+	}
+
+	var buffer bytes.Buffer
+
+	if err := tmpl.Execute(&buffer, data); err != nil {
+		return nil, fmt.Errorf("error executing template: %w", err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func writeSchema(sb *strings.Builder, attributes []*Attribute, indent int, data *templateData) {
+	tabs := strings.Repeat("\t", indent)
+
+	for _, a := range attributes {
+		fmt.Fprintf(sb, "%s%q: {\n", tabs, a.Name)
+		fmt.Fprintf(sb, "%s\tType: schema.%s,\n", tabs, a.Type)
+
+		if a.Required {
+			fmt.Fprintf(sb, "%s\tRequired: true,\n", tabs)
+		}
+
+		if a.Optional {
+			fmt.Fprintf(sb, "%s\tOptional: true,\n", tabs)
+		}
+
+		if a.Computed {
+			fmt.Fprintf(sb, "%s\tComputed: true,\n", tabs)
+		}
+
+		if a.ForceNew {
+			fmt.Fprintf(sb, "%s\tForceNew: true,\n", tabs)
+		}
+
+		if a.Sensitive {
+			fmt.Fprintf(sb, "%s\tSensitive: true,\n", tabs)
+		}
+
+		if a.MinItems > 0 {
+			fmt.Fprintf(sb, "%s\tMinItems: %d,\n", tabs, a.MinItems)
+		}
+
+		if a.MaxItems > 0 {
+			fmt.Fprintf(sb, "%s\tMaxItems: %d,\n", tabs, a.MaxItems)
+		}
+
+		if a.ValidateFunc != "" {
+			fmt.Fprintf(sb, "%s\tValidateFunc: %s,\n", tabs, a.ValidateFunc)
+			data.ImportValidation = true
+		}
+
+		if a.JSON && !a.ReadOnly {
+			fmt.Fprintf(sb, "%s\tDiffSuppressFunc: verify.SuppressEquivalentJSONDiffs,\n", tabs)
+			data.ImportVerify = true
+		}
+
+		switch {
+		case a.IsBlock():
+			fmt.Fprintf(sb, "%s\tElem: &schema.Resource{\n%s\t\tSchema: map[string]*schema.Schema{\n", tabs, tabs)
+			writeSchema(sb, a.Attributes, indent+3, data)
+			fmt.Fprintf(sb, "%s\t\t},\n%s\t},\n", tabs, tabs)
+		case a.ElemType != "":
+			fmt.Fprintf(sb, "%s\tElem: &schema.Schema{Type: schema.%s},\n", tabs, a.ElemType)
+		}
+
+		fmt.Fprintf(sb, "%s},\n", tabs)
+	}
+}
+
+func writeAttributes(sb *strings.Builder, attributes []*Attribute, indent int, qualifier string) {
+	tabs := strings.Repeat("\t", indent)
+
+	for _, a := range attributes {
+		fmt.Fprintf(sb, "%s%q: {\n", tabs, a.Name)
+		fmt.Fprintf(sb, "%s\tPropertyName: %q,\n", tabs, a.PropertyName)
+
+		if a.Object {
+			fmt.Fprintf(sb, "%s\tObject: true,\n", tabs)
+		}
+
+		if a.JSON {
+			fmt.Fprintf(sb, "%s\tJSON: true,\n", tabs)
+		}
+
+		if a.ReadOnly {
+			fmt.Fprintf(sb, "%s\tReadOnly: true,\n", tabs)
+		}
+
+		if a.WriteOnly {
+			fmt.Fprintf(sb, "%s\tWriteOnly: true,\n", tabs)
+		}
+
+		if a.IsBlock() {
+			fmt.Fprintf(sb, "%s\tAttributes: %sAttributes{\n", tabs, qualifier)
+			writeAttributes(sb, a.Attributes, indent+2, qualifier)
+			fmt.Fprintf(sb, "%s\t},\n", tabs)
+		}
+
+		fmt.Fprintf(sb, "%s},\n", tabs)
+	}
+}
+
+// writeOnlyPaths returns the state paths of write-only attributes, which cannot be imported.
+// Write-only attributes nested in a list of blocks are ignored through the whole list.
+func writeOnlyPaths(attributes []*Attribute, prefix string) []string {
+	var paths []string
+
+	for _, a := range attributes {
+		path := prefix + a.Name
+
+		switch {
+		case a.WriteOnly:
+			paths = append(paths, path)
+		case a.Object:
+			paths = append(paths, writeOnlyPaths(a.Attributes, path+".0.")...)
+		case a.IsBlock() && len(writeOnlyPaths(a.Attributes, "")) > 0:
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
+
+// testConfigName is the verb formatting the random name into the acceptance test configuration.
+const testConfigName = "%[1]q"
+
+// testConfig returns the arguments of the acceptance test configuration,
+// or the reason why the configuration cannot be generated.
+func testConfig(r *Resource) (string, string) {
+	var sb strings.Builder
+	var unsupported []string
+
+	for _, a := range r.Attributes {
+		if !a.Required {
+			continue
+		}
+
+		value, ok := exampleValue(a, testConfigName)
+
+		if !ok {
+			unsupported = append(unsupported, a.Name)
+			continue
+		}
+
+		if value != testConfigName {
+			value = strings.ReplaceAll(value, "%", "%%")
+		}
+
+		fmt.Fprintf(&sb, "  %s = %s\n", a.Name, value)
+	}
+
+	if len(unsupported) > 0 {
+		return "", fmt.Sprintf("configuration values required for: %s", strings.Join(unsupported, ", "))
+	}
+
+	return sb.String(), ""
+}
+
+func exampleConfig(r *Resource) string {
+	var sb strings.Builder
+
+	for _, a := range r.Attributes {
+		if !a.Required {
+			continue
+		}
+
+		value, ok := exampleValue(a, `"example"`)
+
+		if !ok {
+			value = "..."
+		}
+
+		fmt.Fprintf(&sb, "  %s = %s\n", a.Name, value)
+	}
+
+	return sb.String()
+}
+
+func exampleValue(a *Attribute, stringValue string) (string, bool) {
+	switch {
+	case a.IsBlock() || a.ElemType != "":
+		return "", false
+	case a.JSON:
+		return "jsonencode({})", true
+	case len(a.Enum) > 0:
+		return fmt.Sprintf("%q", a.Enum[0]), true
+	case a.Type == "TypeString":
+		return stringValue, true
+	case a.Type == "TypeInt" || a.Type == "TypeFloat":
+		return "1", true
+	case a.Type == "TypeBool":
+		return "false", true
+	}
+
+	return "", false
+}
+
+func attributeDocs(attributes []*Attribute) (required, optional, computed []string, blocks []blockDoc) {
+	for _, a := range attributes {
+		switch {
+		case a.ReadOnly:
+			computed = append(computed, attributeDoc(a, ""))
+		case a.Required:
+			required = append(required, attributeDoc(a, "(Required) "))
+		default:
+			optional = append(optional, attributeDoc(a, "(Optional) "))
+		}
+
+		if a.IsBlock() {
+			blocks = append(blocks, nestedBlockDocs(a.Name, a.Attributes)...)
+		}
+	}
+
+	return required, optional, computed, blocks
+}
+
+func nestedBlockDocs(name string, attributes []*Attribute) []blockDoc {
+	block := blockDoc{Name: name}
+	var nested []blockDoc
+
+	for _, a := range attributes {
+		prefix := "(Optional) "
+
+		switch {
+		case a.ReadOnly:
+			prefix = ""
+		case a.Required:
+			prefix = "(Required) "
+		}
+
+		block.Attributes = append(block.Attributes, attributeDoc(a, prefix))
+
+		if a.IsBlock() {
+			nested = append(nested, nestedBlockDocs(a.Name, a.Attributes)...)
+		}
+	}
+
+	return append([]blockDoc{block}, nested...)
+}
+
+func attributeDoc(a *Attribute, prefix string) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "`%s` - %s", a.Name, prefix)
+
+	description := a.Description
+
+	if description == "" {
+		description = fmt.Sprintf("%s property.", a.PropertyName)
+	}
+
+	sb.WriteString(description)
+
+	if a.IsBlock() {
+		fmt.Fprintf(&sb, " See [`%s`](#%s) below.", a.Name, a.Name)
+	}
+
+	if a.JSON {
+		sb.WriteString(" JSON string.")
+	}
+
+	if len(a.Enum) > 0 {
+		fmt.Fprintf(&sb, " Valid values: `%s`.", strings.Join(a.Enum, "`, `"))
+	}
+
+	if a.ForceNew && !a.ReadOnly {
+		sb.WriteString(" Changing this value forces a new resource.")
+	}
+
+	if a.WriteOnly {
+		sb.WriteString(" This value is not returned by the API, so changes made outside Terraform are not detected.")
+	}
+
+	return sb.String()
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+const sourceTemplateBody = `
+// Code generated by internal/generate/cloudcontrol/main.go; DO NOT EDIT.
+
+package {{ .PackageName }}
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+{{- if .ImportValidation }}
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+{{- end }}
+{{- if .ImportRuntime }}
+	tfcloudcontrol "github.com/hashicorp/terraform-provider-aws/internal/service/cloudcontrol"
+{{- end }}
+{{- if .ImportVerify }}
+	"github.com/hashicorp/terraform-provider-aws/internal/verify"
+{{- end }}
+)
+
+// {{ .FunctionName }} returns the {{ .Name }} resource, which manages {{ .TypeName }} resources through Cloud Control API.
+func {{ .FunctionName }}() *schema.Resource {
+	return {{ .Qualifier }}NewResource({{ .TypeVariable }}, map[string]*schema.Schema{
+{{ .Schema }}	})
+}
+
+var {{ .TypeVariable }} = &{{ .Qualifier }}ResourceType{
+	TypeName: {{ printf "%q" .TypeName }},
+	Attributes: {{ .Qualifier }}Attributes{
+{{ .Attributes }}	},
+}
+`
+
+const testTemplateBody = `
+// Code generated by internal/generate/cloudcontrol/main.go; DO NOT EDIT.
+
+package {{ .PackageName }}_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/cloudcontrolapi"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	tfcloudcontrol "github.com/hashicorp/terraform-provider-aws/internal/service/cloudcontrol"
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
+)
+
+func {{ .TestFunction }}_basic(t *testing.T) {
+{{- if .TestSkipReason }}
+	t.Skip({{ printf "%q" .TestSkipReason }})
+
+{{ end }}
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "{{ .Name }}.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ErrorCheck:        acctest.ErrorCheck(t, cloudcontrolapi.EndpointsID),
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccCheck{{ .TestName }}Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAcc{{ .TestName }}Config(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheck{{ .TestName }}Exists(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+{{- if .ImportIgnore }}
+				ImportStateVerifyIgnore: []string{ {{- range $i, $v := .ImportIgnore }}{{ if $i }}, {{ end }}{{ printf "%q" $v }}{{ end -}} },
+{{- end }}
+			},
+		},
+	})
+}
+
+func testAccCheck{{ .TestName }}Exists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Cloud Control API Resource ID is set")
+		}
+
+		conn := acctest.Provider.Meta().(*conns.AWSClient).CloudControlConn
+
+		_, err := tfcloudcontrol.FindResourceByID(context.Background(), conn, rs.Primary.ID, {{ printf "%q" .TypeName }}, "", "")
+
+		return err
+	}
+}
+
+func testAccCheck{{ .TestName }}Destroy(s *terraform.State) error {
+	conn := acctest.Provider.Meta().(*conns.AWSClient).CloudControlConn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "{{ .Name }}" {
+			continue
+		}
+
+		_, err := tfcloudcontrol.FindResourceByID(context.Background(), conn, rs.Primary.ID, {{ printf "%q" .TypeName }}, "", "")
+
+		if tfresource.NotFound(err) {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return fmt.Errorf("Cloud Control API Resource %s still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAcc{{ .TestName }}Config(rName string) string {
+{{- if .TestConfigUsesName }}
+	return fmt.Sprintf(` + "`" + `
+resource "{{ .Name }}" "test" {
+{{ .TestConfig }}}
+` + "`" + `, rName)
+{{- else }}
+	return ` + "`" + `
+resource "{{ .Name }}" "test" {
+{{ .TestConfig }}}
+` + "`" + `
+{{- end }}
+}
+`
+
+const docsTemplateBody = `---
+subcategory: "{{ .Subcategory }}"
+layout: "aws"
+page_title: "AWS: {{ .Name }}"
+description: |-
+    Manages {{ .TypeName }} resources through Cloud Control API.
+---
+
+# Resource: {{ .Name }}
+
+{{ if .Description }}{{ .Description }}
+
+{{ end -}}
+This resource manages a ` + "`{{ .TypeName }}`" + ` CloudFormation resource through Cloud Control API. It is generated from the CloudFormation resource type schema.
+
+## Example Usage
+
+` + "```terraform" + `
+resource "{{ .Name }}" "example" {
+{{ .ExampleConfig }}}
+` + "```" + `
+
+## Argument Reference
+{{- if .RequiredDocs }}
+
+The following arguments are required:
+{{ range .RequiredDocs }}
+* {{ . }}
+{{- end }}
+{{- end }}
+{{- if .OptionalDocs }}
+
+The following arguments are optional:
+{{ range .OptionalDocs }}
+* {{ . }}
+{{- end }}
+{{- end }}
+{{- range .BlockDocs }}
+
+### {{ .Name }}
+{{ range .Attributes }}
+* {{ . }}
+{{- end }}
+{{- end }}
+
+## Attributes Reference
+
+In addition to all arguments above, the following attributes are exported:
+
+* ` + "`id`" + ` - Cloud Control API identifier of the resource.
+{{ range .ComputedDocs }}* {{ . }}
+{{ end }}
+## Import
+
+` + "`{{ .Name }}`" + ` can be imported using the Cloud Control API identifier, e.g.,
+
+` + "```" + `
+$ terraform import {{ .Name }}.example example
+` + "```" + `
+{{- if .HasWriteOnly }}
+
+Write-only arguments are not returned by the API and are therefore not imported.
+{{- end }}
+`
@@ -0,0 +1,444 @@
+package discover
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// skippedAttributes are attributes which are never written to the configuration.
+var skippedAttributes = map[string]bool{
+	"id":       true,
+	"tags_all": true,
+}
+
+var (
+	identifierRegexp         = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+	mapKeyRegexp             = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	invalidNameCharsRegexp   = regexp.MustCompile(`[^a-z0-9_-]+`)
+	repeatedUnderscoreRegexp = regexp.MustCompile(`_+`)
+)
+
+// Config returns the Terraform configuration of the discovered resources, followed by an import block per resource.
+// String values equal to the ID or ARN of another discovered resource are written as references to that resource.
+// Attributes with the provider's region as value are omitted.
+func Config(provider *schema.Provider, resources []*Resource, region string) []byte {
+	w := &configWriter{
+		provider:   provider,
+		region:     region,
+		addresses:  make(map[*Resource]string),
+		references: make(map[string]string),
+	}
+
+	w.nameResources(resources)
+	w.indexReferences(resources)
+
+	var sb strings.Builder
+
+	for i, r := range resources {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+
+		w.writeResource(&sb, r)
+	}
+
+	for _, r := range resources {
+		fmt.Fprintf(&sb, "\nimport {\n  to = %s\n  id = %s\n}\n", w.addresses[r], quote(r.ImportID))
+	}
+
+	return []byte(sb.String())
+}
+
+type configWriter struct {
+	provider *schema.Provider
+	region   string
+
+	// addresses are the addresses of the resources, e.g. aws_vpc.main.
+	addresses map[*Resource]string
+
+	// references maps IDs and ARNs to the expressions referencing them, e.g. aws_vpc.main.id.
+	references map[string]string
+}
+
+// nameResources assigns a unique address to each resource, based on its Name tag, name or ID.
+func (w *configWriter) nameResources(resources []*Resource) {
+	used := make(map[string]bool)
+
+	for _, r := range resources {
+		name := resourceName(r)
+		address := r.Type + "." + name
+
+		for i := 2; used[address]; i++ {
+			address = fmt.Sprintf("%s.%s_%d", r.Type, name, i)
+		}
+
+		used[address] = true
+		w.addresses[r] = address
+	}
+}
+
+func resourceName(r *Resource) string {
+	name := r.Name
+
+	if name == "" {
+		name = defaultResourceName(r)
+	}
+
+	name = invalidNameCharsRegexp.ReplaceAllString(strings.ToLower(name), "_")
+	name = strings.Trim(repeatedUnderscoreRegexp.ReplaceAllString(name, "_"), "_-")
+
+	if name == "" || !identifierRegexp.MatchString(name) {
+		name = strings.TrimPrefix(r.Type, "aws_") + "_" + name
+	}
+
+	return strings.TrimSuffix(name, "_")
+}
+
+func defaultResourceName(r *Resource) string {
+	if tags, ok := r.Data.Get("tags").(map[string]interface{}); ok {
+		if v, ok := tags["Name"].(string); ok && v != "" {
+			return v
+		}
+	}
+
+	if v, ok := r.Data.GetOk("name"); ok {
+		if v, ok := v.(string); ok {
+			return v
+		}
+	}
+
+	return r.ImportID
+}
+
+// indexReferences indexes the IDs and ARNs of the resources. Values shared by several resources are not indexed.
+func (w *configWriter) indexReferences(resources []*Resource) {
+	ambiguous := make(map[string]bool)
+
+	index := func(value, expression string) {
+		if value == "" || ambiguous[value] {
+			return
+		}
+
+		if _, ok := w.references[value]; ok {
+			delete(w.references, value)
+			ambiguous[value] = true
+
+			return
+		}
+
+		w.references[value] = expression
+	}
+
+	for _, r := range resources {
+		address := w.addresses[r]
+
+		index(r.Data.Id(), address+".id")
+
+		if v, ok := r.Data.Get("arn").(string); ok {
+			index(v, address+".arn")
+		}
+	}
+}
+
+func (w *configWriter) writeResource(sb *strings.Builder, r *Resource) {
+	address := w.addresses[r]
+	name := strings.TrimPrefix(address, r.Type+".")
+
+	fmt.Fprintf(sb, "resource %q %q {\n", r.Type, name)
+
+	b := &bodyWriter{
+		configWriter: w,
+		self:         address + ".",
+		topLevel:     true,
+	}
+
+	b.write(sb, w.provider.ResourcesMap[r.Type].Schema, func(k string) interface{} {
+		return r.Data.Get(k)
+	}, 1)
+
+	sb.WriteString("}\n")
+}
+
+type bodyWriter struct {
+	*configWriter
+
+	// self is the prefix of references to the resource being written, which are never used.
+	self     string
+	topLevel bool
+}
+
+type attributeLine struct {
+	name  string
+	value string
+}
+
+func (b *bodyWriter) write(sb *strings.Builder, s map[string]*schema.Schema, get func(string) interface{}, indent int) {
+	tabs := strings.Repeat("  ", indent)
+	names := make([]string, 0, len(s))
+
+	for k := range s {
+		names = append(names, k)
+	}
+
+	sort.Strings(names)
+
+	var attributes []attributeLine
+	var blocks []string
+	written := make(map[string]bool)
+
+	for _, k := range names {
+		v := s[k]
+		value := get(k)
+
+		if !b.writable(k, v, value, written) {
+			continue
+		}
+
+		written[k] = true
+
+		if elem, ok := v.Elem.(*schema.Resource); ok && (v.Type == schema.TypeList || v.Type == schema.TypeSet) {
+			nested := &bodyWriter{configWriter: b.configWriter, self: b.self}
+
+			for _, e := range listValue(value) {
+				m, ok := e.(map[string]interface{})
+
+				if !ok {
+					continue
+				}
+
+				var block strings.Builder
+
+				fmt.Fprintf(&block, "%s%s {\n", tabs, k)
+				nested.write(&block, elem.Schema, func(k string) interface{} { return m[k] }, indent+1)
+				fmt.Fprintf(&block, "%s}\n", tabs)
+
+				blocks = append(blocks, block.String())
+			}
+
+			continue
+		}
+
+		attributes = append(attributes, attributeLine{name: k, value: b.value(v, value, indent)})
+	}
+
+	writeAttributes(sb, attributes, tabs)
+
+	for i, block := range blocks {
+		if i > 0 || len(attributes) > 0 {
+			sb.WriteString("\n")
+		}
+
+		sb.WriteString(block)
+	}
+}
+
+// writable returns whether an attribute is written to the configuration.
+func (b *bodyWriter) writable(k string, v *schema.Schema, value interface{}, written map[string]bool) bool {
+	if b.topLevel && skippedAttributes[k] {
+		return false
+	}
+
+	if !v.Required && !v.Optional {
+		return false
+	}
+
+	if v.Required {
+		return true
+	}
+
+	if v.Deprecated != "" || v.Sensitive || isZero(value) {
+		return false
+	}
+
+	if v.Default != nil && fmt.Sprint(v.Default) == fmt.Sprint(value) {
+		return false
+	}
+
+	if b.topLevel && k == "region" && value == b.region {
+		return false
+	}
+
+	if b.topLevel {
+		for _, conflicts := range [][]string{v.ConflictsWith, v.ExactlyOneOf} {
+			for _, conflict := range conflicts {
+				if written[conflict] {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+func (b *bodyWriter) value(v *schema.Schema, value interface{}, indent int) string {
+	switch value := value.(type) {
+	case string:
+		if reference, ok := b.references[value]; ok && !strings.HasPrefix(reference, b.self) {
+			return reference
+		}
+
+		return quote(value)
+	case int:
+		return strconv.Itoa(value)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	case map[string]interface{}:
+		return b.mapValue(v, value, indent)
+	case []interface{}, *schema.Set:
+		elem := elemSchema(v)
+		values := listValue(value)
+		elements := make([]string, 0, len(values))
+
+		for _, e := range values {
+			elements = append(elements, b.value(elem, e, indent))
+		}
+
+		if _, ok := value.(*schema.Set); ok {
+			sort.Strings(elements)
+		}
+
+		return "[" + strings.Join(elements, ", ") + "]"
+	}
+
+	return "null"
+}
+
+func (b *bodyWriter) mapValue(v *schema.Schema, m map[string]interface{}, indent int) string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	elem := elemSchema(v)
+	attributes := make([]attributeLine, 0, len(keys))
+
+	for _, k := range keys {
+		name := k
+
+		if !mapKeyRegexp.MatchString(k) {
+			name = quote(k)
+		}
+
+		attributes = append(attributes, attributeLine{name: name, value: b.value(elem, m[k], indent+1)})
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("{\n")
+	writeAttributes(&sb, attributes, strings.Repeat("  ", indent+1))
+	sb.WriteString(strings.Repeat("  ", indent) + "}")
+
+	return sb.String()
+}
+
+// writeAttributes writes attributes, aligning the equals signs of consecutive single line attributes as terraform fmt does.
+func writeAttributes(sb *strings.Builder, attributes []attributeLine, tabs string) {
+	for i := 0; i < len(attributes); {
+		j, width := i, 0
+
+		for ; j < len(attributes) && !strings.Contains(attributes[j].value, "\n"); j++ {
+			if n := len(attributes[j].name); n > width {
+				width = n
+			}
+		}
+
+		if j == i {
+			fmt.Fprintf(sb, "%s%s = %s\n", tabs, attributes[i].name, attributes[i].value)
+			i++
+
+			continue
+		}
+
+		for ; i < j; i++ {
+			fmt.Fprintf(sb, "%s%-*s = %s\n", tabs, width, attributes[i].name, attributes[i].value)
+		}
+	}
+}
+
+// elemSchema returns the schema of the elements of a list, set or map of primitives, if any.
+func elemSchema(v *schema.Schema) *schema.Schema {
+	if v == nil {
+		return nil
+	}
+
+	elem, _ := v.Elem.(*schema.Schema)
+
+	return elem
+}
+
+func listValue(value interface{}) []interface{} {
+	switch value := value.(type) {
+	case []interface{}:
+		return value
+	case *schema.Set:
+		return value.List()
+	}
+
+	return nil
+}
+
+func isZero(value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	case int:
+		return value == 0
+	case float64:
+		return value == 0
+	case bool:
+		return !value
+	case map[string]interface{}:
+		return len(value) == 0
+	case []interface{}:
+		return len(value) == 0
+	case *schema.Set:
+		return value.Len() == 0
+	}
+
+	return false
+}
+
+// quote returns a quoted HCL string literal, escaping template sequences.
+func quote(s string) string {
+	var sb strings.Builder
+
+	sb.WriteByte('"')
+
+	for i, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case r == '\n':
+			sb.WriteString(`\n`)
+		case r == '\r':
+			sb.WriteString(`\r`)
+		case r == '\t':
+			sb.WriteString(`\t`)
+		case r < 0x20:
+			fmt.Fprintf(&sb, `\u%04x`, r)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			sb.WriteRune(r)
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	sb.WriteByte('"')
+
+	return sb.String()
+}
@@ -0,0 +1,153 @@
+package discover
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// Lister returns the import IDs of all resources of a type.
+type Lister func(ctx context.Context, meta interface{}) ([]string, error)
+
+// Resource is a discovered resource.
+type Resource struct {
+	// Type is the Terraform resource type, e.g. aws_vpc.
+	Type string
+
+	// Name is the preferred name of the resource in the configuration. Defaults to its Name tag, name or ID.
+	Name string
+
+	// ImportID is the ID the resource was imported with.
+	ImportID string
+
+	// Data is the resource's state as read by the provider.
+	Data *schema.ResourceData
+}
+
+// Discoverer discovers existing resources by listing them and reading them with the provider's own resource implementations.
+type Discoverer struct {
+	// Provider is a configured provider, i.e. its Meta is set.
+	Provider *schema.Provider
+
+	// Listers are the listers of the discoverable resource types.
+	Listers map[string]Lister
+
+	// Tags are the tags that discovered resources must have. An empty value matches any tag value.
+	Tags map[string]string
+}
+
+// Discover returns the resources of the specified types, sorted by type and import ID.
+func (d *Discoverer) Discover(ctx context.Context, types []string) ([]*Resource, error) {
+	var resources []*Resource
+
+	for _, typeName := range types {
+		lister, ok := d.Listers[typeName]
+
+		if !ok {
+			return nil, fmt.Errorf("resource type (%s) cannot be discovered", typeName)
+		}
+
+		r, ok := d.Provider.ResourcesMap[typeName]
+
+		if !ok {
+			return nil, fmt.Errorf("unknown resource type: %s", typeName)
+		}
+
+		ids, err := lister(ctx, d.Provider.Meta())
+
+		if err != nil {
+			return nil, fmt.Errorf("error listing %s resources: %w", typeName, err)
+		}
+
+		sort.Strings(ids)
+
+		for _, id := range ids {
+			resource, err := d.Read(ctx, typeName, id)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if resource == nil {
+				log.Printf("[WARN] %s (%s) not found, skipping", typeName, id)
+				continue
+			}
+
+			if !matchTags(r, resource.Data, d.Tags) {
+				continue
+			}
+
+			resources = append(resources, resource)
+		}
+	}
+
+	return resources, nil
+}
+
+// Read imports and refreshes a resource as Terraform does, returning nil if the resource does not exist.
+func (d *Discoverer) Read(ctx context.Context, typeName, importID string) (*Resource, error) {
+	r, ok := d.Provider.ResourcesMap[typeName]
+
+	if !ok {
+		return nil, fmt.Errorf("unknown resource type: %s", typeName)
+	}
+
+	states, err := d.Provider.ImportState(ctx, &terraform.InstanceInfo{Type: typeName}, importID)
+
+	if err != nil {
+		return nil, fmt.Errorf("error importing %s (%s): %w", typeName, importID, err)
+	}
+
+	if len(states) == 0 {
+		return nil, nil
+	}
+
+	state, diags := r.RefreshWithoutUpgrade(ctx, states[0], d.Provider.Meta())
+
+	for _, v := range diags {
+		if v.Severity == diag.Error {
+			return nil, fmt.Errorf("error reading %s (%s): %s: %s", typeName, importID, v.Summary, v.Detail)
+		}
+	}
+
+	if state == nil {
+		return nil, nil
+	}
+
+	return &Resource{
+		Type:     typeName,
+		ImportID: importID,
+		Data:     r.Data(state),
+	}, nil
+}
+
+func matchTags(r *schema.Resource, data *schema.ResourceData, tags map[string]string) bool {
+	if len(tags) == 0 {
+		return true
+	}
+
+	if _, ok := r.Schema["tags"]; !ok {
+		return false
+	}
+
+	m, _ := data.Get("tags").(map[string]interface{})
+
+	for k, v := range tags {
+		value, ok := m[k]
+
+		if !ok {
+			return false
+		}
+
+		if v != "" && value != v {
+			return false
+		}
+	}
+
+	return true
+}
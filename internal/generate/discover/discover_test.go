@@ -0,0 +1,333 @@
+package discover
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+)
+
+// testRemoteObjects are the remote objects read by the test provider, by ID.
+var testRemoteObjects = map[string]map[string]interface{}{
+	"net-1": {
+		"arn":        "arn:aws:test:us-west-2:123456789012:network/net-1", //lintignore:AWSAT003,AWSAT005
+		"cidr_block": "10.0.0.0/16",
+		"region":     "us-west-2", //lintignore:AWSAT003
+		"tags":       map[string]interface{}{"Name": "Main Network", "Environment": "production"},
+	},
+	"net-2": {
+		"arn":        "arn:aws:test:us-west-2:123456789012:network/net-2", //lintignore:AWSAT003,AWSAT005
+		"cidr_block": "10.1.0.0/16",
+		"region":     "us-west-2", //lintignore:AWSAT003
+		"tags":       map[string]interface{}{"Environment": "development"},
+	},
+	"sub-1": {
+		"description": "Web ${tier}",
+		"enabled":     true,
+		"name":        "web",
+		"network_id":  "net-1",
+		"region":      "us-east-1", //lintignore:AWSAT003
+		"rule": []interface{}{
+			map[string]interface{}{"cidr_blocks": []interface{}{"0.0.0.0/0"}, "port": 443, "target_id": "net-1"},
+		},
+		"tags":    map[string]interface{}{"Environment": "production", "cost-center": "42"},
+		"retries": 3,
+	},
+}
+
+func testProvider() *schema.Provider {
+	read := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		object, ok := testRemoteObjects[d.Id()]
+
+		if !ok {
+			d.SetId("")
+			return nil
+		}
+
+		for k, v := range object {
+			if err := d.Set(k, v); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		return nil
+	}
+
+	resource := func(s map[string]*schema.Schema) *schema.Resource {
+		s["region"] = &schema.Schema{Type: schema.TypeString, Optional: true, Computed: true}
+		s["tags"] = &schema.Schema{Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}}
+		s["tags_all"] = &schema.Schema{Type: schema.TypeMap, Computed: true, Elem: &schema.Schema{Type: schema.TypeString}}
+
+		return &schema.Resource{
+			ReadContext: read,
+			Importer: &schema.ResourceImporter{
+				StateContext: schema.ImportStatePassthroughContext,
+			},
+			Schema: s,
+		}
+	}
+
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"aws_test_network": resource(map[string]*schema.Schema{
+				"arn":        {Type: schema.TypeString, Computed: true},
+				"cidr_block": {Type: schema.TypeString, Required: true},
+			}),
+			"aws_test_subnet": resource(map[string]*schema.Schema{
+				"description": {Type: schema.TypeString, Optional: true},
+				"enabled":     {Type: schema.TypeBool, Optional: true},
+				"legacy":      {Type: schema.TypeString, Optional: true, Deprecated: "use description"},
+				"name":        {Type: schema.TypeString, Optional: true, Computed: true, ConflictsWith: []string{"name_prefix"}},
+				"name_prefix": {Type: schema.TypeString, Optional: true, Computed: true, ConflictsWith: []string{"name"}},
+				"network_id":  {Type: schema.TypeString, Required: true},
+				"retries":     {Type: schema.TypeInt, Optional: true, Default: 3},
+				"rule": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"cidr_blocks": {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+							"port":        {Type: schema.TypeInt, Required: true},
+							"target_id":   {Type: schema.TypeString, Optional: true},
+						},
+					},
+				},
+			}),
+		},
+	}
+}
+
+func testListers() map[string]Lister {
+	return map[string]Lister{
+		"aws_test_network": func(ctx context.Context, meta interface{}) ([]string, error) {
+			return []string{"net-2", "net-1"}, nil
+		},
+		"aws_test_subnet": func(ctx context.Context, meta interface{}) ([]string, error) {
+			return []string{"sub-1", "sub-deleted"}, nil
+		},
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	d := &Discoverer{
+		Provider: testProvider(),
+		Listers:  testListers(),
+	}
+
+	resources, err := d.Discover(context.Background(), []string{"aws_test_network", "aws_test_subnet"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+
+	for _, r := range resources {
+		got = append(got, r.Type+"/"+r.ImportID)
+	}
+
+	if expected := []string{"aws_test_network/net-1", "aws_test_network/net-2", "aws_test_subnet/sub-1"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+
+	if _, err := d.Discover(context.Background(), []string{"aws_test_unknown"}); err == nil {
+		t.Error("expected error discovering type without lister")
+	}
+}
+
+func TestDiscoverTags(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Tags     map[string]string
+		Expected []string
+	}{
+		{
+			Name:     "key and value",
+			Tags:     map[string]string{"Environment": "production"},
+			Expected: []string{"net-1", "sub-1"},
+		},
+		{
+			Name:     "key only",
+			Tags:     map[string]string{"Name": ""},
+			Expected: []string{"net-1"},
+		},
+		{
+			Name:     "no match",
+			Tags:     map[string]string{"Environment": "staging"},
+			Expected: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			d := &Discoverer{
+				Provider: testProvider(),
+				Listers:  testListers(),
+				Tags:     testCase.Tags,
+			}
+
+			resources, err := d.Discover(context.Background(), []string{"aws_test_network", "aws_test_subnet"})
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+
+			for _, r := range resources {
+				got = append(got, r.ImportID)
+			}
+
+			if !reflect.DeepEqual(got, testCase.Expected) {
+				t.Errorf("got %v, expected %v", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestConfig(t *testing.T) {
+	p := testProvider()
+	d := &Discoverer{
+		Provider: p,
+		Listers:  testListers(),
+	}
+
+	resources, err := d.Discover(context.Background(), []string{"aws_test_network", "aws_test_subnet"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//lintignore:AWSAT003,AWSAT005
+	expected := `resource "aws_test_network" "main_network" {
+  cidr_block = "10.0.0.0/16"
+  tags = {
+    Environment = "production"
+    Name        = "Main Network"
+  }
+}
+
+resource "aws_test_network" "net-2" {
+  cidr_block = "10.1.0.0/16"
+  tags = {
+    Environment = "development"
+  }
+}
+
+resource "aws_test_subnet" "web" {
+  description = "Web $${tier}"
+  enabled     = true
+  name        = "web"
+  network_id  = aws_test_network.main_network.id
+  region      = "us-east-1"
+  tags = {
+    Environment   = "production"
+    "cost-center" = "42"
+  }
+
+  rule {
+    cidr_blocks = ["0.0.0.0/0"]
+    port        = 443
+    target_id   = aws_test_network.main_network.id
+  }
+}
+
+import {
+  to = aws_test_network.main_network
+  id = "net-1"
+}
+
+import {
+  to = aws_test_network.net-2
+  id = "net-2"
+}
+
+import {
+  to = aws_test_subnet.web
+  id = "sub-1"
+}
+`
+
+	if got := string(Config(p, resources, "us-west-2")); got != expected { //lintignore:AWSAT003
+		t.Errorf("got:\n%s\nexpected:\n%s", got, expected)
+	}
+}
+
+func TestQuote(t *testing.T) {
+	testCases := []struct {
+		Input    string
+		Expected string
+	}{
+		{Input: "simple", Expected: `"simple"`},
+		{Input: `say "hi"\n`, Expected: `"say \"hi\"\\n"`},
+		{Input: "line1\nline2\ttab", Expected: `"line1\nline2\ttab"`},
+		{Input: "${var} %{if} $5 100%", Expected: `"$${var} %%{if} $5 100%"`},
+		{Input: "\x01", Expected: `"\u0001"`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Input, func(t *testing.T) {
+			if got := quote(testCase.Input); got != testCase.Expected {
+				t.Errorf("got %s, expected %s", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestListVPCsMockEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("error parsing request: %s", err)
+		}
+
+		if action := r.Form.Get("Action"); action != "DescribeVpcs" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `<Response><Errors><Error><Code>InvalidAction</Code><Message>%s</Message></Error></Errors></Response>`, action)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/xml")
+		fmt.Fprint(w, `<DescribeVpcsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <requestId>test</requestId>
+  <vpcSet>
+    <item><vpcId>vpc-default</vpcId><isDefault>true</isDefault></item>
+    <item><vpcId>vpc-12345678</vpcId><isDefault>false</isDefault></item>
+  </vpcSet>
+</DescribeVpcsResponse>`)
+	}))
+	defer server.Close()
+
+	config := &conns.Config{
+		AccessKey:               "mock",
+		SecretKey:               "mock",
+		Region:                  "us-west-2", //lintignore:AWSAT003
+		Endpoints:               map[string]string{conns.EC2: server.URL},
+		MaxRetries:              1,
+		SkipCredsValidation:     true,
+		SkipGetEC2Platforms:     true,
+		SkipMetadataApiCheck:    true,
+		SkipRequestingAccountId: true,
+	}
+
+	client, err := config.Client()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := listVPCs(context.Background(), client)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{"vpc-12345678"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+}
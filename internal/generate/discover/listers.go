@@ -0,0 +1,206 @@
+package discover
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	tfapigatewayv2 "github.com/hashicorp/terraform-provider-aws/internal/service/apigatewayv2"
+	tfec2 "github.com/hashicorp/terraform-provider-aws/internal/service/ec2"
+	tfiam "github.com/hashicorp/terraform-provider-aws/internal/service/iam"
+)
+
+// Listers returns the listers of the resource types that can be discovered.
+// Resources managed through dedicated default resources, e.g. default VPCs and aws_default_vpc, are not listed.
+func Listers() map[string]Lister {
+	return map[string]Lister{
+		"aws_apigatewayv2_api": listAPIGatewayV2APIs,
+		"aws_iam_policy":       listIAMPolicies,
+		"aws_iam_user":         listIAMUsers,
+		"aws_internet_gateway": listInternetGateways,
+		"aws_route_table":      listRouteTables,
+		"aws_security_group":   listSecurityGroups,
+		"aws_subnet":           listSubnets,
+		"aws_vpc":              listVPCs,
+	}
+}
+
+func listAPIGatewayV2APIs(ctx context.Context, meta interface{}) ([]string, error) {
+	conn := meta.(*conns.AWSClient).APIGatewayV2Conn
+
+	apis, err := tfapigatewayv2.FindAPIs(conn, &apigatewayv2.GetApisInput{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+
+	for _, v := range apis {
+		ids = append(ids, aws.StringValue(v.ApiId))
+	}
+
+	return ids, nil
+}
+
+func listIAMPolicies(ctx context.Context, meta interface{}) ([]string, error) {
+	conn := meta.(*conns.AWSClient).IAMConn
+
+	policies, err := tfiam.FindPolicies(conn, "", "", "")
+
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+
+	for _, v := range policies {
+		policyARN, err := arn.Parse(aws.StringValue(v.Arn))
+
+		// AWS managed policies cannot be managed.
+		if err != nil || policyARN.AccountID == "aws" {
+			continue
+		}
+
+		ids = append(ids, aws.StringValue(v.Arn))
+	}
+
+	return ids, nil
+}
+
+func listIAMUsers(ctx context.Context, meta interface{}) ([]string, error) {
+	conn := meta.(*conns.AWSClient).IAMConn
+
+	users, err := tfiam.FindUsers(conn, "", "")
+
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+
+	for _, v := range users {
+		ids = append(ids, aws.StringValue(v.UserName))
+	}
+
+	return ids, nil
+}
+
+func listInternetGateways(ctx context.Context, meta interface{}) ([]string, error) {
+	conn := meta.(*conns.AWSClient).EC2Conn
+
+	internetGateways, err := tfec2.FindInternetGateways(conn, &ec2.DescribeInternetGatewaysInput{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+
+	for _, v := range internetGateways {
+		ids = append(ids, aws.StringValue(v.InternetGatewayId))
+	}
+
+	return ids, nil
+}
+
+func listRouteTables(ctx context.Context, meta interface{}) ([]string, error) {
+	conn := meta.(*conns.AWSClient).EC2Conn
+
+	routeTables, err := tfec2.FindRouteTables(conn, &ec2.DescribeRouteTablesInput{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+
+	for _, v := range routeTables {
+		if isMainRouteTable(v) {
+			continue
+		}
+
+		ids = append(ids, aws.StringValue(v.RouteTableId))
+	}
+
+	return ids, nil
+}
+
+func isMainRouteTable(routeTable *ec2.RouteTable) bool {
+	for _, v := range routeTable.Associations {
+		if aws.BoolValue(v.Main) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func listSecurityGroups(ctx context.Context, meta interface{}) ([]string, error) {
+	conn := meta.(*conns.AWSClient).EC2Conn
+
+	securityGroups, err := tfec2.FindSecurityGroups(conn, &ec2.DescribeSecurityGroupsInput{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+
+	for _, v := range securityGroups {
+		if aws.StringValue(v.GroupName) == "default" {
+			continue
+		}
+
+		ids = append(ids, aws.StringValue(v.GroupId))
+	}
+
+	return ids, nil
+}
+
+func listSubnets(ctx context.Context, meta interface{}) ([]string, error) {
+	conn := meta.(*conns.AWSClient).EC2Conn
+
+	subnets, err := tfec2.FindSubnets(conn, &ec2.DescribeSubnetsInput{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+
+	for _, v := range subnets {
+		if aws.BoolValue(v.DefaultForAz) {
+			continue
+		}
+
+		ids = append(ids, aws.StringValue(v.SubnetId))
+	}
+
+	return ids, nil
+}
+
+func listVPCs(ctx context.Context, meta interface{}) ([]string, error) {
+	conn := meta.(*conns.AWSClient).EC2Conn
+
+	vpcs, err := tfec2.FindVPCs(conn, &ec2.DescribeVpcsInput{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+
+	for _, v := range vpcs {
+		if aws.BoolValue(v.IsDefault) {
+			continue
+		}
+
+		ids = append(ids, aws.StringValue(v.VpcId))
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,130 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	"github.com/hashicorp/terraform-provider-aws/internal/generate/discover"
+	"github.com/hashicorp/terraform-provider-aws/internal/provider"
+)
+
+var (
+	types     = flag.String("Types", "", "comma-separated resource types to discover (default all discoverable types)")
+	region    = flag.String("Region", os.Getenv(conns.EnvVarDefaultRegion), "region to discover resources in")
+	profile   = flag.String("Profile", "", "shared configuration profile")
+	tags      = flag.String("Tags", "", "comma-separated key=value tags that discovered resources must have; a key without value matches any value")
+	endpoint  = flag.String("Endpoint", "", "endpoint of all services, e.g. a local mock; disables credentials and account ID validation")
+	output    = flag.String("Output", "", "output file (default standard output)")
+	listTypes = flag.Bool("List", false, "list discoverable resource types")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage:\n")
+	fmt.Fprintf(os.Stderr, "\tmain.go [flags]\n\n")
+	fmt.Fprintf(os.Stderr, "Flags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	log.SetFlags(0)
+	flag.Usage = usage
+	flag.Parse()
+
+	listers := discover.Listers()
+
+	var typeNames []string
+
+	if *types != "" {
+		typeNames = strings.Split(*types, ",")
+	} else {
+		for typeName := range listers {
+			typeNames = append(typeNames, typeName)
+		}
+
+		sort.Strings(typeNames)
+	}
+
+	if *listTypes {
+		for _, typeName := range typeNames {
+			fmt.Println(typeName)
+		}
+
+		return
+	}
+
+	if *region == "" {
+		log.Fatal("region is required: set -Region or AWS_DEFAULT_REGION")
+	}
+
+	tagFilter, err := parseTags(*tags)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx := context.Background()
+	p := provider.Provider()
+
+	if err := discover.ConfigureProvider(ctx, p, discover.ProviderConfig{Region: *region, Profile: *profile, Endpoint: *endpoint}); err != nil {
+		log.Fatal(err)
+	}
+
+	d := &discover.Discoverer{
+		Provider: p,
+		Listers:  listers,
+		Tags:     tagFilter,
+	}
+
+	resources, err := d.Discover(ctx, typeNames)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	config := discover.Config(p, resources, *region)
+
+	if *output == "" {
+		os.Stdout.Write(config)
+
+		return
+	}
+
+	if err := os.WriteFile(*output, config, 0644); err != nil {
+		log.Fatalf("error writing file (%s): %s", *output, err)
+	}
+
+	log.Printf("discovered %d resources, written to %s", len(resources), *output)
+}
+
+func parseTags(s string) (map[string]string, error) {
+	tags := make(map[string]string)
+
+	if s == "" {
+		return tags, nil
+	}
+
+	for _, tag := range strings.Split(s, ",") {
+		parts := strings.SplitN(tag, "=", 2)
+
+		if parts[0] == "" {
+			return nil, fmt.Errorf("invalid tag (%q), expected key=value", tag)
+		}
+
+		if len(parts) == 2 {
+			tags[parts[0]] = parts[1]
+		} else {
+			tags[parts[0]] = ""
+		}
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,59 @@
+package discover
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+)
+
+// ProviderConfig is the configuration of the provider used for discovery.
+type ProviderConfig struct {
+	Region  string
+	Profile string
+
+	// Endpoint is the endpoint of all services, e.g. a local mock.
+	// Credentials and account ID validation are disabled when set.
+	Endpoint string
+}
+
+// ConfigureProvider configures the provider as from a provider block.
+func ConfigureProvider(ctx context.Context, p *schema.Provider, c ProviderConfig) error {
+	config := map[string]interface{}{
+		"region": c.Region,
+	}
+
+	if c.Profile != "" {
+		config["profile"] = c.Profile
+	}
+
+	if c.Endpoint != "" {
+		endpoints := make(map[string]interface{})
+
+		for _, key := range conns.HCLKeys() {
+			endpoints[key] = c.Endpoint
+		}
+
+		config["endpoints"] = []interface{}{endpoints}
+		config["s3_force_path_style"] = true
+		config["skip_credentials_validation"] = true
+		config["skip_get_ec2_platforms"] = true
+		config["skip_metadata_api_check"] = true
+		config["skip_requesting_account_id"] = true
+	}
+
+	if diags := p.Configure(ctx, terraform.NewResourceConfigRaw(config)); diags.HasError() {
+		var errs []string
+
+		for _, d := range diags {
+			errs = append(errs, fmt.Sprintf("%s: %s", d.Summary, d.Detail))
+		}
+
+		return fmt.Errorf("error configuring provider: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	"github.com/hashicorp/terraform-provider-aws/internal/generate/cloudcontrol"
+	"github.com/hashicorp/terraform-provider-aws/internal/generate/discover"
+	"github.com/hashicorp/terraform-provider-aws/internal/generate/stackmigrate"
+	"github.com/hashicorp/terraform-provider-aws/internal/provider"
+	tfcloudformation "github.com/hashicorp/terraform-provider-aws/internal/service/cloudformation"
+)
+
+var (
+	stackName = flag.String("Stack", "", "name or ID of the CloudFormation stack to migrate")
+	region    = flag.String("Region", os.Getenv(conns.EnvVarDefaultRegion), "region of the stack")
+	profile   = flag.String("Profile", "", "shared configuration profile")
+	endpoint  = flag.String("Endpoint", "", "endpoint of all services, e.g. a local mock; disables credentials and account ID validation")
+	output    = flag.String("Output", "", "output file (default standard output)")
+	retain    = flag.Bool("Retain", false, "update the stack to set DeletionPolicy: Retain on every resource, so that it can be deleted without deleting its resources")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage:\n")
+	fmt.Fprintf(os.Stderr, "\tmain.go -Stack <stack-name> [flags]\n\n")
+	fmt.Fprintf(os.Stderr, "Flags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	log.SetFlags(0)
+	flag.Usage = usage
+	flag.Parse()
+
+	if *stackName == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *region == "" {
+		log.Fatal("region is required: set -Region or AWS_DEFAULT_REGION")
+	}
+
+	ctx := context.Background()
+	p := provider.Provider()
+
+	if err := discover.ConfigureProvider(ctx, p, discover.ProviderConfig{Region: *region, Profile: *profile, Endpoint: *endpoint}); err != nil {
+		log.Fatal(err)
+	}
+
+	conn := p.Meta().(*conns.AWSClient).CloudFormationConn
+
+	stackResources, err := tfcloudformation.FindStackResources(conn, *stackName)
+
+	if err != nil {
+		log.Fatalf("error listing CloudFormation Stack (%s) resources: %s", *stackName, err)
+	}
+
+	sort.Slice(stackResources, func(i, j int) bool {
+		return aws.StringValue(stackResources[i].LogicalResourceId) < aws.StringValue(stackResources[j].LogicalResourceId)
+	})
+
+	d := &discover.Discoverer{
+		Provider: p,
+	}
+
+	var resources []*discover.Resource
+	var skipped []string
+
+	for _, v := range stackResources {
+		logicalID := aws.StringValue(v.LogicalResourceId)
+		physicalID := aws.StringValue(v.PhysicalResourceId)
+		resourceType := aws.StringValue(v.ResourceType)
+
+		if status := aws.StringValue(v.ResourceStatus); status == cloudformation.ResourceStatusDeleteComplete || physicalID == "" {
+			skipped = append(skipped, fmt.Sprintf("%s (%s): not created", logicalID, resourceType))
+			continue
+		}
+
+		mapping, ok := tfcloudformation.FindResourceTypeMapping(resourceType)
+
+		if !ok {
+			skipped = append(skipped, fmt.Sprintf("%s (%s): no Terraform resource type mapping", logicalID, resourceType))
+			continue
+		}
+
+		r, err := d.Read(ctx, mapping.TerraformType, mapping.ImportID(physicalID))
+
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s (%s): %s", logicalID, resourceType, err))
+			continue
+		}
+
+		if r == nil {
+			skipped = append(skipped, fmt.Sprintf("%s (%s): %s (%s) not found", logicalID, resourceType, mapping.TerraformType, physicalID))
+			continue
+		}
+
+		r.Name = cloudcontrol.SnakeCase(logicalID)
+		resources = append(resources, r)
+	}
+
+	var sb strings.Builder
+
+	if len(skipped) > 0 {
+		fmt.Fprintf(&sb, "# Resources of CloudFormation Stack %s not migrated:\n", *stackName)
+
+		for _, v := range skipped {
+			fmt.Fprintf(&sb, "#   %s\n", v)
+		}
+
+		sb.WriteString("\n")
+	}
+
+	sb.Write(discover.Config(p, resources, *region))
+
+	if *output == "" {
+		os.Stdout.WriteString(sb.String())
+	} else {
+		if err := os.WriteFile(*output, []byte(sb.String()), 0644); err != nil {
+			log.Fatalf("error writing file (%s): %s", *output, err)
+		}
+
+		log.Printf("migrated %d of %d resources, written to %s", len(resources), len(stackResources), *output)
+	}
+
+	if *retain {
+		if err := retainStackResources(conn, *stackName); err != nil {
+			log.Fatal(err)
+		}
+
+		log.Printf("CloudFormation Stack (%s) resources retained: deleting the stack now leaves its resources in place", *stackName)
+	}
+}
+
+// retainStackResources updates the stack's template, keeping its parameters, to retain all resources on stack deletion.
+func retainStackResources(conn *cloudformation.CloudFormation, stackName string) error {
+	stack, err := tfcloudformation.FindStackByID(conn, stackName)
+
+	if err != nil {
+		return fmt.Errorf("error reading CloudFormation Stack (%s): %w", stackName, err)
+	}
+
+	template, err := conn.GetTemplate(&cloudformation.GetTemplateInput{
+		StackName:     stack.StackId,
+		TemplateStage: aws.String(cloudformation.TemplateStageOriginal),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error reading CloudFormation Stack (%s) template: %w", stackName, err)
+	}
+
+	body, err := stackmigrate.RetainTemplate(aws.StringValue(template.TemplateBody))
+
+	if err != nil {
+		return fmt.Errorf("error updating CloudFormation Stack (%s) template: %w", stackName, err)
+	}
+
+	requestToken := resource.UniqueId()
+	input := &cloudformation.UpdateStackInput{
+		Capabilities:       stack.Capabilities,
+		ClientRequestToken: aws.String(requestToken),
+		StackName:          stack.StackId,
+		TemplateBody:       aws.String(body),
+	}
+
+	for _, v := range stack.Parameters {
+		input.Parameters = append(input.Parameters, &cloudformation.Parameter{
+			ParameterKey:     v.ParameterKey,
+			UsePreviousValue: aws.Bool(true),
+		})
+	}
+
+	_, err = conn.UpdateStack(input)
+
+	if tfawserr.ErrMessageContains(err, tfcloudformation.ErrCodeValidationError, "No updates are to be performed") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error updating CloudFormation Stack (%s): %w", stackName, err)
+	}
+
+	if _, err := tfcloudformation.WaitStackUpdated(conn, aws.StringValue(stack.StackId), requestToken, tfcloudformation.StackUpdatedDefaultTimeout); err != nil {
+		return fmt.Errorf("error waiting for CloudFormation Stack (%s) update: %w", stackName, err)
+	}
+
+	return nil
+}
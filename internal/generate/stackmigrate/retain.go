@@ -0,0 +1,219 @@
+package stackmigrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	deletionPolicyKey    = "DeletionPolicy"
+	deletionPolicyRetain = "Retain"
+)
+
+// RetainTemplate returns the CloudFormation template body with the DeletionPolicy of every resource set to Retain,
+// so that deleting the stack leaves its resources in place.
+// JSON templates are re-encoded. YAML templates are edited line by line, preserving comments and intrinsic function tags,
+// and must use block style for the Resources section and each resource.
+func RetainTemplate(body string) (string, error) {
+	if strings.HasPrefix(strings.TrimSpace(body), "{") {
+		return retainJSON(body)
+	}
+
+	return retainYAML(body)
+}
+
+func retainJSON(body string) (string, error) {
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+
+	var template map[string]interface{}
+
+	if err := decoder.Decode(&template); err != nil {
+		return "", fmt.Errorf("error decoding JSON template: %w", err)
+	}
+
+	resources, ok := template["Resources"].(map[string]interface{})
+
+	if !ok {
+		return "", fmt.Errorf("template has no Resources section")
+	}
+
+	for logicalID, v := range resources {
+		resource, ok := v.(map[string]interface{})
+
+		if !ok {
+			return "", fmt.Errorf("resource (%s) is not an object", logicalID)
+		}
+
+		resource[deletionPolicyKey] = deletionPolicyRetain
+	}
+
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(template); err != nil {
+		return "", fmt.Errorf("error encoding JSON template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+func retainYAML(body string) (string, error) {
+	lines := strings.Split(body, "\n")
+	start := -1
+
+	for i, line := range lines {
+		if key, value := yamlKeyValue(line); indentation(line) == 0 && key == "Resources" {
+			if value != "" {
+				return "", fmt.Errorf("line %d: Resources section must be a block mapping", i+1)
+			}
+
+			start = i
+
+			break
+		}
+	}
+
+	if start < 0 {
+		return "", fmt.Errorf("template has no Resources section")
+	}
+
+	output := append([]string{}, lines[:start+1]...)
+	resourceIndent := -1
+	i := start + 1
+
+	for i < len(lines) {
+		line := lines[i]
+
+		if isBlankOrComment(line) {
+			output = append(output, line)
+			i++
+
+			continue
+		}
+
+		indent := indentation(line)
+
+		if indent == 0 {
+			break
+		}
+
+		if resourceIndent < 0 {
+			resourceIndent = indent
+		}
+
+		logicalID, value := yamlKeyValue(line)
+
+		if indent != resourceIndent || logicalID == "" {
+			return "", fmt.Errorf("line %d: unexpected indentation or content in Resources section", i+1)
+		}
+
+		if value != "" {
+			return "", fmt.Errorf("line %d: resource (%s) must be a block mapping", i+1, logicalID)
+		}
+
+		output = append(output, line)
+		i++
+
+		// The resource's attributes are the following lines indented deeper than the resource.
+		attributeIndent, policy, end := -1, -1, i
+
+		for ; end < len(lines); end++ {
+			if isBlankOrComment(lines[end]) {
+				continue
+			}
+
+			indent := indentation(lines[end])
+
+			if indent <= resourceIndent {
+				break
+			}
+
+			if attributeIndent < 0 {
+				attributeIndent = indent
+			}
+
+			if key, _ := yamlKeyValue(lines[end]); indent == attributeIndent && key == deletionPolicyKey {
+				policy = end
+			}
+		}
+
+		if attributeIndent < 0 {
+			return "", fmt.Errorf("line %d: resource (%s) is empty", i, logicalID)
+		}
+
+		policyLine := strings.Repeat(" ", attributeIndent) + deletionPolicyKey + ": " + deletionPolicyRetain
+
+		if policy < 0 {
+			output = append(output, policyLine)
+		}
+
+		for ; i < end; i++ {
+			if i == policy {
+				output = append(output, policyLine)
+			} else {
+				output = append(output, lines[i])
+			}
+		}
+	}
+
+	return strings.Join(append(output, lines[i:]...), "\n"), nil
+}
+
+// yamlKeyValue returns the key and value, without trailing comment, of a block mapping entry.
+func yamlKeyValue(line string) (string, string) {
+	line = strings.TrimSpace(line)
+
+	if strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "#") {
+		return "", ""
+	}
+
+	var key string
+
+	if strings.HasPrefix(line, `"`) || strings.HasPrefix(line, "'") {
+		end := strings.IndexByte(line[1:], line[0])
+
+		if end < 0 {
+			return "", ""
+		}
+
+		key, line = line[1:end+1], line[end+2:]
+
+		if !strings.HasPrefix(line, ":") {
+			return "", ""
+		}
+
+		line = line[1:]
+	} else {
+		i := strings.Index(line, ":")
+
+		if i < 0 || (i+1 < len(line) && line[i+1] != ' ') {
+			return "", ""
+		}
+
+		key, line = line[:i], line[i+1:]
+	}
+
+	value := strings.TrimSpace(line)
+
+	if strings.HasPrefix(value, "#") {
+		value = ""
+	}
+
+	return key, value
+}
+
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func isBlankOrComment(line string) bool {
+	line = strings.TrimSpace(line)
+
+	return line == "" || strings.HasPrefix(line, "#")
+}
@@ -0,0 +1,152 @@
+package stackmigrate
+
+import (
+	"testing"
+)
+
+func TestRetainTemplateJSON(t *testing.T) {
+	input := `{
+  "AWSTemplateFormatVersion": "2010-09-09",
+  "Resources": {
+    "Bucket": {"Type": "AWS::S3::Bucket", "DeletionPolicy": "Delete"},
+    "Queue": {"Type": "AWS::SQS::Queue", "Properties": {"DelaySeconds": 5, "QueueName": "<queue>"}}
+  }
+}`
+
+	expected := `{
+  "AWSTemplateFormatVersion": "2010-09-09",
+  "Resources": {
+    "Bucket": {
+      "DeletionPolicy": "Retain",
+      "Type": "AWS::S3::Bucket"
+    },
+    "Queue": {
+      "DeletionPolicy": "Retain",
+      "Properties": {
+        "DelaySeconds": 5,
+        "QueueName": "<queue>"
+      },
+      "Type": "AWS::SQS::Queue"
+    }
+  }
+}
+`
+
+	got, err := RetainTemplate(input)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != expected {
+		t.Errorf("got:\n%s\nexpected:\n%s", got, expected)
+	}
+}
+
+func TestRetainTemplateYAML(t *testing.T) {
+	input := `AWSTemplateFormatVersion: "2010-09-09"
+Parameters:
+  Name:
+    Type: String
+Resources:
+  # The network.
+  Vpc:
+    Type: AWS::EC2::VPC
+    Properties:
+      CidrBlock: 10.0.0.0/16
+
+  Subnet:
+      DeletionPolicy: Delete # was Delete
+      Type: AWS::EC2::Subnet
+      Properties:
+        VpcId: !Ref Vpc
+        CidrBlock: !Select [0, !Cidr [!GetAtt Vpc.CidrBlock, 1, 8]]
+  "Bucket":
+    Type: AWS::S3::Bucket
+    Metadata:
+      Notes: |
+        DeletionPolicy: Delete
+Outputs:
+  VpcId:
+    Value: !Ref Vpc
+`
+
+	expected := `AWSTemplateFormatVersion: "2010-09-09"
+Parameters:
+  Name:
+    Type: String
+Resources:
+  # The network.
+  Vpc:
+    DeletionPolicy: Retain
+    Type: AWS::EC2::VPC
+    Properties:
+      CidrBlock: 10.0.0.0/16
+
+  Subnet:
+      DeletionPolicy: Retain
+      Type: AWS::EC2::Subnet
+      Properties:
+        VpcId: !Ref Vpc
+        CidrBlock: !Select [0, !Cidr [!GetAtt Vpc.CidrBlock, 1, 8]]
+  "Bucket":
+    DeletionPolicy: Retain
+    Type: AWS::S3::Bucket
+    Metadata:
+      Notes: |
+        DeletionPolicy: Delete
+Outputs:
+  VpcId:
+    Value: !Ref Vpc
+`
+
+	got, err := RetainTemplate(input)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != expected {
+		t.Errorf("got:\n%s\nexpected:\n%s", got, expected)
+	}
+}
+
+func TestRetainTemplateErrors(t *testing.T) {
+	testCases := []struct {
+		Name  string
+		Input string
+	}{
+		{
+			Name:  "JSON without resources",
+			Input: `{"Outputs": {}}`,
+		},
+		{
+			Name:  "invalid JSON",
+			Input: `{"Resources": `,
+		},
+		{
+			Name:  "YAML without resources",
+			Input: "Outputs:\n  Foo:\n    Value: bar\n",
+		},
+		{
+			Name:  "YAML flow style resources",
+			Input: "Resources: {Vpc: {Type: AWS::EC2::VPC}}\n",
+		},
+		{
+			Name:  "YAML flow style resource",
+			Input: "Resources:\n  Vpc: {Type: AWS::EC2::VPC}\n",
+		},
+		{
+			Name:  "YAML empty resource",
+			Input: "Resources:\n  Vpc:\n  Subnet:\n    Type: AWS::EC2::Subnet\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			if _, err := RetainTemplate(testCase.Input); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
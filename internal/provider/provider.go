@@ -189,6 +189,8 @@ func Provider() *schema.Provider {
 
 			"assume_role": assumeRoleSchema(),
 
+			"assume_role_with_web_identity": assumeRoleWithWebIdentitySchema(),
+
 			"shared_credentials_file": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -260,6 +262,35 @@ func Provider() *schema.Provider {
 				Description: descriptions["http_proxy"],
 			},
 
+			"no_proxy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: descriptions["no_proxy"],
+			},
+
+			"custom_ca_bundle": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWS_CA_BUNDLE", ""),
+				Description: descriptions["custom_ca_bundle"],
+			},
+
+			"ec2_metadata_service_endpoint": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("AWS_EC2_METADATA_SERVICE_ENDPOINT", ""),
+				Description:  descriptions["ec2_metadata_service_endpoint"],
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+
+			"ec2_metadata_service_endpoint_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("AWS_EC2_METADATA_SERVICE_ENDPOINT_MODE", ""),
+				Description:  descriptions["ec2_metadata_service_endpoint_mode"],
+				ValidateFunc: validation.StringInSlice(conns.EC2MetadataServiceEndpointMode_Values(), false),
+			},
+
 			"endpoints": endpointsSchema(),
 
 			"ignore_tags": {
@@ -335,6 +366,20 @@ func Provider() *schema.Provider {
 				Default:     false,
 				Description: descriptions["s3_force_path_style"],
 			},
+
+			"use_dualstack_endpoint": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWS_USE_DUALSTACK_ENDPOINT", false),
+				Description: descriptions["use_dualstack_endpoint"],
+			},
+
+			"use_fips_endpoint": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWS_USE_FIPS_ENDPOINT", false),
+				Description: descriptions["use_fips_endpoint"],
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -366,11 +411,13 @@ func Provider() *schema.Provider {
 			"aws_batch_compute_environment": batch.DataSourceComputeEnvironment(),
 			"aws_batch_job_queue":           batch.DataSourceJobQueue(),
 
-			"aws_cloudcontrolapi_resource": cloudcontrol.DataSourceResource(),
+			"aws_cloudcontrolapi_resource":  cloudcontrol.DataSourceResource(),
+			"aws_cloudcontrolapi_resources": cloudcontrol.DataSourceResources(),
 
-			"aws_cloudformation_export": cloudformation.DataSourceExport(),
-			"aws_cloudformation_stack":  cloudformation.DataSourceStack(),
-			"aws_cloudformation_type":   cloudformation.DataSourceType(),
+			"aws_cloudformation_export":          cloudformation.DataSourceExport(),
+			"aws_cloudformation_stack":           cloudformation.DataSourceStack(),
+			"aws_cloudformation_stack_resources": cloudformation.DataSourceStackResources(),
+			"aws_cloudformation_type":            cloudformation.DataSourceType(),
 
 			"aws_cloudfront_cache_policy":                   cloudfront.DataSourceCachePolicy(),
 			"aws_cloudfront_distribution":                   cloudfront.DataSourceDistribution(),
@@ -383,8 +430,11 @@ func Provider() *schema.Provider {
 
 			"aws_cloudtrail_service_account": cloudtrail.DataSourceServiceAccount(),
 
-			"aws_cloudwatch_event_connection": events.DataSourceConnection(),
-			"aws_cloudwatch_event_source":     events.DataSourceSource(),
+			"aws_cloudwatch_dashboard_document": cloudwatch.DataSourceDashboardDocument(),
+
+			"aws_cloudwatch_event_connection":    events.DataSourceConnection(),
+			"aws_cloudwatch_event_pattern_match": events.DataSourcePatternMatch(),
+			"aws_cloudwatch_event_source":        events.DataSourceSource(),
 
 			"aws_cloudwatch_log_group":  cloudwatchlogs.DataSourceGroup(),
 			"aws_cloudwatch_log_groups": cloudwatchlogs.DataSourceGroups(),
@@ -414,6 +464,7 @@ func Provider() *schema.Provider {
 
 			"aws_directory_service_directory": ds.DataSourceDirectory(),
 
+			"aws_dynamodb_query": dynamodb.DataSourceQuery(),
 			"aws_dynamodb_table": dynamodb.DataSourceTable(),
 
 			"aws_ami":                                        ec2.DataSourceAMI(),
@@ -483,10 +534,11 @@ func Provider() *schema.Provider {
 			"aws_ecr_image":               ecr.DataSourceImage(),
 			"aws_ecr_repository":          ecr.DataSourceRepository(),
 
-			"aws_ecs_cluster":              ecs.DataSourceCluster(),
-			"aws_ecs_container_definition": ecs.DataSourceContainerDefinition(),
-			"aws_ecs_service":              ecs.DataSourceService(),
-			"aws_ecs_task_definition":      ecs.DataSourceTaskDefinition(),
+			"aws_ecs_cluster":                       ecs.DataSourceCluster(),
+			"aws_ecs_container_definition":          ecs.DataSourceContainerDefinition(),
+			"aws_ecs_container_definition_document": ecs.DataSourceContainerDefinitionDocument(),
+			"aws_ecs_service":                       ecs.DataSourceService(),
+			"aws_ecs_task_definition":               ecs.DataSourceTaskDefinition(),
 
 			"aws_efs_access_point":  efs.DataSourceAccessPoint(),
 			"aws_efs_access_points": efs.DataSourceAccessPoints(),
@@ -497,6 +549,7 @@ func Provider() *schema.Provider {
 			"aws_eks_cluster":      eks.DataSourceCluster(),
 			"aws_eks_clusters":     eks.DataSourceClusters(),
 			"aws_eks_cluster_auth": eks.DataSourceClusterAuth(),
+			"aws_eks_kubeconfig":   eks.DataSourceKubeconfig(),
 			"aws_eks_node_group":   eks.DataSourceNodeGroup(),
 			"aws_eks_node_groups":  eks.DataSourceNodeGroups(),
 
@@ -530,6 +583,7 @@ func Provider() *schema.Provider {
 
 			"aws_glue_connection":                       glue.DataSourceConnection(),
 			"aws_glue_data_catalog_encryption_settings": glue.DataSourceDataCatalogEncryptionSettings(),
+			"aws_glue_schema_inference":                 glue.DataSourceSchemaInference(),
 			"aws_glue_script":                           glue.DataSourceScript(),
 
 			"aws_guardduty_detector": guardduty.DataSourceDetector(),
@@ -605,6 +659,8 @@ func Provider() *schema.Provider {
 			"aws_neptune_engine_version":        neptune.DataSourceEngineVersion(),
 			"aws_neptune_orderable_db_instance": neptune.DataSourceOrderableDBInstance(),
 
+			"aws_networkfirewall_suricata_rules": networkfirewall.DataSourceSuricataRules(),
+
 			"aws_organizations_delegated_administrators": organizations.DataSourceDelegatedAdministrators(),
 			"aws_organizations_delegated_services":       organizations.DataSourceDelegatedServices(),
 			"aws_organizations_organization":             organizations.DataSourceOrganization(),
@@ -671,8 +727,9 @@ func Provider() *schema.Provider {
 			"aws_servicequotas_service":       servicequotas.DataSourceService(),
 			"aws_servicequotas_service_quota": servicequotas.DataSourceServiceQuota(),
 
-			"aws_sfn_activity":      sfn.DataSourceActivity(),
-			"aws_sfn_state_machine": sfn.DataSourceStateMachine(),
+			"aws_sfn_activity":                 sfn.DataSourceActivity(),
+			"aws_sfn_state_machine":            sfn.DataSourceStateMachine(),
+			"aws_sfn_state_machine_definition": sfn.DataSourceStateMachineDefinition(),
 
 			"aws_signer_signing_job":     signer.DataSourceSigningJob(),
 			"aws_signer_signing_profile": signer.DataSourceSigningProfile(),
@@ -707,6 +764,7 @@ func Provider() *schema.Provider {
 
 			"aws_wafv2_ip_set":            wafv2.DataSourceIPSet(),
 			"aws_wafv2_regex_pattern_set": wafv2.DataSourceRegexPatternSet(),
+			"aws_wafv2_rule_document":     wafv2.DataSourceRuleDocument(),
 			"aws_wafv2_rule_group":        wafv2.DataSourceRuleGroup(),
 			"aws_wafv2_web_acl":           wafv2.DataSourceWebACL(),
 
@@ -1017,6 +1075,7 @@ func Provider() *schema.Provider {
 			"aws_dynamodb_kinesis_streaming_destination": dynamodb.ResourceKinesisStreamingDestination(),
 			"aws_dynamodb_table":                         dynamodb.ResourceTable(),
 			"aws_dynamodb_table_item":                    dynamodb.ResourceTableItem(),
+			"aws_dynamodb_table_items":                   dynamodb.ResourceTableItems(),
 			"aws_dynamodb_tag":                           dynamodb.ResourceTag(),
 
 			"aws_ami":                                             ec2.ResourceAMI(),
@@ -1110,6 +1169,8 @@ func Provider() *schema.Provider {
 			"aws_vpc_peering_connection":                          ec2.ResourceVPCPeeringConnection(),
 			"aws_vpc_peering_connection_accepter":                 ec2.ResourceVPCPeeringConnectionAccepter(),
 			"aws_vpc_peering_connection_options":                  ec2.ResourceVPCPeeringConnectionOptions(),
+			"aws_vpc_security_group_egress_rule":                  ec2.ResourceSecurityGroupEgressRule(),
+			"aws_vpc_security_group_ingress_rule":                 ec2.ResourceSecurityGroupIngressRule(),
 			"aws_vpn_connection":                                  ec2.ResourceVPNConnection(),
 			"aws_vpn_connection_route":                            ec2.ResourceVPNConnectionRoute(),
 			"aws_vpn_gateway":                                     ec2.ResourceVPNGateway(),
@@ -1140,6 +1201,7 @@ func Provider() *schema.Provider {
 			"aws_eks_addon":                    eks.ResourceAddon(),
 			"aws_eks_cluster":                  eks.ResourceCluster(),
 			"aws_eks_fargate_profile":          eks.ResourceFargateProfile(),
+			"aws_eks_iam_mapping":              eks.ResourceIAMMapping(),
 			"aws_eks_identity_provider_config": eks.ResourceIdentityProviderConfig(),
 			"aws_eks_node_group":               eks.ResourceNodeGroup(),
 
@@ -1629,6 +1691,7 @@ func Provider() *schema.Provider {
 			"aws_ssm_maintenance_window_target": ssm.ResourceMaintenanceWindowTarget(),
 			"aws_ssm_maintenance_window_task":   ssm.ResourceMaintenanceWindowTask(),
 			"aws_ssm_parameter":                 ssm.ResourceParameter(),
+			"aws_ssm_parameter_tree":            ssm.ResourceParameterTree(),
 			"aws_ssm_patch_baseline":            ssm.ResourcePatchBaseline(),
 			"aws_ssm_patch_group":               ssm.ResourcePatchGroup(),
 			"aws_ssm_resource_data_sync":        ssm.ResourceResourceDataSync(),
@@ -1708,6 +1771,8 @@ func Provider() *schema.Provider {
 		},
 	}
 
+	addRegionArguments(provider)
+
 	provider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
 		terraformVersion := provider.TerraformVersion
 		if terraformVersion == "" {
@@ -1750,6 +1815,20 @@ func init() {
 		"http_proxy": "The address of an HTTP proxy to use when accessing the AWS API. " +
 			"Can also be configured using the `HTTP_PROXY` or `HTTPS_PROXY` environment variables.",
 
+		"no_proxy": "Comma-separated list of hosts, domains and IP address ranges that are accessed without the HTTP proxy. " +
+			"Can also be configured using the `NO_PROXY` environment variable.",
+
+		"custom_ca_bundle": "File containing custom root and intermediate certificates in PEM format, " +
+			"trusted in place of the system's certificate authorities. " +
+			"Can also be configured using the `AWS_CA_BUNDLE` environment variable.",
+
+		"ec2_metadata_service_endpoint": "Address of the EC2 metadata service endpoint to use. " +
+			"Can also be configured using the `AWS_EC2_METADATA_SERVICE_ENDPOINT` environment variable.",
+
+		"ec2_metadata_service_endpoint_mode": "Protocol to use with the EC2 metadata service endpoint. " +
+			"Valid values are `IPv4` and `IPv6`. " +
+			"Can also be configured using the `AWS_EC2_METADATA_SERVICE_ENDPOINT_MODE` environment variable.",
+
 		"endpoint": "Use this to override the default service endpoint URL",
 
 		"insecure": "Explicitly allow the provider to perform \"insecure\" SSL requests. If omitted, " +
@@ -1774,94 +1853,67 @@ func init() {
 			"i.e., http://s3.amazonaws.com/BUCKET/KEY. By default, the S3 client will\n" +
 			"use virtual hosted bucket addressing when possible\n" +
 			"(http://BUCKET.s3.amazonaws.com/KEY). Specific to the Amazon S3 service.",
+
+		"use_dualstack_endpoint": "Resolve an endpoint with DualStack capability. " +
+			"Can also be configured using the `AWS_USE_DUALSTACK_ENDPOINT` environment variable.",
+
+		"use_fips_endpoint": "Resolve an endpoint with FIPS capability. " +
+			"Can also be configured using the `AWS_USE_FIPS_ENDPOINT` environment variable.",
 	}
 }
 
 func providerConfigure(d *schema.ResourceData, terraformVersion string) (interface{}, error) {
 	config := conns.Config{
-		AccessKey:               d.Get("access_key").(string),
-		SecretKey:               d.Get("secret_key").(string),
-		Profile:                 d.Get("profile").(string),
-		Token:                   d.Get("token").(string),
-		Region:                  d.Get("region").(string),
-		CredsFilename:           d.Get("shared_credentials_file").(string),
-		DefaultTagsConfig:       expandProviderDefaultTags(d.Get("default_tags").([]interface{})),
-		Endpoints:               make(map[string]string),
-		MaxRetries:              d.Get("max_retries").(int),
-		IgnoreTagsConfig:        expandProviderIgnoreTags(d.Get("ignore_tags").([]interface{})),
-		Insecure:                d.Get("insecure").(bool),
-		HTTPProxy:               d.Get("http_proxy").(string),
-		SkipCredsValidation:     d.Get("skip_credentials_validation").(bool),
-		SkipGetEC2Platforms:     d.Get("skip_get_ec2_platforms").(bool),
-		SkipRegionValidation:    d.Get("skip_region_validation").(bool),
-		SkipRequestingAccountId: d.Get("skip_requesting_account_id").(bool),
-		SkipMetadataApiCheck:    d.Get("skip_metadata_api_check").(bool),
-		S3ForcePathStyle:        d.Get("s3_force_path_style").(bool),
-		TerraformVersion:        terraformVersion,
+		AccessKey:                      d.Get("access_key").(string),
+		SecretKey:                      d.Get("secret_key").(string),
+		Profile:                        d.Get("profile").(string),
+		Token:                          d.Get("token").(string),
+		Region:                         d.Get("region").(string),
+		CredsFilename:                  d.Get("shared_credentials_file").(string),
+		DefaultTagsConfig:              expandProviderDefaultTags(d.Get("default_tags").([]interface{})),
+		Endpoints:                      make(map[string]string),
+		MaxRetries:                     d.Get("max_retries").(int),
+		IgnoreTagsConfig:               expandProviderIgnoreTags(d.Get("ignore_tags").([]interface{})),
+		Insecure:                       d.Get("insecure").(bool),
+		HTTPProxy:                      d.Get("http_proxy").(string),
+		NoProxy:                        d.Get("no_proxy").(string),
+		CustomCABundle:                 d.Get("custom_ca_bundle").(string),
+		EC2MetadataServiceEndpoint:     d.Get("ec2_metadata_service_endpoint").(string),
+		EC2MetadataServiceEndpointMode: d.Get("ec2_metadata_service_endpoint_mode").(string),
+		SkipCredsValidation:            d.Get("skip_credentials_validation").(bool),
+		SkipGetEC2Platforms:            d.Get("skip_get_ec2_platforms").(bool),
+		SkipRegionValidation:           d.Get("skip_region_validation").(bool),
+		SkipRequestingAccountId:        d.Get("skip_requesting_account_id").(bool),
+		SkipMetadataApiCheck:           d.Get("skip_metadata_api_check").(bool),
+		S3ForcePathStyle:               d.Get("s3_force_path_style").(bool),
+		UseDualStackEndpoint:           d.Get("use_dualstack_endpoint").(bool),
+		UseFIPSEndpoint:                d.Get("use_fips_endpoint").(bool),
+		TerraformVersion:               terraformVersion,
 	}
 
-	if l, ok := d.Get("assume_role").([]interface{}); ok && len(l) > 0 && l[0] != nil {
-		m := l[0].(map[string]interface{})
+	for _, tfMapRaw := range d.Get("assume_role").([]interface{}) {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
 
-		if v, ok := m["duration_seconds"].(int); ok && v != 0 {
-			config.AssumeRoleDurationSeconds = v
+		if !ok {
+			continue
 		}
 
-		if v, ok := m["external_id"].(string); ok && v != "" {
-			config.AssumeRoleExternalID = v
-		}
+		assumeRole := expandProviderAssumeRole(tfMap)
 
-		if v, ok := m["policy"].(string); ok && v != "" {
-			config.AssumeRolePolicy = v
+		// An empty role ARN (e.g. from a variable) disables assuming the role.
+		if assumeRole.RoleARN == "" {
+			continue
 		}
 
-		if policyARNSet, ok := m["policy_arns"].(*schema.Set); ok && policyARNSet.Len() > 0 {
-			for _, policyARNRaw := range policyARNSet.List() {
-				policyARN, ok := policyARNRaw.(string)
-
-				if !ok {
-					continue
-				}
-
-				config.AssumeRolePolicyARNs = append(config.AssumeRolePolicyARNs, policyARN)
-			}
-		}
-
-		if v, ok := m["role_arn"].(string); ok && v != "" {
-			config.AssumeRoleARN = v
-		}
+		config.AssumeRole = append(config.AssumeRole, assumeRole)
 
-		if v, ok := m["session_name"].(string); ok && v != "" {
-			config.AssumeRoleSessionName = v
-		}
-
-		if tagMapRaw, ok := m["tags"].(map[string]interface{}); ok && len(tagMapRaw) > 0 {
-			config.AssumeRoleTags = make(map[string]string)
-
-			for k, vRaw := range tagMapRaw {
-				v, ok := vRaw.(string)
-
-				if !ok {
-					continue
-				}
-
-				config.AssumeRoleTags[k] = v
-			}
-		}
-
-		if transitiveTagKeySet, ok := m["transitive_tag_keys"].(*schema.Set); ok && transitiveTagKeySet.Len() > 0 {
-			for _, transitiveTagKeyRaw := range transitiveTagKeySet.List() {
-				transitiveTagKey, ok := transitiveTagKeyRaw.(string)
-
-				if !ok {
-					continue
-				}
+		log.Printf("[INFO] assume_role configuration set: (ARN: %q, SessionID: %q, ExternalID: %q)", assumeRole.RoleARN, assumeRole.SessionName, assumeRole.ExternalID)
+	}
 
-				config.AssumeRoleTransitiveTagKeys = append(config.AssumeRoleTransitiveTagKeys, transitiveTagKey)
-			}
-		}
+	if v, ok := d.Get("assume_role_with_web_identity").([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		config.AssumeRoleWithWebIdentity = expandProviderAssumeRoleWithWebIdentity(v[0].(map[string]interface{}))
 
-		log.Printf("[INFO] assume_role configuration set: (ARN: %q, SessionID: %q, ExternalID: %q)", config.AssumeRoleARN, config.AssumeRoleSessionName, config.AssumeRoleExternalID)
+		log.Printf("[INFO] assume_role_with_web_identity configuration set: (ARN: %q, SessionID: %q)", config.AssumeRoleWithWebIdentity.RoleARN, config.AssumeRoleWithWebIdentity.SessionName)
 	}
 
 	endpointsSet := d.Get("endpoints").(*schema.Set)
@@ -1899,9 +1951,9 @@ func providerConfigure(d *schema.ResourceData, terraformVersion string) (interfa
 
 func assumeRoleSchema() *schema.Schema {
 	return &schema.Schema{
-		Type:     schema.TypeList,
-		Optional: true,
-		MaxItems: 1,
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Roles to assume, in order, prior to making API calls. Each role is assumed using the credentials of the previous role.",
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"duration_seconds": {
@@ -1957,6 +2009,63 @@ func assumeRoleSchema() *schema.Schema {
 	}
 }
 
+func assumeRoleWithWebIdentitySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"duration_seconds": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Description:  "Seconds to restrict the assume role session duration.",
+					ValidateFunc: validation.IntBetween(900, 43200),
+				},
+				"policy": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "IAM Policy JSON describing further restricting permissions for the IAM Role being assumed.",
+					ValidateFunc: validation.StringIsJSON,
+				},
+				"policy_arns": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Description: "Amazon Resource Names (ARNs) of IAM Policies describing further restricting permissions for the IAM Role being assumed.",
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: verify.ValidARN,
+					},
+				},
+				"role_arn": {
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  "Amazon Resource Name of an IAM Role to assume using a web identity token.",
+					ValidateFunc: verify.ValidARN,
+				},
+				"session_name": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Identifier for the assumed role session.",
+				},
+				"web_identity_token": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Sensitive:    true,
+					Description:  "OAuth 2.0 access token or OpenID Connect ID token provided by the identity provider.",
+					ExactlyOneOf: []string{"assume_role_with_web_identity.0.web_identity_token", "assume_role_with_web_identity.0.web_identity_token_file"},
+				},
+				"web_identity_token_file": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "Path of a file containing an OAuth 2.0 access token or OpenID Connect ID token provided by the identity provider.",
+					ExactlyOneOf: []string{"assume_role_with_web_identity.0.web_identity_token", "assume_role_with_web_identity.0.web_identity_token_file"},
+				},
+			},
+		},
+	}
+}
+
 func endpointsSchema() *schema.Schema {
 	endpointsAttributes := make(map[string]*schema.Schema)
 
@@ -2010,3 +2119,101 @@ func expandProviderIgnoreTags(l []interface{}) *tftags.IgnoreConfig {
 
 	return ignoreConfig
 }
+
+func expandProviderAssumeRole(m map[string]interface{}) conns.AssumeRole {
+	assumeRole := conns.AssumeRole{}
+
+	if v, ok := m["duration_seconds"].(int); ok && v != 0 {
+		assumeRole.DurationSeconds = v
+	}
+
+	if v, ok := m["external_id"].(string); ok && v != "" {
+		assumeRole.ExternalID = v
+	}
+
+	if v, ok := m["policy"].(string); ok && v != "" {
+		assumeRole.Policy = v
+	}
+
+	if v, ok := m["policy_arns"].(*schema.Set); ok && v.Len() > 0 {
+		assumeRole.PolicyARNs = expandProviderStringSet(v)
+	}
+
+	if v, ok := m["role_arn"].(string); ok && v != "" {
+		assumeRole.RoleARN = v
+	}
+
+	if v, ok := m["session_name"].(string); ok && v != "" {
+		assumeRole.SessionName = v
+	}
+
+	if tagMapRaw, ok := m["tags"].(map[string]interface{}); ok && len(tagMapRaw) > 0 {
+		assumeRole.Tags = make(map[string]string)
+
+		for k, vRaw := range tagMapRaw {
+			v, ok := vRaw.(string)
+
+			if !ok {
+				continue
+			}
+
+			assumeRole.Tags[k] = v
+		}
+	}
+
+	if v, ok := m["transitive_tag_keys"].(*schema.Set); ok && v.Len() > 0 {
+		assumeRole.TransitiveTagKeys = expandProviderStringSet(v)
+	}
+
+	return assumeRole
+}
+
+func expandProviderAssumeRoleWithWebIdentity(m map[string]interface{}) *conns.AssumeRoleWithWebIdentity {
+	assumeRole := &conns.AssumeRoleWithWebIdentity{}
+
+	if v, ok := m["duration_seconds"].(int); ok && v != 0 {
+		assumeRole.DurationSeconds = v
+	}
+
+	if v, ok := m["policy"].(string); ok && v != "" {
+		assumeRole.Policy = v
+	}
+
+	if v, ok := m["policy_arns"].(*schema.Set); ok && v.Len() > 0 {
+		assumeRole.PolicyARNs = expandProviderStringSet(v)
+	}
+
+	if v, ok := m["role_arn"].(string); ok && v != "" {
+		assumeRole.RoleARN = v
+	}
+
+	if v, ok := m["session_name"].(string); ok && v != "" {
+		assumeRole.SessionName = v
+	}
+
+	if v, ok := m["web_identity_token"].(string); ok && v != "" {
+		assumeRole.WebIdentityToken = v
+	}
+
+	if v, ok := m["web_identity_token_file"].(string); ok && v != "" {
+		assumeRole.WebIdentityTokenFile = v
+	}
+
+	return assumeRole
+}
+
+func expandProviderStringSet(set *schema.Set) []string {
+	var l []string
+
+	for _, vRaw := range set.List() {
+		v, ok := vRaw.(string)
+
+		if !ok {
+			continue
+		}
+
+		l = append(l, v)
+	}
+
+	return l
+}
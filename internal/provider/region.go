@@ -0,0 +1,390 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	"github.com/hashicorp/terraform-provider-aws/internal/verify"
+)
+
+const (
+	regionArgument = "region"
+
+	// regionImportIDSeparator separates a resource's import ID from the region it is imported from,
+	// e.g. vpc-0123456789abcdef0@eu-west-1.
+	regionImportIDSeparator = "@"
+)
+
+var regionRegexp = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-\d$`)
+
+// globalServicePrefixes are the name prefixes of resources and data sources for services
+// whose API is only available in a single region of each partition.
+var globalServicePrefixes = []string{
+	"aws_account_",
+	"aws_budgets_",
+	"aws_ce_",
+	"aws_chime_",
+	"aws_cloudfront_",
+	"aws_cur_",
+	"aws_globalaccelerator_",
+	"aws_iam_",
+	"aws_networkmanager_",
+	"aws_organizations_",
+	"aws_pricing_",
+	"aws_route53_",
+	"aws_route53domains_",
+	"aws_route53recoverycontrolconfig_",
+	"aws_route53recoveryreadiness_",
+	"aws_shield_",
+	"aws_waf_",
+}
+
+// regionalServicePrefixes are exceptions to globalServicePrefixes.
+var regionalServicePrefixes = []string{
+	"aws_route53_resolver_",
+}
+
+// nonRegionalNames are the resources and data sources that make no regional API calls.
+var nonRegionalNames = map[string]bool{
+	"aws_arn":                               true,
+	"aws_billing_service_account":           true,
+	"aws_caller_identity":                   true,
+	"aws_canonical_user_id":                 true,
+	"aws_cloudwatch_event_pattern_match":    true,
+	"aws_default_tags":                      true,
+	"aws_ecs_container_definition_document": true,
+	"aws_glue_schema_inference":             true,
+	"aws_ip_ranges":                         true,
+	"aws_networkfirewall_suricata_rules":    true,
+	"aws_partition":                         true,
+	"aws_region":                            true,
+	"aws_regions":                           true,
+	"aws_sfn_state_machine_definition":      true,
+	"aws_wafv2_rule_document":               true,
+}
+
+// addRegionArguments adds a top-level region argument to all the provider's regional resources and data sources
+// so that a single provider configuration can manage resources in any region.
+// Resources and data sources that already have a region attribute are left unchanged.
+func addRegionArguments(provider *schema.Provider) {
+	for name, r := range provider.ResourcesMap {
+		if isRegional(name, r) {
+			addResourceRegionArgument(r)
+		}
+	}
+
+	for name, r := range provider.DataSourcesMap {
+		if isRegional(name, r) {
+			addDataSourceRegionArgument(r)
+		}
+	}
+}
+
+func isRegional(name string, r *schema.Resource) bool {
+	if nonRegionalNames[name] {
+		return false
+	}
+
+	if _, ok := r.Schema[regionArgument]; ok {
+		return false
+	}
+
+	for _, prefix := range regionalServicePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	for _, prefix := range globalServicePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func addResourceRegionArgument(r *schema.Resource) {
+	r.Schema[regionArgument] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ForceNew:     true,
+		ValidateFunc: verify.ValidRegionName,
+	}
+
+	if f := r.Create; f != nil {
+		r.Create = func(d *schema.ResourceData, meta interface{}) error {
+			client, err := regionalClient(d, meta)
+
+			if err != nil {
+				return err
+			}
+
+			if err := d.Set(regionArgument, client.Region); err != nil {
+				return fmt.Errorf("error setting region: %w", err)
+			}
+
+			return f(d, client)
+		}
+	}
+
+	if f := r.Read; f != nil {
+		r.Read = func(d *schema.ResourceData, meta interface{}) error {
+			client, err := regionalClient(d, meta)
+
+			if err != nil {
+				return err
+			}
+
+			if err := f(d, client); err != nil {
+				return err
+			}
+
+			return setRegion(d, client)
+		}
+	}
+
+	if f := r.Update; f != nil {
+		r.Update = func(d *schema.ResourceData, meta interface{}) error {
+			client, err := regionalClient(d, meta)
+
+			if err != nil {
+				return err
+			}
+
+			return f(d, client)
+		}
+	}
+
+	if f := r.Delete; f != nil {
+		r.Delete = func(d *schema.ResourceData, meta interface{}) error {
+			client, err := regionalClient(d, meta)
+
+			if err != nil {
+				return err
+			}
+
+			return f(d, client)
+		}
+	}
+
+	r.CreateContext = wrapCreateContext(r.CreateContext)
+	r.CreateWithoutTimeout = wrapCreateContext(r.CreateWithoutTimeout)
+	r.ReadContext = wrapReadContext(r.ReadContext)
+	r.ReadWithoutTimeout = wrapReadContext(r.ReadWithoutTimeout)
+	r.UpdateContext = wrapContext(r.UpdateContext)
+	r.UpdateWithoutTimeout = wrapContext(r.UpdateWithoutTimeout)
+	r.DeleteContext = wrapContext(r.DeleteContext)
+	r.DeleteWithoutTimeout = wrapContext(r.DeleteWithoutTimeout)
+
+	customizeDiff := r.CustomizeDiff
+	r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		client := meta.(*conns.AWSClient)
+
+		// An unconfigured region defaults to the provider's region, so changing the provider's region replaces the resource.
+		// Resources in state from before the region argument was added are left alone until they are refreshed.
+		if o, _ := d.GetChange(regionArgument); (d.Id() == "" || o.(string) != "") && regionUnconfigured(d) {
+			if err := d.SetNew(regionArgument, client.Region); err != nil {
+				return fmt.Errorf("error setting region: %w", err)
+			}
+		}
+
+		if customizeDiff == nil {
+			return nil
+		}
+
+		client, err := client.RegionalClient(regionFromDiff(d))
+
+		if err != nil {
+			return err
+		}
+
+		return customizeDiff(ctx, d, client)
+	}
+
+	if importer := r.Importer; importer != nil {
+		state, stateContext := importer.State, importer.StateContext
+
+		r.Importer = &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				if id, region, ok := parseRegionImportID(d.Id()); ok {
+					d.SetId(id)
+
+					if err := d.Set(regionArgument, region); err != nil {
+						return nil, fmt.Errorf("error setting region: %w", err)
+					}
+				}
+
+				client, err := regionalClient(d, meta)
+
+				if err != nil {
+					return nil, err
+				}
+
+				if err := d.Set(regionArgument, client.Region); err != nil {
+					return nil, fmt.Errorf("error setting region: %w", err)
+				}
+
+				switch {
+				case stateContext != nil:
+					return stateContext(ctx, d, client)
+				case state != nil:
+					return state(d, client)
+				default:
+					return []*schema.ResourceData{d}, nil
+				}
+			},
+		}
+	}
+}
+
+func addDataSourceRegionArgument(r *schema.Resource) {
+	r.Schema[regionArgument] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: verify.ValidRegionName,
+	}
+
+	if f := r.Read; f != nil {
+		r.Read = func(d *schema.ResourceData, meta interface{}) error {
+			client, err := regionalClient(d, meta)
+
+			if err != nil {
+				return err
+			}
+
+			if err := f(d, client); err != nil {
+				return err
+			}
+
+			return setRegion(d, client)
+		}
+	}
+
+	r.ReadContext = wrapReadContext(r.ReadContext)
+	r.ReadWithoutTimeout = wrapReadContext(r.ReadWithoutTimeout)
+}
+
+func wrapCreateContext(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		client, err := regionalClient(d, meta)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := d.Set(regionArgument, client.Region); err != nil {
+			return diag.Errorf("error setting region: %s", err)
+		}
+
+		return f(ctx, d, client)
+	}
+}
+
+func wrapReadContext(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		client, err := regionalClient(d, meta)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		diags := f(ctx, d, client)
+
+		if diags.HasError() {
+			return diags
+		}
+
+		if err := setRegion(d, client); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+
+		return diags
+	}
+}
+
+func wrapContext(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		client, err := regionalClient(d, meta)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		return f(ctx, d, client)
+	}
+}
+
+// regionalClient returns the client for the region of a resource or data source.
+// Resources in state from before the region argument was added are in the provider's region.
+func regionalClient(d *schema.ResourceData, meta interface{}) (*conns.AWSClient, error) {
+	return meta.(*conns.AWSClient).RegionalClient(d.Get(regionArgument).(string))
+}
+
+// setRegion sets the region of a resource or data source that was found.
+func setRegion(d *schema.ResourceData, client *conns.AWSClient) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if err := d.Set(regionArgument, client.Region); err != nil {
+		return fmt.Errorf("error setting region: %w", err)
+	}
+
+	return nil
+}
+
+func regionUnconfigured(d *schema.ResourceDiff) bool {
+	v := d.GetRawConfig()
+
+	if v.IsNull() || !v.IsKnown() || !v.Type().HasAttribute(regionArgument) {
+		return false
+	}
+
+	return v.GetAttr(regionArgument).IsNull()
+}
+
+func regionFromDiff(d *schema.ResourceDiff) string {
+	if !d.NewValueKnown(regionArgument) {
+		return ""
+	}
+
+	return d.Get(regionArgument).(string)
+}
+
+// parseRegionImportID splits an import ID of the form ID@REGION.
+// IDs that contain the separator but do not end with a region name, e.g. email addresses, are not split.
+func parseRegionImportID(id string) (string, string, bool) {
+	i := strings.LastIndex(id, regionImportIDSeparator)
+
+	if i <= 0 {
+		return "", "", false
+	}
+
+	region := id[i+len(regionImportIDSeparator):]
+
+	if !regionRegexp.MatchString(region) {
+		return "", "", false
+	}
+
+	return id[:i], region, true
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestParseRegionImportID(t *testing.T) {
+	testCases := []struct {
+		ID             string
+		ExpectedID     string
+		ExpectedRegion string
+		ExpectedOK     bool
+	}{
+		{
+			ID: "vpc-0123456789abcdef0",
+		},
+		{
+			ID:             "vpc-0123456789abcdef0@eu-west-1", //lintignore:AWSAT003
+			ExpectedID:     "vpc-0123456789abcdef0",
+			ExpectedRegion: "eu-west-1", //lintignore:AWSAT003
+			ExpectedOK:     true,
+		},
+		{
+			ID:             "name@with@separators@us-gov-west-1", //lintignore:AWSAT003
+			ExpectedID:     "name@with@separators",
+			ExpectedRegion: "us-gov-west-1", //lintignore:AWSAT003
+			ExpectedOK:     true,
+		},
+		{
+			ID: "user@example.com",
+		},
+		{
+			ID: "@eu-west-1", //lintignore:AWSAT003
+		},
+		{
+			ID: "vpc-0123456789abcdef0@",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.ID, func(t *testing.T) {
+			id, region, ok := parseRegionImportID(testCase.ID)
+
+			if ok != testCase.ExpectedOK {
+				t.Fatalf("got ok %t, expected %t", ok, testCase.ExpectedOK)
+			}
+
+			if id != testCase.ExpectedID {
+				t.Errorf("got ID %q, expected %q", id, testCase.ExpectedID)
+			}
+
+			if region != testCase.ExpectedRegion {
+				t.Errorf("got region %q, expected %q", region, testCase.ExpectedRegion)
+			}
+		})
+	}
+}
+
+func TestAddRegionArguments(t *testing.T) {
+	p := Provider()
+
+	testCases := []struct {
+		Name       string
+		DataSource bool
+		Expected   bool
+	}{
+		{Name: "aws_vpc", Expected: true},
+		{Name: "aws_vpc", DataSource: true, Expected: true},
+		{Name: "aws_route53_resolver_endpoint", Expected: true},
+		{Name: "aws_route53_zone", Expected: false},
+		{Name: "aws_iam_role", Expected: false},
+		{Name: "aws_region", DataSource: true, Expected: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			m := p.ResourcesMap
+
+			if testCase.DataSource {
+				m = p.DataSourcesMap
+			}
+
+			r, ok := m[testCase.Name]
+
+			if !ok {
+				t.Fatalf("%s not found", testCase.Name)
+			}
+
+			v, ok := r.Schema[regionArgument]
+
+			if ok != testCase.Expected {
+				t.Fatalf("got region argument %t, expected %t", ok, testCase.Expected)
+			}
+
+			if ok && !v.Optional {
+				t.Errorf("region argument is not optional")
+			}
+		})
+	}
+
+	// Resources that already have a region attribute keep it.
+	if v := p.ResourcesMap["aws_s3_bucket"].Schema[regionArgument]; v.Optional {
+		t.Errorf("aws_s3_bucket region attribute changed")
+	}
+}
@@ -0,0 +1,471 @@
+package apigateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	openAPIExportTypeOAS30   = "oas30"
+	openAPIExportTypeSwagger = "swagger"
+
+	restAPIBodyDriftAdded    = "added"
+	restAPIBodyDriftModified = "modified"
+	restAPIBodyDriftRemoved  = "removed"
+)
+
+// restAPIBodyDrift describes one method whose live configuration differs
+// from the OpenAPI specification configured in the REST API body.
+type restAPIBodyDrift struct {
+	Path   string
+	Method string
+	Change string
+	Fields []string
+}
+
+var (
+	openAPIOperationKeys = []string{
+		"delete",
+		"get",
+		"head",
+		"options",
+		"patch",
+		"post",
+		"put",
+		"x-amazon-apigateway-any-method",
+	}
+
+	stageVariableReferenceRegexp = regexp.MustCompile(`\$\{stageVariables\.([^}]*)\}`)
+	stageVariableNameRegexp      = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+)
+
+// parseOpenAPIDocument decodes a JSON or YAML OpenAPI specification.
+// Values are round-tripped through JSON so that documents from either format
+// are represented by the same Go types.
+func parseOpenAPIDocument(body string) (map[string]interface{}, error) {
+	var raw interface{}
+
+	if err := json.Unmarshal([]byte(body), &raw); err != nil {
+		var yamlRaw interface{}
+
+		if err := yaml.Unmarshal([]byte(body), &yamlRaw); err != nil {
+			return nil, fmt.Errorf("specification is neither valid JSON nor YAML: %w", err)
+		}
+
+		b, err := json.Marshal(convertYAMLValue(yamlRaw))
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(b, &raw); err != nil {
+			return nil, err
+		}
+	}
+
+	doc, ok := raw.(map[string]interface{})
+
+	if !ok {
+		return nil, fmt.Errorf("specification must be an object")
+	}
+
+	return doc, nil
+}
+
+func convertYAMLValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[fmt.Sprintf("%v", k)] = convertYAMLValue(e)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, e := range v {
+			l[i] = convertYAMLValue(e)
+		}
+		return l
+	default:
+		return v
+	}
+}
+
+// openAPIExportType returns the GetExport type matching the specification version.
+func openAPIExportType(doc map[string]interface{}) string {
+	if _, ok := doc["swagger"]; ok {
+		return openAPIExportTypeSwagger
+	}
+
+	return openAPIExportTypeOAS30
+}
+
+// normalizeOpenAPIOperations returns the per-path, per-method configuration
+// that API Gateway creates from a specification. Documentation-only fields are
+// dropped and values that API Gateway defaults on import or adds on export are
+// normalized so that a configured specification and an export compare equal.
+func normalizeOpenAPIOperations(doc map[string]interface{}) map[string]map[string]interface{} {
+	operations := make(map[string]map[string]interface{})
+
+	paths, _ := doc["paths"].(map[string]interface{})
+
+	for path, v := range paths {
+		pathItem, ok := v.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		pathParameters, _ := pathItem["parameters"].([]interface{})
+		methods := make(map[string]interface{})
+
+		for _, method := range openAPIOperationKeys {
+			operation, ok := pathItem[method].(map[string]interface{})
+
+			if !ok {
+				continue
+			}
+
+			methods[method] = normalizeOpenAPIOperation(operation, pathParameters)
+		}
+
+		operations[path] = methods
+	}
+
+	return operations
+}
+
+func normalizeOpenAPIOperation(operation map[string]interface{}, pathParameters []interface{}) map[string]interface{} {
+	m := make(map[string]interface{})
+
+	parameters, _ := operation["parameters"].([]interface{})
+	if v := normalizeOpenAPIParameters(append(append([]interface{}{}, pathParameters...), parameters...)); len(v) > 0 {
+		m["parameters"] = v
+	}
+
+	if v, ok := operation["security"].([]interface{}); ok {
+		if v := normalizeOpenAPISecurity(v); len(v) > 0 {
+			m["security"] = v
+		}
+	}
+
+	if v, ok := operation["responses"].(map[string]interface{}); ok && len(v) > 0 {
+		statusCodes := make([]string, 0, len(v))
+		for statusCode := range v {
+			statusCodes = append(statusCodes, statusCode)
+		}
+		sort.Strings(statusCodes)
+		m["responses"] = statusCodes
+	}
+
+	if v, ok := operation["x-amazon-apigateway-integration"].(map[string]interface{}); ok {
+		m["x-amazon-apigateway-integration"] = normalizeOpenAPIIntegration(v)
+	}
+
+	for _, k := range []string{"x-amazon-apigateway-auth", "x-amazon-apigateway-request-validator"} {
+		if v, ok := operation[k]; ok {
+			m[k] = v
+		}
+	}
+
+	return m
+}
+
+// normalizeOpenAPIParameters reduces parameters to "in:name" identifiers, with
+// a "(required)" suffix for required parameters, sorted for comparison.
+// Body parameters describe models rather than method request parameters.
+func normalizeOpenAPIParameters(parameters []interface{}) []string {
+	seen := make(map[string]string)
+
+	for _, v := range parameters {
+		parameter, ok := v.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		in, _ := parameter["in"].(string)
+		name, _ := parameter["name"].(string)
+
+		if in == "" || in == "body" || name == "" {
+			continue
+		}
+
+		key := in + ":" + name
+		value := key
+
+		if required, _ := parameter["required"].(bool); required || in == "path" {
+			value += " (required)"
+		}
+
+		// Operation level parameters override path level parameters.
+		seen[key] = value
+	}
+
+	result := make([]string, 0, len(seen))
+	for _, v := range seen {
+		result = append(result, v)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+func normalizeOpenAPISecurity(security []interface{}) []string {
+	var result []string
+
+	for _, v := range security {
+		requirement, ok := v.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		for name := range requirement {
+			result = append(result, name)
+		}
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+func normalizeOpenAPIIntegration(integration map[string]interface{}) map[string]interface{} {
+	m := make(map[string]interface{}, len(integration))
+
+	for k, v := range integration {
+		switch k {
+		// Generated by API Gateway on export, or only used by HTTP APIs.
+		case "cacheNamespace", "payloadFormatVersion":
+			continue
+		case "type", "passthroughBehavior":
+			if s, ok := v.(string); ok {
+				v = strings.ToLower(s)
+			}
+		case "httpMethod":
+			if s, ok := v.(string); ok {
+				v = strings.ToUpper(s)
+			}
+		case "timeoutInMillis":
+			v = fmt.Sprintf("%v", v)
+		case "responses":
+			if responses, ok := v.(map[string]interface{}); ok {
+				v = normalizeOpenAPIIntegrationResponses(responses)
+			}
+		}
+
+		if isEmptyOpenAPIValue(v) {
+			continue
+		}
+
+		m[k] = v
+	}
+
+	defaults := map[string]interface{}{
+		"connectionType":      "INTERNET",
+		"passthroughBehavior": "when_no_match",
+		"timeoutInMillis":     "29000",
+	}
+
+	for k, v := range defaults {
+		if _, ok := m[k]; !ok {
+			m[k] = v
+		}
+	}
+
+	return m
+}
+
+func normalizeOpenAPIIntegrationResponses(responses map[string]interface{}) map[string]interface{} {
+	m := make(map[string]interface{}, len(responses))
+
+	for pattern, v := range responses {
+		response, ok := v.(map[string]interface{})
+
+		if !ok {
+			m[pattern] = v
+			continue
+		}
+
+		r := make(map[string]interface{}, len(response))
+
+		for k, v := range response {
+			if k == "statusCode" {
+				v = fmt.Sprintf("%v", v)
+			}
+
+			if isEmptyOpenAPIValue(v) {
+				continue
+			}
+
+			r[k] = v
+		}
+
+		m[pattern] = r
+	}
+
+	return m
+}
+
+func isEmptyOpenAPIValue(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+
+	return false
+}
+
+// diffOpenAPIOperations compares the normalized configured and live operations.
+// Methods present only in the live API are reported as added, unless the
+// specification is merged into the API, which keeps them. Methods missing
+// from the live API are reported as removed, and differing methods as modified
+// along with the dotted paths of the fields that differ.
+func diffOpenAPIOperations(configured, live map[string]map[string]interface{}, merge bool) []*restAPIBodyDrift {
+	var drift []*restAPIBodyDrift
+
+	paths := make(map[string]struct{})
+	for path := range configured {
+		paths[path] = struct{}{}
+	}
+	for path := range live {
+		paths[path] = struct{}{}
+	}
+
+	for path := range paths {
+		methods := make(map[string]struct{})
+		for method := range configured[path] {
+			methods[method] = struct{}{}
+		}
+		for method := range live[path] {
+			methods[method] = struct{}{}
+		}
+
+		for method := range methods {
+			c, inConfigured := configured[path][method]
+			l, inLive := live[path][method]
+
+			switch {
+			case !inLive:
+				drift = append(drift, &restAPIBodyDrift{Path: path, Method: method, Change: restAPIBodyDriftRemoved})
+			case !inConfigured:
+				if !merge {
+					drift = append(drift, &restAPIBodyDrift{Path: path, Method: method, Change: restAPIBodyDriftAdded})
+				}
+			default:
+				if fields := diffOpenAPIValues("", c, l); len(fields) > 0 {
+					drift = append(drift, &restAPIBodyDrift{Path: path, Method: method, Change: restAPIBodyDriftModified, Fields: fields})
+				}
+			}
+		}
+	}
+
+	sort.Slice(drift, func(i, j int) bool {
+		if drift[i].Path != drift[j].Path {
+			return drift[i].Path < drift[j].Path
+		}
+		return drift[i].Method < drift[j].Method
+	})
+
+	return drift
+}
+
+func diffOpenAPIValues(prefix string, a, b interface{}) []string {
+	am, aok := a.(map[string]interface{})
+	bm, bok := b.(map[string]interface{})
+
+	if !aok || !bok {
+		if reflect.DeepEqual(a, b) {
+			return nil
+		}
+
+		return []string{prefix}
+	}
+
+	keys := make(map[string]struct{})
+	for k := range am {
+		keys[k] = struct{}{}
+	}
+	for k := range bm {
+		keys[k] = struct{}{}
+	}
+
+	var fields []string
+
+	for k := range keys {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+
+		fields = append(fields, diffOpenAPIValues(key, am[k], bm[k])...)
+	}
+
+	sort.Strings(fields)
+
+	return fields
+}
+
+// restAPIBodyDiff returns the drift between a configured specification and
+// a specification exported from API Gateway. The mode is the PutRestApi mode
+// with which the configured specification is put.
+func restAPIBodyDiff(configured, exported, mode string) ([]*restAPIBodyDrift, error) {
+	configuredDoc, err := parseOpenAPIDocument(configured)
+
+	if err != nil {
+		return nil, fmt.Errorf("error parsing configured specification: %w", err)
+	}
+
+	exportedDoc, err := parseOpenAPIDocument(exported)
+
+	if err != nil {
+		return nil, fmt.Errorf("error parsing exported specification: %w", err)
+	}
+
+	return diffOpenAPIOperations(normalizeOpenAPIOperations(configuredDoc), normalizeOpenAPIOperations(exportedDoc), mode == apigateway.PutModeMerge), nil
+}
+
+// stageVariableNames returns the sorted, unique names of the stage variables
+// referenced as ${stageVariables.name} in a specification.
+func stageVariableNames(body string) []string {
+	seen := make(map[string]struct{})
+
+	for _, match := range stageVariableReferenceRegexp.FindAllStringSubmatch(body, -1) {
+		seen[match[1]] = struct{}{}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func flattenRestAPIBodyDrift(drift []*restAPIBodyDrift) []interface{} {
+	tfList := make([]interface{}, 0, len(drift))
+
+	for _, v := range drift {
+		tfList = append(tfList, map[string]interface{}{
+			"change": v.Change,
+			"fields": v.Fields,
+			"method": v.Method,
+			"path":   v.Path,
+		})
+	}
+
+	return tfList
+}
@@ -0,0 +1,255 @@
+package apigateway
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/apigateway"
+)
+
+func TestRestAPIBodyDiff(t *testing.T) {
+	configured := `{
+  "swagger": "2.0",
+  "info": {"title": "test", "version": "2017-04-20T04:08:08Z"},
+  "schemes": ["https"],
+  "paths": {
+    "/pets/{petId}": {
+      "parameters": [{"name": "petId", "in": "path", "type": "string"}],
+      "get": {
+        "summary": "Get a pet",
+        "responses": {"200": {"description": "OK"}},
+        "x-amazon-apigateway-integration": {
+          "httpMethod": "get",
+          "type": "HTTP",
+          "uri": "https://${stageVariables.backend}/pets/{petId}",
+          "responses": {"default": {"statusCode": 200}}
+        }
+      }
+    }
+  }
+}`
+
+	testCases := []struct {
+		Name     string
+		Exported string
+		Merge    bool
+		Expected []*restAPIBodyDrift
+	}{
+		{
+			Name: "equivalent",
+			Exported: `{
+  "swagger": "2.0",
+  "info": {"title": "test", "version": "2021-11-30T10:00:00Z"},
+  "host": "abcdef1234.execute-api.us-west-2.amazonaws.com",
+  "basePath": "/prod",
+  "paths": {
+    "/pets/{petId}": {
+      "get": {
+        "parameters": [{"name": "petId", "in": "path", "required": true, "type": "string"}],
+        "responses": {"200": {"description": "200 response"}},
+        "x-amazon-apigateway-integration": {
+          "type": "http",
+          "httpMethod": "GET",
+          "uri": "https://${stageVariables.backend}/pets/{petId}",
+          "responses": {"default": {"statusCode": "200"}},
+          "passthroughBehavior": "when_no_match",
+          "timeoutInMillis": 29000,
+          "cacheNamespace": "abc123"
+        }
+      }
+    }
+  }
+}`,
+		},
+		{
+			Name: "yaml",
+			Exported: `
+swagger: "2.0"
+paths:
+  /pets/{petId}:
+    get:
+      parameters:
+      - name: petId
+        in: path
+        required: true
+      responses:
+        "200":
+          description: 200 response
+      x-amazon-apigateway-integration:
+        type: http
+        httpMethod: GET
+        uri: https://${stageVariables.backend}/pets/{petId}
+        responses:
+          default:
+            statusCode: 200
+`,
+		},
+		{
+			Name: "modified",
+			Exported: `{
+  "swagger": "2.0",
+  "paths": {
+    "/pets/{petId}": {
+      "get": {
+        "parameters": [
+          {"name": "petId", "in": "path", "required": true},
+          {"name": "verbose", "in": "query"}
+        ],
+        "responses": {"200": {"description": "200 response"}},
+        "x-amazon-apigateway-integration": {
+          "type": "http",
+          "httpMethod": "GET",
+          "uri": "https://api.example.com/pets/{petId}",
+          "responses": {"default": {"statusCode": "200"}},
+          "timeoutInMillis": 5000
+        }
+      }
+    }
+  }
+}`,
+			Expected: []*restAPIBodyDrift{
+				{
+					Path:   "/pets/{petId}",
+					Method: "get",
+					Change: restAPIBodyDriftModified,
+					Fields: []string{
+						"parameters",
+						"x-amazon-apigateway-integration.timeoutInMillis",
+						"x-amazon-apigateway-integration.uri",
+					},
+				},
+			},
+		},
+		{
+			Name: "added and removed",
+			Exported: `{
+  "openapi": "3.0.1",
+  "paths": {
+    "/pets/{petId}": {
+      "delete": {
+        "x-amazon-apigateway-integration": {"type": "mock"}
+      }
+    },
+    "/health": {
+      "x-amazon-apigateway-any-method": {
+        "x-amazon-apigateway-integration": {"type": "mock"}
+      }
+    }
+  }
+}`,
+			Expected: []*restAPIBodyDrift{
+				{
+					Path:   "/health",
+					Method: "x-amazon-apigateway-any-method",
+					Change: restAPIBodyDriftAdded,
+				},
+				{
+					Path:   "/pets/{petId}",
+					Method: "delete",
+					Change: restAPIBodyDriftAdded,
+				},
+				{
+					Path:   "/pets/{petId}",
+					Method: "get",
+					Change: restAPIBodyDriftRemoved,
+				},
+			},
+		},
+		{
+			Name:  "added and removed in merge mode",
+			Merge: true,
+			Exported: `{
+  "openapi": "3.0.1",
+  "paths": {
+    "/pets/{petId}": {
+      "delete": {
+        "x-amazon-apigateway-integration": {"type": "mock"}
+      }
+    },
+    "/health": {
+      "x-amazon-apigateway-any-method": {
+        "x-amazon-apigateway-integration": {"type": "mock"}
+      }
+    }
+  }
+}`,
+			Expected: []*restAPIBodyDrift{
+				{
+					Path:   "/pets/{petId}",
+					Method: "get",
+					Change: restAPIBodyDriftRemoved,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			mode := apigateway.PutModeOverwrite
+
+			if testCase.Merge {
+				mode = apigateway.PutModeMerge
+			}
+
+			got, err := restAPIBodyDiff(configured, testCase.Exported, mode)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(got, testCase.Expected) {
+				t.Errorf("got drift:")
+				for _, v := range got {
+					t.Errorf("  %#v", v)
+				}
+				t.Errorf("expected drift:")
+				for _, v := range testCase.Expected {
+					t.Errorf("  %#v", v)
+				}
+			}
+		})
+	}
+}
+
+func TestRestAPIBodyDiff_invalid(t *testing.T) {
+	for _, body := range []string{`[1, 2]`, "paths: [\n"} {
+		if _, err := restAPIBodyDiff(body, `{}`, apigateway.PutModeOverwrite); err == nil {
+			t.Errorf("expected error for %q", body)
+		}
+	}
+}
+
+func TestStageVariableNames(t *testing.T) {
+	body := `{"uri": "https://${stageVariables.host}/${stageVariables.path_1}/${stageVariables.host}"}`
+	expected := []string{"host", "path_1"}
+
+	if got := stageVariableNames(body); !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+
+	if got := stageVariableNames(`{}`); len(got) != 0 {
+		t.Errorf("got %v, expected no names", got)
+	}
+}
+
+func TestValidRestAPIBodyStageVariables(t *testing.T) {
+	validBodies := []string{
+		`{}`,
+		`{"uri": "https://${stageVariables.host_1}/"}`,
+	}
+	for _, v := range validBodies {
+		if _, errors := validRestAPIBodyStageVariables(v, "body"); len(errors) != 0 {
+			t.Errorf("%q should be valid: %q", v, errors)
+		}
+	}
+
+	invalidBodies := []string{
+		`{"uri": "https://${stageVariables.host-name}/"}`,
+		`{"uri": "https://${stageVariables.}/"}`,
+	}
+	for _, v := range invalidBodies {
+		if _, errors := validRestAPIBodyStageVariables(v, "body"); len(errors) == 0 {
+			t.Errorf("%q should be invalid", v)
+		}
+	}
+}
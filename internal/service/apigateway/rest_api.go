@@ -1,6 +1,7 @@
 package apigateway
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
@@ -10,6 +11,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/apigateway"
 	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -64,6 +66,38 @@ func ResourceRestAPI() *schema.Resource {
 			},
 
 			"body": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validRestAPIBodyStageVariables,
+			},
+
+			"body_drift": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"change": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"fields": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"method": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"export_stage_name": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
@@ -80,6 +114,19 @@ func ResourceRestAPI() *schema.Resource {
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 
+			"put_rest_api_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      apigateway.PutModeOverwrite,
+				ValidateFunc: validation.StringInSlice(apigateway.PutMode_Values(), false),
+			},
+
+			"stage_variable_names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
 			"minimum_compression_size": {
 				Type:         schema.TypeInt,
 				Optional:     true,
@@ -143,7 +190,10 @@ func ResourceRestAPI() *schema.Resource {
 			"tags_all": tftags.TagsSchemaComputed(),
 		},
 
-		CustomizeDiff: verify.SetTagsDiff,
+		CustomizeDiff: customdiff.Sequence(
+			resourceRestAPIBodyCustomizeDiff,
+			verify.SetTagsDiff,
+		),
 	}
 }
 
@@ -205,7 +255,7 @@ func resourceRestAPICreate(d *schema.ResourceData, meta interface{}) error {
 
 		input := &apigateway.PutRestApiInput{
 			RestApiId: gateway.Id,
-			Mode:      aws.String(apigateway.PutModeOverwrite),
+			Mode:      aws.String(d.Get("put_rest_api_mode").(string)),
 			Body:      []byte(body.(string)),
 		}
 
@@ -427,6 +477,88 @@ func resourceRestAPIRead(d *schema.ResourceData, meta interface{}) error {
 	}.String()
 	d.Set("arn", rest_api_arn)
 
+	body := d.Get("body").(string)
+	d.Set("stage_variable_names", stageVariableNames(body))
+
+	var drift []*restAPIBodyDrift
+
+	// The stage is not redeployed yet when the specification was just put, so no drift is reported
+	// until the next refresh.
+	if v, ok := d.GetOk("export_stage_name"); ok && body != "" && !d.HasChanges("body", "body_drift") {
+		drift, err = readRestAPIBodyDrift(conn, d.Id(), v.(string), body, d.Get("put_rest_api_mode").(string))
+
+		if err != nil {
+			return fmt.Errorf("error reading API Gateway REST API (%s) body drift: %w", d.Id(), err)
+		}
+	}
+
+	if err := d.Set("body_drift", flattenRestAPIBodyDrift(drift)); err != nil {
+		return fmt.Errorf("error setting body_drift: %w", err)
+	}
+
+	return nil
+}
+
+// readRestAPIBodyDrift exports the OpenAPI specification of a deployed stage and
+// compares it with the configured specification. Drift is not reported while
+// the stage does not exist or when the configured specification can't be parsed.
+func readRestAPIBodyDrift(conn *apigateway.APIGateway, restAPIID, stageName, body, mode string) ([]*restAPIBodyDrift, error) {
+	doc, err := parseOpenAPIDocument(body)
+
+	if err != nil {
+		log.Printf("[WARN] Unable to parse API Gateway REST API (%s) body, skipping drift detection: %s", restAPIID, err)
+		return nil, nil
+	}
+
+	output, err := conn.GetExport(&apigateway.GetExportInput{
+		Accepts:    aws.String("application/json"),
+		ExportType: aws.String(openAPIExportType(doc)),
+		Parameters: aws.StringMap(map[string]string{
+			"extensions": "apigateway",
+		}),
+		RestApiId: aws.String(restAPIID),
+		StageName: aws.String(stageName),
+	})
+
+	if tfawserr.ErrCodeEquals(err, apigateway.ErrCodeNotFoundException) {
+		log.Printf("[WARN] API Gateway REST API (%s) stage (%s) not found, skipping drift detection", restAPIID, stageName)
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error exporting stage (%s): %w", stageName, err)
+	}
+
+	return restAPIBodyDiff(body, string(output.Body), mode)
+}
+
+func resourceRestAPIBodyCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.HasChange("body") {
+		if !diff.NewValueKnown("body") {
+			if err := diff.SetNewComputed("stage_variable_names"); err != nil {
+				return err
+			}
+
+			return diff.SetNewComputed("body_drift")
+		}
+
+		if err := diff.SetNew("stage_variable_names", stageVariableNames(diff.Get("body").(string))); err != nil {
+			return err
+		}
+
+		if diff.Id() != "" {
+			return diff.SetNewComputed("body_drift")
+		}
+
+		return nil
+	}
+
+	// Drift detected on refresh is planned as a change, which puts the configured specification again.
+	// The new value is unknown so that dependents, e.g. deployment triggers, are planned to change too.
+	if diff.Id() != "" && diff.Get("export_stage_name").(string) != "" && len(diff.Get("body_drift").([]interface{})) > 0 {
+		return diff.SetNewComputed("body_drift")
+	}
+
 	return nil
 }
 
@@ -566,13 +698,13 @@ func resourceRestAPIUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	if d.HasChanges("body", "parameters") {
+	if d.HasChanges("body", "body_drift", "parameters") {
 		if body, ok := d.GetOk("body"); ok {
 			log.Printf("[DEBUG] Updating API Gateway from OpenAPI spec: %s", d.Id())
 
 			input := &apigateway.PutRestApiInput{
 				RestApiId: aws.String(d.Id()),
-				Mode:      aws.String(apigateway.PutModeOverwrite),
+				Mode:      aws.String(d.Get("put_rest_api_mode").(string)),
 				Body:      []byte(body.(string)),
 			}
 
@@ -525,6 +525,141 @@ func TestAccAPIGatewayRestAPI_body(t *testing.T) {
 	})
 }
 
+func TestAccAPIGatewayRestAPI_putRestAPIMode(t *testing.T) {
+	var conf apigateway.RestApi
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_api_gateway_rest_api.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t); acctest.PreCheckAPIGatewayTypeEDGE(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, apigateway.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckRestAPIDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRestAPIPutRestAPIModeConfig(rName, "/test", apigateway.PutModeMerge),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRestAPIExists(resourceName, &conf),
+					testAccCheckRestAPIRoutes(&conf, []string{"/", "/test"}),
+					resource.TestCheckResourceAttr(resourceName, "put_rest_api_mode", apigateway.PutModeMerge),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"body", "put_rest_api_mode"},
+			},
+			// Merging keeps the routes of the previous specification.
+			{
+				Config: testAccRestAPIPutRestAPIModeConfig(rName, "/update", apigateway.PutModeMerge),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRestAPIExists(resourceName, &conf),
+					testAccCheckRestAPIRoutes(&conf, []string{"/", "/test", "/update"}),
+				),
+			},
+			{
+				Config: testAccRestAPIPutRestAPIModeConfig(rName, "/overwrite", apigateway.PutModeOverwrite),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRestAPIExists(resourceName, &conf),
+					testAccCheckRestAPIRoutes(&conf, []string{"/", "/overwrite"}),
+					resource.TestCheckResourceAttr(resourceName, "put_rest_api_mode", apigateway.PutModeOverwrite),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAPIGatewayRestAPI_bodyDrift(t *testing.T) {
+	var conf apigateway.RestApi
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_api_gateway_rest_api.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t); acctest.PreCheckAPIGatewayTypeEDGE(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, apigateway.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckRestAPIDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRestAPIBodyDriftConfig(rName, apigateway.PutModeOverwrite),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRestAPIExists(resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "body_drift.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "export_stage_name", "test"),
+					resource.TestCheckResourceAttr(resourceName, "stage_variable_names.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "stage_variable_names.0", "backend"),
+				),
+			},
+			// A method added and deployed outside of Terraform is reported as drift.
+			{
+				PreConfig:          testAccRestAPIAddRootMethod(t, &conf, "DELETE", "test"),
+				Config:             testAccRestAPIBodyDriftConfig(rName, apigateway.PutModeOverwrite),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Applying puts the specification again and the deployment triggers redeploy the stage.
+			{
+				Config: testAccRestAPIBodyDriftConfig(rName, apigateway.PutModeOverwrite),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "body_drift.#", "0"),
+				),
+			},
+			{
+				Config:   testAccRestAPIBodyDriftConfig(rName, apigateway.PutModeOverwrite),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccAPIGatewayRestAPI_bodyDriftMerge(t *testing.T) {
+	var conf apigateway.RestApi
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_api_gateway_rest_api.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t); acctest.PreCheckAPIGatewayTypeEDGE(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, apigateway.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckRestAPIDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRestAPIBodyDriftConfig(rName, apigateway.PutModeMerge),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRestAPIExists(resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "body_drift.#", "0"),
+				),
+			},
+			// Merging keeps methods that are not in the specification, so they are not reported as drift.
+			{
+				PreConfig: testAccRestAPIAddRootMethod(t, &conf, "DELETE", "test"),
+				Config:    testAccRestAPIBodyDriftConfig(rName, apigateway.PutModeMerge),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "body_drift.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAPIGatewayRestAPI_invalidStageVariable(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, apigateway.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckRestAPIDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRestAPIBodyStageVariableConfig(rName, "backend-host"),
+				ExpectError: regexp.MustCompile(`stage variable names can only contain alphanumeric and underscore characters`),
+			},
+		},
+	})
+}
+
 func TestAccAPIGatewayRestAPI_description(t *testing.T) {
 	var conf apigateway.RestApi
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
@@ -1217,6 +1352,56 @@ func testAccCheckRestAPIRoutes(conf *apigateway.RestApi, routes []string) resour
 	}
 }
 
+// testAccRestAPIAddRootMethod adds a mock method to the root resource of the
+// REST API and deploys it to the stage, outside of Terraform.
+func testAccRestAPIAddRootMethod(t *testing.T, conf *apigateway.RestApi, httpMethod, stageName string) func() {
+	return func() {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).APIGatewayConn
+
+		resp, err := conn.GetResources(&apigateway.GetResourcesInput{
+			RestApiId: conf.Id,
+		})
+		if err != nil {
+			t.Fatalf("error reading API Gateway REST API (%s) resources: %s", aws.StringValue(conf.Id), err)
+		}
+
+		var rootResourceID *string
+		for _, resource := range resp.Items {
+			if aws.StringValue(resource.Path) == "/" {
+				rootResourceID = resource.Id
+			}
+		}
+
+		_, err = conn.PutMethod(&apigateway.PutMethodInput{
+			AuthorizationType: aws.String("NONE"),
+			HttpMethod:        aws.String(httpMethod),
+			ResourceId:        rootResourceID,
+			RestApiId:         conf.Id,
+		})
+		if err != nil {
+			t.Fatalf("error creating API Gateway REST API (%s) method: %s", aws.StringValue(conf.Id), err)
+		}
+
+		_, err = conn.PutIntegration(&apigateway.PutIntegrationInput{
+			HttpMethod: aws.String(httpMethod),
+			ResourceId: rootResourceID,
+			RestApiId:  conf.Id,
+			Type:       aws.String(apigateway.IntegrationTypeMock),
+		})
+		if err != nil {
+			t.Fatalf("error creating API Gateway REST API (%s) integration: %s", aws.StringValue(conf.Id), err)
+		}
+
+		_, err = conn.CreateDeployment(&apigateway.CreateDeploymentInput{
+			RestApiId: conf.Id,
+			StageName: aws.String(stageName),
+		})
+		if err != nil {
+			t.Fatalf("error creating API Gateway REST API (%s) deployment: %s", aws.StringValue(conf.Id), err)
+		}
+	}
+}
+
 func testAccCheckRestAPIExists(n string, res *apigateway.RestApi) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -1950,6 +2135,121 @@ resource "aws_api_gateway_rest_api" "test" {
 `, rName, basePath)
 }
 
+func testAccRestAPIPutRestAPIModeConfig(rName string, basePath string, mode string) string {
+	return fmt.Sprintf(`
+resource "aws_api_gateway_rest_api" "test" {
+  name              = %[1]q
+  put_rest_api_mode = %[3]q
+
+  body = jsonencode({
+    swagger = "2.0"
+    info = {
+      title   = "test"
+      version = "2017-04-20T04:08:08Z"
+    }
+    schemes = ["https"]
+    paths = {
+      %[2]q = {
+        get = {
+          responses = {
+            "200" = {
+              description = "OK"
+            }
+          }
+          x-amazon-apigateway-integration = {
+            httpMethod = "GET"
+            type       = "HTTP"
+            responses = {
+              default = {
+                statusCode = 200
+              }
+            }
+            uri = "https://api.example.com/"
+          }
+        }
+      }
+    }
+  })
+}
+`, rName, basePath, mode)
+}
+
+func testAccRestAPIBodyDriftConfig(rName string, mode string) string {
+	return fmt.Sprintf(`
+resource "aws_api_gateway_rest_api" "test" {
+  name              = %[1]q
+  export_stage_name = "test"
+  put_rest_api_mode = %[2]q
+
+  body = jsonencode({
+    openapi = "3.0.1"
+    info = {
+      title   = "test"
+      version = "1.0"
+    }
+    paths = {
+      "/pets" = {
+        get = {
+          x-amazon-apigateway-integration = {
+            httpMethod = "GET"
+            type       = "HTTP_PROXY"
+            uri        = "https://$${stageVariables.backend}/pets"
+          }
+        }
+      }
+    }
+  })
+}
+
+resource "aws_api_gateway_deployment" "test" {
+  rest_api_id = aws_api_gateway_rest_api.test.id
+  stage_name  = "test"
+
+  variables = {
+    backend = "api.example.com"
+  }
+
+  triggers = {
+    redeployment = sha1(jsonencode([
+      aws_api_gateway_rest_api.test.body,
+      aws_api_gateway_rest_api.test.body_drift,
+    ]))
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+`, rName, mode)
+}
+
+func testAccRestAPIBodyStageVariableConfig(rName string, stageVariable string) string {
+	return fmt.Sprintf(`
+resource "aws_api_gateway_rest_api" "test" {
+  name = %[1]q
+
+  body = jsonencode({
+    swagger = "2.0"
+    info = {
+      title   = "test"
+      version = "2017-04-20T04:08:08Z"
+    }
+    paths = {
+      "/test" = {
+        get = {
+          x-amazon-apigateway-integration = {
+            httpMethod = "GET"
+            type       = "HTTP_PROXY"
+            uri        = "https://$${stageVariables.%[2]s}/"
+          }
+        }
+      }
+    }
+  })
+}
+`, rName, stageVariable)
+}
+
 func testAccRestAPIDescriptionConfig(rName string, description string) string {
 	return fmt.Sprintf(`
 resource "aws_api_gateway_rest_api" "test" {
@@ -46,3 +46,15 @@ func validUsagePlanQuotaSettings(v map[string]interface{}) (errors []error) {
 
 	return
 }
+
+// validRestAPIBodyStageVariables checks that every ${stageVariables.name}
+// reference in an OpenAPI specification uses a valid stage variable name.
+func validRestAPIBodyStageVariables(v interface{}, k string) (ws []string, errors []error) {
+	for _, match := range stageVariableReferenceRegexp.FindAllStringSubmatch(v.(string), -1) {
+		if !stageVariableNameRegexp.MatchString(match[1]) {
+			errors = append(errors, fmt.Errorf("%q references stage variable %q: stage variable names can only contain alphanumeric and underscore characters", k, match[1]))
+		}
+	}
+
+	return
+}
@@ -76,3 +76,27 @@ func FindResourceByID(ctx context.Context, conn *cloudcontrolapi.CloudControlApi
 
 	return output.ResourceDescription, nil
 }
+
+func FindResourceDescriptions(ctx context.Context, conn *cloudcontrolapi.CloudControlApi, input *cloudcontrolapi.ListResourcesInput) ([]*cloudcontrolapi.ResourceDescription, error) {
+	var output []*cloudcontrolapi.ResourceDescription
+
+	err := conn.ListResourcesPagesWithContext(ctx, input, func(page *cloudcontrolapi.ListResourcesOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, v := range page.ResourceDescriptions {
+			if v != nil {
+				output = append(output, v)
+			}
+		}
+
+		return !lastPage
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
@@ -0,0 +1,200 @@
+package cloudcontrol
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+// jsonPointerSegments returns the unescaped reference tokens of a JSON Pointer, e.g. [Tags 0 Key] for /Tags/0/Key.
+func jsonPointerSegments(pointer string) []string {
+	if pointer == "" {
+		return nil
+	}
+
+	segments := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+
+	for i, segment := range segments {
+		segments[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+	}
+
+	return segments
+}
+
+// propertyPathSegments returns the property names of a CloudFormation resource schema property pointer,
+// without the array wildcards, e.g. [Tags Key] for /properties/Tags/*/Key.
+func propertyPathSegments(pointer cfschema.PropertyJsonPointer) []string {
+	var segments []string
+
+	for _, segment := range pointer.Path() {
+		if segment != "*" {
+			segments = append(segments, segment)
+		}
+	}
+
+	return segments
+}
+
+// propertyPathMatches returns whether a properties path, e.g. [Tags 0 Key], is or is within one of the pointed to properties.
+func propertyPathMatches(pointers cfschema.PropertyJsonPointers, path []string) bool {
+	var names []string
+
+	for _, segment := range path {
+		if _, err := strconv.Atoi(segment); err != nil {
+			names = append(names, segment)
+		}
+	}
+
+	for _, pointer := range pointers {
+		segments := propertyPathSegments(pointer)
+
+		if len(segments) == 0 || len(segments) > len(names) {
+			continue
+		}
+
+		matches := true
+
+		for i, segment := range segments {
+			if names[i] != segment {
+				matches = false
+				break
+			}
+		}
+
+		if matches {
+			return true
+		}
+	}
+
+	return false
+}
+
+// removeProperties returns the properties JSON document without the pointed to properties.
+func removeProperties(properties string, pointers cfschema.PropertyJsonPointers) (string, error) {
+	var v interface{}
+
+	if err := json.Unmarshal([]byte(properties), &v); err != nil {
+		return "", fmt.Errorf("error decoding properties: %w", err)
+	}
+
+	for _, pointer := range pointers {
+		removeProperty(v, propertyPathSegments(pointer))
+	}
+
+	b, err := json.Marshal(v)
+
+	if err != nil {
+		return "", fmt.Errorf("error encoding properties: %w", err)
+	}
+
+	return string(b), nil
+}
+
+func removeProperty(v interface{}, path []string) {
+	if len(path) == 0 {
+		return
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if len(path) == 1 {
+			delete(v, path[0])
+			return
+		}
+
+		removeProperty(v[path[0]], path[1:])
+	case []interface{}:
+		for _, e := range v {
+			removeProperty(e, path)
+		}
+	}
+}
+
+// copyProperties returns the properties JSON document with the pointed to properties that are missing
+// copied from the source JSON document.
+func copyProperties(properties, source string, pointers cfschema.PropertyJsonPointers) (string, error) {
+	var dst, src interface{}
+
+	if err := json.Unmarshal([]byte(properties), &dst); err != nil {
+		return "", fmt.Errorf("error decoding properties: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(source), &src); err != nil {
+		return "", fmt.Errorf("error decoding properties: %w", err)
+	}
+
+	copied := false
+
+	for _, pointer := range pointers {
+		if copyProperty(dst, src, propertyPathSegments(pointer)) {
+			copied = true
+		}
+	}
+
+	if !copied {
+		return properties, nil
+	}
+
+	b, err := json.Marshal(dst)
+
+	if err != nil {
+		return "", fmt.Errorf("error encoding properties: %w", err)
+	}
+
+	return string(b), nil
+}
+
+func copyProperty(dst, src interface{}, path []string) bool {
+	if len(path) == 0 {
+		return false
+	}
+
+	switch dst := dst.(type) {
+	case map[string]interface{}:
+		src, ok := src.(map[string]interface{})
+
+		if !ok {
+			return false
+		}
+
+		if len(path) > 1 {
+			return copyProperty(dst[path[0]], src[path[0]], path[1:])
+		}
+
+		if _, ok := dst[path[0]]; ok {
+			return false
+		}
+
+		v, ok := src[path[0]]
+
+		if !ok {
+			return false
+		}
+
+		dst[path[0]] = v
+
+		return true
+	case []interface{}:
+		src, ok := src.([]interface{})
+
+		// Elements can only be matched when the number of elements is unchanged.
+		if !ok || len(src) != len(dst) {
+			return false
+		}
+
+		copied := false
+
+		for i := range dst {
+			if copyProperty(dst[i], src[i], path) {
+				copied = true
+			}
+		}
+
+		return copied
+	}
+
+	return false
+}
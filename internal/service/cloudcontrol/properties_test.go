@@ -0,0 +1,137 @@
+package cloudcontrol
+
+import (
+	"reflect"
+	"testing"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+	"github.com/mattbaird/jsonpatch"
+)
+
+func TestPropertyPathMatches(t *testing.T) {
+	pointers := cfschema.PropertyJsonPointers{
+		"/properties/Arn",
+		"/properties/Settings/Secret",
+		"/properties/Tags/*/Value",
+	}
+
+	testCases := []struct {
+		Pointer  string
+		Expected bool
+	}{
+		{Pointer: "/Arn", Expected: true},
+		{Pointer: "/ArnSuffix", Expected: false},
+		{Pointer: "/Name", Expected: false},
+		{Pointer: "/Settings", Expected: false},
+		{Pointer: "/Settings/Secret", Expected: true},
+		{Pointer: "/Settings/Secret/Value", Expected: true},
+		{Pointer: "/Tags/0/Key", Expected: false},
+		{Pointer: "/Tags/1/Value", Expected: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Pointer, func(t *testing.T) {
+			if got := propertyPathMatches(pointers, jsonPointerSegments(testCase.Pointer)); got != testCase.Expected {
+				t.Errorf("got %t, expected %t", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestJSONPointerSegments(t *testing.T) {
+	if got, expected := jsonPointerSegments("/Labels/a~1b/c~0d"), []string{"Labels", "a/b", "c~d"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %#v, expected %#v", got, expected)
+	}
+}
+
+func TestRemoveProperties(t *testing.T) {
+	got, err := removeProperties(
+		`{"Arn":"arn","Name":"test","Settings":{"Id":"1","Enabled":true},"Tags":[{"Key":"k1","Id":"a"},{"Key":"k2"}]}`,
+		cfschema.PropertyJsonPointers{"/properties/Arn", "/properties/Settings/Id", "/properties/Tags/*/Id", "/properties/Missing/Id"},
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := `{"Name":"test","Settings":{"Enabled":true},"Tags":[{"Key":"k1"},{"Key":"k2"}]}`; got != expected {
+		t.Errorf("got %s, expected %s", got, expected)
+	}
+}
+
+func TestCopyProperties(t *testing.T) {
+	pointers := cfschema.PropertyJsonPointers{"/properties/Password", "/properties/Settings/Secret", "/properties/Users/*/Password"}
+
+	testCases := []struct {
+		Name       string
+		Properties string
+		Source     string
+		Expected   string
+	}{
+		{
+			Name:       "no write-only values",
+			Properties: `{"Name": "test"}`,
+			Source:     `{"Name":"test"}`,
+			Expected:   `{"Name": "test"}`,
+		},
+		{
+			Name:       "write-only values",
+			Properties: `{"Name":"test","Settings":{"Enabled":true},"Users":[{"Name":"u1"},{"Name":"u2"}]}`,
+			Source:     `{"Name":"test","Password":"p","Settings":{"Enabled":true,"Secret":"s"},"Users":[{"Name":"u1","Password":"p1"},{"Name":"u2"}]}`,
+			Expected:   `{"Name":"test","Password":"p","Settings":{"Enabled":true,"Secret":"s"},"Users":[{"Name":"u1","Password":"p1"},{"Name":"u2"}]}`,
+		},
+		{
+			Name:       "returned values",
+			Properties: `{"Password":"returned"}`,
+			Source:     `{"Password":"configured"}`,
+			Expected:   `{"Password":"returned"}`,
+		},
+		{
+			Name:       "element count changed",
+			Properties: `{"Users":[{"Name":"u1"}]}`,
+			Source:     `{"Users":[{"Name":"u1","Password":"p1"},{"Name":"u2","Password":"p2"}]}`,
+			Expected:   `{"Users":[{"Name":"u1"}]}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got, err := copyProperties(testCase.Properties, testCase.Source, pointers)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != testCase.Expected {
+				t.Errorf("got %s, expected %s", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestFilterPatchOperations(t *testing.T) {
+	cfResource := &cfschema.Resource{
+		ReadOnlyProperties:  cfschema.PropertyJsonPointers{"/properties/Arn"},
+		WriteOnlyProperties: cfschema.PropertyJsonPointers{"/properties/Password"},
+	}
+
+	patch := []jsonpatch.JsonPatchOperation{
+		{Operation: "replace", Path: "/Arn", Value: "arn"},
+		{Operation: "replace", Path: "/Name", Value: "test"},
+		{Operation: "remove", Path: "/Password"},
+		{Operation: "add", Path: "/Password", Value: "p"},
+	}
+
+	expected := []jsonpatch.JsonPatchOperation{
+		{Operation: "replace", Path: "/Name", Value: "test"},
+		{Operation: "add", Path: "/Password", Value: "p"},
+	}
+
+	if got := filterPatchOperations(cfResource, patch); !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %#v, expected %#v", got, expected)
+	}
+
+	if got := filterPatchOperations(nil, patch); !reflect.DeepEqual(got, patch) {
+		t.Errorf("got %#v, expected %#v", got, patch)
+	}
+}
@@ -0,0 +1,346 @@
+package cloudcontrol
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// propertyPath is a parsed JSONPath-style expression selecting values from a resource's properties.
+// The supported subset is the optional root ($), object members (.Name or ['Name']),
+// array elements counting from the end if negative ([0] or [-1]), wildcards (.* or [*])
+// and filters selecting the array elements or object members for which a relative path
+// selects a value equal or not equal to a JSON literal ([?(@.Key=='Name')] or [?(@.Port!=22)]).
+type propertyPath []propertyPathSegment
+
+type propertyPathSegment interface {
+	selectValues(v interface{}) []interface{}
+}
+
+type memberSegment string
+
+type indexSegment int
+
+type wildcardSegment struct{}
+
+type filterSegment struct {
+	path   propertyPath
+	negate bool
+	value  interface{}
+}
+
+func (s memberSegment) selectValues(v interface{}) []interface{} {
+	if m, ok := v.(map[string]interface{}); ok {
+		if v, ok := m[string(s)]; ok {
+			return []interface{}{v}
+		}
+	}
+
+	return nil
+}
+
+func (s indexSegment) selectValues(v interface{}) []interface{} {
+	l, ok := v.([]interface{})
+
+	if !ok {
+		return nil
+	}
+
+	i := int(s)
+
+	if i < 0 {
+		i += len(l)
+	}
+
+	if i < 0 || i >= len(l) {
+		return nil
+	}
+
+	return []interface{}{l[i]}
+}
+
+func (wildcardSegment) selectValues(v interface{}) []interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		return v
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+
+		for k := range v {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		values := make([]interface{}, 0, len(keys))
+
+		for _, k := range keys {
+			values = append(values, v[k])
+		}
+
+		return values
+	}
+
+	return nil
+}
+
+func (s filterSegment) selectValues(v interface{}) []interface{} {
+	var values []interface{}
+
+	for _, v := range (wildcardSegment{}).selectValues(v) {
+		matched := false
+
+		for _, selected := range s.path.selectValues(v) {
+			if reflect.DeepEqual(selected, s.value) {
+				matched = true
+				break
+			}
+		}
+
+		if matched != s.negate {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+// selectValues returns the values selected by the path from a decoded JSON document.
+func (p propertyPath) selectValues(v interface{}) []interface{} {
+	values := []interface{}{v}
+
+	for _, segment := range p {
+		var next []interface{}
+
+		for _, v := range values {
+			next = append(next, segment.selectValues(v)...)
+		}
+
+		values = next
+	}
+
+	return values
+}
+
+// parsePropertyPath parses a JSONPath-style expression.
+func parsePropertyPath(s string) (propertyPath, error) {
+	p := &propertyPathParser{s: strings.TrimSpace(s)}
+
+	if strings.HasPrefix(p.s, "$") {
+		p.pos++
+	} else if !strings.HasPrefix(p.s, ".") && !strings.HasPrefix(p.s, "[") {
+		// A leading member name without the root, e.g. Tags[*].Key.
+		p.s = "." + p.s
+	}
+
+	path, err := p.parse(false)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid property path (%s): %w", s, err)
+	}
+
+	return path, nil
+}
+
+type propertyPathParser struct {
+	s   string
+	pos int
+}
+
+// parse parses segments until the end of the expression or, in a filter, the comparison operator.
+func (p *propertyPathParser) parse(inFilter bool) (propertyPath, error) {
+	var path propertyPath
+
+	for p.pos < len(p.s) {
+		switch c := p.s[p.pos]; {
+		case c == '.':
+			p.pos++
+
+			if p.consume("*") {
+				path = append(path, wildcardSegment{})
+				continue
+			}
+
+			name := p.scanName()
+
+			if name == "" {
+				return nil, fmt.Errorf("expected member name at offset %d", p.pos)
+			}
+
+			path = append(path, memberSegment(name))
+		case c == '[':
+			p.pos++
+
+			segment, err := p.parseBracket()
+
+			if err != nil {
+				return nil, err
+			}
+
+			path = append(path, segment)
+		case inFilter && (c == '=' || c == '!' || c == ' '):
+			return path, nil
+		default:
+			return nil, fmt.Errorf("unexpected %q at offset %d", c, p.pos)
+		}
+	}
+
+	return path, nil
+}
+
+func (p *propertyPathParser) parseBracket() (propertyPathSegment, error) {
+	var segment propertyPathSegment
+
+	switch {
+	case p.consume("*"):
+		segment = wildcardSegment{}
+	case p.consume("?("):
+		filter, err := p.parseFilter()
+
+		if err != nil {
+			return nil, err
+		}
+
+		segment = filter
+	case p.pos < len(p.s) && (p.s[p.pos] == '\'' || p.s[p.pos] == '"'):
+		name, err := p.scanQuoted()
+
+		if err != nil {
+			return nil, err
+		}
+
+		segment = memberSegment(name)
+	default:
+		end := strings.IndexByte(p.s[p.pos:], ']')
+
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated [ at offset %d", p.pos-1)
+		}
+
+		i, err := strconv.Atoi(strings.TrimSpace(p.s[p.pos : p.pos+end]))
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid array index at offset %d", p.pos)
+		}
+
+		p.pos += end
+		segment = indexSegment(i)
+	}
+
+	if !p.consume("]") {
+		return nil, fmt.Errorf("expected ] at offset %d", p.pos)
+	}
+
+	return segment, nil
+}
+
+func (p *propertyPathParser) parseFilter() (filterSegment, error) {
+	filter := filterSegment{}
+
+	if !p.consume("@") {
+		return filter, fmt.Errorf("expected @ at offset %d", p.pos)
+	}
+
+	path, err := p.parse(true)
+
+	if err != nil {
+		return filter, err
+	}
+
+	filter.path = path
+	p.skipSpaces()
+
+	switch {
+	case p.consume("=="):
+	case p.consume("!="):
+		filter.negate = true
+	default:
+		return filter, fmt.Errorf("expected == or != at offset %d", p.pos)
+	}
+
+	p.skipSpaces()
+
+	if p.pos < len(p.s) && p.s[p.pos] == '\'' {
+		value, err := p.scanQuoted()
+
+		if err != nil {
+			return filter, err
+		}
+
+		filter.value = value
+	} else {
+		end := strings.IndexByte(p.s[p.pos:], ')')
+
+		if end < 0 {
+			return filter, fmt.Errorf("unterminated filter at offset %d", p.pos)
+		}
+
+		if err := json.Unmarshal([]byte(strings.TrimSpace(p.s[p.pos:p.pos+end])), &filter.value); err != nil {
+			return filter, fmt.Errorf("invalid filter value at offset %d: %w", p.pos, err)
+		}
+
+		p.pos += end
+	}
+
+	p.skipSpaces()
+
+	if !p.consume(")") {
+		return filter, fmt.Errorf("expected ) at offset %d", p.pos)
+	}
+
+	return filter, nil
+}
+
+func (p *propertyPathParser) consume(token string) bool {
+	if strings.HasPrefix(p.s[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+
+	return false
+}
+
+func (p *propertyPathParser) skipSpaces() {
+	for p.pos < len(p.s) && p.s[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *propertyPathParser) scanName() string {
+	start := p.pos
+
+	for p.pos < len(p.s) && !strings.ContainsRune(".[]=! ", rune(p.s[p.pos])) {
+		p.pos++
+	}
+
+	return p.s[start:p.pos]
+}
+
+// scanQuoted scans a single- or double-quoted string in which the quote character can be escaped with a backslash.
+func (p *propertyPathParser) scanQuoted() (string, error) {
+	quote := p.s[p.pos]
+	start := p.pos
+	p.pos++
+
+	var sb strings.Builder
+
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		p.pos++
+
+		switch {
+		case c == '\\' && p.pos < len(p.s):
+			sb.WriteByte(p.s[p.pos])
+			p.pos++
+		case c == quote:
+			return sb.String(), nil
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return "", fmt.Errorf("unterminated string at offset %d", start)
+}
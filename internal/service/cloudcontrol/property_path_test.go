@@ -0,0 +1,197 @@
+package cloudcontrol
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestPropertyPathSelectValues(t *testing.T) {
+	document := `{
+  "BucketName": "example",
+  "Port": 443,
+  "Enabled": true,
+  "Tags": [
+    {"Key": "Environment", "Value": "production"},
+    {"Key": "Team", "Value": "platform"}
+  ],
+  "Rules": [
+    {"Port": 22, "Cidrs": ["10.0.0.0/8"]},
+    {"Port": 443, "Cidrs": ["0.0.0.0/0", "::/0"]}
+  ],
+  "Dotted.Name": "quoted"
+}`
+
+	var v interface{}
+
+	if err := json.Unmarshal([]byte(document), &v); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		Path     string
+		Expected []interface{}
+	}{
+		{
+			Path:     "$.BucketName",
+			Expected: []interface{}{"example"},
+		},
+		{
+			Path:     "BucketName",
+			Expected: []interface{}{"example"},
+		},
+		{
+			Path:     "$.Port",
+			Expected: []interface{}{float64(443)},
+		},
+		{
+			Path: "$.Missing",
+		},
+		{
+			Path:     "$.Tags[*].Key",
+			Expected: []interface{}{"Environment", "Team"},
+		},
+		{
+			Path:     "$.Tags[1].Value",
+			Expected: []interface{}{"platform"},
+		},
+		{
+			Path:     "$.Tags[-1].Key",
+			Expected: []interface{}{"Team"},
+		},
+		{
+			Path: "$.Tags[2].Key",
+		},
+		{
+			Path:     "$.Tags[?(@.Key=='Environment')].Value",
+			Expected: []interface{}{"production"},
+		},
+		{
+			Path:     "$.Tags[?(@.Key != 'Environment')].Value",
+			Expected: []interface{}{"platform"},
+		},
+		{
+			Path:     "$.Rules[?(@.Port==443)].Cidrs[*]",
+			Expected: []interface{}{"0.0.0.0/0", "::/0"},
+		},
+		{
+			Path:     "$.Rules[?(@.Cidrs[*]=='10.0.0.0/8')].Port",
+			Expected: []interface{}{float64(22)},
+		},
+		{
+			Path:     "$['Dotted.Name']",
+			Expected: []interface{}{"quoted"},
+		},
+		{
+			Path:     `$["Tags"][0]["Key"]`,
+			Expected: []interface{}{"Environment"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Path, func(t *testing.T) {
+			path, err := parsePropertyPath(testCase.Path)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			got := path.selectValues(v)
+
+			if len(got) == 0 && len(testCase.Expected) == 0 {
+				return
+			}
+
+			if !reflect.DeepEqual(got, testCase.Expected) {
+				t.Errorf("got %#v, expected %#v", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestParsePropertyPathInvalid(t *testing.T) {
+	for _, path := range []string{
+		"$.",
+		"$.Tags[",
+		"$.Tags[x]",
+		"$.Tags[*",
+		"$['Name",
+		"$.Tags[?(@.Key)]",
+		"$.Tags[?(@.Key=='x']",
+		"$.Tags[?(@.Key==x)]",
+		"$.Tags[?(Key=='x')]",
+		"$Name",
+	} {
+		t.Run(path, func(t *testing.T) {
+			if _, err := parsePropertyPath(path); err == nil {
+				t.Errorf("expected error, got none")
+			}
+		})
+	}
+}
+
+func TestResourcesFiltersMatch(t *testing.T) {
+	properties := `{"VpcId":"vpc-12345678","CidrBlock":"10.0.0.0/16","EnableDnsSupport":true,"Tags":[{"Key":"Name","Value":"example"}]}`
+
+	testCases := []struct {
+		Name     string
+		Filters  []interface{}
+		Expected bool
+	}{
+		{
+			Name: "string",
+			Filters: []interface{}{
+				map[string]interface{}{"path": "$.VpcId", "values": []interface{}{"vpc-87654321", "vpc-12345678"}},
+			},
+			Expected: true,
+		},
+		{
+			Name: "boolean",
+			Filters: []interface{}{
+				map[string]interface{}{"path": "$.EnableDnsSupport", "values": []interface{}{"true"}},
+			},
+			Expected: true,
+		},
+		{
+			Name: "all filters match",
+			Filters: []interface{}{
+				map[string]interface{}{"path": "$.CidrBlock", "values": []interface{}{"10.0.0.0/16"}},
+				map[string]interface{}{"path": "$.Tags[?(@.Key=='Name')].Value", "values": []interface{}{"example"}},
+			},
+			Expected: true,
+		},
+		{
+			Name: "one filter does not match",
+			Filters: []interface{}{
+				map[string]interface{}{"path": "$.CidrBlock", "values": []interface{}{"10.0.0.0/16"}},
+				map[string]interface{}{"path": "$.Tags[?(@.Key=='Name')].Value", "values": []interface{}{"other"}},
+			},
+		},
+		{
+			Name: "missing property",
+			Filters: []interface{}{
+				map[string]interface{}{"path": "$.InstanceTenancy", "values": []interface{}{"default"}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			filters, err := expandResourcesFilters(testCase.Filters)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			got, err := filters.match(properties)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != testCase.Expected {
+				t.Errorf("got %t, expected %t", got, testCase.Expected)
+			}
+		})
+	}
+}
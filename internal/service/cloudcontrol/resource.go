@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -29,6 +30,10 @@ func ResourceResource() *schema.Resource {
 		ReadContext:   resourceResourceRead,
 		UpdateContext: resourceResourceUpdate,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceResourceImport,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(2 * time.Hour),
 			Delete: schema.DefaultTimeout(2 * time.Hour),
@@ -141,7 +146,24 @@ func resourceResourceRead(ctx context.Context, d *schema.ResourceData, meta inte
 		return diag.FromErr(fmt.Errorf("error reading Cloud Control API Resource (%s): %w", d.Id(), err))
 	}
 
-	d.Set("properties", resourceDescription.Properties)
+	properties := aws.StringValue(resourceDescription.Properties)
+
+	cfResource, err := resourceSchemaResource(d.Get("schema").(string))
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Cloud Control API Resource (%s): %w", d.Id(), err))
+	}
+
+	// Write-only properties such as passwords are never returned, so preserve the configured values.
+	if desiredState := d.Get("desired_state").(string); cfResource != nil && len(cfResource.WriteOnlyProperties) > 0 && desiredState != "" {
+		properties, err = copyProperties(properties, desiredState, cfResource.WriteOnlyProperties)
+
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error reading Cloud Control API Resource (%s): %w", d.Id(), err))
+		}
+	}
+
+	d.Set("properties", properties)
 
 	return nil
 }
@@ -152,7 +174,13 @@ func resourceResourceUpdate(ctx context.Context, d *schema.ResourceData, meta in
 	if d.HasChange("desired_state") {
 		oldRaw, newRaw := d.GetChange("desired_state")
 
-		patchDocument, err := patchDocument(oldRaw.(string), newRaw.(string))
+		cfResource, err := resourceSchemaResource(d.Get("schema").(string))
+
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error updating Cloud Control API Resource (%s): %w", d.Id(), err))
+		}
+
+		patchDocument, err := patchDocument(cfResource, oldRaw.(string), newRaw.(string))
 
 		if err != nil {
 			return diag.Diagnostics{
@@ -237,6 +265,49 @@ func resourceResourceDelete(ctx context.Context, d *schema.ResourceData, meta in
 	return nil
 }
 
+func resourceResourceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), ",", 2)
+
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected format (%q), expected <type-name>,<identifier>", d.Id())
+	}
+
+	typeName, identifier := parts[0], parts[1]
+
+	output, err := tfcloudformation.FindTypeByName(ctx, meta.(*conns.AWSClient).CloudFormationConn, typeName)
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading CloudFormation Type (%s): %w", typeName, err)
+	}
+
+	cfResource, err := resourceSchemaResource(aws.StringValue(output.Schema))
+
+	if err != nil {
+		return nil, err
+	}
+
+	resourceDescription, err := FindResourceByID(ctx, meta.(*conns.AWSClient).CloudControlConn, identifier, typeName, "", "")
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading Cloud Control API Resource (%s): %w", identifier, err)
+	}
+
+	// Read-only properties cannot be part of the desired state.
+	// Write-only properties are not returned and must be added to the configuration.
+	desiredState, err := removeProperties(aws.StringValue(resourceDescription.Properties), cfResource.ReadOnlyProperties)
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading Cloud Control API Resource (%s): %w", identifier, err)
+	}
+
+	d.SetId(identifier)
+	d.Set("desired_state", desiredState)
+	d.Set("schema", output.Schema)
+	d.Set("type_name", typeName)
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceResourceCustomizeDiffGetSchema(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
 	conn := meta.(*conns.AWSClient).CloudFormationConn
 
@@ -303,7 +374,7 @@ func resourceResourceCustomizeDiffSchemaDiff(ctx context.Context, diff *schema.R
 		return fmt.Errorf("error creating desired_state JSON Patch: %w", err)
 	}
 
-	for _, patch := range patches {
+	for _, patch := range filterPatchOperations(cfResource, patches) {
 		if cfResource.IsCreateOnlyPropertyPath(patch.Path) {
 			if err := diff.ForceNew("desired_state"); err != nil {
 				return fmt.Errorf("error setting desired_state ForceNew: %w", err)
@@ -317,14 +388,16 @@ func resourceResourceCustomizeDiffSchemaDiff(ctx context.Context, diff *schema.R
 }
 
 // patchDocument returns a JSON Patch document describing the difference between `old` and `new`.
-func patchDocument(old, new string) (string, error) {
+// Operations on read-only properties, which cannot be updated, are ignored.
+// Removals of write-only properties are ignored as their current values are unknown, e.g. after import.
+func patchDocument(cfResource *cfschema.Resource, old, new string) (string, error) {
 	patch, err := jsonpatch.CreatePatch([]byte(old), []byte(new))
 
 	if err != nil {
 		return "", err
 	}
 
-	b, err := json.Marshal(patch)
+	b, err := json.Marshal(filterPatchOperations(cfResource, patch))
 
 	if err != nil {
 		return "", err
@@ -332,3 +405,47 @@ func patchDocument(old, new string) (string, error) {
 
 	return string(b), nil
 }
+
+// filterPatchOperations returns the JSON Patch operations that can be sent to Cloud Control API.
+func filterPatchOperations(cfResource *cfschema.Resource, patch []jsonpatch.JsonPatchOperation) []jsonpatch.JsonPatchOperation {
+	operations := make([]jsonpatch.JsonPatchOperation, 0, len(patch))
+
+	for _, operation := range patch {
+		if cfResource != nil {
+			path := jsonPointerSegments(operation.Path)
+
+			if propertyPathMatches(cfResource.ReadOnlyProperties, path) {
+				continue
+			}
+
+			if operation.Operation == "remove" && propertyPathMatches(cfResource.WriteOnlyProperties, path) {
+				continue
+			}
+		}
+
+		operations = append(operations, operation)
+	}
+
+	return operations
+}
+
+// resourceSchemaResource returns the parsed CloudFormation resource schema, or nil if the schema is empty.
+func resourceSchemaResource(resourceSchema string) (*cfschema.Resource, error) {
+	if resourceSchema == "" {
+		return nil, nil
+	}
+
+	cfResourceSchema, err := cfschema.NewResourceJsonSchemaDocument(cfschema.Sanitize(resourceSchema))
+
+	if err != nil {
+		return nil, fmt.Errorf("error parsing CloudFormation Resource Schema JSON: %w", err)
+	}
+
+	cfResource, err := cfResourceSchema.Resource()
+
+	if err != nil {
+		return nil, fmt.Errorf("error converting CloudFormation Resource Schema JSON: %w", err)
+	}
+
+	return cfResource, nil
+}
@@ -0,0 +1,441 @@
+package cloudcontrol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudcontrolapi"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
+)
+
+// ResourceType describes how the attributes of a resource with a typed schema map to
+// the properties of the CloudFormation resource type that it manages through Cloud Control API.
+// Resource types are generated from CloudFormation resource schemas by internal/generate/cloudcontrol.
+type ResourceType struct {
+	TypeName   string
+	Attributes Attributes
+}
+
+// Attributes maps Terraform attribute names to CloudFormation properties.
+type Attributes map[string]*Attribute
+
+type Attribute struct {
+	// PropertyName is the name of the CloudFormation property.
+	PropertyName string
+
+	// Attributes are the attributes of a nested block.
+	Attributes Attributes
+
+	// Object is whether the property is a single object, represented by a block with a maximum of one element,
+	// rather than an array of objects.
+	Object bool
+
+	// JSON is whether the property is an arbitrary JSON value, represented by a JSON string.
+	JSON bool
+
+	// ReadOnly is whether the property is returned by the resource type's handlers but cannot be configured.
+	ReadOnly bool
+
+	// WriteOnly is whether the property can be configured but is not returned by the resource type's handlers.
+	// Its value is preserved from the configuration.
+	WriteOnly bool
+}
+
+// NewResource returns a resource with the specified schema whose lifecycle is handled through Cloud Control API.
+func NewResource(resourceType *ResourceType, s map[string]*schema.Schema) *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceType.create,
+		ReadContext:   resourceType.read,
+		UpdateContext: resourceType.update,
+		DeleteContext: resourceType.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Hour),
+			Delete: schema.DefaultTimeout(2 * time.Hour),
+			Update: schema.DefaultTimeout(2 * time.Hour),
+		},
+
+		Schema: s,
+	}
+}
+
+func (t *ResourceType) create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.AWSClient).CloudControlConn
+
+	desiredState, err := json.Marshal(t.Attributes.expand(d.GetRawConfig()))
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating Cloud Control API Resource (%s): %w", t.TypeName, err))
+	}
+
+	input := &cloudcontrolapi.CreateResourceInput{
+		ClientToken:  aws.String(resource.UniqueId()),
+		DesiredState: aws.String(string(desiredState)),
+		TypeName:     aws.String(t.TypeName),
+	}
+
+	output, err := conn.CreateResourceWithContext(ctx, input)
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating Cloud Control API Resource (%s): %w", t.TypeName, err))
+	}
+
+	if output == nil || output.ProgressEvent == nil {
+		return diag.FromErr(fmt.Errorf("error creating Cloud Control API Resource (%s): empty result", t.TypeName))
+	}
+
+	// Always try to capture the identifier before returning errors
+	d.SetId(aws.StringValue(output.ProgressEvent.Identifier))
+
+	output.ProgressEvent, err = waitProgressEventOperationStatusSuccess(ctx, conn, aws.StringValue(output.ProgressEvent.RequestToken), d.Timeout(schema.TimeoutCreate))
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for Cloud Control API Resource (%s) create: %w", d.Id(), err))
+	}
+
+	// Some resources do not set the identifier until after creation
+	if d.Id() == "" {
+		d.SetId(aws.StringValue(output.ProgressEvent.Identifier))
+	}
+
+	return t.read(ctx, d, meta)
+}
+
+func (t *ResourceType) read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.AWSClient).CloudControlConn
+
+	resourceDescription, err := FindResourceByID(ctx, conn, d.Id(), t.TypeName, "", "")
+
+	if !d.IsNewResource() && tfresource.NotFound(err) {
+		log.Printf("[WARN] Cloud Control API Resource (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Cloud Control API Resource (%s): %w", d.Id(), err))
+	}
+
+	var properties map[string]interface{}
+
+	if err := json.Unmarshal([]byte(aws.StringValue(resourceDescription.Properties)), &properties); err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Cloud Control API Resource (%s): error decoding properties: %w", d.Id(), err))
+	}
+
+	for k, v := range t.Attributes.flatten(properties, "", d.Get) {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(fmt.Errorf("error setting %s: %w", k, err))
+		}
+	}
+
+	return nil
+}
+
+func (t *ResourceType) update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.AWSClient).CloudControlConn
+
+	oldState := t.Attributes.expand(d.GetRawState())
+	newState := t.Attributes.expand(d.GetRawConfig())
+
+	// Unconfigured optional attributes keep their values, as for any Optional and Computed attribute.
+	t.Attributes.mergeUnconfigured(newState, oldState)
+
+	oldDesiredState, err := json.Marshal(oldState)
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating Cloud Control API Resource (%s): %w", d.Id(), err))
+	}
+
+	newDesiredState, err := json.Marshal(newState)
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating Cloud Control API Resource (%s): %w", d.Id(), err))
+	}
+
+	// Read-only attributes are never expanded, so no schema is needed to filter the patch.
+	patchDocument, err := patchDocument(nil, string(oldDesiredState), string(newDesiredState))
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating Cloud Control API Resource (%s): error creating JSON Patch: %w", d.Id(), err))
+	}
+
+	if patchDocument != "[]" {
+		input := &cloudcontrolapi.UpdateResourceInput{
+			ClientToken:   aws.String(resource.UniqueId()),
+			Identifier:    aws.String(d.Id()),
+			PatchDocument: aws.String(patchDocument),
+			TypeName:      aws.String(t.TypeName),
+		}
+
+		output, err := conn.UpdateResourceWithContext(ctx, input)
+
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error updating Cloud Control API Resource (%s): %w", d.Id(), err))
+		}
+
+		if output == nil || output.ProgressEvent == nil {
+			return diag.FromErr(fmt.Errorf("error updating Cloud Control API Resource (%s): empty result", d.Id()))
+		}
+
+		if _, err := waitProgressEventOperationStatusSuccess(ctx, conn, aws.StringValue(output.ProgressEvent.RequestToken), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(fmt.Errorf("error waiting for Cloud Control API Resource (%s) update: %w", d.Id(), err))
+		}
+	}
+
+	return t.read(ctx, d, meta)
+}
+
+func (t *ResourceType) delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.AWSClient).CloudControlConn
+
+	input := &cloudcontrolapi.DeleteResourceInput{
+		ClientToken: aws.String(resource.UniqueId()),
+		Identifier:  aws.String(d.Id()),
+		TypeName:    aws.String(t.TypeName),
+	}
+
+	output, err := conn.DeleteResourceWithContext(ctx, input)
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting Cloud Control API Resource (%s): %w", d.Id(), err))
+	}
+
+	if output == nil || output.ProgressEvent == nil {
+		return diag.FromErr(fmt.Errorf("error deleting Cloud Control API Resource (%s): empty result", d.Id()))
+	}
+
+	progressEvent, err := waitProgressEventOperationStatusSuccess(ctx, conn, aws.StringValue(output.ProgressEvent.RequestToken), d.Timeout(schema.TimeoutDelete))
+
+	if progressEvent != nil && aws.StringValue(progressEvent.ErrorCode) == cloudcontrolapi.HandlerErrorCodeNotFound {
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for Cloud Control API Resource (%s) delete: %w", d.Id(), err))
+	}
+
+	return nil
+}
+
+// expand returns the CloudFormation properties of a configuration or state value.
+// Null values and read-only attributes are omitted.
+func (attributes Attributes) expand(v cty.Value) map[string]interface{} {
+	properties := make(map[string]interface{})
+
+	if v.IsNull() || !v.IsKnown() {
+		return properties
+	}
+
+	for name, attribute := range attributes {
+		if attribute.ReadOnly || !v.Type().HasAttribute(name) {
+			continue
+		}
+
+		if v := attribute.expand(v.GetAttr(name)); v != nil {
+			properties[attribute.PropertyName] = v
+		}
+	}
+
+	return properties
+}
+
+func (attribute *Attribute) expand(v cty.Value) interface{} {
+	if v.IsNull() || !v.IsKnown() {
+		return nil
+	}
+
+	ty := v.Type()
+
+	switch {
+	case attribute.Attributes != nil:
+		elements := v.AsValueSlice()
+
+		if attribute.Object {
+			if len(elements) == 0 {
+				return nil
+			}
+
+			return attribute.Attributes.expand(elements[0])
+		}
+
+		l := make([]interface{}, 0, len(elements))
+
+		for _, v := range elements {
+			l = append(l, attribute.Attributes.expand(v))
+		}
+
+		return l
+	case ty.IsListType() || ty.IsSetType():
+		l := make([]interface{}, 0, v.LengthInt())
+
+		for _, v := range v.AsValueSlice() {
+			if v := expandPrimitive(v, false); v != nil {
+				l = append(l, v)
+			}
+		}
+
+		return l
+	case ty.IsMapType():
+		m := make(map[string]interface{}, v.LengthInt())
+
+		for k, v := range v.AsValueMap() {
+			if v := expandPrimitive(v, false); v != nil {
+				m[k] = v
+			}
+		}
+
+		return m
+	default:
+		return expandPrimitive(v, attribute.JSON)
+	}
+}
+
+func expandPrimitive(v cty.Value, isJSON bool) interface{} {
+	if v.IsNull() || !v.IsKnown() {
+		return nil
+	}
+
+	switch v.Type() {
+	case cty.Bool:
+		return v.True()
+	case cty.Number:
+		if i, accuracy := v.AsBigFloat().Int64(); accuracy == 0 {
+			return i
+		}
+
+		f, _ := v.AsBigFloat().Float64()
+
+		return f
+	case cty.String:
+		if !isJSON {
+			return v.AsString()
+		}
+
+		var value interface{}
+
+		if err := json.Unmarshal([]byte(v.AsString()), &value); err != nil {
+			return v.AsString()
+		}
+
+		return value
+	}
+
+	return nil
+}
+
+// flatten returns the attribute values of CloudFormation properties.
+// The values of write-only attributes are read from the prior values using the attribute paths, e.g. "settings.0.password".
+func (attributes Attributes) flatten(properties map[string]interface{}, path string, prior func(string) interface{}) map[string]interface{} {
+	values := make(map[string]interface{}, len(attributes))
+
+	for name, attribute := range attributes {
+		attributePath := name
+
+		if path != "" {
+			attributePath = path + "." + name
+		}
+
+		if attribute.WriteOnly {
+			values[name] = prior(attributePath)
+			continue
+		}
+
+		values[name] = attribute.flatten(properties[attribute.PropertyName], attributePath, prior)
+	}
+
+	return values
+}
+
+func (attribute *Attribute) flatten(v interface{}, path string, prior func(string) interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+
+	if attribute.JSON {
+		b, err := json.Marshal(v)
+
+		if err != nil {
+			return nil
+		}
+
+		return string(b)
+	}
+
+	if attribute.Attributes != nil {
+		if attribute.Object {
+			m, ok := v.(map[string]interface{})
+
+			if !ok {
+				return nil
+			}
+
+			return []interface{}{attribute.Attributes.flatten(m, path+".0", prior)}
+		}
+
+		l, ok := v.([]interface{})
+
+		if !ok {
+			return nil
+		}
+
+		values := make([]interface{}, 0, len(l))
+
+		for i, v := range l {
+			m, ok := v.(map[string]interface{})
+
+			if !ok {
+				continue
+			}
+
+			values = append(values, attribute.Attributes.flatten(m, path+"."+strconv.Itoa(i), prior))
+		}
+
+		return values
+	}
+
+	return v
+}
+
+// mergeUnconfigured copies the properties of unconfigured attributes from the old desired state to the new desired state,
+// recursing into the objects of nested blocks that are in both.
+func (attributes Attributes) mergeUnconfigured(new, old map[string]interface{}) {
+	for _, attribute := range attributes {
+		oldValue, ok := old[attribute.PropertyName]
+
+		if !ok {
+			continue
+		}
+
+		newValue, ok := new[attribute.PropertyName]
+
+		if !ok {
+			new[attribute.PropertyName] = oldValue
+			continue
+		}
+
+		if attribute.Attributes == nil || !attribute.Object {
+			continue
+		}
+
+		newMap, newOK := newValue.(map[string]interface{})
+		oldMap, oldOK := oldValue.(map[string]interface{})
+
+		if newOK && oldOK {
+			attribute.Attributes.mergeUnconfigured(newMap, oldMap)
+		}
+	}
+}
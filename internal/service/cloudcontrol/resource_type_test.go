@@ -0,0 +1,211 @@
+package cloudcontrol
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+var testResourceTypeAttributes = Attributes{
+	"arn": {
+		PropertyName: "Arn",
+		ReadOnly:     true,
+	},
+	"name": {
+		PropertyName: "Name",
+	},
+	"password": {
+		PropertyName: "Password",
+		WriteOnly:    true,
+	},
+	"policy_document": {
+		PropertyName: "PolicyDocument",
+		JSON:         true,
+	},
+	"ports": {
+		PropertyName: "Ports",
+	},
+	"settings": {
+		PropertyName: "Settings",
+		Object:       true,
+		Attributes: Attributes{
+			"enabled": {
+				PropertyName: "Enabled",
+			},
+			"secret": {
+				PropertyName: "Secret",
+				WriteOnly:    true,
+			},
+			"weight": {
+				PropertyName: "Weight",
+			},
+		},
+	},
+	"tags": {
+		PropertyName: "Tags",
+		Attributes: Attributes{
+			"key": {
+				PropertyName: "Key",
+			},
+			"value": {
+				PropertyName: "Value",
+			},
+		},
+	},
+	"labels": {
+		PropertyName: "Labels",
+	},
+}
+
+var testResourceTypeSettingsType = cty.Object(map[string]cty.Type{
+	"enabled": cty.Bool,
+	"secret":  cty.String,
+	"weight":  cty.Number,
+})
+
+var testResourceTypeTagType = cty.Object(map[string]cty.Type{
+	"key":   cty.String,
+	"value": cty.String,
+})
+
+func TestAttributesExpand(t *testing.T) {
+	v := cty.ObjectVal(map[string]cty.Value{
+		"arn":             cty.StringVal("arn:aws:example:::test"), //lintignore:AWSAT005
+		"id":              cty.NullVal(cty.String),
+		"labels":          cty.MapVal(map[string]cty.Value{"team": cty.StringVal("platform")}),
+		"name":            cty.StringVal("test"),
+		"password":        cty.StringVal("secret"),
+		"policy_document": cty.StringVal(`{"Version":"2012-10-17"}`),
+		"ports":           cty.SetVal([]cty.Value{cty.NumberIntVal(443)}),
+		"settings": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"enabled": cty.False,
+			"secret":  cty.NullVal(cty.String),
+			"weight":  cty.NumberFloatVal(0.5),
+		})}),
+		"tags": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"key":   cty.StringVal("Name"),
+			"value": cty.StringVal("test"),
+		})}),
+	})
+
+	expected := map[string]interface{}{
+		"Labels":         map[string]interface{}{"team": "platform"},
+		"Name":           "test",
+		"Password":       "secret",
+		"PolicyDocument": map[string]interface{}{"Version": "2012-10-17"},
+		"Ports":          []interface{}{int64(443)},
+		"Settings": map[string]interface{}{
+			"Enabled": false,
+			"Weight":  0.5,
+		},
+		"Tags": []interface{}{
+			map[string]interface{}{"Key": "Name", "Value": "test"},
+		},
+	}
+
+	if got := testResourceTypeAttributes.expand(v); !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %#v, expected %#v", got, expected)
+	}
+}
+
+func TestAttributesExpandNull(t *testing.T) {
+	v := cty.ObjectVal(map[string]cty.Value{
+		"name":     cty.StringVal("test"),
+		"settings": cty.ListValEmpty(testResourceTypeSettingsType),
+		"tags":     cty.NullVal(cty.List(testResourceTypeTagType)),
+	})
+
+	expected := map[string]interface{}{
+		"Name": "test",
+	}
+
+	if got := testResourceTypeAttributes.expand(v); !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %#v, expected %#v", got, expected)
+	}
+}
+
+func TestAttributesFlatten(t *testing.T) {
+	properties := map[string]interface{}{
+		"Arn":            "arn:aws:example:::test", //lintignore:AWSAT005
+		"Name":           "test",
+		"PolicyDocument": map[string]interface{}{"Version": "2012-10-17"},
+		"Ports":          []interface{}{float64(443)},
+		"Settings": map[string]interface{}{
+			"Enabled": true,
+			"Weight":  float64(1),
+		},
+		"Tags": []interface{}{
+			map[string]interface{}{"Key": "Name", "Value": "test"},
+		},
+		"Unknown": "ignored",
+	}
+
+	prior := map[string]interface{}{
+		"password":          "secret",
+		"settings.0.secret": "nested-secret",
+	}
+
+	expected := map[string]interface{}{
+		"arn":             "arn:aws:example:::test", //lintignore:AWSAT005
+		"labels":          nil,
+		"name":            "test",
+		"password":        "secret",
+		"policy_document": `{"Version":"2012-10-17"}`,
+		"ports":           []interface{}{float64(443)},
+		"settings": []interface{}{
+			map[string]interface{}{
+				"enabled": true,
+				"secret":  "nested-secret",
+				"weight":  float64(1),
+			},
+		},
+		"tags": []interface{}{
+			map[string]interface{}{"key": "Name", "value": "test"},
+		},
+	}
+
+	got := testResourceTypeAttributes.flatten(properties, "", func(path string) interface{} {
+		return prior[path]
+	})
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %#v, expected %#v", got, expected)
+	}
+}
+
+func TestAttributesMergeUnconfigured(t *testing.T) {
+	old := map[string]interface{}{
+		"Labels": map[string]interface{}{"team": "platform", "removed": "value"},
+		"Name":   "test",
+		"Ports":  []interface{}{int64(443)},
+		"Settings": map[string]interface{}{
+			"Enabled": true,
+			"Weight":  int64(1),
+		},
+	}
+
+	new := map[string]interface{}{
+		"Labels": map[string]interface{}{"team": "platform"},
+		"Name":   "test",
+		"Settings": map[string]interface{}{
+			"Weight": int64(2),
+		},
+	}
+
+	expected := map[string]interface{}{
+		"Labels": map[string]interface{}{"team": "platform"},
+		"Name":   "test",
+		"Ports":  []interface{}{int64(443)},
+		"Settings": map[string]interface{}{
+			"Enabled": true,
+			"Weight":  int64(2),
+		},
+	}
+
+	testResourceTypeAttributes.mergeUnconfigured(new, old)
+
+	if !reflect.DeepEqual(new, expected) {
+		t.Errorf("got %#v, expected %#v", new, expected)
+	}
+}
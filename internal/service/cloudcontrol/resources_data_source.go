@@ -0,0 +1,264 @@
+package cloudcontrol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudcontrolapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	"github.com/hashicorp/terraform-provider-aws/internal/flex"
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
+)
+
+func DataSourceResources() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceResourcesRead,
+
+		Schema: map[string]*schema.Schema{
+			"filter": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validPropertyPath,
+						},
+						"values": {
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"hydrate": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"resource_model": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"resources": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"identifier": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"properties": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"role_arn": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"type_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`[A-Za-z0-9]{2,64}::[A-Za-z0-9]{2,64}::[A-Za-z0-9]{2,64}`), "must be three alphanumeric sections separated by double colons (::)"),
+			},
+			"type_version_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func dataSourceResourcesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.AWSClient).CloudControlConn
+
+	typeName := d.Get("type_name").(string)
+	typeVersionID := d.Get("type_version_id").(string)
+	roleARN := d.Get("role_arn").(string)
+
+	filters, err := expandResourcesFilters(d.Get("filter").(*schema.Set).List())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	input := &cloudcontrolapi.ListResourcesInput{
+		TypeName: aws.String(typeName),
+	}
+	if v, ok := d.GetOk("resource_model"); ok {
+		input.ResourceModel = aws.String(v.(string))
+	}
+	if roleARN != "" {
+		input.RoleArn = aws.String(roleARN)
+	}
+	if typeVersionID != "" {
+		input.TypeVersionId = aws.String(typeVersionID)
+	}
+
+	resourceDescriptions, err := FindResourceDescriptions(ctx, conn, input)
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error listing Cloud Control API (%s) Resources: %w", typeName, err))
+	}
+
+	ids := make([]string, 0, len(resourceDescriptions))
+	resources := make([]interface{}, 0, len(resourceDescriptions))
+
+	for _, resourceDescription := range resourceDescriptions {
+		identifier := aws.StringValue(resourceDescription.Identifier)
+		properties := aws.StringValue(resourceDescription.Properties)
+
+		// The properties returned by ListResources are a subset of the resource's properties for some resource types.
+		if d.Get("hydrate").(bool) {
+			resourceDescription, err := FindResourceByID(ctx, conn, identifier, typeName, typeVersionID, roleARN)
+
+			// The resource may have been deleted since it was listed.
+			if tfresource.NotFound(err) {
+				continue
+			}
+
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("error reading Cloud Control API (%s) Resource (%s): %w", typeName, identifier, err))
+			}
+
+			properties = aws.StringValue(resourceDescription.Properties)
+		}
+
+		if len(filters) > 0 {
+			match, err := filters.match(properties)
+
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("error filtering Cloud Control API (%s) Resource (%s): %w", typeName, identifier, err))
+			}
+
+			if !match {
+				continue
+			}
+		}
+
+		ids = append(ids, identifier)
+		resources = append(resources, map[string]interface{}{
+			"identifier": identifier,
+			"properties": properties,
+		})
+	}
+
+	d.SetId(typeName)
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting ids: %w", err))
+	}
+
+	if err := d.Set("resources", resources); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting resources: %w", err))
+	}
+
+	return nil
+}
+
+type resourcesFilter struct {
+	path   propertyPath
+	values map[string]bool
+}
+
+// resourcesFilters match the resources for which every filter's path selects at least one of the filter's values.
+type resourcesFilters []resourcesFilter
+
+func expandResourcesFilters(tfList []interface{}) (resourcesFilters, error) {
+	var filters resourcesFilters
+
+	for _, tfMapRaw := range tfList {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		path, err := parsePropertyPath(tfMap["path"].(string))
+
+		if err != nil {
+			return nil, err
+		}
+
+		values := make(map[string]bool)
+
+		for _, v := range aws.StringValueSlice(flex.ExpandStringList(tfMap["values"].([]interface{}))) {
+			values[v] = true
+		}
+
+		filters = append(filters, resourcesFilter{
+			path:   path,
+			values: values,
+		})
+	}
+
+	return filters, nil
+}
+
+func (filters resourcesFilters) match(properties string) (bool, error) {
+	var document interface{}
+
+	if err := json.Unmarshal([]byte(properties), &document); err != nil {
+		return false, fmt.Errorf("error decoding properties: %w", err)
+	}
+
+	for _, filter := range filters {
+		match := false
+
+		for _, v := range filter.path.selectValues(document) {
+			if filter.values[propertyValueString(v)] {
+				match = true
+				break
+			}
+		}
+
+		if !match {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// propertyValueString returns a selected property value as compared to filter values.
+// Strings are compared as is and all other values as JSON.
+func propertyValueString(v interface{}) string {
+	if v, ok := v.(string); ok {
+		return v
+	}
+
+	b, err := json.Marshal(v)
+
+	if err != nil {
+		return ""
+	}
+
+	return string(b)
+}
+
+func validPropertyPath(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := parsePropertyPath(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q: %w", k, err))
+	}
+
+	return
+}
@@ -0,0 +1,108 @@
+package cloudcontrol_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/cloudcontrolapi"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+)
+
+func TestAccCloudControlResourcesDataSource_basic(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	dataSourceName := "data.aws_cloudcontrolapi_resources.test"
+	resourceName := "aws_cloudcontrolapi_resource.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ErrorCheck:        acctest.ErrorCheck(t, cloudcontrolapi.EndpointsID),
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccCheckResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourcesDataSourceConfig(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "ids.0", resourceName, "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "resources.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "resources.0.identifier", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "resources.0.properties", resourceName, "properties"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudControlResourcesDataSource_hydrate(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	dataSourceName := "data.aws_cloudcontrolapi_resources.test"
+	resourceName := "aws_cloudcontrolapi_resource.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ErrorCheck:        acctest.ErrorCheck(t, cloudcontrolapi.EndpointsID),
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccCheckResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourcesDataSourceHydrateConfig(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "ids.0", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "resources.0.properties", resourceName, "properties"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourcesDataSourceConfig(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_cloudcontrolapi_resource" "test" {
+  type_name = "AWS::Logs::LogGroup"
+
+  desired_state = jsonencode({
+    LogGroupName = %[1]q
+  })
+}
+
+data "aws_cloudcontrolapi_resources" "test" {
+  type_name = aws_cloudcontrolapi_resource.test.type_name
+
+  filter {
+    path   = "$.LogGroupName"
+    values = [%[1]q]
+  }
+}
+`, rName)
+}
+
+func testAccResourcesDataSourceHydrateConfig(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_cloudcontrolapi_resource" "test" {
+  type_name = "AWS::Logs::LogGroup"
+
+  desired_state = jsonencode({
+    LogGroupName    = %[1]q
+    RetentionInDays = 7
+  })
+}
+
+data "aws_cloudcontrolapi_resources" "test" {
+  type_name = aws_cloudcontrolapi_resource.test.type_name
+  hydrate   = true
+
+  filter {
+    path   = "$.LogGroupName"
+    values = [%[1]q]
+  }
+
+  filter {
+    path   = "$.RetentionInDays"
+    values = ["7"]
+  }
+}
+`, rName)
+}
@@ -74,6 +74,40 @@ func FindStackByID(conn *cloudformation.CloudFormation, id string) (*cloudformat
 	return stack, nil
 }
 
+func FindStackResources(conn *cloudformation.CloudFormation, stackName string) ([]*cloudformation.StackResourceSummary, error) {
+	input := &cloudformation.ListStackResourcesInput{
+		StackName: aws.String(stackName),
+	}
+	var output []*cloudformation.StackResourceSummary
+
+	err := conn.ListStackResourcesPages(input, func(page *cloudformation.ListStackResourcesOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, v := range page.StackResourceSummaries {
+			if v != nil {
+				output = append(output, v)
+			}
+		}
+
+		return !lastPage
+	})
+
+	if tfawserr.ErrMessageContains(err, ErrCodeValidationError, "does not exist") {
+		return nil, &resource.NotFoundError{
+			LastError:   err,
+			LastRequest: input,
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
 func FindStackInstanceAccountIdByOrgIDs(conn *cloudformation.CloudFormation, stackSetName, region string, orgIDs []string) (string, error) {
 	input := &cloudformation.ListStackInstancesInput{
 		StackInstanceRegion: aws.String(region),
@@ -265,3 +299,89 @@ func FindTypeRegistrationByToken(ctx context.Context, conn *cloudformation.Cloud
 
 	return output, nil
 }
+
+// FindChangeSetChanges returns all changes of a change set, following pagination.
+func FindChangeSetChanges(conn *cloudformation.CloudFormation, stackID, changeSetName string) ([]*cloudformation.Change, error) {
+	input := &cloudformation.DescribeChangeSetInput{
+		ChangeSetName: aws.String(changeSetName),
+		StackName:     aws.String(stackID),
+	}
+	var output []*cloudformation.Change
+
+	for {
+		page, err := conn.DescribeChangeSet(input)
+
+		if tfawserr.ErrCodeEquals(err, cloudformation.ErrCodeChangeSetNotFoundException) {
+			return nil, &resource.NotFoundError{
+				LastError:   err,
+				LastRequest: input,
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range page.Changes {
+			if v != nil {
+				output = append(output, v)
+			}
+		}
+
+		if aws.StringValue(page.NextToken) == "" {
+			break
+		}
+
+		input.NextToken = page.NextToken
+	}
+
+	return output, nil
+}
+
+func FindStackDriftDetectionStatusByID(conn *cloudformation.CloudFormation, id string) (*cloudformation.DescribeStackDriftDetectionStatusOutput, error) {
+	input := &cloudformation.DescribeStackDriftDetectionStatusInput{
+		StackDriftDetectionId: aws.String(id),
+	}
+
+	output, err := conn.DescribeStackDriftDetectionStatus(input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if output == nil {
+		return nil, tfresource.NewEmptyResultError(input)
+	}
+
+	return output, nil
+}
+
+// FindStackResourceDrifts returns the resources of a stack whose drift status is
+// one of the specified statuses.
+func FindStackResourceDrifts(conn *cloudformation.CloudFormation, stackName string, statuses []string) ([]*cloudformation.StackResourceDrift, error) {
+	input := &cloudformation.DescribeStackResourceDriftsInput{
+		StackName:                       aws.String(stackName),
+		StackResourceDriftStatusFilters: aws.StringSlice(statuses),
+	}
+	var output []*cloudformation.StackResourceDrift
+
+	err := conn.DescribeStackResourceDriftsPages(input, func(page *cloudformation.DescribeStackResourceDriftsOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, v := range page.StackResourceDrifts {
+			if v != nil {
+				output = append(output, v)
+			}
+		}
+
+		return !lastPage
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
@@ -47,3 +47,60 @@ func flattenParameters(cfParams []*cloudformation.Parameter,
 	}
 	return params
 }
+
+func flattenStackChanges(apiObjects []*cloudformation.Change) []interface{} {
+	tfList := make([]interface{}, 0, len(apiObjects))
+
+	for _, apiObject := range apiObjects {
+		if apiObject == nil || apiObject.ResourceChange == nil {
+			continue
+		}
+
+		resourceChange := apiObject.ResourceChange
+
+		tfList = append(tfList, map[string]interface{}{
+			"action":               aws.StringValue(resourceChange.Action),
+			"logical_resource_id":  aws.StringValue(resourceChange.LogicalResourceId),
+			"physical_resource_id": aws.StringValue(resourceChange.PhysicalResourceId),
+			"replacement":          aws.StringValue(resourceChange.Replacement),
+			"resource_type":        aws.StringValue(resourceChange.ResourceType),
+			"scope":                aws.StringValueSlice(resourceChange.Scope),
+		})
+	}
+
+	return tfList
+}
+
+func flattenStackResourceDrifts(apiObjects []*cloudformation.StackResourceDrift) []interface{} {
+	tfList := make([]interface{}, 0, len(apiObjects))
+
+	for _, apiObject := range apiObjects {
+		if apiObject == nil {
+			continue
+		}
+
+		var propertyDifferences []interface{}
+		for _, v := range apiObject.PropertyDifferences {
+			if v == nil {
+				continue
+			}
+
+			propertyDifferences = append(propertyDifferences, map[string]interface{}{
+				"actual_value":    aws.StringValue(v.ActualValue),
+				"difference_type": aws.StringValue(v.DifferenceType),
+				"expected_value":  aws.StringValue(v.ExpectedValue),
+				"property_path":   aws.StringValue(v.PropertyPath),
+			})
+		}
+
+		tfList = append(tfList, map[string]interface{}{
+			"drift_status":         aws.StringValue(apiObject.StackResourceDriftStatus),
+			"logical_resource_id":  aws.StringValue(apiObject.LogicalResourceId),
+			"physical_resource_id": aws.StringValue(apiObject.PhysicalResourceId),
+			"property_differences": propertyDifferences,
+			"resource_type":        aws.StringValue(apiObject.ResourceType),
+		})
+	}
+
+	return tfList
+}
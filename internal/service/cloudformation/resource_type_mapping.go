@@ -0,0 +1,87 @@
+package cloudformation
+
+import (
+	"strings"
+)
+
+// ResourceTypeMapping maps a CloudFormation resource type to the Terraform resource type managing the same resources.
+type ResourceTypeMapping struct {
+	// TerraformType is the Terraform resource type, e.g. aws_vpc.
+	TerraformType string
+
+	// ImportIDFormat describes the Terraform import ID, e.g. "VPC ID".
+	ImportIDFormat string
+
+	// importID returns the Terraform import ID of a resource from its CloudFormation physical resource ID.
+	// The physical resource ID is the import ID when nil.
+	importID func(physicalResourceID string) string
+}
+
+// ImportID returns the Terraform import ID of a resource from its CloudFormation physical resource ID.
+func (m ResourceTypeMapping) ImportID(physicalResourceID string) string {
+	if m.importID == nil {
+		return physicalResourceID
+	}
+
+	return m.importID(physicalResourceID)
+}
+
+// resourceTypeMappings are the mappings of the CloudFormation resource types whose physical resource ID can be
+// converted to a Terraform import ID without looking the resource up.
+var resourceTypeMappings = map[string]ResourceTypeMapping{
+	"AWS::ApiGateway::RestApi":                  {TerraformType: "aws_api_gateway_rest_api", ImportIDFormat: "REST API ID"},
+	"AWS::AutoScaling::AutoScalingGroup":        {TerraformType: "aws_autoscaling_group", ImportIDFormat: "Auto Scaling Group name"},
+	"AWS::CloudFront::Distribution":             {TerraformType: "aws_cloudfront_distribution", ImportIDFormat: "distribution ID"},
+	"AWS::DynamoDB::Table":                      {TerraformType: "aws_dynamodb_table", ImportIDFormat: "table name"},
+	"AWS::EC2::Instance":                        {TerraformType: "aws_instance", ImportIDFormat: "instance ID"},
+	"AWS::EC2::InternetGateway":                 {TerraformType: "aws_internet_gateway", ImportIDFormat: "internet gateway ID"},
+	"AWS::EC2::LaunchTemplate":                  {TerraformType: "aws_launch_template", ImportIDFormat: "launch template ID"},
+	"AWS::EC2::NatGateway":                      {TerraformType: "aws_nat_gateway", ImportIDFormat: "NAT gateway ID"},
+	"AWS::EC2::RouteTable":                      {TerraformType: "aws_route_table", ImportIDFormat: "route table ID"},
+	"AWS::EC2::SecurityGroup":                   {TerraformType: "aws_security_group", ImportIDFormat: "security group ID"},
+	"AWS::EC2::Subnet":                          {TerraformType: "aws_subnet", ImportIDFormat: "subnet ID"},
+	"AWS::EC2::VPC":                             {TerraformType: "aws_vpc", ImportIDFormat: "VPC ID"},
+	"AWS::ECR::Repository":                      {TerraformType: "aws_ecr_repository", ImportIDFormat: "repository name"},
+	"AWS::ECS::Cluster":                         {TerraformType: "aws_ecs_cluster", ImportIDFormat: "cluster name", importID: ecsClusterImportID},
+	"AWS::ElasticLoadBalancingV2::Listener":     {TerraformType: "aws_lb_listener", ImportIDFormat: "listener ARN"},
+	"AWS::ElasticLoadBalancingV2::LoadBalancer": {TerraformType: "aws_lb", ImportIDFormat: "load balancer ARN"},
+	"AWS::ElasticLoadBalancingV2::TargetGroup":  {TerraformType: "aws_lb_target_group", ImportIDFormat: "target group ARN"},
+	"AWS::Events::Rule":                         {TerraformType: "aws_cloudwatch_event_rule", ImportIDFormat: "[event bus name/]rule name", importID: eventsRuleImportID},
+	"AWS::IAM::InstanceProfile":                 {TerraformType: "aws_iam_instance_profile", ImportIDFormat: "instance profile name"},
+	"AWS::IAM::ManagedPolicy":                   {TerraformType: "aws_iam_policy", ImportIDFormat: "policy ARN"},
+	"AWS::IAM::Role":                            {TerraformType: "aws_iam_role", ImportIDFormat: "role name"},
+	"AWS::IAM::User":                            {TerraformType: "aws_iam_user", ImportIDFormat: "user name"},
+	"AWS::Kinesis::Stream":                      {TerraformType: "aws_kinesis_stream", ImportIDFormat: "stream name"},
+	"AWS::KMS::Alias":                           {TerraformType: "aws_kms_alias", ImportIDFormat: "alias name"},
+	"AWS::KMS::Key":                             {TerraformType: "aws_kms_key", ImportIDFormat: "key ID"},
+	"AWS::Lambda::Function":                     {TerraformType: "aws_lambda_function", ImportIDFormat: "function name"},
+	"AWS::Logs::LogGroup":                       {TerraformType: "aws_cloudwatch_log_group", ImportIDFormat: "log group name"},
+	"AWS::Route53::HostedZone":                  {TerraformType: "aws_route53_zone", ImportIDFormat: "hosted zone ID"},
+	"AWS::S3::Bucket":                           {TerraformType: "aws_s3_bucket", ImportIDFormat: "bucket name"},
+	"AWS::SecretsManager::Secret":               {TerraformType: "aws_secretsmanager_secret", ImportIDFormat: "secret ARN"},
+	"AWS::SNS::Topic":                           {TerraformType: "aws_sns_topic", ImportIDFormat: "topic ARN"},
+	"AWS::SQS::Queue":                           {TerraformType: "aws_sqs_queue", ImportIDFormat: "queue URL"},
+	"AWS::SSM::Parameter":                       {TerraformType: "aws_ssm_parameter", ImportIDFormat: "parameter name"},
+	"AWS::StepFunctions::StateMachine":          {TerraformType: "aws_sfn_state_machine", ImportIDFormat: "state machine ARN"},
+}
+
+// FindResourceTypeMapping returns the Terraform resource type mapping of a CloudFormation resource type.
+func FindResourceTypeMapping(resourceType string) (ResourceTypeMapping, bool) {
+	m, ok := resourceTypeMappings[resourceType]
+
+	return m, ok
+}
+
+// ecsClusterImportID returns the cluster name of a cluster ARN.
+func ecsClusterImportID(physicalResourceID string) string {
+	if i := strings.LastIndex(physicalResourceID, "/"); i >= 0 {
+		return physicalResourceID[i+1:]
+	}
+
+	return physicalResourceID
+}
+
+// eventsRuleImportID converts the event-bus-name|rule-name physical resource ID of rules on custom event buses.
+func eventsRuleImportID(physicalResourceID string) string {
+	return strings.Replace(physicalResourceID, "|", "/", 1)
+}
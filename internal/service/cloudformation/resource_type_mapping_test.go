@@ -0,0 +1,83 @@
+package cloudformation_test
+
+import (
+	"testing"
+
+	tfcloudformation "github.com/hashicorp/terraform-provider-aws/internal/service/cloudformation"
+)
+
+func TestFindResourceTypeMapping(t *testing.T) {
+	testCases := []struct {
+		TestName           string
+		ResourceType       string
+		PhysicalResourceID string
+		ExpectedType       string
+		ExpectedImportID   string
+	}{
+		{
+			TestName:           "physical ID",
+			ResourceType:       "AWS::EC2::VPC",
+			PhysicalResourceID: "vpc-12345678",
+			ExpectedType:       "aws_vpc",
+			ExpectedImportID:   "vpc-12345678",
+		},
+		{
+			TestName:           "ECS cluster ARN",
+			ResourceType:       "AWS::ECS::Cluster",
+			PhysicalResourceID: "arn:aws:ecs:us-west-2:123456789012:cluster/example", //lintignore:AWSAT003,AWSAT005
+			ExpectedType:       "aws_ecs_cluster",
+			ExpectedImportID:   "example",
+		},
+		{
+			TestName:           "ECS cluster name",
+			ResourceType:       "AWS::ECS::Cluster",
+			PhysicalResourceID: "example",
+			ExpectedType:       "aws_ecs_cluster",
+			ExpectedImportID:   "example",
+		},
+		{
+			TestName:           "event rule on default bus",
+			ResourceType:       "AWS::Events::Rule",
+			PhysicalResourceID: "example-rule",
+			ExpectedType:       "aws_cloudwatch_event_rule",
+			ExpectedImportID:   "example-rule",
+		},
+		{
+			TestName:           "event rule on custom bus",
+			ResourceType:       "AWS::Events::Rule",
+			PhysicalResourceID: "example-bus|example-rule",
+			ExpectedType:       "aws_cloudwatch_event_rule",
+			ExpectedImportID:   "example-bus/example-rule",
+		},
+		{
+			TestName:     "unmapped",
+			ResourceType: "AWS::CloudFormation::WaitConditionHandle",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			m, ok := tfcloudformation.FindResourceTypeMapping(testCase.ResourceType)
+
+			if testCase.ExpectedType == "" {
+				if ok {
+					t.Fatalf("expected no mapping, got %#v", m)
+				}
+
+				return
+			}
+
+			if !ok {
+				t.Fatal("expected mapping")
+			}
+
+			if m.TerraformType != testCase.ExpectedType {
+				t.Errorf("got type %s, expected %s", m.TerraformType, testCase.ExpectedType)
+			}
+
+			if got := m.ImportID(testCase.PhysicalResourceID); got != testCase.ExpectedImportID {
+				t.Errorf("got import ID %s, expected %s", got, testCase.ExpectedImportID)
+			}
+		})
+	}
+}
@@ -1,12 +1,18 @@
 package cloudformation
 
 import (
+	"context"
+	"crypto/sha256"
 	"fmt"
 	"log"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
@@ -14,6 +20,7 @@ import (
 	"github.com/hashicorp/terraform-provider-aws/internal/conns"
 	"github.com/hashicorp/terraform-provider-aws/internal/flex"
 	tftags "github.com/hashicorp/terraform-provider-aws/internal/tags"
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
 	"github.com/hashicorp/terraform-provider-aws/internal/verify"
 )
 
@@ -116,9 +123,111 @@ func ResourceStack() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"change_set_preview": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"change_set_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"planned_changes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"logical_resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"physical_resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"replacement": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"scope": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"detect_drift": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"drift_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"drifted_resources": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"drift_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"logical_resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"physical_resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"property_differences": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"actual_value": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"difference_type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"expected_value": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"property_path": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"resource_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 
-		CustomizeDiff: verify.SetTagsDiff,
+		CustomizeDiff: customdiff.Sequence(
+			verify.SetTagsDiff,
+			resourceStackChangeSetCustomizeDiff,
+		),
 	}
 }
 
@@ -306,6 +415,21 @@ func resourceStackRead(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	if d.Get("detect_drift").(bool) {
+		driftStatus, drifts, err := detectStackDrift(conn, d.Id())
+		if err != nil {
+			return fmt.Errorf("error detecting CloudFormation Stack (%s) drift: %w", d.Id(), err)
+		}
+
+		d.Set("drift_status", driftStatus)
+		if err := d.Set("drifted_resources", flattenStackResourceDrifts(drifts)); err != nil {
+			return fmt.Errorf("error setting drifted_resources: %w", err)
+		}
+	} else {
+		d.Set("drift_status", nil)
+		d.Set("drifted_resources", nil)
+	}
+
 	return nil
 }
 
@@ -314,6 +438,38 @@ func resourceStackUpdate(d *schema.ResourceData, meta interface{}) error {
 	defaultTagsConfig := meta.(*conns.AWSClient).DefaultTagsConfig
 	tags := defaultTagsConfig.MergeTags(tftags.New(d.Get("tags").(map[string]interface{})))
 
+	if name := d.Get("change_set_name").(string); d.Get("change_set_preview").(bool) && d.HasChange("change_set_name") && name != "" {
+		if err := executeStackChangeSet(conn, d.Id(), name, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("error updating CloudFormation stack (%s): %w", d.Id(), err)
+		}
+
+		// Stack policies are not part of change sets.
+		if d.HasChanges("policy_body", "policy_url") {
+			input := &cloudformation.SetStackPolicyInput{
+				StackName: aws.String(d.Id()),
+			}
+
+			if v, ok := d.GetOk("policy_body"); ok {
+				policy, err := structure.NormalizeJsonString(v)
+				if err != nil {
+					return fmt.Errorf("policy body contains an invalid JSON: %s", err)
+				}
+				input.StackPolicyBody = aws.String(policy)
+			}
+			if v, ok := d.GetOk("policy_url"); ok {
+				input.StackPolicyURL = aws.String(v.(string))
+			}
+
+			if _, err := conn.SetStackPolicy(input); err != nil {
+				return fmt.Errorf("error setting CloudFormation stack (%s) policy: %w", d.Id(), err)
+			}
+		}
+
+		log.Printf("[INFO] CloudFormation stack (%s) updated", d.Id())
+
+		return resourceStackRead(d, meta)
+	}
+
 	requestToken := resource.UniqueId()
 	input := &cloudformation.UpdateStackInput{
 		StackName:          aws.String(d.Id()),
@@ -409,3 +565,237 @@ func resourceStackDelete(d *schema.ResourceData, meta interface{}) error {
 
 	return nil
 }
+
+// stackChangeSetKeys are the arguments that are applied to the stack by a change set.
+var stackChangeSetKeys = []string{
+	"capabilities",
+	"iam_role_arn",
+	"notification_arns",
+	"parameters",
+	"tags_all",
+	"template_body",
+	"template_url",
+}
+
+// resourceStackChangeSetCustomizeDiff creates a change set for the planned stack
+// update when change_set_preview is enabled and exposes its resource changes.
+// The change set name is derived from the requested update and the stack version,
+// so the plan made during apply reuses the change set created by an earlier plan.
+func resourceStackChangeSetCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || !diff.Get("change_set_preview").(bool) {
+		return nil
+	}
+
+	changed := false
+	for _, key := range stackChangeSetKeys {
+		if !diff.HasChange(key) {
+			continue
+		}
+
+		if !diff.NewValueKnown(key) {
+			if err := diff.SetNewComputed("change_set_name"); err != nil {
+				return err
+			}
+
+			return diff.SetNewComputed("planned_changes")
+		}
+
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	conn := meta.(*conns.AWSClient).CloudFormationConn
+
+	stack, err := FindStackByID(conn, diff.Id())
+	if err != nil {
+		return fmt.Errorf("error reading CloudFormation Stack (%s): %w", diff.Id(), err)
+	}
+
+	input, err := expandStackChangeSetInput(diff, diff.Id())
+	if err != nil {
+		return err
+	}
+
+	// Including the time of the last stack update ensures that change sets
+	// created against an earlier version of the stack aren't reused.
+	lastUpdated := stack.CreationTime
+	if stack.LastUpdatedTime != nil {
+		lastUpdated = stack.LastUpdatedTime
+	}
+
+	name := fmt.Sprintf("terraform-%x", sha256.Sum256([]byte(input.String()+aws.TimeValue(lastUpdated).String())))
+	input.ChangeSetName = aws.String(name)
+
+	_, err = FindChangeSetByStackIDAndChangeSetName(conn, diff.Id(), name)
+
+	if tfresource.NotFound(err) {
+		log.Printf("[DEBUG] Creating CloudFormation Stack change set: %s", input)
+		_, err = conn.CreateChangeSet(input)
+
+		if err != nil {
+			return fmt.Errorf("error creating CloudFormation Stack (%s) change set (%s): %w", diff.Id(), name, err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("error reading CloudFormation Stack (%s) change set (%s): %w", diff.Id(), name, err)
+	}
+
+	changeSet, err := WaitChangeSetCreated(conn, diff.Id(), name)
+
+	var changes []*cloudformation.Change
+
+	if !stackChangeSetHasNoChanges(changeSet) {
+		if err != nil {
+			return fmt.Errorf("error waiting for CloudFormation Stack (%s) change set (%s) creation: %w", diff.Id(), name, err)
+		}
+
+		changes, err = FindChangeSetChanges(conn, diff.Id(), name)
+
+		if err != nil {
+			return fmt.Errorf("error reading CloudFormation Stack (%s) change set (%s) changes: %w", diff.Id(), name, err)
+		}
+	}
+
+	if err := diff.SetNew("change_set_name", name); err != nil {
+		return err
+	}
+
+	return diff.SetNew("planned_changes", flattenStackChanges(changes))
+}
+
+// expandStackChangeSetInput returns the input for an UPDATE change set without a name.
+// Lists are sorted so that a change set name derived from the input is stable.
+func expandStackChangeSetInput(d interface {
+	Get(string) interface{}
+	GetOk(string) (interface{}, bool)
+}, stackID string) (*cloudformation.CreateChangeSetInput, error) {
+	input := &cloudformation.CreateChangeSetInput{
+		ChangeSetType: aws.String(cloudformation.ChangeSetTypeUpdate),
+		StackName:     aws.String(stackID),
+	}
+
+	if v, ok := d.GetOk("template_url"); ok {
+		input.TemplateURL = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("template_body"); ok && input.TemplateURL == nil {
+		template, err := verify.NormalizeJSONOrYAMLString(v)
+		if err != nil {
+			return nil, fmt.Errorf("template body contains an invalid JSON or YAML: %s", err)
+		}
+		input.TemplateBody = aws.String(template)
+	}
+	if v, ok := d.GetOk("capabilities"); ok {
+		values := aws.StringValueSlice(flex.ExpandStringSet(v.(*schema.Set)))
+		sort.Strings(values)
+		input.Capabilities = aws.StringSlice(values)
+	}
+	if v, ok := d.GetOk("notification_arns"); ok {
+		values := aws.StringValueSlice(flex.ExpandStringSet(v.(*schema.Set)))
+		sort.Strings(values)
+		input.NotificationARNs = aws.StringSlice(values)
+	}
+	if v, ok := d.GetOk("parameters"); ok {
+		input.Parameters = expandParameters(v.(map[string]interface{}))
+		sort.Slice(input.Parameters, func(i, j int) bool {
+			return aws.StringValue(input.Parameters[i].ParameterKey) < aws.StringValue(input.Parameters[j].ParameterKey)
+		})
+	}
+	if v, ok := d.GetOk("iam_role_arn"); ok {
+		input.RoleARN = aws.String(v.(string))
+	}
+	if tags := tftags.New(d.Get("tags_all").(map[string]interface{})).IgnoreAWS(); len(tags) > 0 {
+		input.Tags = Tags(tags)
+		sort.Slice(input.Tags, func(i, j int) bool {
+			return aws.StringValue(input.Tags[i].Key) < aws.StringValue(input.Tags[j].Key)
+		})
+	}
+
+	return input, nil
+}
+
+// stackChangeSetHasNoChanges returns whether a change set failed only because
+// the stack already matches the requested update.
+func stackChangeSetHasNoChanges(changeSet *cloudformation.DescribeChangeSetOutput) bool {
+	if changeSet == nil || aws.StringValue(changeSet.Status) != cloudformation.ChangeSetStatusFailed {
+		return false
+	}
+
+	reason := aws.StringValue(changeSet.StatusReason)
+
+	return strings.Contains(reason, "didn't contain changes") || strings.Contains(reason, "No updates are to be performed")
+}
+
+func executeStackChangeSet(conn *cloudformation.CloudFormation, stackID, changeSetName string, timeout time.Duration) error {
+	changeSet, err := FindChangeSetByStackIDAndChangeSetName(conn, stackID, changeSetName)
+
+	if err != nil {
+		return fmt.Errorf("error reading change set (%s): %w", changeSetName, err)
+	}
+
+	if stackChangeSetHasNoChanges(changeSet) {
+		log.Printf("[DEBUG] CloudFormation stack (%s) change set (%s) has no changes", stackID, changeSetName)
+		return nil
+	}
+
+	if status := aws.StringValue(changeSet.Status); status != cloudformation.ChangeSetStatusCreateComplete {
+		return fmt.Errorf("change set (%s) can't be executed (%s): %s", changeSetName, status, aws.StringValue(changeSet.StatusReason))
+	}
+
+	requestToken := resource.UniqueId()
+	input := &cloudformation.ExecuteChangeSetInput{
+		ChangeSetName:      changeSet.ChangeSetId,
+		ClientRequestToken: aws.String(requestToken),
+	}
+
+	log.Printf("[DEBUG] Executing CloudFormation change set: %s", input)
+	if _, err := conn.ExecuteChangeSet(input); err != nil {
+		return fmt.Errorf("error executing change set (%s): %w", changeSetName, err)
+	}
+
+	if _, err := WaitStackUpdated(conn, stackID, requestToken, timeout); err != nil {
+		return fmt.Errorf("error waiting for change set (%s) execution: %w", changeSetName, err)
+	}
+
+	return nil
+}
+
+// detectStackDrift runs drift detection on a stack and returns the stack drift
+// status along with the modified and deleted resources. Stacks that are in a
+// state that doesn't allow drift detection are skipped.
+func detectStackDrift(conn *cloudformation.CloudFormation, stackID string) (string, []*cloudformation.StackResourceDrift, error) {
+	output, err := conn.DetectStackDrift(&cloudformation.DetectStackDriftInput{
+		StackName: aws.String(stackID),
+	})
+
+	if tfawserr.ErrCodeEquals(err, ErrCodeValidationError) {
+		log.Printf("[WARN] Unable to detect CloudFormation stack (%s) drift: %s", stackID, err)
+		return "", nil, nil
+	}
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	status, err := WaitStackDriftDetectionComplete(conn, aws.StringValue(output.StackDriftDetectionId))
+
+	if err != nil {
+		return "", nil, fmt.Errorf("error waiting for drift detection (%s): %w", aws.StringValue(output.StackDriftDetectionId), err)
+	}
+
+	if aws.StringValue(status.DetectionStatus) == cloudformation.StackDriftDetectionStatusDetectionFailed {
+		log.Printf("[WARN] CloudFormation stack (%s) drift detection failed for some resources: %s", stackID, aws.StringValue(status.DetectionStatusReason))
+	}
+
+	drifts, err := FindStackResourceDrifts(conn, stackID, []string{
+		cloudformation.StackResourceDriftStatusDeleted,
+		cloudformation.StackResourceDriftStatusModified,
+	})
+
+	if err != nil {
+		return "", nil, fmt.Errorf("error listing resource drifts: %w", err)
+	}
+
+	return aws.StringValue(status.StackDriftStatus), drifts, nil
+}
@@ -0,0 +1,105 @@
+package cloudformation
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+)
+
+func DataSourceStackResources() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceStackResourcesRead,
+
+		Schema: map[string]*schema.Schema{
+			"resources": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"import_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"logical_resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"physical_resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"terraform_resource_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"stack_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+func dataSourceStackResourcesRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).CloudFormationConn
+
+	stackName := d.Get("stack_name").(string)
+
+	summaries, err := FindStackResources(conn, stackName)
+
+	if err != nil {
+		return fmt.Errorf("error listing CloudFormation Stack (%s) resources: %w", stackName, err)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return aws.StringValue(summaries[i].LogicalResourceId) < aws.StringValue(summaries[j].LogicalResourceId)
+	})
+
+	var resources []interface{}
+
+	for _, summary := range summaries {
+		physicalResourceID := aws.StringValue(summary.PhysicalResourceId)
+		resourceType := aws.StringValue(summary.ResourceType)
+
+		tfMap := map[string]interface{}{
+			"import_id":               "",
+			"logical_resource_id":     aws.StringValue(summary.LogicalResourceId),
+			"physical_resource_id":    physicalResourceID,
+			"resource_status":         aws.StringValue(summary.ResourceStatus),
+			"resource_type":           resourceType,
+			"terraform_resource_type": "",
+		}
+
+		if m, ok := FindResourceTypeMapping(resourceType); ok {
+			tfMap["terraform_resource_type"] = m.TerraformType
+
+			if physicalResourceID != "" {
+				tfMap["import_id"] = m.ImportID(physicalResourceID)
+			}
+		}
+
+		resources = append(resources, tfMap)
+	}
+
+	d.SetId(stackName)
+
+	if err := d.Set("resources", resources); err != nil {
+		return fmt.Errorf("error setting resources: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package cloudformation_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+)
+
+func TestAccCloudFormationStackResourcesDataSource_basic(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	dataSourceName := "data.aws_cloudformation_stack_resources.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { acctest.PreCheck(t) },
+		ErrorCheck: acctest.ErrorCheck(t, cloudformation.EndpointsID),
+		Providers:  acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStackResourcesDataSourceConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "resources.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "resources.0.logical_resource_id", "Handle"),
+					resource.TestCheckResourceAttr(dataSourceName, "resources.0.resource_type", "AWS::CloudFormation::WaitConditionHandle"),
+					resource.TestCheckResourceAttr(dataSourceName, "resources.0.terraform_resource_type", ""),
+					resource.TestCheckResourceAttr(dataSourceName, "resources.0.import_id", ""),
+					resource.TestCheckResourceAttr(dataSourceName, "resources.1.logical_resource_id", "Vpc"),
+					resource.TestCheckResourceAttr(dataSourceName, "resources.1.resource_status", "CREATE_COMPLETE"),
+					resource.TestCheckResourceAttr(dataSourceName, "resources.1.resource_type", "AWS::EC2::VPC"),
+					resource.TestCheckResourceAttr(dataSourceName, "resources.1.terraform_resource_type", "aws_vpc"),
+					resource.TestMatchResourceAttr(dataSourceName, "resources.1.import_id", regexp.MustCompile(`^vpc-[a-z0-9]+$`)),
+					resource.TestCheckResourceAttrPair(dataSourceName, "resources.1.import_id", dataSourceName, "resources.1.physical_resource_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccStackResourcesDataSourceConfig(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_cloudformation_stack" "test" {
+  name = %[1]q
+
+  template_body = jsonencode({
+    Resources = {
+      Handle = {
+        Type = "AWS::CloudFormation::WaitConditionHandle"
+      }
+      Vpc = {
+        Type = "AWS::EC2::VPC"
+        Properties = {
+          CidrBlock = "10.0.0.0/16"
+        }
+      }
+    }
+  })
+}
+
+data "aws_cloudformation_stack_resources" "test" {
+  stack_name = aws_cloudformation_stack.test.name
+}
+`, rName)
+}
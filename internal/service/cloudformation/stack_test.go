@@ -7,6 +7,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -444,6 +445,110 @@ func TestAccCloudFormationStack_onFailure(t *testing.T) {
 	})
 }
 
+func TestAccCloudFormationStack_changeSetPreview(t *testing.T) {
+	var stack cloudformation.Stack
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_cloudformation_stack.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, cloudformation.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStackConfig_changeSetPreview(rName, "10.0.0.0/16"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudFormationStackExists(resourceName, &stack),
+					resource.TestCheckResourceAttr(resourceName, "change_set_preview", "true"),
+				),
+			},
+			{
+				Config:             testAccStackConfig_changeSetPreview(rName, "10.1.0.0/16"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccStackConfig_changeSetPreview(rName, "10.1.0.0/16"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudFormationStackExists(resourceName, &stack),
+					resource.TestCheckResourceAttr(resourceName, "parameters.VpcCIDR", "10.1.0.0/16"),
+					resource.TestMatchResourceAttr(resourceName, "change_set_name", regexp.MustCompile(`^terraform-[0-9a-f]{64}$`)),
+					resource.TestCheckResourceAttr(resourceName, "planned_changes.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "planned_changes.0.action", cloudformation.ChangeActionModify),
+					resource.TestCheckResourceAttr(resourceName, "planned_changes.0.logical_resource_id", "MyVPC"),
+					resource.TestCheckResourceAttr(resourceName, "planned_changes.0.replacement", cloudformation.ReplacementTrue),
+					resource.TestCheckResourceAttr(resourceName, "planned_changes.0.resource_type", "AWS::EC2::VPC"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudFormationStack_detectDrift(t *testing.T) {
+	var stack cloudformation.Stack
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_cloudformation_stack.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, cloudformation.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStackConfig_detectDrift(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudFormationStackExists(resourceName, &stack),
+					resource.TestCheckResourceAttr(resourceName, "detect_drift", "true"),
+					resource.TestCheckResourceAttr(resourceName, "drift_status", cloudformation.StackDriftStatusInSync),
+					resource.TestCheckResourceAttr(resourceName, "drifted_resources.#", "0"),
+				),
+			},
+			{
+				PreConfig: testAccCheckCloudFormationStackVPCTag(t, &stack, "Name", "drifted"),
+				Config:    testAccStackConfig_detectDrift(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "drift_status", cloudformation.StackDriftStatusDrifted),
+					resource.TestCheckResourceAttr(resourceName, "drifted_resources.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "drifted_resources.0.drift_status", cloudformation.StackResourceDriftStatusModified),
+					resource.TestCheckResourceAttr(resourceName, "drifted_resources.0.logical_resource_id", "MyVPC"),
+					resource.TestCheckResourceAttr(resourceName, "drifted_resources.0.resource_type", "AWS::EC2::VPC"),
+					resource.TestCheckResourceAttrSet(resourceName, "drifted_resources.0.property_differences.0.property_path"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckCloudFormationStackVPCTag tags the VPC created by the stack outside of CloudFormation.
+func testAccCheckCloudFormationStackVPCTag(t *testing.T, stack *cloudformation.Stack, key, value string) func() {
+	return func() {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).EC2Conn
+
+		var vpcID string
+		for _, output := range stack.Outputs {
+			if aws.StringValue(output.OutputKey) == "VpcId" {
+				vpcID = aws.StringValue(output.OutputValue)
+			}
+		}
+
+		_, err := conn.CreateTags(&ec2.CreateTagsInput{
+			Resources: aws.StringSlice([]string{vpcID}),
+			Tags: []*ec2.Tag{
+				{
+					Key:   aws.String(key),
+					Value: aws.String(value),
+				},
+			},
+		})
+
+		if err != nil {
+			t.Fatalf("error tagging VPC (%s): %s", vpcID, err)
+		}
+	}
+}
+
 func testAccCheckCloudFormationStackExists(n string, stack *cloudformation.Stack) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -825,6 +930,66 @@ STACK
 `, rName, cidr)
 }
 
+func testAccStackConfig_changeSetPreview(rName, cidr string) string {
+	return fmt.Sprintf(`
+resource "aws_cloudformation_stack" "test" {
+  name               = %[1]q
+  change_set_preview = true
+
+  parameters = {
+    VpcCIDR = %[2]q
+  }
+
+  template_body = jsonencode({
+    Parameters = {
+      VpcCIDR = {
+        Type = "String"
+      }
+    }
+    Resources = {
+      MyVPC = {
+        Type = "AWS::EC2::VPC"
+        Properties = {
+          CidrBlock = { Ref = "VpcCIDR" }
+          Tags = [
+            { Key = "Name", Value = %[1]q }
+          ]
+        }
+      }
+    }
+  })
+}
+`, rName, cidr)
+}
+
+func testAccStackConfig_detectDrift(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_cloudformation_stack" "test" {
+  name         = %[1]q
+  detect_drift = true
+
+  template_body = jsonencode({
+    Resources = {
+      MyVPC = {
+        Type = "AWS::EC2::VPC"
+        Properties = {
+          CidrBlock = "10.0.0.0/16"
+          Tags = [
+            { Key = "Name", Value = %[1]q }
+          ]
+        }
+      }
+    }
+    Outputs = {
+      VpcId = {
+        Value = { Ref = "MyVPC" }
+      }
+    }
+  })
+}
+`, rName)
+}
+
 func testAccStackConfig_templateURL_withParams(rName, bucketKey, vpcCidr string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
@@ -259,6 +259,34 @@ func WaitStackDeleted(conn *cloudformation.CloudFormation, stackID, requestToken
 	return stack, nil
 }
 
+const (
+	StackDriftDetectionCompleteTimeout = 5 * time.Minute
+)
+
+// WaitStackDriftDetectionComplete waits for a stack drift detection operation to finish.
+// A FAILED detection status is returned without error as drift results are still
+// available for the resources whose drift could be checked.
+func WaitStackDriftDetectionComplete(conn *cloudformation.CloudFormation, id string) (*cloudformation.DescribeStackDriftDetectionStatusOutput, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{cloudformation.StackDriftDetectionStatusDetectionInProgress},
+		Target: []string{
+			cloudformation.StackDriftDetectionStatusDetectionComplete,
+			cloudformation.StackDriftDetectionStatusDetectionFailed,
+		},
+		Refresh:    StatusStackDriftDetection(conn, id),
+		Timeout:    StackDriftDetectionCompleteTimeout,
+		MinTimeout: 2 * time.Second,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*cloudformation.DescribeStackDriftDetectionStatusOutput); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
 const (
 	TypeRegistrationTimeout = 5 * time.Minute
 )
@@ -0,0 +1,811 @@
+package cloudwatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	"github.com/hashicorp/terraform-provider-aws/internal/create"
+	"github.com/hashicorp/terraform-provider-aws/internal/flex"
+	"github.com/hashicorp/terraform-provider-aws/internal/verify"
+)
+
+func DataSourceDashboardDocument() *schema.Resource {
+	yAxisSchema := &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"label": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"max": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validDashboardFloat,
+				},
+				"min": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validDashboardFloat,
+				},
+				"show_units": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+			},
+		},
+	}
+
+	return &schema.Resource{
+		Read: dataSourceDashboardDocumentRead,
+
+		Schema: map[string]*schema.Schema{
+			"end": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"start"},
+			},
+			"json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"period_override": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"auto", "inherit"}, false),
+			},
+			"start": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"widget": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: dashboardMaxWidgets,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"alarm": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"alarms": {
+										Type:     schema.TypeList,
+										Required: true,
+										MinItems: 1,
+										MaxItems: 100,
+										Elem: &schema.Schema{
+											Type:         schema.TypeString,
+											ValidateFunc: verify.ValidARN,
+										},
+									},
+									"sort_by": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validation.StringInSlice([]string{"default", "stateUpdatedTimestamp", "timestamp"}, false),
+									},
+									"states": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem: &schema.Schema{
+											Type:         schema.TypeString,
+											ValidateFunc: validation.StringInSlice([]string{"ALARM", "INSUFFICIENT_DATA", "OK"}, false),
+										},
+									},
+									"title": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
+						"explorer": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"aggregate_by": {
+										Type:     schema.TypeList,
+										Optional: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"function": {
+													Type:         schema.TypeString,
+													Required:     true,
+													ValidateFunc: validation.StringInSlice([]string{"AVG", "MAX", "MIN", "SUM"}, false),
+												},
+												"key": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+											},
+										},
+									},
+									"labels": {
+										Type:     schema.TypeMap,
+										Required: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+									"legend_position": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validation.StringInSlice([]string{"bottom", "hidden", "right"}, false),
+									},
+									"metric": {
+										Type:     schema.TypeList,
+										Required: true,
+										MinItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"metric_name": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+												"resource_type": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+												"stat": {
+													Type:         schema.TypeString,
+													Optional:     true,
+													Default:      "Average",
+													ValidateFunc: validDashboardStat,
+												},
+											},
+										},
+									},
+									"period": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validDashboardPeriod,
+									},
+									"region": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"rows_per_page": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+									"split_by": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"stacked": {
+										Type:     schema.TypeBool,
+										Optional: true,
+									},
+									"title": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"view": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validation.StringInSlice([]string{"bar", "pie", "timeSeries"}, false),
+									},
+									"widgets_per_row": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validation.IntBetween(1, 4),
+									},
+								},
+							},
+						},
+						"height": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      dashboardWidgetDefaultHeight,
+							ValidateFunc: validation.IntBetween(1, 1000),
+						},
+						"log": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"log_group_names": {
+										Type:     schema.TypeList,
+										Required: true,
+										MinItems: 1,
+										MaxItems: 50,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+									"query": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"region": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"stacked": {
+										Type:     schema.TypeBool,
+										Optional: true,
+									},
+									"title": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"view": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Default:      "table",
+										ValidateFunc: validation.StringInSlice([]string{"bar", "pie", "table", "timeSeries"}, false),
+									},
+								},
+							},
+						},
+						"metric": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"alarm_annotations": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem: &schema.Schema{
+											Type:         schema.TypeString,
+											ValidateFunc: verify.ValidARN,
+										},
+									},
+									"horizontal_annotation": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"color": {
+													Type:         schema.TypeString,
+													Optional:     true,
+													ValidateFunc: validDashboardColor,
+												},
+												"fill": {
+													Type:         schema.TypeString,
+													Optional:     true,
+													ValidateFunc: validation.StringInSlice([]string{"above", "below"}, false),
+												},
+												"label": {
+													Type:     schema.TypeString,
+													Optional: true,
+												},
+												"value": {
+													Type:     schema.TypeFloat,
+													Required: true,
+												},
+												"y_axis": {
+													Type:         schema.TypeString,
+													Optional:     true,
+													ValidateFunc: validation.StringInSlice([]string{"left", "right"}, false),
+												},
+											},
+										},
+									},
+									"left_y_axis": yAxisSchema,
+									"live_data": {
+										Type:     schema.TypeBool,
+										Optional: true,
+									},
+									"metric_query": {
+										Type:     schema.TypeList,
+										Required: true,
+										MinItems: 1,
+										MaxItems: dashboardWidgetMaxMetrics,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"account_id": {
+													Type:         schema.TypeString,
+													Optional:     true,
+													ValidateFunc: verify.ValidAccountID,
+												},
+												"color": {
+													Type:         schema.TypeString,
+													Optional:     true,
+													ValidateFunc: validDashboardColor,
+												},
+												"expression": {
+													Type:         schema.TypeString,
+													Optional:     true,
+													ValidateFunc: validation.StringLenBetween(1, dashboardExpressionMaxChars),
+												},
+												"id": {
+													Type:         schema.TypeString,
+													Optional:     true,
+													ValidateFunc: validDashboardMetricID,
+												},
+												"label": {
+													Type:     schema.TypeString,
+													Optional: true,
+												},
+												"metric": {
+													Type:     schema.TypeList,
+													Optional: true,
+													MaxItems: 1,
+													Elem: &schema.Resource{
+														Schema: map[string]*schema.Schema{
+															"dimensions": {
+																Type:     schema.TypeMap,
+																Optional: true,
+																Elem:     &schema.Schema{Type: schema.TypeString},
+															},
+															"metric_name": {
+																Type:         schema.TypeString,
+																Required:     true,
+																ValidateFunc: validation.StringLenBetween(1, 255),
+															},
+															"namespace": {
+																Type:         schema.TypeString,
+																Required:     true,
+																ValidateFunc: validation.StringLenBetween(1, 255),
+															},
+															"period": {
+																Type:         schema.TypeInt,
+																Optional:     true,
+																ValidateFunc: validDashboardPeriod,
+															},
+															"region": {
+																Type:     schema.TypeString,
+																Optional: true,
+															},
+															"stat": {
+																Type:         schema.TypeString,
+																Optional:     true,
+																ValidateFunc: validDashboardStat,
+															},
+														},
+													},
+												},
+												"visible": {
+													Type:     schema.TypeBool,
+													Optional: true,
+													Default:  true,
+												},
+												"y_axis": {
+													Type:         schema.TypeString,
+													Optional:     true,
+													ValidateFunc: validation.StringInSlice([]string{"left", "right"}, false),
+												},
+											},
+										},
+									},
+									"period": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validDashboardPeriod,
+									},
+									"region": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"right_y_axis": yAxisSchema,
+									"set_period_to_time_range": {
+										Type:     schema.TypeBool,
+										Optional: true,
+									},
+									"stacked": {
+										Type:     schema.TypeBool,
+										Optional: true,
+									},
+									"stat": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validDashboardStat,
+									},
+									"title": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"view": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Default:      "timeSeries",
+										ValidateFunc: validation.StringInSlice([]string{"bar", "gauge", "pie", "singleValue", "timeSeries"}, false),
+									},
+								},
+							},
+						},
+						"text": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"background": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validation.StringInSlice([]string{"solid", "transparent"}, false),
+									},
+									"markdown": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+						"width": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      dashboardWidgetDefaultWidth,
+							ValidateFunc: validation.IntBetween(1, dashboardGridWidth),
+						},
+						"x": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntBetween(0, dashboardGridWidth-1),
+						},
+						"y": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// dashboardWidgetTypes are the widget type blocks, each corresponding to a dashboard widget type.
+var dashboardWidgetTypes = []string{"alarm", "explorer", "log", "metric", "text"}
+
+func dataSourceDashboardDocumentRead(d *schema.ResourceData, meta interface{}) error {
+	region := meta.(*conns.AWSClient).Region
+
+	body := &DashboardBody{
+		End:            d.Get("end").(string),
+		PeriodOverride: d.Get("period_override").(string),
+		Start:          d.Get("start").(string),
+		Widgets:        []*DashboardWidget{},
+	}
+
+	for i, v := range d.Get("widget").([]interface{}) {
+		tfMap, ok := v.(map[string]interface{})
+
+		if !ok {
+			return fmt.Errorf("widget %d: empty widget", i)
+		}
+
+		widget, err := expandDashboardWidget(d, i, tfMap, region)
+
+		if err != nil {
+			return fmt.Errorf("widget %d: %w", i, err)
+		}
+
+		body.Widgets = append(body.Widgets, widget)
+	}
+
+	body.LayOut()
+
+	if err := body.Validate(); err != nil {
+		return fmt.Errorf("error validating CloudWatch Dashboard document: %w", err)
+	}
+
+	jsonDoc, err := json.MarshalIndent(body, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	jsonString := string(jsonDoc)
+
+	d.Set("json", jsonString)
+	d.SetId(strconv.Itoa(create.StringHashcode(jsonString)))
+
+	return nil
+}
+
+func expandDashboardWidget(d *schema.ResourceData, i int, tfMap map[string]interface{}, region string) (*DashboardWidget, error) {
+	widget := &DashboardWidget{
+		Width:  tfMap["width"].(int),
+		Height: tfMap["height"].(int),
+	}
+
+	// x and y are zero when unset, so are read with GetOkExists.
+	_, hasX := d.GetOkExists(fmt.Sprintf("widget.%d.x", i))
+	_, hasY := d.GetOkExists(fmt.Sprintf("widget.%d.y", i))
+
+	if hasX != hasY {
+		return nil, fmt.Errorf("x and y must be set together")
+	}
+
+	if hasX {
+		widget.X = intPtr(tfMap["x"].(int))
+		widget.Y = intPtr(tfMap["y"].(int))
+	}
+
+	var typ string
+
+	for _, t := range dashboardWidgetTypes {
+		if v, ok := tfMap[t].([]interface{}); ok && len(v) > 0 {
+			if typ != "" {
+				return nil, fmt.Errorf("only one of %v can be set", dashboardWidgetTypes)
+			}
+
+			typ = t
+		}
+	}
+
+	if typ == "" {
+		return nil, fmt.Errorf("one of %v must be set", dashboardWidgetTypes)
+	}
+
+	widget.Type = typ
+	properties, _ := tfMap[typ].([]interface{})[0].(map[string]interface{})
+
+	if properties == nil {
+		properties = make(map[string]interface{})
+	}
+
+	var err error
+
+	switch typ {
+	case "alarm":
+		widget.Properties = expandDashboardAlarmWidgetProperties(properties)
+	case "explorer":
+		widget.Properties = expandDashboardExplorerWidgetProperties(properties, region)
+	case "log":
+		widget.Properties = expandDashboardLogWidgetProperties(properties, region)
+	case "metric":
+		widget.Properties, err = expandDashboardMetricWidgetProperties(properties, region)
+	case "text":
+		widget.Properties = &DashboardTextWidgetProperties{
+			Background: properties["background"].(string),
+			Markdown:   properties["markdown"].(string),
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return widget, nil
+}
+
+func expandDashboardAlarmWidgetProperties(tfMap map[string]interface{}) *DashboardAlarmWidgetProperties {
+	return &DashboardAlarmWidgetProperties{
+		Alarms: aws.StringValueSlice(flex.ExpandStringList(tfMap["alarms"].([]interface{}))),
+		SortBy: tfMap["sort_by"].(string),
+		States: aws.StringValueSlice(flex.ExpandStringList(tfMap["states"].([]interface{}))),
+		Title:  tfMap["title"].(string),
+	}
+}
+
+func expandDashboardExplorerWidgetProperties(tfMap map[string]interface{}, region string) *DashboardExplorerWidgetProperties {
+	properties := &DashboardExplorerWidgetProperties{
+		Labels:  []*DashboardExplorerLabel{},
+		Period:  tfMap["period"].(int),
+		Region:  dashboardRegion(tfMap, region),
+		SplitBy: tfMap["split_by"].(string),
+		Title:   tfMap["title"].(string),
+	}
+
+	if v, ok := tfMap["aggregate_by"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		aggregateBy := v[0].(map[string]interface{})
+
+		properties.AggregateBy = &DashboardExplorerAggregateBy{
+			Func: aggregateBy["function"].(string),
+			Key:  aggregateBy["key"].(string),
+		}
+	}
+
+	for _, key := range sortedKeys(tfMap["labels"].(map[string]interface{})) {
+		properties.Labels = append(properties.Labels, &DashboardExplorerLabel{
+			Key:   key,
+			Value: tfMap["labels"].(map[string]interface{})[key].(string),
+		})
+	}
+
+	for _, v := range tfMap["metric"].([]interface{}) {
+		metric, ok := v.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		properties.Metrics = append(properties.Metrics, &DashboardExplorerMetric{
+			MetricName:   metric["metric_name"].(string),
+			ResourceType: metric["resource_type"].(string),
+			Stat:         metric["stat"].(string),
+		})
+	}
+
+	options := &DashboardExplorerWidgetOptions{
+		RowsPerPage:   tfMap["rows_per_page"].(int),
+		Stacked:       tfMap["stacked"].(bool),
+		View:          tfMap["view"].(string),
+		WidgetsPerRow: tfMap["widgets_per_row"].(int),
+	}
+
+	if v := tfMap["legend_position"].(string); v != "" {
+		options.Legend = &DashboardExplorerLegend{Position: v}
+	}
+
+	if *options != (DashboardExplorerWidgetOptions{}) {
+		properties.WidgetOptions = options
+	}
+
+	return properties
+}
+
+// expandDashboardLogWidgetProperties prefixes the query with the SOURCE commands of the log groups, as the console does.
+func expandDashboardLogWidgetProperties(tfMap map[string]interface{}, region string) *DashboardLogWidgetProperties {
+	query := ""
+
+	for _, v := range tfMap["log_group_names"].([]interface{}) {
+		query += fmt.Sprintf("SOURCE '%s' | ", v.(string))
+	}
+
+	return &DashboardLogWidgetProperties{
+		Query:   query + tfMap["query"].(string),
+		Region:  dashboardRegion(tfMap, region),
+		Stacked: tfMap["stacked"].(bool),
+		Title:   tfMap["title"].(string),
+		View:    tfMap["view"].(string),
+	}
+}
+
+func expandDashboardMetricWidgetProperties(tfMap map[string]interface{}, region string) (*DashboardMetricWidgetProperties, error) {
+	properties := &DashboardMetricWidgetProperties{
+		LiveData:             tfMap["live_data"].(bool),
+		Period:               tfMap["period"].(int),
+		Region:               dashboardRegion(tfMap, region),
+		SetPeriodToTimeRange: tfMap["set_period_to_time_range"].(bool),
+		Stacked:              tfMap["stacked"].(bool),
+		Stat:                 tfMap["stat"].(string),
+		Title:                tfMap["title"].(string),
+		View:                 tfMap["view"].(string),
+	}
+
+	for i, v := range tfMap["metric_query"].([]interface{}) {
+		metric, err := expandDashboardMetric(v)
+
+		if err != nil {
+			return nil, fmt.Errorf("metric_query %d: %w", i, err)
+		}
+
+		properties.Metrics = append(properties.Metrics, metric)
+	}
+
+	annotations := &DashboardAnnotations{
+		Alarms: aws.StringValueSlice(flex.ExpandStringList(tfMap["alarm_annotations"].([]interface{}))),
+	}
+
+	for _, v := range tfMap["horizontal_annotation"].([]interface{}) {
+		annotation, ok := v.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		annotations.Horizontal = append(annotations.Horizontal, &DashboardHorizontalAnnotation{
+			Color: annotation["color"].(string),
+			Fill:  annotation["fill"].(string),
+			Label: annotation["label"].(string),
+			Value: annotation["value"].(float64),
+			YAxis: annotation["y_axis"].(string),
+		})
+	}
+
+	if len(annotations.Alarms) > 0 || len(annotations.Horizontal) > 0 {
+		properties.Annotations = annotations
+	}
+
+	left := expandDashboardYAxis(tfMap["left_y_axis"].([]interface{}))
+	right := expandDashboardYAxis(tfMap["right_y_axis"].([]interface{}))
+
+	if left != nil || right != nil {
+		properties.YAxis = &DashboardYAxes{
+			Left:  left,
+			Right: right,
+		}
+	}
+
+	return properties, nil
+}
+
+func expandDashboardMetric(v interface{}) (*DashboardMetric, error) {
+	tfMap, ok := v.(map[string]interface{})
+
+	if !ok {
+		return nil, fmt.Errorf("one of expression or metric must be set")
+	}
+
+	metric := &DashboardMetric{
+		Options: DashboardMetricOptions{
+			AccountID:  tfMap["account_id"].(string),
+			Color:      tfMap["color"].(string),
+			Expression: tfMap["expression"].(string),
+			ID:         tfMap["id"].(string),
+			Label:      tfMap["label"].(string),
+		},
+	}
+
+	if !tfMap["visible"].(bool) {
+		metric.Options.Visible = aws.Bool(false)
+	}
+
+	// The left Y axis is the default.
+	if v := tfMap["y_axis"].(string); v == "right" {
+		metric.Options.YAxis = v
+	}
+
+	metrics, _ := tfMap["metric"].([]interface{})
+	hasMetric := len(metrics) > 0 && metrics[0] != nil
+
+	if hasMetric == (metric.Options.Expression != "") {
+		return nil, fmt.Errorf("exactly one of expression or metric must be set")
+	}
+
+	if !hasMetric {
+		return metric, nil
+	}
+
+	m := metrics[0].(map[string]interface{})
+
+	metric.Namespace = m["namespace"].(string)
+	metric.MetricName = m["metric_name"].(string)
+	metric.Dimensions = aws.StringValueMap(flex.ExpandStringMap(m["dimensions"].(map[string]interface{})))
+	metric.Options.Period = m["period"].(int)
+	metric.Options.Region = m["region"].(string)
+	metric.Options.Stat = m["stat"].(string)
+
+	return metric, nil
+}
+
+func expandDashboardYAxis(tfList []interface{}) *DashboardYAxis {
+	if len(tfList) == 0 || tfList[0] == nil {
+		return nil
+	}
+
+	tfMap := tfList[0].(map[string]interface{})
+	axis := &DashboardYAxis{
+		Label: tfMap["label"].(string),
+	}
+
+	if v, err := strconv.ParseFloat(tfMap["max"].(string), 64); err == nil {
+		axis.Max = aws.Float64(v)
+	}
+
+	if v, err := strconv.ParseFloat(tfMap["min"].(string), 64); err == nil {
+		axis.Min = aws.Float64(v)
+	}
+
+	if !tfMap["show_units"].(bool) {
+		axis.ShowUnits = aws.Bool(false)
+	}
+
+	return axis
+}
+
+func dashboardRegion(tfMap map[string]interface{}, region string) string {
+	if v := tfMap["region"].(string); v != "" {
+		return v
+	}
+
+	return region
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
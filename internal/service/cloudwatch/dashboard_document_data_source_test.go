@@ -0,0 +1,226 @@
+package cloudwatch_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+)
+
+func TestAccCloudWatchDashboardDocumentDataSource_basic(t *testing.T) {
+	var dashboard cloudwatch.GetDashboardOutput
+	dataSourceName := "data.aws_cloudwatch_dashboard_document.test"
+	resourceName := "aws_cloudwatch_dashboard.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, cloudwatch.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckDashboardDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDashboardDocumentDataSourceConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					acctest.CheckResourceAttrEquivalentJSON(dataSourceName, "json", testAccDashboardDocumentDataSourceExpectedJSON(rName)),
+					testAccCheckCloudWatchDashboardExists(resourceName, &dashboard),
+					testAccCloudWatchCheckDashboardBodyIsExpected(resourceName, testAccDashboardDocumentDataSourceExpectedJSON(rName)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudWatchDashboardDocumentDataSource_invalid(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { acctest.PreCheck(t) },
+		ErrorCheck: acctest.ErrorCheck(t, cloudwatch.EndpointsID),
+		Providers:  acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDashboardDocumentDataSourceConfig_unknownMetricID,
+				ExpectError: regexp.MustCompile(`references unknown metric ID \(m2\)`),
+			},
+			{
+				Config:      testAccDashboardDocumentDataSourceConfig_multipleTypes,
+				ExpectError: regexp.MustCompile(`only one of`),
+			},
+		},
+	})
+}
+
+func testAccDashboardDocumentDataSourceConfig(rName string) string {
+	return fmt.Sprintf(`
+data "aws_cloudwatch_dashboard_document" "test" {
+  start = "-PT6H"
+
+  widget {
+    width  = 24
+    height = 2
+
+    text {
+      markdown = "# %[1]s"
+    }
+  }
+
+  widget {
+    width = 12
+
+    metric {
+      title = "Errors"
+      stat  = "Sum"
+
+      metric_query {
+        id = "errors"
+
+        metric {
+          namespace   = "AWS/Lambda"
+          metric_name = "Errors"
+
+          dimensions = {
+            FunctionName = %[1]q
+          }
+        }
+      }
+
+      metric_query {
+        id      = "invocations"
+        visible = false
+
+        metric {
+          namespace   = "AWS/Lambda"
+          metric_name = "Invocations"
+
+          dimensions = {
+            FunctionName = %[1]q
+          }
+        }
+      }
+
+      metric_query {
+        expression = "100 * errors / invocations"
+        label      = "Error rate"
+        y_axis     = "right"
+      }
+
+      horizontal_annotation {
+        label = "Threshold"
+        value = 5
+      }
+
+      left_y_axis {
+        min = 0
+      }
+    }
+  }
+
+  widget {
+    width = 12
+
+    log {
+      log_group_names = ["/aws/lambda/%[1]s"]
+      query           = "fields @timestamp, @message | sort @timestamp desc | limit 20"
+    }
+  }
+}
+
+resource "aws_cloudwatch_dashboard" "test" {
+  dashboard_name = %[1]q
+  dashboard_body = data.aws_cloudwatch_dashboard_document.test.json
+}
+`, rName)
+}
+
+func testAccDashboardDocumentDataSourceExpectedJSON(rName string) string {
+	return fmt.Sprintf(`{
+  "start": "-PT6H",
+  "widgets": [
+    {
+      "type": "text",
+      "x": 0,
+      "y": 0,
+      "width": 24,
+      "height": 2,
+      "properties": {
+        "markdown": "# %[1]s"
+      }
+    },
+    {
+      "type": "metric",
+      "x": 0,
+      "y": 2,
+      "width": 12,
+      "height": 6,
+      "properties": {
+        "metrics": [
+          ["AWS/Lambda", "Errors", "FunctionName", %[1]q, {"id": "errors"}],
+          ["AWS/Lambda", "Invocations", "FunctionName", %[1]q, {"id": "invocations", "visible": false}],
+          [{"expression": "100 * errors / invocations", "label": "Error rate", "yAxis": "right"}]
+        ],
+        "annotations": {
+          "horizontal": [{"label": "Threshold", "value": 5}]
+        },
+        "region": %[2]q,
+        "stat": "Sum",
+        "title": "Errors",
+        "view": "timeSeries",
+        "yAxis": {
+          "left": {"min": 0}
+        }
+      }
+    },
+    {
+      "type": "log",
+      "x": 12,
+      "y": 2,
+      "width": 12,
+      "height": 6,
+      "properties": {
+        "query": "SOURCE '/aws/lambda/%[1]s' | fields @timestamp, @message | sort @timestamp desc | limit 20",
+        "region": %[2]q,
+        "view": "table"
+      }
+    }
+  ]
+}`, rName, acctest.Region())
+}
+
+const testAccDashboardDocumentDataSourceConfig_unknownMetricID = `
+data "aws_cloudwatch_dashboard_document" "test" {
+  widget {
+    metric {
+      metric_query {
+        id = "m1"
+
+        metric {
+          namespace   = "AWS/EC2"
+          metric_name = "CPUUtilization"
+        }
+      }
+
+      metric_query {
+        expression = "m1 + m2"
+      }
+    }
+  }
+}
+`
+
+const testAccDashboardDocumentDataSourceConfig_multipleTypes = `
+data "aws_cloudwatch_dashboard_document" "test" {
+  widget {
+    text {
+      markdown = "text"
+    }
+
+    log {
+      log_group_names = ["test"]
+      query           = "fields @message"
+    }
+  }
+}
+`
@@ -0,0 +1,359 @@
+package cloudwatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// dashboardGridWidth is the width of the dashboard grid, in columns.
+	dashboardGridWidth = 24
+
+	dashboardWidgetDefaultWidth  = 6
+	dashboardWidgetDefaultHeight = 6
+
+	// https://docs.aws.amazon.com/AmazonCloudWatch/latest/monitoring/cloudwatch_limits.html
+	dashboardMaxWidgets         = 500
+	dashboardMaxMetrics         = 2500
+	dashboardWidgetMaxMetrics   = 500
+	dashboardMetricIDMaxLength  = 255
+	dashboardExpressionMaxChars = 1024
+)
+
+var (
+	dashboardMetricIDRegexp = regexp.MustCompile(`^[a-z][a-zA-Z0-9_]*$`)
+
+	// dashboardExpressionIdentifierRegexp matches metric math identifiers and string literals.
+	// Metric IDs start with a lowercase letter, while functions and keywords are uppercase.
+	dashboardExpressionIdentifierRegexp = regexp.MustCompile(`'[^']*'|"[^"]*"|[A-Za-z_][A-Za-z0-9_]*`)
+)
+
+// DashboardBody is a dashboard body.
+// https://docs.aws.amazon.com/AmazonCloudWatch/latest/APIReference/CloudWatch-Dashboard-Body-Structure.html
+type DashboardBody struct {
+	Start          string             `json:"start,omitempty"`
+	End            string             `json:"end,omitempty"`
+	PeriodOverride string             `json:"periodOverride,omitempty"`
+	Widgets        []*DashboardWidget `json:"widgets"`
+}
+
+// DashboardWidget is a dashboard widget. Widgets without position are placed by LayOut.
+type DashboardWidget struct {
+	Type       string      `json:"type"`
+	X          *int        `json:"x"`
+	Y          *int        `json:"y"`
+	Width      int         `json:"width"`
+	Height     int         `json:"height"`
+	Properties interface{} `json:"properties"`
+}
+
+type DashboardMetricWidgetProperties struct {
+	Metrics              []*DashboardMetric    `json:"metrics"`
+	Annotations          *DashboardAnnotations `json:"annotations,omitempty"`
+	LiveData             bool                  `json:"liveData,omitempty"`
+	Period               int                   `json:"period,omitempty"`
+	Region               string                `json:"region"`
+	SetPeriodToTimeRange bool                  `json:"setPeriodToTimeRange,omitempty"`
+	Stacked              bool                  `json:"stacked,omitempty"`
+	Stat                 string                `json:"stat,omitempty"`
+	Title                string                `json:"title,omitempty"`
+	View                 string                `json:"view,omitempty"`
+	YAxis                *DashboardYAxes       `json:"yAxis,omitempty"`
+}
+
+// DashboardMetric is a metric or metric math expression of a metric widget.
+// Metrics are encoded as arrays of the namespace, metric name, dimension names and values followed by rendering options,
+// and expressions as an array of rendering options.
+type DashboardMetric struct {
+	Namespace  string
+	MetricName string
+	Dimensions map[string]string
+	Options    DashboardMetricOptions
+}
+
+type DashboardMetricOptions struct {
+	AccountID  string `json:"accountId,omitempty"`
+	Color      string `json:"color,omitempty"`
+	Expression string `json:"expression,omitempty"`
+	ID         string `json:"id,omitempty"`
+	Label      string `json:"label,omitempty"`
+	Period     int    `json:"period,omitempty"`
+	Region     string `json:"region,omitempty"`
+	Stat       string `json:"stat,omitempty"`
+	Visible    *bool  `json:"visible,omitempty"`
+	YAxis      string `json:"yAxis,omitempty"`
+}
+
+func (m *DashboardMetric) MarshalJSON() ([]byte, error) {
+	var v []interface{}
+
+	if m.Options.Expression == "" {
+		v = append(v, m.Namespace, m.MetricName)
+
+		names := make([]string, 0, len(m.Dimensions))
+
+		for name := range m.Dimensions {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			v = append(v, name, m.Dimensions[name])
+		}
+	}
+
+	if m.Options != (DashboardMetricOptions{}) {
+		v = append(v, m.Options)
+	}
+
+	return json.Marshal(v)
+}
+
+type DashboardAnnotations struct {
+	Horizontal []*DashboardHorizontalAnnotation `json:"horizontal,omitempty"`
+	Alarms     []string                         `json:"alarms,omitempty"`
+}
+
+type DashboardHorizontalAnnotation struct {
+	Color string  `json:"color,omitempty"`
+	Fill  string  `json:"fill,omitempty"`
+	Label string  `json:"label,omitempty"`
+	Value float64 `json:"value"`
+	YAxis string  `json:"yAxis,omitempty"`
+}
+
+type DashboardYAxes struct {
+	Left  *DashboardYAxis `json:"left,omitempty"`
+	Right *DashboardYAxis `json:"right,omitempty"`
+}
+
+type DashboardYAxis struct {
+	Label     string   `json:"label,omitempty"`
+	Max       *float64 `json:"max,omitempty"`
+	Min       *float64 `json:"min,omitempty"`
+	ShowUnits *bool    `json:"showUnits,omitempty"`
+}
+
+type DashboardTextWidgetProperties struct {
+	Background string `json:"background,omitempty"`
+	Markdown   string `json:"markdown"`
+}
+
+type DashboardLogWidgetProperties struct {
+	Query   string `json:"query"`
+	Region  string `json:"region"`
+	Stacked bool   `json:"stacked,omitempty"`
+	Title   string `json:"title,omitempty"`
+	View    string `json:"view,omitempty"`
+}
+
+type DashboardAlarmWidgetProperties struct {
+	Alarms []string `json:"alarms"`
+	SortBy string   `json:"sortBy,omitempty"`
+	States []string `json:"states,omitempty"`
+	Title  string   `json:"title,omitempty"`
+}
+
+type DashboardExplorerWidgetProperties struct {
+	AggregateBy   *DashboardExplorerAggregateBy   `json:"aggregateBy,omitempty"`
+	Labels        []*DashboardExplorerLabel       `json:"labels"`
+	Metrics       []*DashboardExplorerMetric      `json:"metrics"`
+	Period        int                             `json:"period,omitempty"`
+	Region        string                          `json:"region"`
+	SplitBy       string                          `json:"splitBy,omitempty"`
+	Title         string                          `json:"title,omitempty"`
+	WidgetOptions *DashboardExplorerWidgetOptions `json:"widgetOptions,omitempty"`
+}
+
+type DashboardExplorerAggregateBy struct {
+	Func string `json:"func"`
+	Key  string `json:"key"`
+}
+
+type DashboardExplorerLabel struct {
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+}
+
+type DashboardExplorerMetric struct {
+	MetricName   string `json:"metricName"`
+	ResourceType string `json:"resourceType"`
+	Stat         string `json:"stat"`
+}
+
+type DashboardExplorerWidgetOptions struct {
+	Legend        *DashboardExplorerLegend `json:"legend,omitempty"`
+	RowsPerPage   int                      `json:"rowsPerPage,omitempty"`
+	Stacked       bool                     `json:"stacked,omitempty"`
+	View          string                   `json:"view,omitempty"`
+	WidgetsPerRow int                      `json:"widgetsPerRow,omitempty"`
+}
+
+type DashboardExplorerLegend struct {
+	Position string `json:"position"`
+}
+
+// LayOut places the widgets without position on the grid, in order, left to right and top to bottom,
+// in the first free space after the previously placed widget.
+func (b *DashboardBody) LayOut() {
+	var placed []*DashboardWidget
+
+	for _, w := range b.Widgets {
+		if w.X != nil && w.Y != nil {
+			placed = append(placed, w)
+		}
+	}
+
+	x, y := 0, 0
+
+	for _, w := range b.Widgets {
+		if w.X != nil && w.Y != nil {
+			continue
+		}
+
+		for {
+			if x > 0 && x+w.Width > dashboardGridWidth {
+				x, y = 0, y+1
+
+				continue
+			}
+
+			if blocker := overlappingWidget(placed, x, y, w.Width, w.Height); blocker != nil {
+				x = *blocker.X + blocker.Width
+
+				continue
+			}
+
+			break
+		}
+
+		w.X, w.Y = intPtr(x), intPtr(y)
+		placed = append(placed, w)
+		x += w.Width
+	}
+}
+
+func overlappingWidget(widgets []*DashboardWidget, x, y, width, height int) *DashboardWidget {
+	for _, w := range widgets {
+		if x < *w.X+w.Width && *w.X < x+width && y < *w.Y+w.Height && *w.Y < y+height {
+			return w
+		}
+	}
+
+	return nil
+}
+
+// Validate validates the dashboard body against the dashboard body constraints that can be checked locally.
+func (b *DashboardBody) Validate() error {
+	if n := len(b.Widgets); n > dashboardMaxWidgets {
+		return fmt.Errorf("dashboard has %d widgets, the maximum is %d", n, dashboardMaxWidgets)
+	}
+
+	metrics := 0
+
+	for i, w := range b.Widgets {
+		if w.Width < 1 || w.Width > dashboardGridWidth || w.Height < 1 {
+			return fmt.Errorf("widget %d: width must be between 1 and %d and height at least 1", i, dashboardGridWidth)
+		}
+
+		if w.X != nil && *w.X+w.Width > dashboardGridWidth {
+			return fmt.Errorf("widget %d: x (%d) plus width (%d) exceeds the grid width (%d)", i, *w.X, w.Width, dashboardGridWidth)
+		}
+
+		if p, ok := w.Properties.(*DashboardMetricWidgetProperties); ok {
+			if err := p.validate(); err != nil {
+				return fmt.Errorf("widget %d: %w", i, err)
+			}
+
+			metrics += len(p.Metrics)
+		}
+	}
+
+	if metrics > dashboardMaxMetrics {
+		return fmt.Errorf("dashboard has %d metrics, the maximum is %d", metrics, dashboardMaxMetrics)
+	}
+
+	return nil
+}
+
+func (p *DashboardMetricWidgetProperties) validate() error {
+	if n := len(p.Metrics); n == 0 {
+		return fmt.Errorf("metric widget has no metrics")
+	} else if n > dashboardWidgetMaxMetrics {
+		return fmt.Errorf("metric widget has %d metrics, the maximum is %d", n, dashboardWidgetMaxMetrics)
+	}
+
+	ids := make(map[string]bool)
+
+	for _, m := range p.Metrics {
+		id := m.Options.ID
+
+		if id == "" {
+			continue
+		}
+
+		if len(id) > dashboardMetricIDMaxLength || !dashboardMetricIDRegexp.MatchString(id) {
+			return fmt.Errorf("metric ID (%s) must start with a lowercase letter and contain only letters, numbers and underscores", id)
+		}
+
+		if ids[id] {
+			return fmt.Errorf("duplicate metric ID (%s)", id)
+		}
+
+		ids[id] = true
+	}
+
+	for _, m := range p.Metrics {
+		expression := m.Options.Expression
+
+		if expression == "" {
+			continue
+		}
+
+		if len(expression) > dashboardExpressionMaxChars {
+			return fmt.Errorf("expression (%s) is longer than %d characters", m.Options.ID, dashboardExpressionMaxChars)
+		}
+
+		for _, id := range DashboardExpressionReferences(expression) {
+			if !ids[id] {
+				return fmt.Errorf("expression (%s) references unknown metric ID (%s)", expression, id)
+			}
+
+			if id == m.Options.ID {
+				return fmt.Errorf("expression (%s) references itself", expression)
+			}
+		}
+	}
+
+	return nil
+}
+
+// DashboardExpressionReferences returns the metric IDs referenced by a metric math expression, in order of first reference.
+// Metrics Insights queries do not reference metric IDs.
+func DashboardExpressionReferences(expression string) []string {
+	if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(expression)), "SELECT ") {
+		return nil
+	}
+
+	var ids []string
+	seen := make(map[string]bool)
+
+	for _, token := range dashboardExpressionIdentifierRegexp.FindAllString(expression, -1) {
+		if !dashboardMetricIDRegexp.MatchString(token) || seen[token] {
+			continue
+		}
+
+		seen[token] = true
+		ids = append(ids, token)
+	}
+
+	return ids
+}
+
+func intPtr(v int) *int {
+	return &v
+}
@@ -0,0 +1,221 @@
+package cloudwatch
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+func TestDashboardBodyLayOut(t *testing.T) {
+	widget := func(width, height int, position ...int) *DashboardWidget {
+		w := &DashboardWidget{Width: width, Height: height}
+
+		if len(position) == 2 {
+			w.X, w.Y = intPtr(position[0]), intPtr(position[1])
+		}
+
+		return w
+	}
+
+	testCases := []struct {
+		Name     string
+		Widgets  []*DashboardWidget
+		Expected [][2]int
+	}{
+		{
+			Name:     "rows",
+			Widgets:  []*DashboardWidget{widget(12, 6), widget(12, 6), widget(6, 3), widget(24, 6)},
+			Expected: [][2]int{{0, 0}, {12, 0}, {0, 6}, {0, 9}},
+		},
+		{
+			Name:     "fills gaps below shorter widgets",
+			Widgets:  []*DashboardWidget{widget(12, 6), widget(12, 3), widget(12, 3)},
+			Expected: [][2]int{{0, 0}, {12, 0}, {12, 3}},
+		},
+		{
+			Name:     "avoids positioned widgets",
+			Widgets:  []*DashboardWidget{widget(24, 2, 0, 0), widget(8, 6), widget(8, 6, 8, 2), widget(8, 6), widget(8, 6)},
+			Expected: [][2]int{{0, 0}, {0, 2}, {8, 2}, {16, 2}, {0, 8}},
+		},
+		{
+			Name:     "too wide",
+			Widgets:  []*DashboardWidget{widget(6, 1), widget(30, 1)},
+			Expected: [][2]int{{0, 0}, {0, 1}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			body := &DashboardBody{Widgets: testCase.Widgets}
+			body.LayOut()
+
+			var got [][2]int
+
+			for _, w := range body.Widgets {
+				got = append(got, [2]int{*w.X, *w.Y})
+			}
+
+			if !reflect.DeepEqual(got, testCase.Expected) {
+				t.Errorf("got %v, expected %v", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestDashboardMetricMarshalJSON(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Metric   *DashboardMetric
+		Expected string
+	}{
+		{
+			Name: "metric",
+			Metric: &DashboardMetric{
+				Namespace:  "AWS/EC2",
+				MetricName: "CPUUtilization",
+				Dimensions: map[string]string{"InstanceId": "i-12345678", "AutoScalingGroupName": "web"},
+			},
+			Expected: `["AWS/EC2","CPUUtilization","AutoScalingGroupName","web","InstanceId","i-12345678"]`,
+		},
+		{
+			Name: "metric with options",
+			Metric: &DashboardMetric{
+				Namespace:  "AWS/EC2",
+				MetricName: "CPUUtilization",
+				Options:    DashboardMetricOptions{ID: "m1", Stat: "p99", Visible: aws.Bool(false)},
+			},
+			Expected: `["AWS/EC2","CPUUtilization",{"id":"m1","stat":"p99","visible":false}]`,
+		},
+		{
+			Name: "expression",
+			Metric: &DashboardMetric{
+				Options: DashboardMetricOptions{Expression: "m1 * 100", ID: "e1", Label: "Percent"},
+			},
+			Expected: `[{"expression":"m1 * 100","id":"e1","label":"Percent"}]`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got, err := json.Marshal(testCase.Metric)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(got) != testCase.Expected {
+				t.Errorf("got %s, expected %s", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestDashboardBodyValidate(t *testing.T) {
+	metricWidget := func(metrics ...*DashboardMetric) *DashboardWidget {
+		return &DashboardWidget{
+			Type:       "metric",
+			X:          intPtr(0),
+			Y:          intPtr(0),
+			Width:      6,
+			Height:     6,
+			Properties: &DashboardMetricWidgetProperties{Metrics: metrics},
+		}
+	}
+	metric := func(id string) *DashboardMetric {
+		return &DashboardMetric{Namespace: "AWS/EC2", MetricName: "CPUUtilization", Options: DashboardMetricOptions{ID: id}}
+	}
+	expression := func(id, expression string) *DashboardMetric {
+		return &DashboardMetric{Options: DashboardMetricOptions{ID: id, Expression: expression}}
+	}
+
+	testCases := []struct {
+		Name          string
+		Widgets       []*DashboardWidget
+		ExpectedError string
+	}{
+		{
+			Name:    "valid",
+			Widgets: []*DashboardWidget{metricWidget(metric("m1"), metric("m2"), expression("e1", "(m1 + m2) / 2"), metric(""))},
+		},
+		{
+			Name:    "search expression",
+			Widgets: []*DashboardWidget{metricWidget(expression("e1", `SEARCH('{AWS/EC2,InstanceId} MetricName="CPUUtilization"', 'Average', 300)`))},
+		},
+		{
+			Name:    "metrics insights query",
+			Widgets: []*DashboardWidget{metricWidget(expression("q1", `SELECT AVG(CPUUtilization) FROM SCHEMA("AWS/EC2", InstanceId) WHERE env = 'prod'`))},
+		},
+		{
+			Name:          "beyond grid",
+			Widgets:       []*DashboardWidget{{Type: "text", X: intPtr(20), Y: intPtr(0), Width: 6, Height: 6, Properties: &DashboardTextWidgetProperties{}}},
+			ExpectedError: "exceeds the grid width",
+		},
+		{
+			Name:          "no metrics",
+			Widgets:       []*DashboardWidget{metricWidget()},
+			ExpectedError: "no metrics",
+		},
+		{
+			Name:          "duplicate ID",
+			Widgets:       []*DashboardWidget{metricWidget(metric("m1"), metric("m1"))},
+			ExpectedError: "duplicate metric ID (m1)",
+		},
+		{
+			Name:          "invalid ID",
+			Widgets:       []*DashboardWidget{metricWidget(metric("M1"))},
+			ExpectedError: "must start with a lowercase letter",
+		},
+		{
+			Name:          "unknown reference",
+			Widgets:       []*DashboardWidget{metricWidget(metric("m1"), expression("e1", "m1 + m2"))},
+			ExpectedError: "unknown metric ID (m2)",
+		},
+		{
+			Name:          "self reference",
+			Widgets:       []*DashboardWidget{metricWidget(metric("m1"), expression("e1", "e1 + m1"))},
+			ExpectedError: "references itself",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := (&DashboardBody{Widgets: testCase.Widgets}).Validate()
+
+			if testCase.ExpectedError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), testCase.ExpectedError) {
+				t.Errorf("got error %v, expected error containing %q", err, testCase.ExpectedError)
+			}
+		})
+	}
+}
+
+func TestDashboardExpressionReferences(t *testing.T) {
+	testCases := []struct {
+		Expression string
+		Expected   []string
+	}{
+		{Expression: "m1 + m2 * m1", Expected: []string{"m1", "m2"}},
+		{Expression: "SUM(METRICS()) / 2", Expected: nil},
+		{Expression: "FILL(errors, 0) / requests", Expected: []string{"errors", "requests"}},
+		{Expression: `SEARCH('{AWS/Lambda,FunctionName} errors', 'Sum', 60)`, Expected: nil},
+		{Expression: "select max(x) from schema(y)", Expected: nil},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Expression, func(t *testing.T) {
+			if got := DashboardExpressionReferences(testCase.Expression); !reflect.DeepEqual(got, testCase.Expected) {
+				t.Errorf("got %v, expected %v", got, testCase.Expected)
+			}
+		})
+	}
+}
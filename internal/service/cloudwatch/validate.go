@@ -3,6 +3,7 @@ package cloudwatch
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 )
 
 func validDashboardName(v interface{}, k string) (ws []string, errors []error) {
@@ -36,3 +37,60 @@ func validEC2AutomateARN(v interface{}, k string) (ws []string, errors []error)
 
 	return
 }
+
+func validDashboardColor(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if !regexp.MustCompile(`^#[0-9a-fA-F]{6}$`).MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q must be a six digit hexadecimal color, e.g. #1f77b4: %q", k, value))
+	}
+
+	return
+}
+
+func validDashboardFloat(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if _, err := strconv.ParseFloat(value, 64); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a number: %q", k, value))
+	}
+
+	return
+}
+
+func validDashboardMetricID(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if len(value) > dashboardMetricIDMaxLength || !dashboardMetricIDRegexp.MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q must start with a lowercase letter and contain only letters, numbers and underscores: %q", k, value))
+	}
+
+	return
+}
+
+// validDashboardPeriod validates a metric period, which is 1, 5, 10 or 30 seconds for high resolution metrics
+// or a multiple of 60 seconds.
+func validDashboardPeriod(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(int)
+
+	switch {
+	case value == 1, value == 5, value == 10, value == 30:
+	case value > 0 && value%60 == 0:
+	default:
+		errors = append(errors, fmt.Errorf("%q must be 1, 5, 10, 30 or a multiple of 60: %d", k, value))
+	}
+
+	return
+}
+
+// validDashboardStat validates a statistic, which is a standard statistic or an extended statistic such as p99, tm90 or PR(10:50).
+func validDashboardStat(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if !regexp.MustCompile(`^(SampleCount|Average|Sum|Minimum|Maximum|IQM|(p|tm|wm|tc|ts)\d{1,2}(\.\d{1,2})?|(p|tm|wm|tc|ts)100|(TM|WM|TC|TS|PR)\(\d*(\.\d+)?%?:\d*(\.\d+)?%?\))$`).MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q must be a standard or extended statistic: %q", k, value))
+	}
+
+	return
+}
@@ -65,3 +65,53 @@ func TestValidEC2AutomateARN(t *testing.T) {
 		}
 	}
 }
+
+func TestValidDashboardPeriod(t *testing.T) {
+	validPeriods := []int{1, 5, 10, 30, 60, 300, 86400}
+	for _, v := range validPeriods {
+		_, errors := validDashboardPeriod(v, "period")
+		if len(errors) != 0 {
+			t.Fatalf("%d should be a valid period: %q", v, errors)
+		}
+	}
+
+	invalidPeriods := []int{0, -60, 2, 45, 90}
+	for _, v := range invalidPeriods {
+		_, errors := validDashboardPeriod(v, "period")
+		if len(errors) == 0 {
+			t.Fatalf("%d should be an invalid period", v)
+		}
+	}
+}
+
+func TestValidDashboardStat(t *testing.T) {
+	validStats := []string{
+		"Average",
+		"SampleCount",
+		"p99",
+		"p99.9",
+		"p100",
+		"tm90",
+		"TM(10%:90%)",
+		"PR(:300)",
+	}
+	for _, v := range validStats {
+		_, errors := validDashboardStat(v, "stat")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid statistic: %q", v, errors)
+		}
+	}
+
+	invalidStats := []string{
+		"",
+		"average",
+		"p999",
+		"TM(10%)",
+	}
+	for _, v := range invalidStats {
+		_, errors := validDashboardStat(v, "stat")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid statistic", v)
+		}
+	}
+}
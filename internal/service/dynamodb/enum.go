@@ -0,0 +1,34 @@
+package dynamodb
+
+import (
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+const (
+	TableItemFormatAttributeValue = "attribute_value"
+	TableItemFormatJSON           = "json"
+)
+
+func TableItemFormat_Values() []string {
+	return []string{
+		TableItemFormatAttributeValue,
+		TableItemFormatJSON,
+	}
+}
+
+const (
+	TableItemAttributeTypeBS = "BS"
+	TableItemAttributeTypeNS = "NS"
+	TableItemAttributeTypeSS = "SS"
+)
+
+func TableItemAttributeType_Values() []string {
+	return []string{
+		dynamodb.ScalarAttributeTypeB,
+		TableItemAttributeTypeBS,
+		dynamodb.ScalarAttributeTypeN,
+		TableItemAttributeTypeNS,
+		dynamodb.ScalarAttributeTypeS,
+		TableItemAttributeTypeSS,
+	}
+}
@@ -2,10 +2,14 @@ package dynamodb
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"math/big"
+	"sort"
 	"strings"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 )
 
@@ -55,3 +59,359 @@ func flattenDynamoDBTableItemAttributes(attrs map[string]*dynamodb.AttributeValu
 
 	return rawBuffer.String(), nil
 }
+
+// ExpandTableItem decodes an item in the specified format.
+// attributeTypes is only used for the JSON format, see ExpandTableItemJSON.
+func ExpandTableItem(input, format string, attributeTypes map[string]string) (map[string]*dynamodb.AttributeValue, error) {
+	if format == TableItemFormatJSON {
+		return ExpandTableItemJSON(input, attributeTypes)
+	}
+
+	return ExpandTableItemAttributes(input)
+}
+
+func flattenTableItem(attrs map[string]*dynamodb.AttributeValue, format string) (string, error) {
+	if format == TableItemFormatJSON {
+		return flattenTableItemJSON(attrs)
+	}
+
+	return flattenDynamoDBTableItemAttributes(attrs)
+}
+
+// ExpandTableItemJSON decodes an item written as plain JSON into DynamoDB attribute values.
+// Strings, numbers, booleans, nulls, arrays and objects map to S, N, BOOL, NULL, L and M respectively.
+// Numbers keep their exact decimal representation.
+// Binary and set values have no JSON equivalent, so top-level attributes may be given an explicit type
+// (B, BS, N, NS, S or SS) in attributeTypes. Binary values are base64 encoded strings.
+func ExpandTableItemJSON(input string, attributeTypes map[string]string) (map[string]*dynamodb.AttributeValue, error) {
+	var item map[string]interface{}
+
+	dec := json.NewDecoder(strings.NewReader(input))
+	dec.UseNumber()
+
+	if err := dec.Decode(&item); err != nil {
+		return nil, fmt.Errorf("Decoding failed: %s", err)
+	}
+
+	if item == nil {
+		return nil, fmt.Errorf("Decoding failed: item must be a JSON object")
+	}
+
+	attributes := make(map[string]*dynamodb.AttributeValue, len(item))
+
+	for name, value := range item {
+		var attribute *dynamodb.AttributeValue
+		var err error
+
+		if attributeType, ok := attributeTypes[name]; ok {
+			attribute, err = expandTypedJSONAttributeValue(value, attributeType)
+		} else {
+			attribute, err = expandJSONAttributeValue(value)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("attribute %q: %w", name, err)
+		}
+
+		attributes[name] = attribute
+	}
+
+	return attributes, nil
+}
+
+func expandJSONAttributeValue(v interface{}) (*dynamodb.AttributeValue, error) {
+	switch v := v.(type) {
+	case nil:
+		return &dynamodb.AttributeValue{NULL: aws.Bool(true)}, nil
+	case bool:
+		return &dynamodb.AttributeValue{BOOL: aws.Bool(v)}, nil
+	case json.Number:
+		return &dynamodb.AttributeValue{N: aws.String(v.String())}, nil
+	case string:
+		return &dynamodb.AttributeValue{S: aws.String(v)}, nil
+	case []interface{}:
+		l := make([]*dynamodb.AttributeValue, 0, len(v))
+
+		for _, v := range v {
+			attribute, err := expandJSONAttributeValue(v)
+
+			if err != nil {
+				return nil, err
+			}
+
+			l = append(l, attribute)
+		}
+
+		return &dynamodb.AttributeValue{L: l}, nil
+	case map[string]interface{}:
+		m := make(map[string]*dynamodb.AttributeValue, len(v))
+
+		for k, v := range v {
+			attribute, err := expandJSONAttributeValue(v)
+
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+
+			m[k] = attribute
+		}
+
+		return &dynamodb.AttributeValue{M: m}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported JSON value type %T", v)
+}
+
+func expandTypedJSONAttributeValue(v interface{}, attributeType string) (*dynamodb.AttributeValue, error) {
+	switch attributeType {
+	case dynamodb.ScalarAttributeTypeS:
+		if v, ok := v.(string); ok {
+			return &dynamodb.AttributeValue{S: aws.String(v)}, nil
+		}
+	case dynamodb.ScalarAttributeTypeN:
+		if v, err := expandJSONNumber(v); err == nil {
+			return &dynamodb.AttributeValue{N: aws.String(v)}, nil
+		}
+	case dynamodb.ScalarAttributeTypeB:
+		if v, ok := v.(string); ok {
+			b, err := base64.StdEncoding.DecodeString(v)
+
+			if err != nil {
+				return nil, fmt.Errorf("invalid base64 binary value: %w", err)
+			}
+
+			return &dynamodb.AttributeValue{B: b}, nil
+		}
+	case TableItemAttributeTypeSS, TableItemAttributeTypeNS, TableItemAttributeTypeBS:
+		l, ok := v.([]interface{})
+
+		if !ok || len(l) == 0 {
+			return nil, fmt.Errorf("%s value must be a non-empty JSON array", attributeType)
+		}
+
+		attribute := &dynamodb.AttributeValue{}
+
+		for _, v := range l {
+			element, err := expandTypedJSONAttributeValue(v, attributeType[:1])
+
+			if err != nil {
+				return nil, err
+			}
+
+			switch attributeType {
+			case TableItemAttributeTypeSS:
+				attribute.SS = append(attribute.SS, element.S)
+			case TableItemAttributeTypeNS:
+				attribute.NS = append(attribute.NS, element.N)
+			case TableItemAttributeTypeBS:
+				attribute.BS = append(attribute.BS, element.B)
+			}
+		}
+
+		return attribute, nil
+	default:
+		return nil, fmt.Errorf("unsupported attribute type %q", attributeType)
+	}
+
+	return nil, fmt.Errorf("value %v cannot be converted to type %s", v, attributeType)
+}
+
+// expandJSONNumber accepts JSON numbers and strings containing numbers.
+func expandJSONNumber(v interface{}) (string, error) {
+	var s string
+
+	switch v := v.(type) {
+	case json.Number:
+		s = v.String()
+	case string:
+		s = v
+	default:
+		return "", fmt.Errorf("not a number")
+	}
+
+	if _, ok := new(big.Float).SetString(s); !ok {
+		return "", fmt.Errorf("not a number")
+	}
+
+	return s, nil
+}
+
+// flattenTableItemJSON encodes DynamoDB attribute values as plain JSON, the inverse of ExpandTableItemJSON.
+// Sets are encoded as sorted arrays and binary values as base64 encoded strings.
+func flattenTableItemJSON(attrs map[string]*dynamodb.AttributeValue) (string, error) {
+	item := make(map[string]interface{}, len(attrs))
+
+	for name, attribute := range attrs {
+		item[name] = flattenJSONAttributeValue(attribute)
+	}
+
+	b, err := json.Marshal(item)
+
+	if err != nil {
+		return "", fmt.Errorf("Encoding failed: %s", err)
+	}
+
+	return string(b), nil
+}
+
+func flattenJSONAttributeValue(attribute *dynamodb.AttributeValue) interface{} {
+	switch {
+	case attribute == nil:
+		return nil
+	case attribute.B != nil:
+		return base64.StdEncoding.EncodeToString(attribute.B)
+	case attribute.BOOL != nil:
+		return aws.BoolValue(attribute.BOOL)
+	case attribute.BS != nil:
+		l := make([]string, 0, len(attribute.BS))
+		for _, v := range attribute.BS {
+			l = append(l, base64.StdEncoding.EncodeToString(v))
+		}
+		sort.Strings(l)
+		return l
+	case attribute.L != nil:
+		l := make([]interface{}, 0, len(attribute.L))
+		for _, v := range attribute.L {
+			l = append(l, flattenJSONAttributeValue(v))
+		}
+		return l
+	case attribute.M != nil:
+		m := make(map[string]interface{}, len(attribute.M))
+		for k, v := range attribute.M {
+			m[k] = flattenJSONAttributeValue(v)
+		}
+		return m
+	case attribute.N != nil:
+		return json.Number(aws.StringValue(attribute.N))
+	case attribute.NS != nil:
+		l := aws.StringValueSlice(attribute.NS)
+		sort.Slice(l, func(i, j int) bool {
+			return compareNumberStrings(l[i], l[j]) < 0
+		})
+		ns := make([]json.Number, 0, len(l))
+		for _, v := range l {
+			ns = append(ns, json.Number(v))
+		}
+		return ns
+	case attribute.S != nil:
+		return aws.StringValue(attribute.S)
+	case attribute.SS != nil:
+		l := aws.StringValueSlice(attribute.SS)
+		sort.Strings(l)
+		return l
+	}
+
+	return nil
+}
+
+// Enough binary precision for DynamoDB's 38 significant decimal digits.
+const tableItemNumberPrecision = 128
+
+func compareNumberStrings(a, b string) int {
+	x, okX := new(big.Float).SetPrec(tableItemNumberPrecision).SetString(a)
+	y, okY := new(big.Float).SetPrec(tableItemNumberPrecision).SetString(b)
+
+	if !okX || !okY {
+		return strings.Compare(a, b)
+	}
+
+	return x.Cmp(y)
+}
+
+// TableItemAttributesEqual reports whether two items hold the same values.
+// Numbers are compared numerically and sets without regard to order.
+func TableItemAttributesEqual(a, b map[string]*dynamodb.AttributeValue) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for k, v := range a {
+		if !attributeValuesEqual(v, b[k]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func attributeValuesEqual(a, b *dynamodb.AttributeValue) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	switch {
+	case a.B != nil:
+		return bytes.Equal(a.B, b.B)
+	case a.BOOL != nil:
+		return b.BOOL != nil && aws.BoolValue(a.BOOL) == aws.BoolValue(b.BOOL)
+	case a.BS != nil:
+		return stringSetsEqual(flattenBinarySet(a.BS), flattenBinarySet(b.BS))
+	case a.L != nil:
+		if b.L == nil || len(a.L) != len(b.L) {
+			return false
+		}
+		for i := range a.L {
+			if !attributeValuesEqual(a.L[i], b.L[i]) {
+				return false
+			}
+		}
+		return true
+	case a.M != nil:
+		return b.M != nil && TableItemAttributesEqual(a.M, b.M)
+	case a.N != nil:
+		return b.N != nil && compareNumberStrings(aws.StringValue(a.N), aws.StringValue(b.N)) == 0
+	case a.NS != nil:
+		if b.NS == nil || len(a.NS) != len(b.NS) {
+			return false
+		}
+		x, y := aws.StringValueSlice(a.NS), aws.StringValueSlice(b.NS)
+		sort.Slice(x, func(i, j int) bool { return compareNumberStrings(x[i], x[j]) < 0 })
+		sort.Slice(y, func(i, j int) bool { return compareNumberStrings(y[i], y[j]) < 0 })
+		for i := range x {
+			if compareNumberStrings(x[i], y[i]) != 0 {
+				return false
+			}
+		}
+		return true
+	case a.NULL != nil:
+		return b.NULL != nil && aws.BoolValue(a.NULL) == aws.BoolValue(b.NULL)
+	case a.S != nil:
+		return b.S != nil && aws.StringValue(a.S) == aws.StringValue(b.S)
+	case a.SS != nil:
+		return b.SS != nil && stringSetsEqual(aws.StringValueSlice(a.SS), aws.StringValueSlice(b.SS))
+	}
+
+	return false
+}
+
+func flattenBinarySet(bs [][]byte) []string {
+	if bs == nil {
+		return nil
+	}
+
+	l := make([]string, 0, len(bs))
+	for _, v := range bs {
+		l = append(l, base64.StdEncoding.EncodeToString(v))
+	}
+
+	return l
+}
+
+func stringSetsEqual(a, b []string) bool {
+	if a == nil || b == nil || len(a) != len(b) {
+		return false
+	}
+
+	x := append([]string{}, a...)
+	y := append([]string{}, b...)
+	sort.Strings(x)
+	sort.Strings(y)
+
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+
+	return true
+}
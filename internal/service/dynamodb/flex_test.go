@@ -0,0 +1,213 @@
+package dynamodb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+func TestExpandTableItemJSON(t *testing.T) {
+	input := `{
+  "id": 12345678901234567890123456789012345678,
+  "name": "test",
+  "enabled": true,
+  "deleted": null,
+  "price": 1.50,
+  "tags": ["a", 1],
+  "nested": {"count": 0},
+  "colors": ["red", "blue"],
+  "sizes": [1, "2.5"],
+  "data": "aGVsbG8=",
+  "chunks": ["aGVsbG8=", "d29ybGQ="]
+}`
+
+	attributeTypes := map[string]string{
+		"colors": TableItemAttributeTypeSS,
+		"sizes":  TableItemAttributeTypeNS,
+		"data":   dynamodb.ScalarAttributeTypeB,
+		"chunks": TableItemAttributeTypeBS,
+	}
+
+	got, err := ExpandTableItemJSON(input, attributeTypes)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]*dynamodb.AttributeValue{
+		"id":      {N: aws.String("12345678901234567890123456789012345678")},
+		"name":    {S: aws.String("test")},
+		"enabled": {BOOL: aws.Bool(true)},
+		"deleted": {NULL: aws.Bool(true)},
+		"price":   {N: aws.String("1.50")},
+		"tags":    {L: []*dynamodb.AttributeValue{{S: aws.String("a")}, {N: aws.String("1")}}},
+		"nested":  {M: map[string]*dynamodb.AttributeValue{"count": {N: aws.String("0")}}},
+		"colors":  {SS: aws.StringSlice([]string{"red", "blue"})},
+		"sizes":   {NS: aws.StringSlice([]string{"1", "2.5"})},
+		"data":    {B: []byte("hello")},
+		"chunks":  {BS: [][]byte{[]byte("hello"), []byte("world")}},
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestExpandTableItemJSON_errors(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		Input          string
+		AttributeTypes map[string]string
+	}{
+		{
+			Name:  "not an object",
+			Input: `["a"]`,
+		},
+		{
+			Name:  "invalid JSON",
+			Input: `{"a":`,
+		},
+		{
+			Name:           "string set of numbers",
+			Input:          `{"a": [1, 2]}`,
+			AttributeTypes: map[string]string{"a": TableItemAttributeTypeSS},
+		},
+		{
+			Name:           "empty set",
+			Input:          `{"a": []}`,
+			AttributeTypes: map[string]string{"a": TableItemAttributeTypeNS},
+		},
+		{
+			Name:           "invalid number",
+			Input:          `{"a": "one"}`,
+			AttributeTypes: map[string]string{"a": dynamodb.ScalarAttributeTypeN},
+		},
+		{
+			Name:           "invalid binary",
+			Input:          `{"a": "not base64!"}`,
+			AttributeTypes: map[string]string{"a": dynamodb.ScalarAttributeTypeB},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			if _, err := ExpandTableItemJSON(testCase.Input, testCase.AttributeTypes); err == nil {
+				t.Error("expected error, got none")
+			}
+		})
+	}
+}
+
+func TestFlattenTableItemJSON(t *testing.T) {
+	input := map[string]*dynamodb.AttributeValue{
+		"id":      {N: aws.String("12345678901234567890123456789012345678")},
+		"name":    {S: aws.String("test")},
+		"enabled": {BOOL: aws.Bool(false)},
+		"deleted": {NULL: aws.Bool(true)},
+		"tags":    {L: []*dynamodb.AttributeValue{{S: aws.String("a")}, {N: aws.String("1")}}},
+		"nested":  {M: map[string]*dynamodb.AttributeValue{"count": {N: aws.String("0")}}},
+		"colors":  {SS: aws.StringSlice([]string{"red", "blue"})},
+		"sizes":   {NS: aws.StringSlice([]string{"10", "2.5"})},
+		"data":    {B: []byte("hello")},
+		"chunks":  {BS: [][]byte{[]byte("world"), []byte("hello")}},
+	}
+
+	got, err := flattenTableItemJSON(input)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := `{"chunks":["aGVsbG8=","d29ybGQ="],"colors":["blue","red"],"data":"aGVsbG8=","deleted":null,"enabled":false,"id":12345678901234567890123456789012345678,"name":"test","nested":{"count":0},"sizes":[2.5,10],"tags":["a",1]}`
+
+	if got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestTableItemJSONRoundTrip(t *testing.T) {
+	input := `{"chunks":["aGVsbG8="],"id":1,"sizes":[1,2],"tags":["b","a"]}`
+	attributeTypes := map[string]string{
+		"chunks": TableItemAttributeTypeBS,
+		"sizes":  TableItemAttributeTypeNS,
+		"tags":   TableItemAttributeTypeSS,
+	}
+
+	attributes, err := ExpandTableItemJSON(input, attributeTypes)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	output, err := flattenTableItemJSON(attributes)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	roundTripped, err := ExpandTableItemJSON(output, attributeTypes)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !TableItemAttributesEqual(attributes, roundTripped) {
+		t.Errorf("expected %v, got %v", attributes, roundTripped)
+	}
+}
+
+func TestTableItemAttributesEqual(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		A        map[string]*dynamodb.AttributeValue
+		B        map[string]*dynamodb.AttributeValue
+		Expected bool
+	}{
+		{
+			Name:     "equivalent numbers",
+			A:        map[string]*dynamodb.AttributeValue{"a": {N: aws.String("1.50")}},
+			B:        map[string]*dynamodb.AttributeValue{"a": {N: aws.String("1.5")}},
+			Expected: true,
+		},
+		{
+			Name:     "different numbers",
+			A:        map[string]*dynamodb.AttributeValue{"a": {N: aws.String("12345678901234567890123456789012345678")}},
+			B:        map[string]*dynamodb.AttributeValue{"a": {N: aws.String("12345678901234567890123456789012345679")}},
+			Expected: false,
+		},
+		{
+			Name:     "reordered sets",
+			A:        map[string]*dynamodb.AttributeValue{"a": {SS: aws.StringSlice([]string{"x", "y"})}, "b": {NS: aws.StringSlice([]string{"1", "2.0"})}},
+			B:        map[string]*dynamodb.AttributeValue{"a": {SS: aws.StringSlice([]string{"y", "x"})}, "b": {NS: aws.StringSlice([]string{"2", "1"})}},
+			Expected: true,
+		},
+		{
+			Name:     "reordered lists",
+			A:        map[string]*dynamodb.AttributeValue{"a": {L: []*dynamodb.AttributeValue{{S: aws.String("x")}, {S: aws.String("y")}}}},
+			B:        map[string]*dynamodb.AttributeValue{"a": {L: []*dynamodb.AttributeValue{{S: aws.String("y")}, {S: aws.String("x")}}}},
+			Expected: false,
+		},
+		{
+			Name:     "different types",
+			A:        map[string]*dynamodb.AttributeValue{"a": {S: aws.String("1")}},
+			B:        map[string]*dynamodb.AttributeValue{"a": {N: aws.String("1")}},
+			Expected: false,
+		},
+		{
+			Name:     "missing attribute",
+			A:        map[string]*dynamodb.AttributeValue{"a": {S: aws.String("1")}},
+			B:        map[string]*dynamodb.AttributeValue{"b": {S: aws.String("1")}},
+			Expected: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			if got := TableItemAttributesEqual(testCase.A, testCase.B); got != testCase.Expected {
+				t.Errorf("expected %t, got %t", testCase.Expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package dynamodb
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	"github.com/hashicorp/terraform-provider-aws/internal/flex"
+)
+
+const (
+	queryDefaultMaxItems = 100
+	queryMaxMaxItems     = 10000
+)
+
+func DataSourceQuery() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceQueryRead,
+
+		Schema: map[string]*schema.Schema{
+			"consistent_read": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"expression_attribute_names": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"expression_attribute_values": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"filter_expression": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"index_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"item_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"item_format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      TableItemFormatJSON,
+				ValidateFunc: validation.StringInSlice(TableItemFormat_Values(), false),
+			},
+			"items": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"key_condition_expression": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"max_items": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      queryDefaultMaxItems,
+				ValidateFunc: validation.IntBetween(1, queryMaxMaxItems),
+			},
+			"projection_expression": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"scan_index_forward": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"table_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"truncated": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceQueryRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).DynamoDBConn
+
+	tableName := d.Get("table_name").(string)
+	format := d.Get("item_format").(string)
+	maxItems := d.Get("max_items").(int)
+
+	var names map[string]*string
+	if v, ok := d.GetOk("expression_attribute_names"); ok && len(v.(map[string]interface{})) > 0 {
+		names = flex.ExpandStringMap(v.(map[string]interface{}))
+	}
+
+	var values map[string]*dynamodb.AttributeValue
+	if v, ok := d.GetOk("expression_attribute_values"); ok {
+		var err error
+
+		values, err = ExpandTableItem(v.(string), format, nil)
+
+		if err != nil {
+			return fmt.Errorf("error decoding expression_attribute_values: %w", err)
+		}
+	}
+
+	var filterExpression, indexName, projectionExpression *string
+	if v, ok := d.GetOk("filter_expression"); ok {
+		filterExpression = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("index_name"); ok {
+		indexName = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("projection_expression"); ok {
+		projectionExpression = aws.String(v.(string))
+	}
+
+	var items []map[string]*dynamodb.AttributeValue
+	truncated := false
+
+	// Pages are read until the result cap is reached. Limit is not used as it applies before filtering.
+	collect := func(page []map[string]*dynamodb.AttributeValue, lastPage bool) bool {
+		for _, item := range page {
+			if len(items) == maxItems {
+				truncated = true
+				return false
+			}
+
+			items = append(items, item)
+		}
+
+		return !lastPage
+	}
+
+	var err error
+
+	if v, ok := d.GetOk("key_condition_expression"); ok {
+		input := &dynamodb.QueryInput{
+			ConsistentRead:            aws.Bool(d.Get("consistent_read").(bool)),
+			ExpressionAttributeNames:  names,
+			ExpressionAttributeValues: values,
+			FilterExpression:          filterExpression,
+			IndexName:                 indexName,
+			KeyConditionExpression:    aws.String(v.(string)),
+			ProjectionExpression:      projectionExpression,
+			ScanIndexForward:          aws.Bool(d.Get("scan_index_forward").(bool)),
+			TableName:                 aws.String(tableName),
+		}
+
+		log.Printf("[DEBUG] Querying DynamoDB Table: %s", input)
+		err = conn.QueryPages(input, func(page *dynamodb.QueryOutput, lastPage bool) bool {
+			if page == nil {
+				return !lastPage
+			}
+
+			return collect(page.Items, lastPage)
+		})
+	} else {
+		input := &dynamodb.ScanInput{
+			ConsistentRead:            aws.Bool(d.Get("consistent_read").(bool)),
+			ExpressionAttributeNames:  names,
+			ExpressionAttributeValues: values,
+			FilterExpression:          filterExpression,
+			IndexName:                 indexName,
+			ProjectionExpression:      projectionExpression,
+			TableName:                 aws.String(tableName),
+		}
+
+		log.Printf("[DEBUG] Scanning DynamoDB Table: %s", input)
+		err = conn.ScanPages(input, func(page *dynamodb.ScanOutput, lastPage bool) bool {
+			if page == nil {
+				return !lastPage
+			}
+
+			return collect(page.Items, lastPage)
+		})
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading DynamoDB Table (%s) items: %w", tableName, err)
+	}
+
+	documents := make([]string, 0, len(items))
+
+	for _, item := range items {
+		document, err := flattenTableItem(item, format)
+
+		if err != nil {
+			return fmt.Errorf("error encoding DynamoDB Table (%s) item: %w", tableName, err)
+		}
+
+		documents = append(documents, document)
+	}
+
+	d.SetId(tableName)
+
+	d.Set("item_count", len(documents))
+	if err := d.Set("items", documents); err != nil {
+		return fmt.Errorf("error setting items: %w", err)
+	}
+	d.Set("truncated", truncated)
+
+	return nil
+}
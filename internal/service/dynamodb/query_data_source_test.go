@@ -0,0 +1,122 @@
+package dynamodb_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+)
+
+func TestAccDynamoDBQueryDataSource_basic(t *testing.T) {
+	dataSourceName := "data.aws_dynamodb_query.test"
+	tableName := fmt.Sprintf("tf-acc-test-%s", sdkacctest.RandString(8))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { acctest.PreCheck(t) },
+		ErrorCheck: acctest.ErrorCheck(t, dynamodb.EndpointsID),
+		Providers:  acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccQueryDataSourceConfig(tableName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "item_count", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "items.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "items.0", `{"enabled":true,"flag":"alpha","service":"web","weight":12345678901234567890}`),
+					resource.TestCheckResourceAttr(dataSourceName, "items.1", `{"enabled":false,"flag":"beta","service":"web","weight":0.5}`),
+					resource.TestCheckResourceAttr(dataSourceName, "truncated", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynamoDBQueryDataSource_scan(t *testing.T) {
+	dataSourceName := "data.aws_dynamodb_query.test"
+	tableName := fmt.Sprintf("tf-acc-test-%s", sdkacctest.RandString(8))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { acctest.PreCheck(t) },
+		ErrorCheck: acctest.ErrorCheck(t, dynamodb.EndpointsID),
+		Providers:  acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccQueryDataSourceScanConfig(tableName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "item_count", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "items.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "truncated", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccQueryDataSourceBaseConfig(tableName string) string {
+	return fmt.Sprintf(`
+resource "aws_dynamodb_table" "test" {
+  name         = %[1]q
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "service"
+  range_key    = "flag"
+
+  attribute {
+    name = "service"
+    type = "S"
+  }
+
+  attribute {
+    name = "flag"
+    type = "S"
+  }
+}
+
+resource "aws_dynamodb_table_items" "test" {
+  table_name  = aws_dynamodb_table.test.name
+  hash_key    = aws_dynamodb_table.test.hash_key
+  range_key   = aws_dynamodb_table.test.range_key
+  item_format = "json"
+
+  items_jsonl = <<EOF
+{"service": "web", "flag": "alpha", "enabled": true, "weight": 12345678901234567890}
+{"service": "web", "flag": "beta", "enabled": false, "weight": 0.5}
+{"service": "api", "flag": "alpha", "enabled": true}
+EOF
+}
+`, tableName)
+}
+
+func testAccQueryDataSourceConfig(tableName string) string {
+	return acctest.ConfigCompose(testAccQueryDataSourceBaseConfig(tableName), `
+data "aws_dynamodb_query" "test" {
+  table_name               = aws_dynamodb_table_items.test.table_name
+  key_condition_expression = "#service = :service"
+  consistent_read          = true
+
+  expression_attribute_names = {
+    "#service" = "service"
+  }
+
+  expression_attribute_values = jsonencode({
+    ":service" = "web"
+  })
+}
+`)
+}
+
+func testAccQueryDataSourceScanConfig(tableName string) string {
+	return acctest.ConfigCompose(testAccQueryDataSourceBaseConfig(tableName), `
+data "aws_dynamodb_query" "test" {
+  table_name        = aws_dynamodb_table_items.test.table_name
+  filter_expression = "enabled = :enabled"
+  consistent_read   = true
+  max_items         = 1
+
+  expression_attribute_values = jsonencode({
+    ":enabled" = true
+  })
+}
+`)
+}
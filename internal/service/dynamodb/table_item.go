@@ -1,15 +1,19 @@
 package dynamodb
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-aws/internal/conns"
 	"github.com/hashicorp/terraform-provider-aws/internal/verify"
 )
@@ -21,7 +25,17 @@ func ResourceTableItem() *schema.Resource {
 		Update: resourceTableItemUpdate,
 		Delete: resourceTableItemDelete,
 
+		CustomizeDiff: validateTableItemDiff,
+
 		Schema: map[string]*schema.Schema{
+			"attribute_types": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(TableItemAttributeType_Values(), false),
+				},
+			},
 			"table_name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -38,20 +52,66 @@ func ResourceTableItem() *schema.Resource {
 				Optional: true,
 			},
 			"item": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentTableItemDiffs,
+			},
+			"item_format": {
 				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validateDynamoDbTableItem,
+				Optional:     true,
+				Default:      TableItemFormatAttributeValue,
+				ValidateFunc: validation.StringInSlice(TableItemFormat_Values(), false),
 			},
 		},
 	}
 }
 
-func validateDynamoDbTableItem(v interface{}, k string) (ws []string, errors []error) {
-	_, err := ExpandTableItemAttributes(v.(string))
+func validateTableItemDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("item") || !d.NewValueKnown("attribute_types") {
+		return nil
+	}
+
+	_, err := ExpandTableItem(d.Get("item").(string), d.Get("item_format").(string), expandTableItemAttributeTypes(d.Get("attribute_types").(map[string]interface{})))
+
+	if err != nil {
+		return fmt.Errorf("Invalid format of %q: %s", "item", err)
+	}
+
+	return nil
+}
+
+// suppressEquivalentTableItemDiffs suppresses differences between items that decode to the same attribute values.
+// The old item is decoded in the prior format, as the format may change along with the item.
+func suppressEquivalentTableItemDiffs(k, old, new string, d *schema.ResourceData) bool {
+	oldAttributes, err := expandTableItemFromResourceData(oldTableItemsGetter{d}, old)
+
+	if err != nil {
+		return false
+	}
+
+	newAttributes, err := expandTableItemFromResourceData(d, new)
+
 	if err != nil {
-		errors = append(errors, fmt.Errorf("Invalid format of %q: %s", k, err))
+		return false
 	}
-	return
+
+	return TableItemAttributesEqual(oldAttributes, newAttributes)
+}
+
+func expandTableItemAttributeTypes(tfMap map[string]interface{}) map[string]string {
+	attributeTypes := make(map[string]string, len(tfMap))
+
+	for k, v := range tfMap {
+		attributeTypes[k] = v.(string)
+	}
+
+	return attributeTypes
+}
+
+// expandTableItemFromResourceData decodes the item in the resource's configured format.
+func expandTableItemFromResourceData(d tableItemsGetter, item string) (map[string]*dynamodb.AttributeValue, error) {
+	return ExpandTableItem(item, d.Get("item_format").(string), expandTableItemAttributeTypes(d.Get("attribute_types").(map[string]interface{})))
 }
 
 func resourceTableItemCreate(d *schema.ResourceData, meta interface{}) error {
@@ -60,7 +120,7 @@ func resourceTableItemCreate(d *schema.ResourceData, meta interface{}) error {
 	tableName := d.Get("table_name").(string)
 	hashKey := d.Get("hash_key").(string)
 	item := d.Get("item").(string)
-	attributes, err := ExpandTableItemAttributes(item)
+	attributes, err := expandTableItemFromResourceData(d, item)
 	if err != nil {
 		return err
 	}
@@ -93,14 +153,14 @@ func resourceTableItemUpdate(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG] Updating DynamoDB table %s", d.Id())
 	conn := meta.(*conns.AWSClient).DynamoDBConn
 
-	if d.HasChange("item") {
+	if d.HasChanges("item", "item_format", "attribute_types") {
 		tableName := d.Get("table_name").(string)
 		hashKey := d.Get("hash_key").(string)
 		rangeKey := d.Get("range_key").(string)
 
 		oldItem, newItem := d.GetChange("item")
 
-		attributes, err := ExpandTableItemAttributes(newItem.(string))
+		attributes, err := expandTableItemFromResourceData(d, newItem.(string))
 		if err != nil {
 			return err
 		}
@@ -129,7 +189,7 @@ func resourceTableItemUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 
 		oItem := oldItem.(string)
-		oldAttributes, err := ExpandTableItemAttributes(oItem)
+		oldAttributes, err := expandTableItemFromResourceData(oldTableItemsGetter{d}, oItem)
 		if err != nil {
 			return err
 		}
@@ -163,7 +223,7 @@ func resourceTableItemRead(d *schema.ResourceData, meta interface{}) error {
 	tableName := d.Get("table_name").(string)
 	hashKey := d.Get("hash_key").(string)
 	rangeKey := d.Get("range_key").(string)
-	attributes, err := ExpandTableItemAttributes(d.Get("item").(string))
+	attributes, err := expandTableItemFromResourceData(d, d.Get("item").(string))
 	if err != nil {
 		return err
 	}
@@ -191,9 +251,10 @@ func resourceTableItemRead(d *schema.ResourceData, meta interface{}) error {
 		return nil
 	}
 
-	// The record exists, now test if it differs from what is desired
-	if !reflect.DeepEqual(result.Item, attributes) {
-		itemAttrs, err := flattenDynamoDBTableItemAttributes(result.Item)
+	// The record exists, now test if it differs from what is desired.
+	// Only the attributes in the configured item were requested, so other attributes are not compared.
+	if !TableItemAttributesEqual(result.Item, attributes) {
+		itemAttrs, err := flattenTableItem(result.Item, d.Get("item_format").(string))
 		if err != nil {
 			return err
 		}
@@ -208,7 +269,7 @@ func resourceTableItemRead(d *schema.ResourceData, meta interface{}) error {
 func resourceTableItemDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*conns.AWSClient).DynamoDBConn
 
-	attributes, err := ExpandTableItemAttributes(d.Get("item").(string))
+	attributes, err := expandTableItemFromResourceData(d, d.Get("item").(string))
 	if err != nil {
 		return err
 	}
@@ -225,21 +286,37 @@ func resourceTableItemDelete(d *schema.ResourceData, meta interface{}) error {
 
 // Helpers
 
+// Attribute names are replaced by positional placeholders as names may contain characters
+// that are not valid in expressions.
 func BuildExpressionAttributeNames(attrs map[string]*dynamodb.AttributeValue) map[string]*string {
 	names := map[string]*string{}
-	for key := range attrs {
-		names["#a_"+key] = aws.String(key)
+	for i, key := range sortedTableItemAttributeNames(attrs) {
+		names[expressionAttributeNamePlaceholder(i)] = aws.String(key)
 	}
 
 	return names
 }
 
 func BuildProjectionExpression(attrs map[string]*dynamodb.AttributeValue) *string {
+	placeholders := []string{}
+	for i := range sortedTableItemAttributeNames(attrs) {
+		placeholders = append(placeholders, expressionAttributeNamePlaceholder(i))
+	}
+	return aws.String(strings.Join(placeholders, ", "))
+}
+
+func sortedTableItemAttributeNames(attrs map[string]*dynamodb.AttributeValue) []string {
 	keys := []string{}
 	for key := range attrs {
 		keys = append(keys, key)
 	}
-	return aws.String("#a_" + strings.Join(keys, ", #a_"))
+	sort.Strings(keys)
+
+	return keys
+}
+
+func expressionAttributeNamePlaceholder(i int) string {
+	return "#a" + strconv.Itoa(i)
 }
 
 func buildDynamoDbTableItemId(tableName string, hashKey string, rangeKey string, attrs map[string]*dynamodb.AttributeValue) string {
@@ -235,6 +235,85 @@ func TestAccDynamoDBTableItem_updateWithRangeKey(t *testing.T) {
 	})
 }
 
+func TestAccDynamoDBTableItem_jsonFormat(t *testing.T) {
+	var conf dynamodb.GetItemOutput
+
+	tableName := fmt.Sprintf("tf-acc-test-%s", sdkacctest.RandString(8))
+	hashKey := "hashKey"
+	itemContent := `{
+	"hashKey": "something",
+	"count": 12345678901234567890,
+	"enabled": true,
+	"colors": ["red", "blue"],
+	"nested": {"list": [1, "two", null]}
+}`
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, dynamodb.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckItemDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccItemJSONFormatConfig(tableName, hashKey, itemContent),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTableItemExists("aws_dynamodb_table_item.test", &conf),
+					testAccCheckTableItemCount(tableName, 1),
+					resource.TestCheckResourceAttr("aws_dynamodb_table_item.test", "item_format", tfdynamodb.TableItemFormatJSON),
+					resource.TestCheckResourceAttr("aws_dynamodb_table_item.test", "attribute_types.%", "1"),
+					resource.TestCheckResourceAttr("aws_dynamodb_table_item.test", "attribute_types.colors", tfdynamodb.TableItemAttributeTypeSS),
+					resource.TestCheckResourceAttr("aws_dynamodb_table_item.test", "item", itemContent+"\n"),
+					testAccCheckTableItemAttribute(&conf, "count", &dynamodb.AttributeValue{N: aws.String("12345678901234567890")}),
+					testAccCheckTableItemAttribute(&conf, "colors", &dynamodb.AttributeValue{SS: aws.StringSlice([]string{"red", "blue"})}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynamoDBTableItem_changeFormat(t *testing.T) {
+	var conf dynamodb.GetItemOutput
+
+	tableName := fmt.Sprintf("tf-acc-test-%s", sdkacctest.RandString(8))
+	hashKey := "hashKey"
+	itemBefore := `{
+	"hashKey": {"S": "something"},
+	"count": {"N": "1"}
+}`
+	itemAfter := `{
+	"hashKey": "something",
+	"count": 2,
+	"colors": ["red", "blue"]
+}`
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, dynamodb.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckItemDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccItemBasicConfig(tableName, hashKey, itemBefore),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTableItemExists("aws_dynamodb_table_item.test", &conf),
+					testAccCheckTableItemCount(tableName, 1),
+					testAccCheckTableItemAttribute(&conf, "count", &dynamodb.AttributeValue{N: aws.String("1")}),
+				),
+			},
+			{
+				Config: testAccItemJSONFormatConfig(tableName, hashKey, itemAfter),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTableItemExists("aws_dynamodb_table_item.test", &conf),
+					testAccCheckTableItemCount(tableName, 1),
+					resource.TestCheckResourceAttr("aws_dynamodb_table_item.test", "item_format", tfdynamodb.TableItemFormatJSON),
+					testAccCheckTableItemAttribute(&conf, "count", &dynamodb.AttributeValue{N: aws.String("2")}),
+					testAccCheckTableItemAttribute(&conf, "colors", &dynamodb.AttributeValue{SS: aws.StringSlice([]string{"red", "blue"})}),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckItemDestroy(s *terraform.State) error {
 	conn := acctest.Provider.Meta().(*conns.AWSClient).DynamoDBConn
 
@@ -244,7 +323,7 @@ func testAccCheckItemDestroy(s *terraform.State) error {
 		}
 
 		attrs := rs.Primary.Attributes
-		attributes, err := tfdynamodb.ExpandTableItemAttributes(attrs["item"])
+		attributes, err := tfdynamodb.ExpandTableItem(attrs["item"], attrs["item_format"], nil)
 		if err != nil {
 			return err
 		}
@@ -286,7 +365,7 @@ func testAccCheckTableItemExists(n string, item *dynamodb.GetItemOutput) resourc
 		conn := acctest.Provider.Meta().(*conns.AWSClient).DynamoDBConn
 
 		attrs := rs.Primary.Attributes
-		attributes, err := tfdynamodb.ExpandTableItemAttributes(attrs["item"])
+		attributes, err := tfdynamodb.ExpandTableItem(attrs["item"], attrs["item_format"], nil)
 		if err != nil {
 			return err
 		}
@@ -327,6 +406,18 @@ func testAccCheckTableItemCount(tableName string, count int64) resource.TestChec
 	}
 }
 
+func testAccCheckTableItemAttribute(item *dynamodb.GetItemOutput, name string, expected *dynamodb.AttributeValue) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		actual := map[string]*dynamodb.AttributeValue{name: item.Item[name]}
+
+		if !tfdynamodb.TableItemAttributesEqual(actual, map[string]*dynamodb.AttributeValue{name: expected}) {
+			return fmt.Errorf("expected attribute %s to be %s, got %s", name, expected, item.Item[name])
+		}
+
+		return nil
+	}
+}
+
 func testAccItemBasicConfig(tableName, hashKey, item string) string {
 	return fmt.Sprintf(`
 resource "aws_dynamodb_table" "test" {
@@ -425,3 +516,33 @@ ITEM
 }
 `, tableName, hashKey, rangeKey, hashKey, rangeKey, firstItem, secondItem)
 }
+
+func testAccItemJSONFormatConfig(tableName, hashKey, item string) string {
+	return fmt.Sprintf(`
+resource "aws_dynamodb_table" "test" {
+  name           = "%s"
+  read_capacity  = 10
+  write_capacity = 10
+  hash_key       = "%s"
+
+  attribute {
+    name = "%s"
+    type = "S"
+  }
+}
+
+resource "aws_dynamodb_table_item" "test" {
+  table_name  = aws_dynamodb_table.test.name
+  hash_key    = aws_dynamodb_table.test.hash_key
+  item_format = "json"
+
+  attribute_types = {
+    colors = "SS"
+  }
+
+  item = <<ITEM
+%s
+ITEM
+}
+`, tableName, hashKey, hashKey, item)
+}
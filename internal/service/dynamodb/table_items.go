@@ -0,0 +1,567 @@
+package dynamodb
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
+)
+
+const (
+	// https://docs.aws.amazon.com/amazondynamodb/latest/APIReference/API_BatchWriteItem.html
+	tableItemsBatchWriteMaxItems = 25
+	// https://docs.aws.amazon.com/amazondynamodb/latest/APIReference/API_BatchGetItem.html
+	tableItemsBatchGetMaxKeys = 100
+
+	// Items are at most 400 KB, but their JSON encoding may be larger.
+	tableItemsJSONLinesMaxLineBytes = 4 * 1024 * 1024
+
+	tableItemsReadTimeout = 5 * time.Minute
+)
+
+func ResourceTableItems() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTableItemsCreate,
+		Read:   resourceTableItemsRead,
+		Update: resourceTableItemsUpdate,
+		Delete: resourceTableItemsDelete,
+
+		CustomizeDiff: validateTableItemsDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"attribute_types": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(TableItemAttributeType_Values(), false),
+				},
+			},
+			"hash_key": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"item_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"item_format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      TableItemFormatAttributeValue,
+				ValidateFunc: validation.StringInSlice(TableItemFormat_Values(), false),
+			},
+			"items": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsJSON,
+				},
+				ExactlyOneOf: []string{"items", "items_jsonl"},
+			},
+			"items_jsonl": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"items", "items_jsonl"},
+			},
+			"range_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"table_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func validateTableItemsDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for _, k := range []string{"attribute_types", "items", "items_jsonl"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	_, err := expandTableItems(d)
+
+	return err
+}
+
+// tableItem is an item of an aws_dynamodb_table_items resource together with its primary key.
+type tableItem struct {
+	Attributes map[string]*dynamodb.AttributeValue
+	Key        map[string]*dynamodb.AttributeValue
+}
+
+// tableItemsGetter is implemented by both schema.ResourceData and schema.ResourceDiff.
+type tableItemsGetter interface {
+	Get(string) interface{}
+}
+
+// expandTableItems decodes the resource's items, in order, from either the items list or the JSON Lines document.
+// Every item must contain the table's primary key attributes and primary keys must be unique.
+func expandTableItems(d tableItemsGetter) ([]*tableItem, error) {
+	var documents []string
+
+	if v, ok := d.Get("items").([]interface{}); ok && len(v) > 0 {
+		for _, v := range v {
+			v, _ := v.(string)
+			documents = append(documents, v)
+		}
+	} else if v, ok := d.Get("items_jsonl").(string); ok && v != "" {
+		scanner := bufio.NewScanner(strings.NewReader(v))
+		scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), tableItemsJSONLinesMaxLineBytes)
+
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				documents = append(documents, line)
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("error reading items_jsonl: %w", err)
+		}
+	}
+
+	format := d.Get("item_format").(string)
+	attributeTypes := expandTableItemAttributeTypes(d.Get("attribute_types").(map[string]interface{}))
+	hashKey := d.Get("hash_key").(string)
+	rangeKey := d.Get("range_key").(string)
+
+	items := make([]*tableItem, 0, len(documents))
+	seen := make(map[string]int)
+
+	for i, document := range documents {
+		attributes, err := ExpandTableItem(document, format, attributeTypes)
+
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+
+		if _, ok := attributes[hashKey]; !ok {
+			return nil, fmt.Errorf("item %d: missing hash key attribute %q", i, hashKey)
+		}
+
+		if _, ok := attributes[rangeKey]; rangeKey != "" && !ok {
+			return nil, fmt.Errorf("item %d: missing range key attribute %q", i, rangeKey)
+		}
+
+		key := BuildTableItemqueryKey(attributes, hashKey, rangeKey)
+		id := tableItemKeyString(key)
+
+		if j, ok := seen[id]; ok {
+			return nil, fmt.Errorf("item %d: duplicate primary key (item %d)", i, j)
+		}
+
+		seen[id] = i
+		items = append(items, &tableItem{Attributes: attributes, Key: key})
+	}
+
+	return items, nil
+}
+
+// tableItemKeyString returns a string uniquely identifying a primary key.
+func tableItemKeyString(key map[string]*dynamodb.AttributeValue) string {
+	normalized := make(map[string]*dynamodb.AttributeValue, len(key))
+
+	for name, value := range key {
+		// DynamoDB does not preserve the representation of numbers, e.g. "1.0" is returned as "1".
+		if value != nil && value.N != nil {
+			if f, ok := new(big.Float).SetPrec(tableItemNumberPrecision).SetString(aws.StringValue(value.N)); ok {
+				value = &dynamodb.AttributeValue{N: aws.String(f.Text('g', -1))}
+			}
+		}
+
+		normalized[name] = value
+	}
+
+	// Map keys are sorted when encoded.
+	s, _ := flattenTableItemJSON(normalized)
+
+	return s
+}
+
+// flattenTableItems sets the resource's items, in the same form (list or JSON Lines) as configured.
+func flattenTableItems(d *schema.ResourceData, items []*tableItem) error {
+	format := d.Get("item_format").(string)
+	documents := make([]string, 0, len(items))
+
+	for _, item := range items {
+		document, err := flattenTableItem(item.Attributes, format)
+
+		if err != nil {
+			return err
+		}
+
+		documents = append(documents, strings.TrimSpace(document))
+	}
+
+	if _, ok := d.GetOk("items_jsonl"); ok {
+		var jsonl string
+
+		if len(documents) > 0 {
+			jsonl = strings.Join(documents, "\n") + "\n"
+		}
+
+		d.Set("items_jsonl", jsonl)
+
+		return nil
+	}
+
+	return d.Set("items", documents)
+}
+
+func resourceTableItemsCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).DynamoDBConn
+
+	tableName := d.Get("table_name").(string)
+
+	items, err := expandTableItems(d)
+
+	if err != nil {
+		return err
+	}
+
+	requests := make([]*dynamodb.WriteRequest, 0, len(items))
+
+	for _, item := range items {
+		requests = append(requests, &dynamodb.WriteRequest{
+			PutRequest: &dynamodb.PutRequest{Item: item.Attributes},
+		})
+	}
+
+	log.Printf("[DEBUG] Writing %d DynamoDB Table (%s) items", len(requests), tableName)
+	if err := batchWriteTableItems(conn, tableName, requests, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("error writing DynamoDB Table (%s) items: %w", tableName, err)
+	}
+
+	d.SetId(tableName)
+
+	return resourceTableItemsRead(d, meta)
+}
+
+func resourceTableItemsRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).DynamoDBConn
+
+	tableName := d.Get("table_name").(string)
+
+	items, err := expandTableItems(d)
+
+	if err != nil {
+		return err
+	}
+
+	keys := make([]map[string]*dynamodb.AttributeValue, 0, len(items))
+	names := make(map[string]*dynamodb.AttributeValue)
+
+	for _, item := range items {
+		keys = append(keys, item.Key)
+
+		for name, value := range item.Attributes {
+			names[name] = value
+		}
+	}
+
+	current, err := batchGetTableItems(conn, tableName, keys, names, tableItemsReadTimeout)
+
+	if !d.IsNewResource() && tfawserr.ErrCodeEquals(err, dynamodb.ErrCodeResourceNotFoundException) {
+		log.Printf("[WARN] DynamoDB Table (%s) not found, removing DynamoDB Table Items (%s) from state", tableName, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading DynamoDB Table (%s) items: %w", tableName, err)
+	}
+
+	// Only the attributes present in the configured items are compared,
+	// so attributes written outside of Terraform are not reported as drift.
+	drifted := false
+	found := make([]*tableItem, 0, len(items))
+
+	for _, item := range items {
+		attributes, ok := current[tableItemKeyString(item.Key)]
+
+		if !ok {
+			log.Printf("[WARN] DynamoDB Table (%s) item %s not found", tableName, tableItemKeyString(item.Key))
+			drifted = true
+			continue
+		}
+
+		managed := make(map[string]*dynamodb.AttributeValue, len(item.Attributes))
+
+		for name := range item.Attributes {
+			if v, ok := attributes[name]; ok {
+				managed[name] = v
+			}
+		}
+
+		if !TableItemAttributesEqual(managed, item.Attributes) {
+			drifted = true
+		}
+
+		found = append(found, &tableItem{Attributes: managed, Key: item.Key})
+	}
+
+	if drifted {
+		if err := flattenTableItems(d, found); err != nil {
+			return fmt.Errorf("error setting items: %w", err)
+		}
+	}
+
+	d.Set("item_count", len(found))
+
+	return nil
+}
+
+func resourceTableItemsUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).DynamoDBConn
+
+	tableName := d.Get("table_name").(string)
+
+	if d.HasChanges("attribute_types", "item_format", "items", "items_jsonl") {
+		newItems, err := expandTableItems(d)
+
+		if err != nil {
+			return err
+		}
+
+		oldItems, err := expandTableItems(oldTableItemsGetter{d})
+
+		if err != nil {
+			return err
+		}
+
+		oldItemsByKey := make(map[string]*tableItem, len(oldItems))
+
+		for _, item := range oldItems {
+			oldItemsByKey[tableItemKeyString(item.Key)] = item
+		}
+
+		var requests []*dynamodb.WriteRequest
+
+		for _, item := range newItems {
+			id := tableItemKeyString(item.Key)
+
+			if oldItem, ok := oldItemsByKey[id]; !ok || !TableItemAttributesEqual(oldItem.Attributes, item.Attributes) {
+				requests = append(requests, &dynamodb.WriteRequest{
+					PutRequest: &dynamodb.PutRequest{Item: item.Attributes},
+				})
+			}
+
+			delete(oldItemsByKey, id)
+		}
+
+		// Items no longer in configuration.
+		for _, item := range oldItemsByKey {
+			requests = append(requests, &dynamodb.WriteRequest{
+				DeleteRequest: &dynamodb.DeleteRequest{Key: item.Key},
+			})
+		}
+
+		log.Printf("[DEBUG] Writing %d DynamoDB Table (%s) item changes", len(requests), tableName)
+		if err := batchWriteTableItems(conn, tableName, requests, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("error writing DynamoDB Table (%s) items: %w", tableName, err)
+		}
+	}
+
+	return resourceTableItemsRead(d, meta)
+}
+
+func resourceTableItemsDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).DynamoDBConn
+
+	tableName := d.Get("table_name").(string)
+
+	items, err := expandTableItems(d)
+
+	if err != nil {
+		return err
+	}
+
+	requests := make([]*dynamodb.WriteRequest, 0, len(items))
+
+	for _, item := range items {
+		requests = append(requests, &dynamodb.WriteRequest{
+			DeleteRequest: &dynamodb.DeleteRequest{Key: item.Key},
+		})
+	}
+
+	log.Printf("[DEBUG] Deleting %d DynamoDB Table (%s) items", len(requests), tableName)
+	err = batchWriteTableItems(conn, tableName, requests, d.Timeout(schema.TimeoutDelete))
+
+	if tfawserr.ErrCodeEquals(err, dynamodb.ErrCodeResourceNotFoundException) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting DynamoDB Table (%s) items: %w", tableName, err)
+	}
+
+	return nil
+}
+
+// oldTableItemsGetter returns the prior state values of a resource.
+type oldTableItemsGetter struct {
+	d *schema.ResourceData
+}
+
+func (g oldTableItemsGetter) Get(k string) interface{} {
+	o, _ := g.d.GetChange(k)
+
+	return o
+}
+
+// unprocessedTableItemsError is returned when a batch operation leaves items unprocessed, usually due to throttling.
+type unprocessedTableItemsError struct {
+	count int
+}
+
+func (e *unprocessedTableItemsError) Error() string {
+	return fmt.Sprintf("%d unprocessed items", e.count)
+}
+
+func retryableTableItemsBatchError(err error) (bool, error) {
+	var unprocessedErr *unprocessedTableItemsError
+
+	if errors.As(err, &unprocessedErr) || tfawserr.ErrCodeEquals(err, dynamodb.ErrCodeProvisionedThroughputExceededException) {
+		return true, err
+	}
+
+	return false, err
+}
+
+// batchWriteTableItems writes the requests in batches, retrying any unprocessed items until the timeout expires.
+func batchWriteTableItems(conn *dynamodb.DynamoDB, tableName string, requests []*dynamodb.WriteRequest, timeout time.Duration) error {
+	for len(requests) > 0 {
+		n := tableItemsBatchWriteMaxItems
+		if len(requests) < n {
+			n = len(requests)
+		}
+
+		input := &dynamodb.BatchWriteItemInput{
+			RequestItems: map[string][]*dynamodb.WriteRequest{
+				tableName: requests[:n],
+			},
+		}
+		requests = requests[n:]
+
+		_, err := tfresource.RetryWhen(timeout,
+			func() (interface{}, error) {
+				output, err := conn.BatchWriteItem(input)
+
+				if err != nil {
+					return nil, err
+				}
+
+				if unprocessed := output.UnprocessedItems[tableName]; len(unprocessed) > 0 {
+					input.RequestItems = output.UnprocessedItems
+
+					return nil, &unprocessedTableItemsError{count: len(unprocessed)}
+				}
+
+				return nil, nil
+			},
+			retryableTableItemsBatchError,
+		)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// batchGetTableItems returns the projected attributes of the items with the specified keys, keyed by tableItemKeyString.
+// Items that do not exist are omitted. Unprocessed keys are retried until the timeout expires.
+func batchGetTableItems(conn *dynamodb.DynamoDB, tableName string, keys []map[string]*dynamodb.AttributeValue, projection map[string]*dynamodb.AttributeValue, timeout time.Duration) (map[string]map[string]*dynamodb.AttributeValue, error) {
+	items := make(map[string]map[string]*dynamodb.AttributeValue)
+
+	if len(keys) == 0 {
+		return items, nil
+	}
+
+	// The key attributes are always part of the projection.
+	var keyNames []string
+	for name := range keys[0] {
+		keyNames = append(keyNames, name)
+	}
+
+	for len(keys) > 0 {
+		n := tableItemsBatchGetMaxKeys
+		if len(keys) < n {
+			n = len(keys)
+		}
+
+		input := &dynamodb.BatchGetItemInput{
+			RequestItems: map[string]*dynamodb.KeysAndAttributes{
+				tableName: {
+					ConsistentRead:           aws.Bool(true),
+					ExpressionAttributeNames: BuildExpressionAttributeNames(projection),
+					Keys:                     keys[:n],
+					ProjectionExpression:     BuildProjectionExpression(projection),
+				},
+			},
+		}
+		keys = keys[n:]
+
+		_, err := tfresource.RetryWhen(timeout,
+			func() (interface{}, error) {
+				output, err := conn.BatchGetItem(input)
+
+				if err != nil {
+					return nil, err
+				}
+
+				for _, item := range output.Responses[tableName] {
+					key := make(map[string]*dynamodb.AttributeValue, len(keyNames))
+
+					for _, name := range keyNames {
+						key[name] = item[name]
+					}
+
+					items[tableItemKeyString(key)] = item
+				}
+
+				if v, ok := output.UnprocessedKeys[tableName]; ok && len(v.Keys) > 0 {
+					input.RequestItems = output.UnprocessedKeys
+
+					return nil, &unprocessedTableItemsError{count: len(v.Keys)}
+				}
+
+				return nil, nil
+			},
+			retryableTableItemsBatchError,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return items, nil
+}
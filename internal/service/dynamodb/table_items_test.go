@@ -0,0 +1,176 @@
+package dynamodb_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+)
+
+func TestAccDynamoDBTableItems_basic(t *testing.T) {
+	resourceName := "aws_dynamodb_table_items.test"
+	tableName := fmt.Sprintf("tf-acc-test-%s", sdkacctest.RandString(8))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, dynamodb.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckTableItemsDestroy(tableName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTableItemsConfig(tableName, 30),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTableItemCount(tableName, 30),
+					resource.TestCheckResourceAttr(resourceName, "hash_key", "hashKey"),
+					resource.TestCheckResourceAttr(resourceName, "item_count", "30"),
+					resource.TestCheckResourceAttr(resourceName, "item_format", "attribute_value"),
+					resource.TestCheckResourceAttr(resourceName, "items.#", "30"),
+					resource.TestCheckResourceAttr(resourceName, "table_name", tableName),
+				),
+			},
+			{
+				Config: testAccTableItemsConfig(tableName, 10),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTableItemCount(tableName, 10),
+					resource.TestCheckResourceAttr(resourceName, "item_count", "10"),
+					resource.TestCheckResourceAttr(resourceName, "items.#", "10"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynamoDBTableItems_jsonLines(t *testing.T) {
+	resourceName := "aws_dynamodb_table_items.test"
+	tableName := fmt.Sprintf("tf-acc-test-%s", sdkacctest.RandString(8))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, dynamodb.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckTableItemsDestroy(tableName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTableItemsJSONLinesConfig(tableName, `{"hashKey": "a", "rangeKey": 1, "tags": ["x", "y"]}
+{"hashKey": "a", "rangeKey": 2, "data": "aGVsbG8="}
+{"hashKey": "b", "rangeKey": 1}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTableItemCount(tableName, 3),
+					resource.TestCheckResourceAttr(resourceName, "item_count", "3"),
+					resource.TestCheckResourceAttr(resourceName, "item_format", "json"),
+					resource.TestCheckResourceAttr(resourceName, "range_key", "rangeKey"),
+				),
+			},
+			{
+				Config: testAccTableItemsJSONLinesConfig(tableName, `{"hashKey": "a", "rangeKey": 1, "tags": ["y", "x", "z"]}
+{"hashKey": "c", "rangeKey": 3}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTableItemCount(tableName, 2),
+					resource.TestCheckResourceAttr(resourceName, "item_count", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckTableItemsDestroy(tableName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).DynamoDBConn
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "aws_dynamodb_table_items" {
+				continue
+			}
+
+			out, err := conn.Scan(&dynamodb.ScanInput{
+				ConsistentRead: aws.Bool(true),
+				TableName:      aws.String(tableName),
+				Select:         aws.String(dynamodb.SelectCount),
+			})
+
+			if tfawserr.ErrCodeEquals(err, dynamodb.ErrCodeResourceNotFoundException) {
+				continue
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if count := aws.Int64Value(out.Count); count != 0 {
+				return fmt.Errorf("DynamoDB Table Items %s still exist (%d items)", rs.Primary.ID, count)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccTableItemsConfig(tableName string, n int) string {
+	return fmt.Sprintf(`
+resource "aws_dynamodb_table" "test" {
+  name         = %[1]q
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "hashKey"
+
+  attribute {
+    name = "hashKey"
+    type = "S"
+  }
+}
+
+resource "aws_dynamodb_table_items" "test" {
+  table_name = aws_dynamodb_table.test.name
+  hash_key   = aws_dynamodb_table.test.hash_key
+
+  items = [for i in range(%[2]d) : jsonencode({
+    hashKey = { S = "item-${i}" }
+    index   = { N = tostring(i) }
+  })]
+}
+`, tableName, n)
+}
+
+func testAccTableItemsJSONLinesConfig(tableName, items string) string {
+	return fmt.Sprintf(`
+resource "aws_dynamodb_table" "test" {
+  name         = %[1]q
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "hashKey"
+  range_key    = "rangeKey"
+
+  attribute {
+    name = "hashKey"
+    type = "S"
+  }
+
+  attribute {
+    name = "rangeKey"
+    type = "N"
+  }
+}
+
+resource "aws_dynamodb_table_items" "test" {
+  table_name  = aws_dynamodb_table.test.name
+  hash_key    = aws_dynamodb_table.test.hash_key
+  range_key   = aws_dynamodb_table.test.range_key
+  item_format = "json"
+
+  attribute_types = {
+    data = "B"
+    tags = "SS"
+  }
+
+  items_jsonl = <<EOF
+%[2]sEOF
+}
+`, tableName, items)
+}
@@ -67,6 +67,10 @@ const (
 	InvalidGroupNotFound           = "InvalidGroup.NotFound"
 )
 
+const (
+	ErrCodeInvalidSecurityGroupRuleIdNotFound = "InvalidSecurityGroupRuleId.NotFound"
+)
+
 const (
 	ErrCodeInvalidSpotInstanceRequestIDNotFound = "InvalidSpotInstanceRequestID.NotFound"
 )
@@ -433,3 +433,66 @@ func TestFlattenSecurityGroups(t *testing.T) {
 		}
 	}
 }
+
+func TestFlattenSecurityGroupRuleReferencedGroup(t *testing.T) {
+	testCases := []struct {
+		Name       string
+		UserID     string
+		Configured string
+		Expected   string
+	}{
+		{
+			Name:     "same account",
+			UserID:   "123456789012",
+			Expected: "sg-001",
+		},
+		{
+			Name:       "same account configured",
+			UserID:     "123456789012",
+			Configured: "sg-001",
+			Expected:   "sg-001",
+		},
+		{
+			Name:       "same account configured with account ID",
+			UserID:     "123456789012",
+			Configured: "123456789012/sg-001",
+			Expected:   "123456789012/sg-001",
+		},
+		{
+			Name:     "other account",
+			UserID:   "210987654321",
+			Expected: "210987654321/sg-001",
+		},
+		{
+			Name:       "other account configured without account ID",
+			UserID:     "210987654321",
+			Configured: "sg-001",
+			Expected:   "210987654321/sg-001",
+		},
+		{
+			Name:       "other account configured with rule account ID",
+			UserID:     "210987654321",
+			Configured: "123456789012/sg-001",
+			Expected:   "210987654321/sg-001",
+		},
+		{
+			Name:       "other group configured",
+			UserID:     "123456789012",
+			Configured: "123456789012/sg-002",
+			Expected:   "sg-001",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			apiObject := &ec2.ReferencedSecurityGroup{
+				GroupId: aws.String("sg-001"),
+				UserId:  aws.String(testCase.UserID),
+			}
+
+			if got := flattenSecurityGroupRuleReferencedGroup(apiObject, "123456789012", testCase.Configured); got != testCase.Expected {
+				t.Errorf("got %s, expected %s", got, testCase.Expected)
+			}
+		})
+	}
+}
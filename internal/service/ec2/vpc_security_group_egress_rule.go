@@ -0,0 +1,39 @@
+package ec2
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-aws/internal/verify"
+)
+
+func ResourceSecurityGroupEgressRule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSecurityGroupEgressRuleCreate,
+		Read:   resourceSecurityGroupEgressRuleRead,
+		Update: resourceSecurityGroupEgressRuleUpdate,
+		Delete: resourceSecurityGroupEgressRuleDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		CustomizeDiff: verify.SetTagsDiff,
+
+		Schema: securityGroupRuleSchema(),
+	}
+}
+
+func resourceSecurityGroupEgressRuleCreate(d *schema.ResourceData, meta interface{}) error {
+	return resourceSecurityGroupRuleIDCreate(d, meta, true)
+}
+
+func resourceSecurityGroupEgressRuleRead(d *schema.ResourceData, meta interface{}) error {
+	return resourceSecurityGroupRuleIDRead(d, meta, true)
+}
+
+func resourceSecurityGroupEgressRuleUpdate(d *schema.ResourceData, meta interface{}) error {
+	return resourceSecurityGroupRuleIDUpdate(d, meta, true)
+}
+
+func resourceSecurityGroupEgressRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	return resourceSecurityGroupRuleIDDelete(d, meta, true)
+}
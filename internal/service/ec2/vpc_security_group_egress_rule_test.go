@@ -0,0 +1,86 @@
+package ec2_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+	tfec2 "github.com/hashicorp/terraform-provider-aws/internal/service/ec2"
+)
+
+func TestAccVPCSecurityGroupEgressRule_basic(t *testing.T) {
+	var rule ec2.SecurityGroupRule
+	resourceName := "aws_vpc_security_group_egress_rule.test"
+	plResourceName := "aws_ec2_managed_prefix_list.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, ec2.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckSecurityGroupRuleIDDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVPCSecurityGroupEgressRuleConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSecurityGroupRuleIDExists(resourceName, true, &rule),
+					resource.TestCheckResourceAttr(resourceName, "cidr_ipv4", ""),
+					resource.TestCheckResourceAttr(resourceName, "from_port", "443"),
+					resource.TestCheckResourceAttr(resourceName, "ip_protocol", "tcp"),
+					resource.TestCheckResourceAttrPair(resourceName, "prefix_list_id", plResourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "to_port", "443"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccVPCSecurityGroupEgressRule_disappears(t *testing.T) {
+	var rule ec2.SecurityGroupRule
+	resourceName := "aws_vpc_security_group_egress_rule.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, ec2.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckSecurityGroupRuleIDDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVPCSecurityGroupEgressRuleConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSecurityGroupRuleIDExists(resourceName, true, &rule),
+					acctest.CheckResourceDisappears(acctest.Provider, tfec2.ResourceSecurityGroupEgressRule(), resourceName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccVPCSecurityGroupEgressRuleConfig(rName string) string {
+	return acctest.ConfigCompose(testAccVPCSecurityGroupRuleBaseConfig(rName), fmt.Sprintf(`
+resource "aws_ec2_managed_prefix_list" "test" {
+  address_family = "IPv4"
+  max_entries    = 1
+  name           = %[1]q
+}
+
+resource "aws_vpc_security_group_egress_rule" "test" {
+  security_group_id = aws_security_group.test.id
+
+  from_port      = 443
+  ip_protocol    = "tcp"
+  prefix_list_id = aws_ec2_managed_prefix_list.test.id
+  to_port        = 443
+}
+`, rName))
+}
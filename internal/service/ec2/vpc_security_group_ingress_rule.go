@@ -0,0 +1,448 @@
+package ec2
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	tftags "github.com/hashicorp/terraform-provider-aws/internal/tags"
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
+	"github.com/hashicorp/terraform-provider-aws/internal/verify"
+)
+
+func ResourceSecurityGroupIngressRule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSecurityGroupIngressRuleCreate,
+		Read:   resourceSecurityGroupIngressRuleRead,
+		Update: resourceSecurityGroupIngressRuleUpdate,
+		Delete: resourceSecurityGroupIngressRuleDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		CustomizeDiff: verify.SetTagsDiff,
+
+		Schema: securityGroupRuleSchema(),
+	}
+}
+
+func resourceSecurityGroupIngressRuleCreate(d *schema.ResourceData, meta interface{}) error {
+	return resourceSecurityGroupRuleIDCreate(d, meta, false)
+}
+
+func resourceSecurityGroupIngressRuleRead(d *schema.ResourceData, meta interface{}) error {
+	return resourceSecurityGroupRuleIDRead(d, meta, false)
+}
+
+func resourceSecurityGroupIngressRuleUpdate(d *schema.ResourceData, meta interface{}) error {
+	return resourceSecurityGroupRuleIDUpdate(d, meta, false)
+}
+
+func resourceSecurityGroupIngressRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	return resourceSecurityGroupRuleIDDelete(d, meta, false)
+}
+
+// securityGroupRuleSchema returns the schema shared by the aws_vpc_security_group_ingress_rule and
+// aws_vpc_security_group_egress_rule resources. Each resource manages exactly one EC2 security group rule,
+// identified by its security group rule ID.
+func securityGroupRuleSchema() map[string]*schema.Schema {
+	sources := []string{"cidr_ipv4", "cidr_ipv6", "prefix_list_id", "referenced_security_group_id"}
+
+	return map[string]*schema.Schema{
+		"arn": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"cidr_ipv4": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: verify.ValidIPv4CIDRNetworkAddress,
+			ExactlyOneOf: sources,
+		},
+		"cidr_ipv6": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: verify.ValidIPv6CIDRNetworkAddress,
+			ExactlyOneOf: sources,
+		},
+		"description": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validSecurityGroupRuleDescription,
+		},
+		"from_port": {
+			Type:             schema.TypeInt,
+			Optional:         true,
+			DiffSuppressFunc: suppressSecurityGroupRulePortDiffs,
+		},
+		"ip_protocol": {
+			Type:      schema.TypeString,
+			Required:  true,
+			StateFunc: ProtocolStateFunc,
+		},
+		"prefix_list_id": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ExactlyOneOf: sources,
+		},
+		"referenced_security_group_id": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ExactlyOneOf: sources,
+		},
+		"security_group_id": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"security_group_rule_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"tags":     tftags.TagsSchema(),
+		"tags_all": tftags.TagsSchemaComputed(),
+		"to_port": {
+			Type:             schema.TypeInt,
+			Optional:         true,
+			DiffSuppressFunc: suppressSecurityGroupRulePortDiffs,
+		},
+	}
+}
+
+// securityGroupRuleProtocolHasPorts reports whether from_port and to_port apply to the protocol.
+// For ICMP and ICMPv6 they hold the ICMP type and code.
+func securityGroupRuleProtocolHasPorts(protocol string) bool {
+	switch ProtocolForValue(protocol) {
+	case "tcp", "udp", "icmp", "icmpv6":
+		return true
+	default:
+		return false
+	}
+}
+
+func suppressSecurityGroupRulePortDiffs(k, old, new string, d *schema.ResourceData) bool {
+	return !securityGroupRuleProtocolHasPorts(d.Get("ip_protocol").(string))
+}
+
+// securityGroupRuleReferencedGroup splits a referenced security group ID, optionally prefixed by
+// the owning account ID (e.g. 123456789012/sg-12345678), into its parts.
+func securityGroupRuleReferencedGroup(v string) (string, string) {
+	if parts := strings.SplitN(v, "/", 2); len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+
+	return "", v
+}
+
+// flattenSecurityGroupRuleReferencedGroup returns the referenced security group ID, prefixed with its owner's account ID
+// if that differs from the account that owns the rule's security group.
+// The configured value is kept if it identifies the same security group, as it may also be prefixed with the rule's account ID.
+func flattenSecurityGroupRuleReferencedGroup(apiObject *ec2.ReferencedSecurityGroup, ownerID, configured string) string {
+	userID, groupID := aws.StringValue(apiObject.UserId), aws.StringValue(apiObject.GroupId)
+
+	if userID == "" {
+		userID = ownerID
+	}
+
+	if configuredUserID, configuredGroupID := securityGroupRuleReferencedGroup(configured); configuredGroupID == groupID {
+		if configuredUserID == "" && userID == ownerID || configuredUserID == userID {
+			return configured
+		}
+	}
+
+	if userID != ownerID {
+		return fmt.Sprintf("%s/%s", userID, groupID)
+	}
+
+	return groupID
+}
+
+func expandSecurityGroupRuleIPPermission(d *schema.ResourceData) *ec2.IpPermission {
+	protocol := ProtocolForValue(d.Get("ip_protocol").(string))
+	apiObject := &ec2.IpPermission{
+		IpProtocol: aws.String(protocol),
+	}
+
+	if securityGroupRuleProtocolHasPorts(protocol) {
+		apiObject.FromPort = aws.Int64(int64(d.Get("from_port").(int)))
+		apiObject.ToPort = aws.Int64(int64(d.Get("to_port").(int)))
+	}
+
+	var description *string
+	if v, ok := d.GetOk("description"); ok {
+		description = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("cidr_ipv4"); ok {
+		apiObject.IpRanges = []*ec2.IpRange{{
+			CidrIp:      aws.String(v.(string)),
+			Description: description,
+		}}
+	}
+
+	if v, ok := d.GetOk("cidr_ipv6"); ok {
+		apiObject.Ipv6Ranges = []*ec2.Ipv6Range{{
+			CidrIpv6:    aws.String(v.(string)),
+			Description: description,
+		}}
+	}
+
+	if v, ok := d.GetOk("prefix_list_id"); ok {
+		apiObject.PrefixListIds = []*ec2.PrefixListId{{
+			Description:  description,
+			PrefixListId: aws.String(v.(string)),
+		}}
+	}
+
+	if v, ok := d.GetOk("referenced_security_group_id"); ok {
+		userID, groupID := securityGroupRuleReferencedGroup(v.(string))
+		pair := &ec2.UserIdGroupPair{
+			Description: description,
+			GroupId:     aws.String(groupID),
+		}
+
+		if userID != "" {
+			pair.UserId = aws.String(userID)
+		}
+
+		apiObject.UserIdGroupPairs = []*ec2.UserIdGroupPair{pair}
+	}
+
+	return apiObject
+}
+
+func expandSecurityGroupRuleRequest(d *schema.ResourceData) *ec2.SecurityGroupRuleRequest {
+	protocol := ProtocolForValue(d.Get("ip_protocol").(string))
+	apiObject := &ec2.SecurityGroupRuleRequest{
+		Description: aws.String(d.Get("description").(string)),
+		IpProtocol:  aws.String(protocol),
+	}
+
+	if securityGroupRuleProtocolHasPorts(protocol) {
+		apiObject.FromPort = aws.Int64(int64(d.Get("from_port").(int)))
+		apiObject.ToPort = aws.Int64(int64(d.Get("to_port").(int)))
+	}
+
+	if v, ok := d.GetOk("cidr_ipv4"); ok {
+		apiObject.CidrIpv4 = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("cidr_ipv6"); ok {
+		apiObject.CidrIpv6 = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("prefix_list_id"); ok {
+		apiObject.PrefixListId = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("referenced_security_group_id"); ok {
+		// The owning account cannot be specified when modifying a rule.
+		_, groupID := securityGroupRuleReferencedGroup(v.(string))
+		apiObject.ReferencedGroupId = aws.String(groupID)
+	}
+
+	return apiObject
+}
+
+func securityGroupRuleTypeName(isEgress bool) string {
+	if isEgress {
+		return "egress"
+	}
+
+	return "ingress"
+}
+
+func resourceSecurityGroupRuleIDCreate(d *schema.ResourceData, meta interface{}, isEgress bool) error {
+	conn := meta.(*conns.AWSClient).EC2Conn
+	defaultTagsConfig := meta.(*conns.AWSClient).DefaultTagsConfig
+	tags := defaultTagsConfig.MergeTags(tftags.New(d.Get("tags").(map[string]interface{})))
+
+	securityGroupID := d.Get("security_group_id").(string)
+	ipPermissions := []*ec2.IpPermission{expandSecurityGroupRuleIPPermission(d)}
+
+	var tagSpecifications []*ec2.TagSpecification
+	if len(tags) > 0 {
+		tagSpecifications = ec2TagSpecificationsFromKeyValueTags(tags, ec2.ResourceTypeSecurityGroupRule)
+	}
+
+	var rules []*ec2.SecurityGroupRule
+
+	if isEgress {
+		input := &ec2.AuthorizeSecurityGroupEgressInput{
+			GroupId:           aws.String(securityGroupID),
+			IpPermissions:     ipPermissions,
+			TagSpecifications: tagSpecifications,
+		}
+
+		log.Printf("[DEBUG] Creating EC2 Security Group Egress Rule: %s", input)
+		output, err := conn.AuthorizeSecurityGroupEgress(input)
+
+		if err != nil {
+			return fmt.Errorf("error creating EC2 Security Group (%s) egress rule: %w", securityGroupID, err)
+		}
+
+		rules = output.SecurityGroupRules
+	} else {
+		input := &ec2.AuthorizeSecurityGroupIngressInput{
+			GroupId:           aws.String(securityGroupID),
+			IpPermissions:     ipPermissions,
+			TagSpecifications: tagSpecifications,
+		}
+
+		log.Printf("[DEBUG] Creating EC2 Security Group Ingress Rule: %s", input)
+		output, err := conn.AuthorizeSecurityGroupIngress(input)
+
+		if err != nil {
+			return fmt.Errorf("error creating EC2 Security Group (%s) ingress rule: %w", securityGroupID, err)
+		}
+
+		rules = output.SecurityGroupRules
+	}
+
+	if len(rules) != 1 || rules[0] == nil {
+		return fmt.Errorf("error creating EC2 Security Group (%s) %s rule: expected 1 rule, got %d", securityGroupID, securityGroupRuleTypeName(isEgress), len(rules))
+	}
+
+	d.SetId(aws.StringValue(rules[0].SecurityGroupRuleId))
+
+	return resourceSecurityGroupRuleIDRead(d, meta, isEgress)
+}
+
+func resourceSecurityGroupRuleIDRead(d *schema.ResourceData, meta interface{}, isEgress bool) error {
+	conn := meta.(*conns.AWSClient).EC2Conn
+	defaultTagsConfig := meta.(*conns.AWSClient).DefaultTagsConfig
+	ignoreTagsConfig := meta.(*conns.AWSClient).IgnoreTagsConfig
+
+	rule, err := FindSecurityGroupRuleByID(conn, d.Id())
+
+	if err == nil && aws.BoolValue(rule.IsEgress) != isEgress {
+		err = &resource.NotFoundError{
+			Message: fmt.Sprintf("EC2 Security Group Rule (%s) is not an %s rule", d.Id(), securityGroupRuleTypeName(isEgress)),
+		}
+	}
+
+	if !d.IsNewResource() && tfresource.NotFound(err) {
+		log.Printf("[WARN] EC2 Security Group Rule %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading EC2 Security Group Rule (%s): %w", d.Id(), err)
+	}
+
+	ruleARN := arn.ARN{
+		AccountID: aws.StringValue(rule.GroupOwnerId),
+		Partition: meta.(*conns.AWSClient).Partition,
+		Region:    meta.(*conns.AWSClient).Region,
+		Resource:  fmt.Sprintf("security-group-rule/%s", d.Id()),
+		Service:   ec2.ServiceName,
+	}.String()
+	d.Set("arn", ruleARN)
+	d.Set("cidr_ipv4", rule.CidrIpv4)
+	d.Set("cidr_ipv6", rule.CidrIpv6)
+	d.Set("description", rule.Description)
+	d.Set("ip_protocol", ProtocolForValue(aws.StringValue(rule.IpProtocol)))
+	d.Set("prefix_list_id", rule.PrefixListId)
+	d.Set("security_group_id", rule.GroupId)
+	d.Set("security_group_rule_id", rule.SecurityGroupRuleId)
+
+	if securityGroupRuleProtocolHasPorts(aws.StringValue(rule.IpProtocol)) {
+		d.Set("from_port", rule.FromPort)
+		d.Set("to_port", rule.ToPort)
+	} else {
+		d.Set("from_port", nil)
+		d.Set("to_port", nil)
+	}
+
+	if v := rule.ReferencedGroupInfo; v != nil {
+		d.Set("referenced_security_group_id", flattenSecurityGroupRuleReferencedGroup(v, aws.StringValue(rule.GroupOwnerId), d.Get("referenced_security_group_id").(string)))
+	} else {
+		d.Set("referenced_security_group_id", nil)
+	}
+
+	tags := KeyValueTags(rule.Tags).IgnoreAWS().IgnoreConfig(ignoreTagsConfig)
+
+	//lintignore:AWSR002
+	if err := d.Set("tags", tags.RemoveDefaultConfig(defaultTagsConfig).Map()); err != nil {
+		return fmt.Errorf("error setting tags: %w", err)
+	}
+
+	if err := d.Set("tags_all", tags.Map()); err != nil {
+		return fmt.Errorf("error setting tags_all: %w", err)
+	}
+
+	return nil
+}
+
+func resourceSecurityGroupRuleIDUpdate(d *schema.ResourceData, meta interface{}, isEgress bool) error {
+	conn := meta.(*conns.AWSClient).EC2Conn
+
+	if d.HasChangesExcept("tags", "tags_all") {
+		input := &ec2.ModifySecurityGroupRulesInput{
+			GroupId: aws.String(d.Get("security_group_id").(string)),
+			SecurityGroupRules: []*ec2.SecurityGroupRuleUpdate{{
+				SecurityGroupRule:   expandSecurityGroupRuleRequest(d),
+				SecurityGroupRuleId: aws.String(d.Id()),
+			}},
+		}
+
+		log.Printf("[DEBUG] Updating EC2 Security Group Rule: %s", input)
+		_, err := conn.ModifySecurityGroupRules(input)
+
+		if err != nil {
+			return fmt.Errorf("error updating EC2 Security Group Rule (%s): %w", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("tags_all") {
+		o, n := d.GetChange("tags_all")
+		if err := UpdateTags(conn, d.Id(), o, n); err != nil {
+			return fmt.Errorf("error updating EC2 Security Group Rule (%s) tags: %w", d.Id(), err)
+		}
+	}
+
+	return resourceSecurityGroupRuleIDRead(d, meta, isEgress)
+}
+
+func resourceSecurityGroupRuleIDDelete(d *schema.ResourceData, meta interface{}, isEgress bool) error {
+	conn := meta.(*conns.AWSClient).EC2Conn
+
+	securityGroupID := d.Get("security_group_id").(string)
+	ruleIDs := aws.StringSlice([]string{d.Id()})
+
+	var err error
+
+	log.Printf("[DEBUG] Deleting EC2 Security Group Rule: %s", d.Id())
+	if isEgress {
+		_, err = conn.RevokeSecurityGroupEgress(&ec2.RevokeSecurityGroupEgressInput{
+			GroupId:              aws.String(securityGroupID),
+			SecurityGroupRuleIds: ruleIDs,
+		})
+	} else {
+		_, err = conn.RevokeSecurityGroupIngress(&ec2.RevokeSecurityGroupIngressInput{
+			GroupId:              aws.String(securityGroupID),
+			SecurityGroupRuleIds: ruleIDs,
+		})
+	}
+
+	if tfawserr.ErrCodeEquals(err, ErrCodeInvalidSecurityGroupRuleIdNotFound) ||
+		tfawserr.ErrCodeEquals(err, InvalidSecurityGroupIDNotFound) ||
+		tfawserr.ErrCodeEquals(err, InvalidGroupNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting EC2 Security Group Rule (%s): %w", d.Id(), err)
+	}
+
+	return nil
+}
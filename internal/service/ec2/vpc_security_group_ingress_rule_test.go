@@ -0,0 +1,370 @@
+package ec2_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	tfec2 "github.com/hashicorp/terraform-provider-aws/internal/service/ec2"
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
+)
+
+func TestAccVPCSecurityGroupIngressRule_basic(t *testing.T) {
+	var rule ec2.SecurityGroupRule
+	resourceName := "aws_vpc_security_group_ingress_rule.test"
+	sgResourceName := "aws_security_group.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, ec2.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckSecurityGroupRuleIDDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVPCSecurityGroupIngressRuleConfig(rName, "test"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSecurityGroupRuleIDExists(resourceName, false, &rule),
+					acctest.MatchResourceAttrRegionalARN(resourceName, "arn", "ec2", regexp.MustCompile(`security-group-rule/sgr-.+`)),
+					resource.TestCheckResourceAttr(resourceName, "cidr_ipv4", "10.0.0.0/8"),
+					resource.TestCheckResourceAttr(resourceName, "cidr_ipv6", ""),
+					resource.TestCheckResourceAttr(resourceName, "description", "test"),
+					resource.TestCheckResourceAttr(resourceName, "from_port", "80"),
+					resource.TestCheckResourceAttr(resourceName, "ip_protocol", "tcp"),
+					resource.TestCheckResourceAttr(resourceName, "prefix_list_id", ""),
+					resource.TestCheckResourceAttr(resourceName, "referenced_security_group_id", ""),
+					resource.TestCheckResourceAttrPair(resourceName, "security_group_id", sgResourceName, "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "security_group_rule_id", resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "0"),
+					resource.TestCheckResourceAttr(resourceName, "to_port", "8080"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccVPCSecurityGroupIngressRuleConfig(rName, "updated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSecurityGroupRuleIDExists(resourceName, false, &rule),
+					resource.TestCheckResourceAttr(resourceName, "description", "updated"),
+					resource.TestCheckResourceAttr(resourceName, "security_group_rule_id", aws.StringValue(rule.SecurityGroupRuleId)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccVPCSecurityGroupIngressRule_disappears(t *testing.T) {
+	var rule ec2.SecurityGroupRule
+	resourceName := "aws_vpc_security_group_ingress_rule.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, ec2.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckSecurityGroupRuleIDDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVPCSecurityGroupIngressRuleConfig(rName, "test"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSecurityGroupRuleIDExists(resourceName, false, &rule),
+					acctest.CheckResourceDisappears(acctest.Provider, tfec2.ResourceSecurityGroupIngressRule(), resourceName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccVPCSecurityGroupIngressRule_tags(t *testing.T) {
+	var rule ec2.SecurityGroupRule
+	resourceName := "aws_vpc_security_group_ingress_rule.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, ec2.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckSecurityGroupRuleIDDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVPCSecurityGroupIngressRuleTags1Config(rName, "key1", "value1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSecurityGroupRuleIDExists(resourceName, false, &rule),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.key1", "value1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccVPCSecurityGroupIngressRuleTags1Config(rName, "key2", "value2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSecurityGroupRuleIDExists(resourceName, false, &rule),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.key2", "value2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccVPCSecurityGroupIngressRule_referencedSecurityGroup(t *testing.T) {
+	var rule ec2.SecurityGroupRule
+	resourceName := "aws_vpc_security_group_ingress_rule.test"
+	sgResourceName := "aws_security_group.source"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, ec2.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckSecurityGroupRuleIDDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVPCSecurityGroupIngressRuleReferencedSecurityGroupConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSecurityGroupRuleIDExists(resourceName, false, &rule),
+					resource.TestCheckResourceAttr(resourceName, "cidr_ipv4", ""),
+					resource.TestCheckResourceAttr(resourceName, "from_port", "0"),
+					resource.TestCheckResourceAttr(resourceName, "ip_protocol", "-1"),
+					resource.TestCheckResourceAttrPair(resourceName, "referenced_security_group_id", sgResourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "to_port", "0"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccVPCSecurityGroupIngressRule_referencedSecurityGroupAccountID(t *testing.T) {
+	var rule ec2.SecurityGroupRule
+	resourceName := "aws_vpc_security_group_ingress_rule.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, ec2.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckSecurityGroupRuleIDDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVPCSecurityGroupIngressRuleReferencedSecurityGroupAccountIDConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSecurityGroupRuleIDExists(resourceName, false, &rule),
+					resource.TestMatchResourceAttr(resourceName, "referenced_security_group_id", regexp.MustCompile(`^\d{12}/sg-[0-9a-f]+$`)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccVPCSecurityGroupIngressRule_updateSource(t *testing.T) {
+	var rule ec2.SecurityGroupRule
+	resourceName := "aws_vpc_security_group_ingress_rule.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, ec2.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckSecurityGroupRuleIDDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVPCSecurityGroupIngressRuleConfig(rName, "test"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSecurityGroupRuleIDExists(resourceName, false, &rule),
+				),
+			},
+			{
+				Config: testAccVPCSecurityGroupIngressRuleCIDRIPv6Config(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSecurityGroupRuleIDExists(resourceName, false, &rule),
+					resource.TestCheckResourceAttr(resourceName, "cidr_ipv4", ""),
+					resource.TestCheckResourceAttr(resourceName, "cidr_ipv6", "2001:db8::/32"),
+					resource.TestCheckResourceAttr(resourceName, "from_port", "-1"),
+					resource.TestCheckResourceAttr(resourceName, "ip_protocol", "icmpv6"),
+					resource.TestCheckResourceAttr(resourceName, "to_port", "-1"),
+					resource.TestCheckResourceAttr(resourceName, "security_group_rule_id", aws.StringValue(rule.SecurityGroupRuleId)),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSecurityGroupRuleIDDestroy(s *terraform.State) error {
+	conn := acctest.Provider.Meta().(*conns.AWSClient).EC2Conn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_vpc_security_group_egress_rule" && rs.Type != "aws_vpc_security_group_ingress_rule" {
+			continue
+		}
+
+		_, err := tfec2.FindSecurityGroupRuleByID(conn, rs.Primary.ID)
+
+		if tfresource.NotFound(err) {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return fmt.Errorf("EC2 Security Group Rule %s still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccCheckSecurityGroupRuleIDExists(n string, isEgress bool, v *ec2.SecurityGroupRule) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No EC2 Security Group Rule ID is set")
+		}
+
+		conn := acctest.Provider.Meta().(*conns.AWSClient).EC2Conn
+
+		output, err := tfec2.FindSecurityGroupRuleByID(conn, rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if aws.BoolValue(output.IsEgress) != isEgress {
+			return fmt.Errorf("EC2 Security Group Rule %s has unexpected type (egress: %t)", rs.Primary.ID, aws.BoolValue(output.IsEgress))
+		}
+
+		*v = *output
+
+		return nil
+	}
+}
+
+func testAccVPCSecurityGroupRuleBaseConfig(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_vpc" "test" {
+  cidr_block = "10.0.0.0/16"
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_security_group" "test" {
+  vpc_id = aws_vpc.test.id
+  name   = %[1]q
+
+  tags = {
+    Name = %[1]q
+  }
+}
+`, rName)
+}
+
+func testAccVPCSecurityGroupIngressRuleConfig(rName, description string) string {
+	return acctest.ConfigCompose(testAccVPCSecurityGroupRuleBaseConfig(rName), fmt.Sprintf(`
+resource "aws_vpc_security_group_ingress_rule" "test" {
+  security_group_id = aws_security_group.test.id
+
+  cidr_ipv4   = "10.0.0.0/8"
+  description = %[1]q
+  from_port   = 80
+  ip_protocol = "tcp"
+  to_port     = 8080
+}
+`, description))
+}
+
+func testAccVPCSecurityGroupIngressRuleTags1Config(rName, tagKey1, tagValue1 string) string {
+	return acctest.ConfigCompose(testAccVPCSecurityGroupRuleBaseConfig(rName), fmt.Sprintf(`
+resource "aws_vpc_security_group_ingress_rule" "test" {
+  security_group_id = aws_security_group.test.id
+
+  cidr_ipv4   = "10.0.0.0/8"
+  from_port   = 443
+  ip_protocol = "tcp"
+  to_port     = 443
+
+  tags = {
+    %[1]q = %[2]q
+  }
+}
+`, tagKey1, tagValue1))
+}
+
+func testAccVPCSecurityGroupIngressRuleReferencedSecurityGroupConfig(rName string) string {
+	return acctest.ConfigCompose(testAccVPCSecurityGroupRuleBaseConfig(rName), fmt.Sprintf(`
+resource "aws_security_group" "source" {
+  vpc_id = aws_vpc.test.id
+  name   = "%[1]s-source"
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_vpc_security_group_ingress_rule" "test" {
+  security_group_id = aws_security_group.test.id
+
+  ip_protocol                  = "-1"
+  referenced_security_group_id = aws_security_group.source.id
+}
+`, rName))
+}
+
+func testAccVPCSecurityGroupIngressRuleReferencedSecurityGroupAccountIDConfig(rName string) string {
+	return acctest.ConfigCompose(testAccVPCSecurityGroupRuleBaseConfig(rName), fmt.Sprintf(`
+data "aws_caller_identity" "current" {}
+
+resource "aws_security_group" "source" {
+  vpc_id = aws_vpc.test.id
+  name   = "%[1]s-source"
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_vpc_security_group_ingress_rule" "test" {
+  security_group_id = aws_security_group.test.id
+
+  ip_protocol                  = "-1"
+  referenced_security_group_id = "${data.aws_caller_identity.current.account_id}/${aws_security_group.source.id}"
+}
+`, rName))
+}
+
+func testAccVPCSecurityGroupIngressRuleCIDRIPv6Config(rName string) string {
+	return acctest.ConfigCompose(testAccVPCSecurityGroupRuleBaseConfig(rName), `
+resource "aws_vpc_security_group_ingress_rule" "test" {
+  security_group_id = aws_security_group.test.id
+
+  cidr_ipv6   = "2001:db8::/32"
+  from_port   = -1
+  ip_protocol = "icmpv6"
+  to_port     = -1
+}
+`)
+}
@@ -0,0 +1,611 @@
+package ecs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/private/protocol/json/jsonutil"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-aws/internal/create"
+	"github.com/hashicorp/terraform-provider-aws/internal/flex"
+	"github.com/hashicorp/terraform-provider-aws/internal/verify"
+)
+
+// Defaults that ECS applies to container health checks.
+const (
+	containerHealthCheckIntervalDefault = 30
+	containerHealthCheckRetriesDefault  = 3
+	containerHealthCheckTimeoutDefault  = 5
+)
+
+func DataSourceContainerDefinitionDocument() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceContainerDefinitionDocumentRead,
+
+		Schema: map[string]*schema.Schema{
+			"container": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"command": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"cpu": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"depends_on": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"condition": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice(ecs.ContainerCondition_Values(), false),
+									},
+									"container_name": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+						"docker_labels": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"entry_point": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"environment": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"environment_file": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Default:      ecs.EnvironmentFileTypeS3,
+										ValidateFunc: validation.StringInSlice(ecs.EnvironmentFileType_Values(), false),
+									},
+									"value": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: verify.ValidARN,
+									},
+								},
+							},
+						},
+						"essential": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"firelens_configuration": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"options": {
+										Type:     schema.TypeMap,
+										Optional: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+									"type": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice(ecs.FirelensConfigurationType_Values(), false),
+									},
+								},
+							},
+						},
+						"health_check": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"command": {
+										Type:     schema.TypeList,
+										Required: true,
+										MinItems: 1,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+									"interval": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      containerHealthCheckIntervalDefault,
+										ValidateFunc: validation.IntBetween(5, 300),
+									},
+									"retries": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      containerHealthCheckRetriesDefault,
+										ValidateFunc: validation.IntBetween(1, 10),
+									},
+									"start_period": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validation.IntBetween(0, 300),
+									},
+									"timeout": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      containerHealthCheckTimeoutDefault,
+										ValidateFunc: validation.IntBetween(2, 60),
+									},
+								},
+							},
+						},
+						"hostname": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"image": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringLenBetween(1, 255),
+						},
+						"log_configuration": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"log_driver": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice(ecs.LogDriver_Values(), false),
+									},
+									"options": {
+										Type:     schema.TypeMap,
+										Optional: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+									"secret_option": containerSecretSchema(),
+								},
+							},
+						},
+						"memory": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(6),
+						},
+						"memory_reservation": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(6),
+						},
+						"mount_point": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"container_path": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"read_only": {
+										Type:     schema.TypeBool,
+										Optional: true,
+									},
+									"source_volume": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-zA-Z0-9_-]{1,255}$`), "must be up to 255 letters, numbers, hyphens and underscores"),
+						},
+						"port_mapping": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"container_port": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IsPortNumber,
+									},
+									"host_port": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validation.IsPortNumberOrZero,
+									},
+									"protocol": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Default:      ecs.TransportProtocolTcp,
+										ValidateFunc: validation.StringInSlice(ecs.TransportProtocol_Values(), false),
+									},
+								},
+							},
+						},
+						"privileged": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"readonly_root_filesystem": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"secret": containerSecretSchema(),
+						"start_timeout": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"stop_timeout": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"ulimit": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"hard_limit": {
+										Type:     schema.TypeInt,
+										Required: true,
+									},
+									"name": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice(ecs.UlimitName_Values(), false),
+									},
+									"soft_limit": {
+										Type:     schema.TypeInt,
+										Required: true,
+									},
+								},
+							},
+						},
+						"user": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"volumes_from": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"read_only": {
+										Type:     schema.TypeBool,
+										Optional: true,
+									},
+									"source_container": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+						"working_directory": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"network_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      ecs.NetworkModeBridge,
+				ValidateFunc: validation.StringInSlice(ecs.NetworkMode_Values(), false),
+			},
+		},
+	}
+}
+
+func containerSecretSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"value_from": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceContainerDefinitionDocumentRead(d *schema.ResourceData, meta interface{}) error {
+	networkMode := d.Get("network_mode").(string)
+	definitions := expandContainerDefinitionDocumentContainers(d.Get("container").([]interface{}), networkMode)
+
+	if err := validContainerDefinitions(definitions, networkMode); err != nil {
+		return fmt.Errorf("invalid ECS container definitions: %w", err)
+	}
+
+	b, err := jsonutil.BuildJSON(definitions)
+
+	if err != nil {
+		return fmt.Errorf("error encoding ECS container definitions: %w", err)
+	}
+
+	var jsonDoc bytes.Buffer
+
+	if err := json.Indent(&jsonDoc, b, "", "  "); err != nil {
+		return fmt.Errorf("error encoding ECS container definitions: %w", err)
+	}
+
+	jsonString := jsonDoc.String()
+
+	d.SetId(strconv.Itoa(create.StringHashcode(jsonString)))
+	d.Set("json", jsonString)
+
+	return nil
+}
+
+// expandContainerDefinitionDocumentContainers returns container definitions with the defaults that ECS applies,
+// so that they are equal to the definitions that DescribeTaskDefinition returns.
+func expandContainerDefinitionDocumentContainers(tfList []interface{}, networkMode string) []*ecs.ContainerDefinition {
+	var apiObjects []*ecs.ContainerDefinition
+
+	for _, tfMapRaw := range tfList {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		apiObject := &ecs.ContainerDefinition{
+			Essential: aws.Bool(tfMap["essential"].(bool)),
+			Image:     aws.String(tfMap["image"].(string)),
+			Name:      aws.String(tfMap["name"].(string)),
+		}
+
+		if v, ok := tfMap["command"].([]interface{}); ok && len(v) > 0 {
+			apiObject.Command = flex.ExpandStringList(v)
+		}
+
+		if v, ok := tfMap["cpu"].(int); ok && v != 0 {
+			apiObject.Cpu = aws.Int64(int64(v))
+		}
+
+		if v, ok := tfMap["depends_on"].([]interface{}); ok {
+			for _, tfMapRaw := range v {
+				tfMap := tfMapRaw.(map[string]interface{})
+
+				apiObject.DependsOn = append(apiObject.DependsOn, &ecs.ContainerDependency{
+					Condition:     aws.String(tfMap["condition"].(string)),
+					ContainerName: aws.String(tfMap["container_name"].(string)),
+				})
+			}
+		}
+
+		if v, ok := tfMap["docker_labels"].(map[string]interface{}); ok && len(v) > 0 {
+			apiObject.DockerLabels = flex.ExpandStringMap(v)
+		}
+
+		if v, ok := tfMap["entry_point"].([]interface{}); ok && len(v) > 0 {
+			apiObject.EntryPoint = flex.ExpandStringList(v)
+		}
+
+		if v, ok := tfMap["environment"].(map[string]interface{}); ok && len(v) > 0 {
+			for name, value := range v {
+				apiObject.Environment = append(apiObject.Environment, &ecs.KeyValuePair{
+					Name:  aws.String(name),
+					Value: aws.String(value.(string)),
+				})
+			}
+
+			containerDefinitions([]*ecs.ContainerDefinition{apiObject}).OrderEnvironmentVariables()
+		}
+
+		if v, ok := tfMap["environment_file"].([]interface{}); ok {
+			for _, tfMapRaw := range v {
+				tfMap := tfMapRaw.(map[string]interface{})
+
+				apiObject.EnvironmentFiles = append(apiObject.EnvironmentFiles, &ecs.EnvironmentFile{
+					Type:  aws.String(tfMap["type"].(string)),
+					Value: aws.String(tfMap["value"].(string)),
+				})
+			}
+		}
+
+		if v, ok := tfMap["firelens_configuration"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			tfMap := v[0].(map[string]interface{})
+
+			apiObject.FirelensConfiguration = &ecs.FirelensConfiguration{
+				Type: aws.String(tfMap["type"].(string)),
+			}
+
+			if v, ok := tfMap["options"].(map[string]interface{}); ok && len(v) > 0 {
+				apiObject.FirelensConfiguration.Options = flex.ExpandStringMap(v)
+			}
+		}
+
+		if v, ok := tfMap["health_check"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			tfMap := v[0].(map[string]interface{})
+
+			apiObject.HealthCheck = &ecs.HealthCheck{
+				Command:  flex.ExpandStringList(tfMap["command"].([]interface{})),
+				Interval: aws.Int64(int64(tfMap["interval"].(int))),
+				Retries:  aws.Int64(int64(tfMap["retries"].(int))),
+				Timeout:  aws.Int64(int64(tfMap["timeout"].(int))),
+			}
+
+			if v, ok := tfMap["start_period"].(int); ok && v != 0 {
+				apiObject.HealthCheck.StartPeriod = aws.Int64(int64(v))
+			}
+		}
+
+		if v, ok := tfMap["hostname"].(string); ok && v != "" {
+			apiObject.Hostname = aws.String(v)
+		}
+
+		if v, ok := tfMap["log_configuration"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			tfMap := v[0].(map[string]interface{})
+
+			apiObject.LogConfiguration = &ecs.LogConfiguration{
+				LogDriver:     aws.String(tfMap["log_driver"].(string)),
+				SecretOptions: expandContainerDefinitionDocumentSecrets(tfMap["secret_option"].([]interface{})),
+			}
+
+			if v, ok := tfMap["options"].(map[string]interface{}); ok && len(v) > 0 {
+				apiObject.LogConfiguration.Options = flex.ExpandStringMap(v)
+			}
+		}
+
+		if v, ok := tfMap["memory"].(int); ok && v != 0 {
+			apiObject.Memory = aws.Int64(int64(v))
+		}
+
+		if v, ok := tfMap["memory_reservation"].(int); ok && v != 0 {
+			apiObject.MemoryReservation = aws.Int64(int64(v))
+		}
+
+		if v, ok := tfMap["mount_point"].([]interface{}); ok {
+			for _, tfMapRaw := range v {
+				tfMap := tfMapRaw.(map[string]interface{})
+
+				mountPoint := &ecs.MountPoint{
+					ContainerPath: aws.String(tfMap["container_path"].(string)),
+					SourceVolume:  aws.String(tfMap["source_volume"].(string)),
+				}
+
+				if v, ok := tfMap["read_only"].(bool); ok && v {
+					mountPoint.ReadOnly = aws.Bool(v)
+				}
+
+				apiObject.MountPoints = append(apiObject.MountPoints, mountPoint)
+			}
+		}
+
+		if v, ok := tfMap["port_mapping"].([]interface{}); ok {
+			for _, tfMapRaw := range v {
+				tfMap := tfMapRaw.(map[string]interface{})
+				containerPort := int64(tfMap["container_port"].(int))
+
+				portMapping := &ecs.PortMapping{
+					ContainerPort: aws.Int64(containerPort),
+					Protocol:      aws.String(tfMap["protocol"].(string)),
+				}
+
+				if v, ok := tfMap["host_port"].(int); ok && v != 0 {
+					portMapping.HostPort = aws.Int64(int64(v))
+				} else if networkMode == ecs.NetworkModeAwsvpc || networkMode == ecs.NetworkModeHost {
+					// The host port of the awsvpc and host network modes is the container port.
+					portMapping.HostPort = aws.Int64(containerPort)
+				}
+
+				apiObject.PortMappings = append(apiObject.PortMappings, portMapping)
+			}
+		}
+
+		if v, ok := tfMap["privileged"].(bool); ok && v {
+			apiObject.Privileged = aws.Bool(v)
+		}
+
+		if v, ok := tfMap["readonly_root_filesystem"].(bool); ok && v {
+			apiObject.ReadonlyRootFilesystem = aws.Bool(v)
+		}
+
+		apiObject.Secrets = expandContainerDefinitionDocumentSecrets(tfMap["secret"].([]interface{}))
+
+		if v, ok := tfMap["start_timeout"].(int); ok && v != 0 {
+			apiObject.StartTimeout = aws.Int64(int64(v))
+		}
+
+		if v, ok := tfMap["stop_timeout"].(int); ok && v != 0 {
+			apiObject.StopTimeout = aws.Int64(int64(v))
+		}
+
+		if v, ok := tfMap["ulimit"].([]interface{}); ok {
+			for _, tfMapRaw := range v {
+				tfMap := tfMapRaw.(map[string]interface{})
+
+				apiObject.Ulimits = append(apiObject.Ulimits, &ecs.Ulimit{
+					HardLimit: aws.Int64(int64(tfMap["hard_limit"].(int))),
+					Name:      aws.String(tfMap["name"].(string)),
+					SoftLimit: aws.Int64(int64(tfMap["soft_limit"].(int))),
+				})
+			}
+		}
+
+		if v, ok := tfMap["user"].(string); ok && v != "" {
+			apiObject.User = aws.String(v)
+		}
+
+		if v, ok := tfMap["volumes_from"].([]interface{}); ok {
+			for _, tfMapRaw := range v {
+				tfMap := tfMapRaw.(map[string]interface{})
+
+				volumeFrom := &ecs.VolumeFrom{
+					SourceContainer: aws.String(tfMap["source_container"].(string)),
+				}
+
+				if v, ok := tfMap["read_only"].(bool); ok && v {
+					volumeFrom.ReadOnly = aws.Bool(v)
+				}
+
+				apiObject.VolumesFrom = append(apiObject.VolumesFrom, volumeFrom)
+			}
+		}
+
+		if v, ok := tfMap["working_directory"].(string); ok && v != "" {
+			apiObject.WorkingDirectory = aws.String(v)
+		}
+
+		apiObjects = append(apiObjects, apiObject)
+	}
+
+	return apiObjects
+}
+
+func expandContainerDefinitionDocumentSecrets(tfList []interface{}) []*ecs.Secret {
+	var apiObjects []*ecs.Secret
+
+	for _, tfMapRaw := range tfList {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		apiObjects = append(apiObjects, &ecs.Secret{
+			Name:      aws.String(tfMap["name"].(string)),
+			ValueFrom: aws.String(tfMap["value_from"].(string)),
+		})
+	}
+
+	return apiObjects
+}
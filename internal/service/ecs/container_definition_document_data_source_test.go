@@ -0,0 +1,213 @@
+package ecs_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ecs"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+)
+
+func TestAccECSContainerDefinitionDocumentDataSource_basic(t *testing.T) {
+	var def ecs.TaskDefinition
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	dataSourceName := "data.aws_ecs_container_definition_document.test"
+	resourceName := "aws_ecs_task_definition.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, ecs.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckTaskDefinitionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccContainerDefinitionDocumentDataSourceConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTaskDefinitionExists(resourceName, &def),
+					resource.TestCheckResourceAttr(dataSourceName, "json", testAccContainerDefinitionDocumentExpectedJSON),
+				),
+			},
+			{
+				Config:   testAccContainerDefinitionDocumentDataSourceConfig(rName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccECSContainerDefinitionDocumentDataSource_invalid(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { acctest.PreCheck(t) },
+		ErrorCheck: acctest.ErrorCheck(t, ecs.EndpointsID),
+		Providers:  acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccContainerDefinitionDocumentDataSourceConfig_invalid,
+				ExpectError: regexp.MustCompile(`dependency on undefined container \(database\)`),
+			},
+		},
+	})
+}
+
+const testAccContainerDefinitionDocumentExpectedJSON = `[
+  {
+    "cpu": 128,
+    "dependsOn": [
+      {
+        "condition": "START",
+        "containerName": "log_router"
+      }
+    ],
+    "environment": [
+      {
+        "name": "A",
+        "value": "1"
+      },
+      {
+        "name": "B",
+        "value": "2"
+      }
+    ],
+    "essential": true,
+    "healthCheck": {
+      "command": [
+        "CMD-SHELL",
+        "curl -f http://localhost/ || exit 1"
+      ],
+      "interval": 30,
+      "retries": 3,
+      "timeout": 5
+    },
+    "image": "nginx:latest",
+    "logConfiguration": {
+      "logDriver": "awsfirelens",
+      "options": {
+        "Name": "cloudwatch",
+        "auto_create_group": "true",
+        "log_group_name": "/ecs/example"
+      }
+    },
+    "memory": 256,
+    "mountPoints": [
+      {
+        "containerPath": "/data",
+        "sourceVolume": "data"
+      }
+    ],
+    "name": "app",
+    "portMappings": [
+      {
+        "containerPort": 80,
+        "hostPort": 80,
+        "protocol": "tcp"
+      }
+    ],
+    "ulimits": [
+      {
+        "hardLimit": 2048,
+        "name": "nofile",
+        "softLimit": 1024
+      }
+    ]
+  },
+  {
+    "essential": true,
+    "firelensConfiguration": {
+      "type": "fluentbit"
+    },
+    "image": "amazon/aws-for-fluent-bit:latest",
+    "memoryReservation": 50,
+    "name": "log_router"
+  }
+]`
+
+func testAccContainerDefinitionDocumentDataSourceConfig(rName string) string {
+	return fmt.Sprintf(`
+data "aws_ecs_container_definition_document" "test" {
+  network_mode = "awsvpc"
+
+  container {
+    name   = "app"
+    image  = "nginx:latest"
+    cpu    = 128
+    memory = 256
+
+    environment = {
+      B = "2"
+      A = "1"
+    }
+
+    port_mapping {
+      container_port = 80
+    }
+
+    health_check {
+      command = ["CMD-SHELL", "curl -f http://localhost/ || exit 1"]
+    }
+
+    depends_on {
+      container_name = "log_router"
+      condition      = "START"
+    }
+
+    log_configuration {
+      log_driver = "awsfirelens"
+
+      options = {
+        Name              = "cloudwatch"
+        log_group_name    = "/ecs/example"
+        auto_create_group = "true"
+      }
+    }
+
+    mount_point {
+      source_volume  = "data"
+      container_path = "/data"
+    }
+
+    ulimit {
+      name       = "nofile"
+      soft_limit = 1024
+      hard_limit = 2048
+    }
+  }
+
+  container {
+    name               = "log_router"
+    image              = "amazon/aws-for-fluent-bit:latest"
+    memory_reservation = 50
+
+    firelens_configuration {
+      type = "fluentbit"
+    }
+  }
+}
+
+resource "aws_ecs_task_definition" "test" {
+  family                = %[1]q
+  network_mode          = "awsvpc"
+  container_definitions = data.aws_ecs_container_definition_document.test.json
+
+  volume {
+    name = "data"
+  }
+}
+`, rName)
+}
+
+const testAccContainerDefinitionDocumentDataSourceConfig_invalid = `
+data "aws_ecs_container_definition_document" "test" {
+  container {
+    name  = "app"
+    image = "nginx:latest"
+
+    depends_on {
+      container_name = "database"
+      condition      = "HEALTHY"
+    }
+  }
+}
+`
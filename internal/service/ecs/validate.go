@@ -2,6 +2,11 @@ package ecs
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	multierror "github.com/hashicorp/go-multierror"
 )
 
 // Validates that ECS Placement Constraints are set correctly
@@ -47,3 +52,223 @@ func validPlacementStrategy(stratType, stratField string) error {
 	}
 	return nil
 }
+
+// validContainerDefinitions validates the container definitions of a task definition with the given network mode,
+// including the references between its containers.
+func validContainerDefinitions(definitions []*ecs.ContainerDefinition, networkMode string) error {
+	var errs []error
+	containers := make(map[string]*ecs.ContainerDefinition)
+	essential, firelens := 0, 0
+
+	for _, def := range definitions {
+		name := aws.StringValue(def.Name)
+
+		if _, ok := containers[name]; ok {
+			errs = append(errs, fmt.Errorf("container (%s): duplicate container name", name))
+		}
+
+		containers[name] = def
+
+		if aws.BoolValue(def.Essential) {
+			essential++
+		}
+
+		if def.FirelensConfiguration != nil {
+			firelens++
+		}
+	}
+
+	if essential == 0 {
+		errs = append(errs, fmt.Errorf("at least one container must be essential"))
+	}
+
+	if firelens > 1 {
+		errs = append(errs, fmt.Errorf("at most one container may have a FireLens configuration, got %d", firelens))
+	}
+
+	for _, def := range definitions {
+		name := aws.StringValue(def.Name)
+
+		for _, err := range validContainerDefinition(def, containers, networkMode, firelens > 0) {
+			errs = append(errs, fmt.Errorf("container (%s): %w", name, err))
+		}
+	}
+
+	if err := validContainerDependencyCycles(definitions); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return &multierror.Error{Errors: errs}
+	}
+
+	return nil
+}
+
+func validContainerDefinition(def *ecs.ContainerDefinition, containers map[string]*ecs.ContainerDefinition, networkMode string, firelens bool) []error {
+	var errs []error
+	name := aws.StringValue(def.Name)
+
+	if def.Memory != nil && def.MemoryReservation != nil && aws.Int64Value(def.MemoryReservation) > aws.Int64Value(def.Memory) {
+		errs = append(errs, fmt.Errorf("memory_reservation (%d) must not be greater than memory (%d)", aws.Int64Value(def.MemoryReservation), aws.Int64Value(def.Memory)))
+	}
+
+	if networkMode == ecs.NetworkModeNone && len(def.PortMappings) > 0 {
+		errs = append(errs, fmt.Errorf("port mappings are not supported with network mode (%s)", networkMode))
+	}
+
+	portMappings := make(map[string]bool)
+
+	for _, pm := range def.PortMappings {
+		containerPort, hostPort := aws.Int64Value(pm.ContainerPort), aws.Int64Value(pm.HostPort)
+		key := fmt.Sprintf("%d/%s", containerPort, aws.StringValue(pm.Protocol))
+
+		if portMappings[key] {
+			errs = append(errs, fmt.Errorf("duplicate port mapping (%s)", key))
+		}
+
+		portMappings[key] = true
+
+		if (networkMode == ecs.NetworkModeAwsvpc || networkMode == ecs.NetworkModeHost) && hostPort != 0 && hostPort != containerPort {
+			errs = append(errs, fmt.Errorf("host port (%d) must be equal to container port (%d) with network mode (%s)", hostPort, containerPort, networkMode))
+		}
+	}
+
+	secrets := make(map[string]bool)
+
+	for _, secret := range def.Secrets {
+		secretName := aws.StringValue(secret.Name)
+
+		if secrets[secretName] {
+			errs = append(errs, fmt.Errorf("duplicate secret (%s)", secretName))
+		}
+
+		secrets[secretName] = true
+	}
+
+	containerPaths := make(map[string]bool)
+
+	for _, mp := range def.MountPoints {
+		containerPath := aws.StringValue(mp.ContainerPath)
+
+		if containerPaths[containerPath] {
+			errs = append(errs, fmt.Errorf("duplicate mount point container path (%s)", containerPath))
+		}
+
+		containerPaths[containerPath] = true
+	}
+
+	ulimits := make(map[string]bool)
+
+	for _, ulimit := range def.Ulimits {
+		ulimitName := aws.StringValue(ulimit.Name)
+
+		if ulimits[ulimitName] {
+			errs = append(errs, fmt.Errorf("duplicate ulimit (%s)", ulimitName))
+		}
+
+		ulimits[ulimitName] = true
+
+		if aws.Int64Value(ulimit.SoftLimit) > aws.Int64Value(ulimit.HardLimit) {
+			errs = append(errs, fmt.Errorf("ulimit (%s) soft limit must not be greater than hard limit", ulimitName))
+		}
+	}
+
+	if hc := def.HealthCheck; hc != nil && len(hc.Command) > 0 {
+		switch command := aws.StringValue(hc.Command[0]); command {
+		case "CMD", "CMD-SHELL", "NONE":
+		default:
+			errs = append(errs, fmt.Errorf("health check command must start with CMD, CMD-SHELL or NONE, got %s", command))
+		}
+	}
+
+	for _, dependency := range def.DependsOn {
+		containerName, condition := aws.StringValue(dependency.ContainerName), aws.StringValue(dependency.Condition)
+		target, ok := containers[containerName]
+
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("dependency on undefined container (%s)", containerName))
+		case containerName == name:
+			errs = append(errs, fmt.Errorf("container cannot depend on itself"))
+		case condition == ecs.ContainerConditionHealthy && target.HealthCheck == nil:
+			errs = append(errs, fmt.Errorf("dependency condition (%s) requires container (%s) to have a health check", condition, containerName))
+		case (condition == ecs.ContainerConditionComplete || condition == ecs.ContainerConditionSuccess) && aws.BoolValue(target.Essential):
+			errs = append(errs, fmt.Errorf("dependency condition (%s) requires container (%s) not to be essential", condition, containerName))
+		}
+	}
+
+	for _, vf := range def.VolumesFrom {
+		sourceContainer := aws.StringValue(vf.SourceContainer)
+
+		if _, ok := containers[sourceContainer]; !ok {
+			errs = append(errs, fmt.Errorf("volumes from undefined container (%s)", sourceContainer))
+		} else if sourceContainer == name {
+			errs = append(errs, fmt.Errorf("container cannot mount volumes from itself"))
+		}
+	}
+
+	if lc := def.LogConfiguration; lc != nil && aws.StringValue(lc.LogDriver) == ecs.LogDriverAwsfirelens {
+		if !firelens {
+			errs = append(errs, fmt.Errorf("log driver (%s) requires a container with a FireLens configuration", ecs.LogDriverAwsfirelens))
+		} else if def.FirelensConfiguration != nil {
+			errs = append(errs, fmt.Errorf("FireLens container cannot use log driver (%s)", ecs.LogDriverAwsfirelens))
+		}
+	}
+
+	return errs
+}
+
+// validContainerDependencyCycles returns an error if container dependencies form a cycle.
+func validContainerDependencyCycles(definitions []*ecs.ContainerDefinition) error {
+	dependencies := make(map[string][]string)
+
+	for _, def := range definitions {
+		for _, dependency := range def.DependsOn {
+			dependencies[aws.StringValue(def.Name)] = append(dependencies[aws.StringValue(def.Name)], aws.StringValue(dependency.ContainerName))
+		}
+	}
+
+	// Containers that are not in state are unvisited.
+	const (
+		visiting = iota + 1
+		visited
+	)
+
+	state := make(map[string]int)
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("container dependency cycle: %s", strings.Join(append(path, name), " -> "))
+		case visited:
+			return nil
+		}
+
+		state[name] = visiting
+
+		for _, dependency := range dependencies[name] {
+			// Self dependencies are reported separately.
+			if dependency == name {
+				continue
+			}
+
+			if err := visit(dependency, append(path, name)); err != nil {
+				return err
+			}
+		}
+
+		state[name] = visited
+
+		return nil
+	}
+
+	for _, def := range definitions {
+		if err := visit(aws.StringValue(def.Name), nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -2,6 +2,9 @@ package ecs
 
 import (
 	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ecs"
+	multierror "github.com/hashicorp/go-multierror"
 )
 
 func TestValidPlacementConstraint(t *testing.T) {
@@ -86,3 +89,141 @@ func TestValidPlacementStrategy(t *testing.T) {
 		}
 	}
 }
+
+func TestValidContainerDefinitions(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		Definitions string
+		NetworkMode string
+		ErrorCount  int
+	}{
+		{
+			Name: "valid",
+			Definitions: `[
+  {"name": "app", "image": "app", "essential": true, "memory": 512, "memoryReservation": 256,
+   "portMappings": [{"containerPort": 80, "hostPort": 80, "protocol": "tcp"}, {"containerPort": 80, "protocol": "udp"}],
+   "dependsOn": [{"containerName": "init", "condition": "SUCCESS"}, {"containerName": "proxy", "condition": "HEALTHY"}],
+   "logConfiguration": {"logDriver": "awsfirelens"},
+   "volumesFrom": [{"sourceContainer": "init"}]},
+  {"name": "init", "image": "init", "essential": false},
+  {"name": "proxy", "image": "proxy", "essential": true, "healthCheck": {"command": ["CMD-SHELL", "true"]}},
+  {"name": "log_router", "image": "fluent-bit", "essential": true, "firelensConfiguration": {"type": "fluentbit"}}
+]`,
+			NetworkMode: ecs.NetworkModeAwsvpc,
+		},
+		{
+			Name:        "duplicate names and no essential container",
+			Definitions: `[{"name": "app", "image": "app", "essential": false}, {"name": "app", "image": "app", "essential": false}]`,
+			NetworkMode: ecs.NetworkModeBridge,
+			ErrorCount:  2,
+		},
+		{
+			Name:        "memory",
+			Definitions: `[{"name": "app", "image": "app", "essential": true, "memory": 128, "memoryReservation": 256}]`,
+			NetworkMode: ecs.NetworkModeBridge,
+			ErrorCount:  1,
+		},
+		{
+			Name:        "port mappings",
+			Definitions: `[{"name": "app", "image": "app", "essential": true, "portMappings": [{"containerPort": 80, "hostPort": 8080, "protocol": "tcp"}, {"containerPort": 80, "protocol": "tcp"}]}]`,
+			NetworkMode: ecs.NetworkModeAwsvpc,
+			ErrorCount:  2,
+		},
+		{
+			Name:        "port mappings with bridge network mode",
+			Definitions: `[{"name": "app", "image": "app", "essential": true, "portMappings": [{"containerPort": 80, "hostPort": 8080, "protocol": "tcp"}]}]`,
+			NetworkMode: ecs.NetworkModeBridge,
+		},
+		{
+			Name:        "port mappings with none network mode",
+			Definitions: `[{"name": "app", "image": "app", "essential": true, "portMappings": [{"containerPort": 80, "protocol": "tcp"}]}]`,
+			NetworkMode: ecs.NetworkModeNone,
+			ErrorCount:  1,
+		},
+		{
+			Name: "duplicates",
+			Definitions: `[{"name": "app", "image": "app", "essential": true,
+  "secrets": [{"name": "A", "valueFrom": "a"}, {"name": "A", "valueFrom": "b"}],
+  "mountPoints": [{"sourceVolume": "a", "containerPath": "/data"}, {"sourceVolume": "b", "containerPath": "/data"}],
+  "ulimits": [{"name": "nofile", "softLimit": 1024, "hardLimit": 2048}, {"name": "nofile", "softLimit": 4096, "hardLimit": 2048}]}]`,
+			NetworkMode: ecs.NetworkModeBridge,
+			ErrorCount:  4,
+		},
+		{
+			Name:        "health check command",
+			Definitions: `[{"name": "app", "image": "app", "essential": true, "healthCheck": {"command": ["curl", "localhost"]}}]`,
+			NetworkMode: ecs.NetworkModeBridge,
+			ErrorCount:  1,
+		},
+		{
+			Name: "dependencies",
+			Definitions: `[
+  {"name": "app", "image": "app", "essential": true, "dependsOn": [
+    {"containerName": "missing", "condition": "START"},
+    {"containerName": "app", "condition": "START"},
+    {"containerName": "sidecar", "condition": "HEALTHY"},
+    {"containerName": "sidecar", "condition": "COMPLETE"}
+  ], "volumesFrom": [{"sourceContainer": "missing"}, {"sourceContainer": "app"}]},
+  {"name": "sidecar", "image": "sidecar", "essential": true}
+]`,
+			NetworkMode: ecs.NetworkModeBridge,
+			ErrorCount:  6,
+		},
+		{
+			Name: "dependency cycle",
+			Definitions: `[
+  {"name": "a", "image": "a", "essential": true, "dependsOn": [{"containerName": "b", "condition": "START"}]},
+  {"name": "b", "image": "b", "essential": true, "dependsOn": [{"containerName": "c", "condition": "START"}]},
+  {"name": "c", "image": "c", "essential": true, "dependsOn": [{"containerName": "a", "condition": "START"}]}
+]`,
+			NetworkMode: ecs.NetworkModeBridge,
+			ErrorCount:  1,
+		},
+		{
+			Name: "FireLens",
+			Definitions: `[
+  {"name": "app", "image": "app", "essential": true, "logConfiguration": {"logDriver": "awsfirelens"}},
+  {"name": "log_router", "image": "fluent-bit", "essential": true, "firelensConfiguration": {"type": "fluentbit"}, "logConfiguration": {"logDriver": "awsfirelens"}},
+  {"name": "log_router2", "image": "fluentd", "essential": true, "firelensConfiguration": {"type": "fluentd"}}
+]`,
+			NetworkMode: ecs.NetworkModeBridge,
+			ErrorCount:  2,
+		},
+		{
+			Name:        "FireLens container missing",
+			Definitions: `[{"name": "app", "image": "app", "essential": true, "logConfiguration": {"logDriver": "awsfirelens"}}]`,
+			NetworkMode: ecs.NetworkModeBridge,
+			ErrorCount:  1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			definitions, err := expandEcsContainerDefinitions(testCase.Definitions)
+
+			if err != nil {
+				t.Fatalf("error expanding container definitions: %s", err)
+			}
+
+			err = validContainerDefinitions(definitions, testCase.NetworkMode)
+
+			if testCase.ErrorCount == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return
+			}
+
+			errs, ok := err.(*multierror.Error)
+
+			if !ok {
+				t.Fatalf("expected %d errors, got: %v", testCase.ErrorCount, err)
+			}
+
+			if len(errs.Errors) != testCase.ErrorCount {
+				t.Errorf("expected %d errors, got %d: %s", testCase.ErrorCount, len(errs.Errors), err)
+			}
+		})
+	}
+}
@@ -0,0 +1,297 @@
+package eks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
+	"gopkg.in/yaml.v2"
+)
+
+// The aws-auth ConfigMap is read by the AWS IAM Authenticator running in the EKS control plane.
+// See https://docs.aws.amazon.com/eks/latest/userguide/add-user-role.html.
+const (
+	awsAuthConfigMapName      = "aws-auth"
+	awsAuthConfigMapNamespace = "kube-system"
+
+	awsAuthConflictTimeout = 2 * time.Minute
+)
+
+// awsAuthMapping is a single mapRoles or mapUsers entry.
+type awsAuthMapping struct {
+	ARN      string
+	Groups   []string
+	Username string
+}
+
+// awsAuthKeys returns the ConfigMap data key and the entry ARN key for the specified mapping type.
+func awsAuthKeys(mappingType string) (string, string) {
+	if mappingType == IAMMappingTypeUser {
+		return "mapUsers", "userarn"
+	}
+
+	return "mapRoles", "rolearn"
+}
+
+func parseAWSAuthEntries(s string) ([]yaml.MapSlice, error) {
+	var entries []yaml.MapSlice
+
+	if err := yaml.Unmarshal([]byte(s), &entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func awsAuthEntryValue(entry yaml.MapSlice, key string) (interface{}, bool) {
+	for _, item := range entry {
+		if k, ok := item.Key.(string); ok && k == key {
+			return item.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+func awsAuthEntryIndex(entries []yaml.MapSlice, arnKey, arn string) int {
+	for i, entry := range entries {
+		if v, ok := awsAuthEntryValue(entry, arnKey); ok && v == arn {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// FindAWSAuthMapping returns the mapping for the specified ARN from aws-auth ConfigMap data.
+func FindAWSAuthMapping(data map[string]string, mappingType, arn string) (*awsAuthMapping, error) {
+	dataKey, arnKey := awsAuthKeys(mappingType)
+
+	entries, err := parseAWSAuthEntries(data[dataKey])
+
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", dataKey, err)
+	}
+
+	i := awsAuthEntryIndex(entries, arnKey, arn)
+
+	if i == -1 {
+		return nil, &resource.NotFoundError{}
+	}
+
+	mapping := &awsAuthMapping{ARN: arn}
+
+	if v, ok := awsAuthEntryValue(entries[i], "username"); ok {
+		mapping.Username, _ = v.(string)
+	}
+
+	if v, ok := awsAuthEntryValue(entries[i], "groups"); ok {
+		if v, ok := v.([]interface{}); ok {
+			for _, v := range v {
+				if v, ok := v.(string); ok {
+					mapping.Groups = append(mapping.Groups, v)
+				}
+			}
+		}
+	}
+
+	return mapping, nil
+}
+
+// putAWSAuthMapping adds the mapping to aws-auth ConfigMap data, or replaces the existing entry for the same ARN in place.
+// Entries for other ARNs are left untouched.
+func putAWSAuthMapping(data map[string]string, mappingType string, mapping *awsAuthMapping) error {
+	dataKey, arnKey := awsAuthKeys(mappingType)
+
+	entries, err := parseAWSAuthEntries(data[dataKey])
+
+	if err != nil {
+		return fmt.Errorf("error parsing %s: %w", dataKey, err)
+	}
+
+	entry := yaml.MapSlice{
+		{Key: arnKey, Value: mapping.ARN},
+		{Key: "username", Value: mapping.Username},
+	}
+
+	if len(mapping.Groups) > 0 {
+		entry = append(entry, yaml.MapItem{Key: "groups", Value: mapping.Groups})
+	}
+
+	if i := awsAuthEntryIndex(entries, arnKey, mapping.ARN); i == -1 {
+		entries = append(entries, entry)
+	} else {
+		entries[i] = entry
+	}
+
+	return setAWSAuthEntries(data, dataKey, entries)
+}
+
+// removeAWSAuthMapping removes the entry for the specified ARN from aws-auth ConfigMap data.
+func removeAWSAuthMapping(data map[string]string, mappingType, arn string) error {
+	dataKey, arnKey := awsAuthKeys(mappingType)
+
+	entries, err := parseAWSAuthEntries(data[dataKey])
+
+	if err != nil {
+		return fmt.Errorf("error parsing %s: %w", dataKey, err)
+	}
+
+	i := awsAuthEntryIndex(entries, arnKey, arn)
+
+	if i == -1 {
+		return nil
+	}
+
+	entries = append(entries[:i], entries[i+1:]...)
+
+	return setAWSAuthEntries(data, dataKey, entries)
+}
+
+func setAWSAuthEntries(data map[string]string, dataKey string, entries []yaml.MapSlice) error {
+	if len(entries) == 0 {
+		delete(data, dataKey)
+
+		return nil
+	}
+
+	b, err := yaml.Marshal(entries)
+
+	if err != nil {
+		return err
+	}
+
+	data[dataKey] = string(b)
+
+	return nil
+}
+
+// readAWSAuthConfigMapData returns the data of the aws-auth ConfigMap or a resource.NotFoundError.
+func readAWSAuthConfigMapData(ctx context.Context, client *kubernetesClient) (map[string]string, error) {
+	cm, err := client.GetConfigMap(ctx, awsAuthConfigMapNamespace, awsAuthConfigMapName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cm.Data(), nil
+}
+
+// modifyAWSAuthConfigMap applies f to the data of the aws-auth ConfigMap and writes the result back.
+// The update is conditional on the ConfigMap's resourceVersion, so concurrent modifications are not lost:
+// on conflict the ConfigMap is re-read and f is applied again.
+// If the ConfigMap does not exist it is created only when create is true.
+func modifyAWSAuthConfigMap(ctx context.Context, client *kubernetesClient, create bool, f func(map[string]string) error) error {
+	_, err := tfresource.RetryWhenContext(ctx, awsAuthConflictTimeout,
+		func() (interface{}, error) {
+			cm, err := client.GetConfigMap(ctx, awsAuthConfigMapNamespace, awsAuthConfigMapName)
+
+			if tfresource.NotFound(err) {
+				if !create {
+					return nil, nil
+				}
+
+				cm = newKubernetesConfigMap(awsAuthConfigMapNamespace, awsAuthConfigMapName)
+				data := make(map[string]string)
+
+				if err := f(data); err != nil {
+					return nil, err
+				}
+
+				cm.SetData(data)
+
+				return nil, client.CreateConfigMap(ctx, awsAuthConfigMapNamespace, cm)
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			data := cm.Data()
+
+			if err := f(data); err != nil {
+				return nil, err
+			}
+
+			cm.SetData(data)
+
+			return nil, client.UpdateConfigMap(ctx, awsAuthConfigMapNamespace, awsAuthConfigMapName, cm)
+		},
+		func(err error) (bool, error) {
+			if kubernetesErrStatusEquals(err, http.StatusConflict) {
+				return true, err
+			}
+
+			return false, err
+		},
+	)
+
+	return err
+}
+
+// newKubernetesClientForCluster returns a Kubernetes API client for the specified EKS cluster,
+// authenticated with a token generated from the provider's credentials.
+func newKubernetesClientForCluster(meta interface{}, clusterName string) (*kubernetesClient, error) {
+	conn := meta.(*conns.AWSClient).EKSConn
+	stsConn := meta.(*conns.AWSClient).STSConn
+
+	cluster, err := FindClusterByName(conn, clusterName)
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading EKS Cluster (%s): %w", clusterName, err)
+	}
+
+	generator, err := NewGenerator(false, false)
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting token generator: %w", err)
+	}
+
+	token, err := generator.GetWithSTS(clusterName, stsConn)
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting token for EKS Cluster (%s): %w", clusterName, err)
+	}
+
+	var caData string
+
+	if cluster.CertificateAuthority != nil {
+		caData = aws.StringValue(cluster.CertificateAuthority.Data)
+	}
+
+	return newKubernetesClient(aws.StringValue(cluster.Endpoint), caData, token.Token)
+}
+
+// FindIAMMappingByID returns the aws-auth ConfigMap entry for the specified aws_eks_iam_mapping resource ID.
+func FindIAMMappingByID(ctx context.Context, meta interface{}, id string) (*awsAuthMapping, error) {
+	clusterName, mappingARN, err := IAMMappingParseResourceID(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	mappingType, err := iamMappingTypeFromARN(mappingARN)
+
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newKubernetesClientForCluster(meta, clusterName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := readAWSAuthConfigMapData(ctx, client)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return FindAWSAuthMapping(data, mappingType, mappingARN)
+}
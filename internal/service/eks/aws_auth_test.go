@@ -0,0 +1,353 @@
+package eks
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
+)
+
+// fakeKubernetesServer is a minimal stand-in for the Kubernetes API server that stores ConfigMaps
+// in the kube-system namespace and enforces resourceVersion preconditions on update.
+type fakeKubernetesServer struct {
+	sync.Mutex
+
+	configMaps map[string]map[string]interface{}
+	conflicts  int // Number of updates to reject with 409 Conflict before accepting.
+	version    int
+	server     *httptest.Server
+	token      string
+}
+
+func newFakeKubernetesServer(t *testing.T) *fakeKubernetesServer {
+	t.Helper()
+
+	f := &fakeKubernetesServer{
+		configMaps: make(map[string]map[string]interface{}),
+		token:      "k8s-aws-v1.test",
+	}
+	f.server = httptest.NewTLSServer(http.HandlerFunc(f.handle))
+
+	t.Cleanup(f.server.Close)
+
+	return f
+}
+
+func (f *fakeKubernetesServer) client(t *testing.T) *kubernetesClient {
+	t.Helper()
+
+	pemData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: f.server.Certificate().Raw})
+	client, err := newKubernetesClient(f.server.URL, base64.StdEncoding.EncodeToString(pemData), f.token)
+
+	if err != nil {
+		t.Fatalf("error creating client: %s", err)
+	}
+
+	return client
+}
+
+func (f *fakeKubernetesServer) put(name string, data map[string]string) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.version++
+	object := newKubernetesConfigMap(awsAuthConfigMapNamespace, name).object
+	object["metadata"].(map[string]interface{})["resourceVersion"] = strconv.Itoa(f.version)
+	(&kubernetesConfigMap{object: object}).SetData(data)
+	f.configMaps[name] = object
+}
+
+func (f *fakeKubernetesServer) data(name string) map[string]string {
+	f.Lock()
+	defer f.Unlock()
+
+	if object, ok := f.configMaps[name]; ok {
+		return (&kubernetesConfigMap{object: object}).Data()
+	}
+
+	return nil
+}
+
+func (f *fakeKubernetesServer) handle(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer "+f.token {
+		writeFakeKubernetesStatus(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	prefix := "/api/v1/namespaces/" + awsAuthConfigMapNamespace + "/configmaps"
+
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		writeFakeKubernetesStatus(w, http.StatusNotFound, "the server could not find the requested resource")
+		return
+	}
+
+	name := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, prefix), "/")
+
+	switch r.Method {
+	case http.MethodGet:
+		object, ok := f.configMaps[name]
+
+		if !ok {
+			writeFakeKubernetesStatus(w, http.StatusNotFound, `configmaps "`+name+`" not found`)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(object)
+
+	case http.MethodPost:
+		var object map[string]interface{}
+
+		if err := json.NewDecoder(r.Body).Decode(&object); err != nil {
+			writeFakeKubernetesStatus(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		name := object["metadata"].(map[string]interface{})["name"].(string)
+
+		if _, ok := f.configMaps[name]; ok {
+			writeFakeKubernetesStatus(w, http.StatusConflict, `configmaps "`+name+`" already exists`)
+			return
+		}
+
+		f.version++
+		object["metadata"].(map[string]interface{})["resourceVersion"] = strconv.Itoa(f.version)
+		f.configMaps[name] = object
+
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(object)
+
+	case http.MethodPut:
+		var object map[string]interface{}
+
+		if err := json.NewDecoder(r.Body).Decode(&object); err != nil {
+			writeFakeKubernetesStatus(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		current, ok := f.configMaps[name]
+
+		if !ok {
+			writeFakeKubernetesStatus(w, http.StatusNotFound, `configmaps "`+name+`" not found`)
+			return
+		}
+
+		if f.conflicts > 0 {
+			f.conflicts--
+
+			// Simulate a concurrent writer.
+			f.version++
+			current["metadata"].(map[string]interface{})["resourceVersion"] = strconv.Itoa(f.version)
+		}
+
+		if object["metadata"].(map[string]interface{})["resourceVersion"] != current["metadata"].(map[string]interface{})["resourceVersion"] {
+			writeFakeKubernetesStatus(w, http.StatusConflict, "the object has been modified; please apply your changes to the latest version and try again")
+			return
+		}
+
+		f.version++
+		object["metadata"].(map[string]interface{})["resourceVersion"] = strconv.Itoa(f.version)
+		f.configMaps[name] = object
+
+		_ = json.NewEncoder(w).Encode(object)
+
+	default:
+		writeFakeKubernetesStatus(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func writeFakeKubernetesStatus(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Status",
+		"code":       code,
+		"message":    message,
+		"status":     "Failure",
+	})
+}
+
+func TestKubernetesClient_unauthorized(t *testing.T) {
+	server := newFakeKubernetesServer(t)
+	client := server.client(t)
+	client.token = "invalid"
+
+	_, err := client.GetConfigMap(context.Background(), awsAuthConfigMapNamespace, awsAuthConfigMapName)
+
+	if !kubernetesErrStatusEquals(err, http.StatusUnauthorized) {
+		t.Fatalf("expected 401 error, got %v", err)
+	}
+}
+
+func TestModifyAWSAuthConfigMap_create(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeKubernetesServer(t)
+	client := server.client(t)
+
+	if _, err := readAWSAuthConfigMapData(ctx, client); !tfresource.NotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+
+	mapping := &awsAuthMapping{
+		ARN:      "arn:aws:iam::123456789012:role/test", //lintignore:AWSAT005
+		Groups:   []string{"system:masters"},
+		Username: "admin",
+	}
+
+	err := modifyAWSAuthConfigMap(ctx, client, true, func(data map[string]string) error {
+		return putAWSAuthMapping(data, IAMMappingTypeRole, mapping)
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	data, err := readAWSAuthConfigMapData(ctx, client)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got, err := FindAWSAuthMapping(data, IAMMappingTypeRole, mapping.ARN)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !reflect.DeepEqual(got, mapping) {
+		t.Errorf("expected %#v, got %#v", mapping, got)
+	}
+
+	if _, err := FindAWSAuthMapping(data, IAMMappingTypeUser, mapping.ARN); !tfresource.NotFound(err) {
+		t.Errorf("expected not found error for user mapping, got %v", err)
+	}
+}
+
+func TestModifyAWSAuthConfigMap_preservesOtherEntries(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeKubernetesServer(t)
+	client := server.client(t)
+
+	//lintignore:AWSAT005
+	server.put(awsAuthConfigMapName, map[string]string{
+		"mapRoles": `- rolearn: arn:aws:iam::123456789012:role/node
+  username: system:node:{{EC2PrivateDNSName}}
+  groups:
+  - system:bootstrappers
+  - system:nodes
+- rolearn: arn:aws:iam::123456789012:role/test
+  username: old
+`,
+		"mapAccounts": "- \"123456789012\"\n",
+	})
+
+	mapping := &awsAuthMapping{
+		ARN:      "arn:aws:iam::123456789012:role/test", //lintignore:AWSAT005
+		Username: "new",
+	}
+
+	// The first two updates lose a race with a concurrent writer.
+	server.conflicts = 2
+
+	err := modifyAWSAuthConfigMap(ctx, client, true, func(data map[string]string) error {
+		return putAWSAuthMapping(data, IAMMappingTypeRole, mapping)
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	data := server.data(awsAuthConfigMapName)
+
+	if got, err := FindAWSAuthMapping(data, IAMMappingTypeRole, mapping.ARN); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if got.Username != "new" {
+		t.Errorf("expected username new, got %s", got.Username)
+	}
+
+	node, err := FindAWSAuthMapping(data, IAMMappingTypeRole, "arn:aws:iam::123456789012:role/node") //lintignore:AWSAT005
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := []string{"system:bootstrappers", "system:nodes"}; node.Username != "system:node:{{EC2PrivateDNSName}}" || !reflect.DeepEqual(node.Groups, expected) {
+		t.Errorf("unexpected node mapping: %#v", node)
+	}
+
+	if data["mapAccounts"] != "- \"123456789012\"\n" {
+		t.Errorf("unexpected mapAccounts: %q", data["mapAccounts"])
+	}
+
+	// Remove the mapping.
+	err = modifyAWSAuthConfigMap(ctx, client, false, func(data map[string]string) error {
+		return removeAWSAuthMapping(data, IAMMappingTypeRole, mapping.ARN)
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	data = server.data(awsAuthConfigMapName)
+
+	if _, err := FindAWSAuthMapping(data, IAMMappingTypeRole, mapping.ARN); !tfresource.NotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	if _, err := FindAWSAuthMapping(data, IAMMappingTypeRole, "arn:aws:iam::123456789012:role/node"); err != nil { //lintignore:AWSAT005
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestModifyAWSAuthConfigMap_deleteMissing(t *testing.T) {
+	server := newFakeKubernetesServer(t)
+	client := server.client(t)
+
+	err := modifyAWSAuthConfigMap(context.Background(), client, false, func(data map[string]string) error {
+		return removeAWSAuthMapping(data, IAMMappingTypeUser, "arn:aws:iam::123456789012:user/test") //lintignore:AWSAT005
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if data := server.data(awsAuthConfigMapName); data != nil {
+		t.Errorf("expected no ConfigMap, got %#v", data)
+	}
+}
+
+func TestIAMMappingTypeFromARN(t *testing.T) {
+	testCases := []struct {
+		ARN      string
+		Expected string
+	}{
+		{"arn:aws:iam::123456789012:role/test", IAMMappingTypeRole},         //lintignore:AWSAT005
+		{"arn:aws:iam::123456789012:role/path/to/test", IAMMappingTypeRole}, //lintignore:AWSAT005
+		{"arn:aws:iam::123456789012:user/test", IAMMappingTypeUser},         //lintignore:AWSAT005
+		{"arn:aws-us-gov:iam::123456789012:user/test", IAMMappingTypeUser},  //lintignore:AWSAT005
+	}
+
+	for _, testCase := range testCases {
+		got, err := iamMappingTypeFromARN(testCase.ARN)
+
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", testCase.ARN, err)
+		}
+
+		if got != testCase.Expected {
+			t.Errorf("%s: expected %s, got %s", testCase.ARN, testCase.Expected, got)
+		}
+	}
+}
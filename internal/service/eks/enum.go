@@ -13,3 +13,8 @@ func Resources_Values() []string {
 		ResourcesSecrets,
 	}
 }
+
+const (
+	IAMMappingTypeRole = "role"
+	IAMMappingTypeUser = "user"
+)
@@ -0,0 +1,233 @@
+package eks
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-aws/internal/flex"
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
+)
+
+func ResourceIAMMapping() *schema.Resource {
+	return &schema.Resource{
+		CreateWithoutTimeout: resourceIAMMappingCreate,
+		ReadWithoutTimeout:   resourceIAMMappingRead,
+		UpdateWithoutTimeout: resourceIAMMappingUpdate,
+		DeleteWithoutTimeout: resourceIAMMappingDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validClusterName,
+			},
+			"groups": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+			},
+			"role_arn": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validIAMRoleARN,
+				ExactlyOneOf: []string{"role_arn", "user_arn"},
+			},
+			"user_arn": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validIAMUserARN,
+				ExactlyOneOf: []string{"role_arn", "user_arn"},
+			},
+			"username": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+		},
+	}
+}
+
+func resourceIAMMappingCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clusterName := d.Get("cluster_name").(string)
+
+	mappingType, mappingARN := IAMMappingTypeRole, d.Get("role_arn").(string)
+	if v, ok := d.GetOk("user_arn"); ok {
+		mappingType, mappingARN = IAMMappingTypeUser, v.(string)
+	}
+
+	id := IAMMappingCreateResourceID(clusterName, mappingARN)
+
+	client, err := newKubernetesClientForCluster(meta, clusterName)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	mapping := &awsAuthMapping{
+		ARN:      mappingARN,
+		Groups:   aws.StringValueSlice(flex.ExpandStringSet(d.Get("groups").(*schema.Set))),
+		Username: d.Get("username").(string),
+	}
+
+	err = modifyAWSAuthConfigMap(ctx, client, true, func(data map[string]string) error {
+		if _, err := FindAWSAuthMapping(data, mappingType, mappingARN); err == nil {
+			return fmt.Errorf("mapping already exists in %s/%s ConfigMap", awsAuthConfigMapNamespace, awsAuthConfigMapName)
+		} else if !tfresource.NotFound(err) {
+			return err
+		}
+
+		return putAWSAuthMapping(data, mappingType, mapping)
+	})
+
+	if err != nil {
+		return diag.Errorf("error creating EKS IAM Mapping (%s): %s", id, err)
+	}
+
+	d.SetId(id)
+
+	return resourceIAMMappingRead(ctx, d, meta)
+}
+
+func resourceIAMMappingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clusterName, mappingARN, err := IAMMappingParseResourceID(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	mapping, err := FindIAMMappingByID(ctx, meta, d.Id())
+
+	if !d.IsNewResource() && tfresource.NotFound(err) {
+		log.Printf("[WARN] EKS IAM Mapping (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.Errorf("error reading EKS IAM Mapping (%s): %s", d.Id(), err)
+	}
+
+	mappingType, err := iamMappingTypeFromARN(mappingARN)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("cluster_name", clusterName)
+	d.Set("groups", mapping.Groups)
+
+	if mappingType == IAMMappingTypeUser {
+		d.Set("role_arn", nil)
+		d.Set("user_arn", mapping.ARN)
+	} else {
+		d.Set("role_arn", mapping.ARN)
+		d.Set("user_arn", nil)
+	}
+
+	d.Set("username", mapping.Username)
+
+	return nil
+}
+
+func resourceIAMMappingUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clusterName, mappingARN, err := IAMMappingParseResourceID(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	mappingType, err := iamMappingTypeFromARN(mappingARN)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := newKubernetesClientForCluster(meta, clusterName)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	mapping := &awsAuthMapping{
+		ARN:      mappingARN,
+		Groups:   aws.StringValueSlice(flex.ExpandStringSet(d.Get("groups").(*schema.Set))),
+		Username: d.Get("username").(string),
+	}
+
+	err = modifyAWSAuthConfigMap(ctx, client, true, func(data map[string]string) error {
+		return putAWSAuthMapping(data, mappingType, mapping)
+	})
+
+	if err != nil {
+		return diag.Errorf("error updating EKS IAM Mapping (%s): %s", d.Id(), err)
+	}
+
+	return resourceIAMMappingRead(ctx, d, meta)
+}
+
+func resourceIAMMappingDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clusterName, mappingARN, err := IAMMappingParseResourceID(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	mappingType, err := iamMappingTypeFromARN(mappingARN)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := newKubernetesClientForCluster(meta, clusterName)
+
+	if tfresource.NotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Deleting EKS IAM Mapping: %s", d.Id())
+	err = modifyAWSAuthConfigMap(ctx, client, false, func(data map[string]string) error {
+		return removeAWSAuthMapping(data, mappingType, mappingARN)
+	})
+
+	if err != nil {
+		return diag.Errorf("error deleting EKS IAM Mapping (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// iamMappingTypeFromARN returns whether the ARN of an IAM principal is mapped as a user or as a role.
+func iamMappingTypeFromARN(v string) (string, error) {
+	parsedARN, err := arn.Parse(v)
+
+	if err != nil {
+		return "", fmt.Errorf("error parsing ARN (%s): %w", v, err)
+	}
+
+	if strings.HasPrefix(parsedARN.Resource, "user/") {
+		return IAMMappingTypeUser, nil
+	}
+
+	return IAMMappingTypeRole, nil
+}
@@ -0,0 +1,169 @@
+package eks_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/eks"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+	tfeks "github.com/hashicorp/terraform-provider-aws/internal/service/eks"
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
+)
+
+func TestAccEKSIAMMapping_basic(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_eks_iam_mapping.test"
+	roleResourceName := "aws_iam_role.mapped"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t); testAccPreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, eks.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckIAMMappingDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIAMMappingConfig_Role(rName, "admin", "system:masters"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIAMMappingExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "cluster_name", rName),
+					resource.TestCheckResourceAttr(resourceName, "groups.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "groups.*", "system:masters"),
+					resource.TestCheckResourceAttrPair(resourceName, "role_arn", roleResourceName, "arn"),
+					resource.TestCheckResourceAttr(resourceName, "user_arn", ""),
+					resource.TestCheckResourceAttr(resourceName, "username", "admin"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccIAMMappingConfig_Role(rName, "viewer", "view"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIAMMappingExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "groups.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "groups.*", "view"),
+					resource.TestCheckResourceAttr(resourceName, "username", "viewer"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccEKSIAMMapping_user(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_eks_iam_mapping.test"
+	userResourceName := "aws_iam_user.mapped"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t); testAccPreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, eks.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckIAMMappingDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIAMMappingConfig_User(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIAMMappingExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "groups.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "role_arn", ""),
+					resource.TestCheckResourceAttrPair(resourceName, "user_arn", userResourceName, "arn"),
+					resource.TestCheckResourceAttrPair(resourceName, "username", userResourceName, "name"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckIAMMappingExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No EKS IAM Mapping ID is set")
+		}
+
+		_, err := tfeks.FindIAMMappingByID(context.Background(), acctest.Provider.Meta(), rs.Primary.ID)
+
+		return err
+	}
+}
+
+func testAccCheckIAMMappingDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_eks_iam_mapping" {
+			continue
+		}
+
+		_, err := tfeks.FindIAMMappingByID(context.Background(), acctest.Provider.Meta(), rs.Primary.ID)
+
+		if tfresource.NotFound(err) {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return fmt.Errorf("EKS IAM Mapping %s still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccIAMMappingConfig_Base(rName string) string {
+	return acctest.ConfigCompose(testAccClusterConfig_Required(rName), `
+data "aws_caller_identity" "current" {}
+`)
+}
+
+func testAccIAMMappingConfig_Role(rName, username, group string) string {
+	return acctest.ConfigCompose(testAccIAMMappingConfig_Base(rName), fmt.Sprintf(`
+resource "aws_iam_role" "mapped" {
+  name = "%[1]s-mapped"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action    = "sts:AssumeRole"
+      Effect    = "Allow"
+      Principal = { AWS = data.aws_caller_identity.current.account_id }
+    }]
+  })
+}
+
+resource "aws_eks_iam_mapping" "test" {
+  cluster_name = aws_eks_cluster.test.name
+  role_arn     = aws_iam_role.mapped.arn
+  username     = %[2]q
+  groups       = [%[3]q]
+}
+`, rName, username, group))
+}
+
+func testAccIAMMappingConfig_User(rName string) string {
+	return acctest.ConfigCompose(testAccIAMMappingConfig_Base(rName), fmt.Sprintf(`
+resource "aws_iam_user" "mapped" {
+  name = "%[1]s-mapped"
+}
+
+resource "aws_eks_iam_mapping" "test" {
+  cluster_name = aws_eks_cluster.test.name
+  user_arn     = aws_iam_user.mapped.arn
+  username     = aws_iam_user.mapped.name
+}
+`, rName))
+}
@@ -80,3 +80,23 @@ func NodeGroupParseResourceID(id string) (string, string, error) {
 
 	return "", "", fmt.Errorf("unexpected format for ID (%[1]s), expected cluster-name%[2]snode-group-name", id, nodeGroupResourceIDSeparator)
 }
+
+// IAM ARNs contain ":", so a different separator is used.
+const iamMappingResourceIDSeparator = ","
+
+func IAMMappingCreateResourceID(clusterName, arn string) string {
+	parts := []string{clusterName, arn}
+	id := strings.Join(parts, iamMappingResourceIDSeparator)
+
+	return id
+}
+
+func IAMMappingParseResourceID(id string) (string, string, error) {
+	parts := strings.Split(id, iamMappingResourceIDSeparator)
+
+	if len(parts) == 2 && parts[0] != "" && parts[1] != "" {
+		return parts[0], parts[1], nil
+	}
+
+	return "", "", fmt.Errorf("unexpected format for ID (%[1]s), expected cluster-name%[2]siam-arn", id, iamMappingResourceIDSeparator)
+}
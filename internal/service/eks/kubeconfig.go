@@ -0,0 +1,149 @@
+package eks
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	kubeconfigAPIVersion         = "v1"
+	kubeconfigKind               = "Config"
+	kubeconfigExecAPIVersionV1B1 = "client.authentication.k8s.io/v1beta1"
+)
+
+// kubeconfig is the subset of the Kubernetes client configuration file format
+// (https://kubernetes.io/docs/concepts/configuration/organize-cluster-access-kubeconfig/)
+// needed to reach EKS clusters.
+type kubeconfig struct {
+	APIVersion     string                   `yaml:"apiVersion"`
+	Kind           string                   `yaml:"kind"`
+	Clusters       []kubeconfigNamedCluster `yaml:"clusters"`
+	Contexts       []kubeconfigNamedContext `yaml:"contexts"`
+	CurrentContext string                   `yaml:"current-context,omitempty"`
+	Preferences    map[string]interface{}   `yaml:"preferences"`
+	Users          []kubeconfigNamedUser    `yaml:"users"`
+}
+
+type kubeconfigNamedCluster struct {
+	Name    string            `yaml:"name"`
+	Cluster kubeconfigCluster `yaml:"cluster"`
+}
+
+type kubeconfigCluster struct {
+	CertificateAuthorityData string `yaml:"certificate-authority-data,omitempty"`
+	Server                   string `yaml:"server"`
+}
+
+type kubeconfigNamedContext struct {
+	Name    string            `yaml:"name"`
+	Context kubeconfigContext `yaml:"context"`
+}
+
+type kubeconfigContext struct {
+	Cluster string `yaml:"cluster"`
+	User    string `yaml:"user"`
+}
+
+type kubeconfigNamedUser struct {
+	Name string         `yaml:"name"`
+	User kubeconfigUser `yaml:"user"`
+}
+
+type kubeconfigUser struct {
+	Exec  *kubeconfigExec `yaml:"exec,omitempty"`
+	Token string          `yaml:"token,omitempty"`
+}
+
+type kubeconfigExec struct {
+	APIVersion string                 `yaml:"apiVersion"`
+	Args       []string               `yaml:"args,omitempty"`
+	Command    string                 `yaml:"command"`
+	Env        []kubeconfigExecEnvVar `yaml:"env,omitempty"`
+}
+
+type kubeconfigExecEnvVar struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+// kubeconfigEntry describes a single EKS cluster and the credentials used to reach it.
+type kubeconfigEntry struct {
+	ClusterName              string
+	ContextName              string
+	Endpoint                 string
+	CertificateAuthorityData string
+	User                     kubeconfigUser
+}
+
+// buildKubeconfig assembles a kubeconfig document with one cluster, user and context per entry.
+// The cluster and user are keyed by the context name so that multiple entries never collide.
+// If currentContext is empty the first entry's context is selected.
+func buildKubeconfig(entries []kubeconfigEntry, currentContext string) (string, error) {
+	if len(entries) == 0 {
+		return "", fmt.Errorf("at least one cluster is required")
+	}
+
+	config := kubeconfig{
+		APIVersion:  kubeconfigAPIVersion,
+		Kind:        kubeconfigKind,
+		Preferences: map[string]interface{}{},
+	}
+
+	seen := make(map[string]bool)
+
+	for _, entry := range entries {
+		if seen[entry.ContextName] {
+			return "", fmt.Errorf("duplicate context name (%s)", entry.ContextName)
+		}
+		seen[entry.ContextName] = true
+
+		config.Clusters = append(config.Clusters, kubeconfigNamedCluster{
+			Name: entry.ContextName,
+			Cluster: kubeconfigCluster{
+				CertificateAuthorityData: entry.CertificateAuthorityData,
+				Server:                   entry.Endpoint,
+			},
+		})
+		config.Users = append(config.Users, kubeconfigNamedUser{
+			Name: entry.ContextName,
+			User: entry.User,
+		})
+		config.Contexts = append(config.Contexts, kubeconfigNamedContext{
+			Name: entry.ContextName,
+			Context: kubeconfigContext{
+				Cluster: entry.ContextName,
+				User:    entry.ContextName,
+			},
+		})
+	}
+
+	if currentContext == "" {
+		currentContext = entries[0].ContextName
+	}
+
+	if !seen[currentContext] {
+		return "", fmt.Errorf("current context (%s) does not match any cluster context", currentContext)
+	}
+
+	config.CurrentContext = currentContext
+
+	b, err := yaml.Marshal(config)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// kubeconfigExecArgs returns the AWS CLI arguments that obtain a token for the specified cluster.
+func kubeconfigExecArgs(region, clusterName, roleARN string) []string {
+	args := []string{"--region", region, "eks", "get-token", "--cluster-name", clusterName}
+
+	if roleARN != "" {
+		args = append(args, "--role-arn", roleARN)
+	}
+
+	return args
+}
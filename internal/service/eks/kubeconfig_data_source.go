@@ -0,0 +1,278 @@
+package eks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	"github.com/hashicorp/terraform-provider-aws/internal/verify"
+)
+
+func DataSourceKubeconfig() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubeconfigRead,
+
+		Schema: map[string]*schema.Schema{
+			"cluster_names": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validClusterName,
+				},
+			},
+			"clusters": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"arn": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"certificate_authority_data": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"context_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"endpoint": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"context_names": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"current_context": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"exec": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api_version": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  kubeconfigExecAPIVersionV1B1,
+						},
+						"args": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"command": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "aws",
+						},
+						"env": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"profile": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"kubeconfig": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"role_arn": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: verify.ValidARN,
+			},
+		},
+	}
+}
+
+func dataSourceKubeconfigRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).EKSConn
+	region := meta.(*conns.AWSClient).Region
+
+	names := make([]string, 0)
+	for _, v := range d.Get("cluster_names").([]interface{}) {
+		names = append(names, v.(string))
+	}
+
+	contextNames := make(map[string]string)
+	for k, v := range d.Get("context_names").(map[string]interface{}) {
+		contextNames[k] = v.(string)
+	}
+
+	roleARN := d.Get("role_arn").(string)
+
+	var exec map[string]interface{}
+	if v, ok := d.GetOk("exec"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		exec = v.([]interface{})[0].(map[string]interface{})
+	}
+
+	var generator Generator
+	var stsConn *sts.STS
+
+	if exec == nil {
+		var err error
+
+		generator, err = NewGenerator(false, false)
+
+		if err != nil {
+			return fmt.Errorf("error getting token generator: %w", err)
+		}
+
+		stsConn, err = kubeconfigSTSConn(meta.(*conns.AWSClient), roleARN)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	var entries []kubeconfigEntry
+	var clusters []interface{}
+
+	for _, name := range names {
+		cluster, err := FindClusterByName(conn, name)
+
+		if err != nil {
+			return fmt.Errorf("error reading EKS Cluster (%s): %w", name, err)
+		}
+
+		contextName, ok := contextNames[name]
+		if !ok || contextName == "" {
+			contextName = aws.StringValue(cluster.Arn)
+		}
+
+		var caData string
+		if cluster.CertificateAuthority != nil {
+			caData = aws.StringValue(cluster.CertificateAuthority.Data)
+		}
+
+		entry := kubeconfigEntry{
+			ClusterName:              name,
+			ContextName:              contextName,
+			Endpoint:                 aws.StringValue(cluster.Endpoint),
+			CertificateAuthorityData: caData,
+		}
+
+		if exec == nil {
+			token, err := generator.GetWithSTS(name, stsConn)
+
+			if err != nil {
+				return fmt.Errorf("error getting token for EKS Cluster (%s): %w", name, err)
+			}
+
+			entry.User = kubeconfigUser{Token: token.Token}
+		} else {
+			entry.User = kubeconfigUser{Exec: expandKubeconfigExec(exec, region, name, roleARN)}
+		}
+
+		entries = append(entries, entry)
+		clusters = append(clusters, map[string]interface{}{
+			"arn":                        aws.StringValue(cluster.Arn),
+			"certificate_authority_data": caData,
+			"context_name":               contextName,
+			"endpoint":                   aws.StringValue(cluster.Endpoint),
+			"name":                       name,
+		})
+	}
+
+	config, err := buildKubeconfig(entries, d.Get("current_context").(string))
+
+	if err != nil {
+		return fmt.Errorf("error building kubeconfig: %w", err)
+	}
+
+	d.SetId(strings.Join(names, ","))
+
+	if err := d.Set("clusters", clusters); err != nil {
+		return fmt.Errorf("error setting clusters: %w", err)
+	}
+
+	if v := d.Get("current_context").(string); v == "" {
+		d.Set("current_context", entries[0].ContextName)
+	}
+
+	d.Set("kubeconfig", config)
+
+	return nil
+}
+
+// kubeconfigSTSConn returns the STS client used to pre-sign cluster tokens.
+// When roleARN is set the tokens are signed with credentials for that role.
+func kubeconfigSTSConn(client *conns.AWSClient, roleARN string) (*sts.STS, error) {
+	if roleARN == "" {
+		return client.STSConn, nil
+	}
+
+	sess, err := conns.NewSessionForRegion(&client.STSConn.Config, client.Region, client.TerraformVersion)
+
+	if err != nil {
+		return nil, fmt.Errorf("error creating AWS session: %w", err)
+	}
+
+	return sts.New(sess, &aws.Config{Credentials: stscreds.NewCredentials(sess, roleARN)}), nil
+}
+
+func expandKubeconfigExec(tfMap map[string]interface{}, region, clusterName, roleARN string) *kubeconfigExec {
+	apiObject := &kubeconfigExec{
+		APIVersion: tfMap["api_version"].(string),
+		Command:    tfMap["command"].(string),
+	}
+
+	if v, ok := tfMap["args"].([]interface{}); ok && len(v) > 0 {
+		for _, arg := range v {
+			apiObject.Args = append(apiObject.Args, arg.(string))
+		}
+	} else {
+		apiObject.Args = kubeconfigExecArgs(region, clusterName, roleARN)
+	}
+
+	env := make(map[string]string)
+
+	if v, ok := tfMap["env"].(map[string]interface{}); ok {
+		for k, v := range v {
+			env[k] = v.(string)
+		}
+	}
+
+	if v, ok := tfMap["profile"].(string); ok && v != "" {
+		env["AWS_PROFILE"] = v
+	}
+
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		apiObject.Env = append(apiObject.Env, kubeconfigExecEnvVar{Name: k, Value: env[k]})
+	}
+
+	return apiObject
+}
@@ -0,0 +1,84 @@
+package eks_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/eks"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+)
+
+func TestAccEKSKubeconfigDataSource_basic(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	dataSourceName := "data.aws_eks_kubeconfig.test"
+	resourceName := "aws_eks_cluster.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t); testAccPreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, eks.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubeconfigDataSourceConfig_Basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "clusters.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "clusters.0.arn", resourceName, "arn"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "clusters.0.certificate_authority_data", resourceName, "certificate_authority.0.data"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "clusters.0.context_name", resourceName, "arn"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "clusters.0.endpoint", resourceName, "endpoint"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "current_context", resourceName, "arn"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "kubeconfig"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccEKSKubeconfigDataSource_exec(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	dataSourceName := "data.aws_eks_kubeconfig.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t); testAccPreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, eks.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubeconfigDataSourceConfig_Exec(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "clusters.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "clusters.0.context_name", "test"),
+					resource.TestCheckResourceAttr(dataSourceName, "current_context", "test"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "kubeconfig"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubeconfigDataSourceConfig_Basic(rName string) string {
+	return acctest.ConfigCompose(testAccClusterConfig_Required(rName), `
+data "aws_eks_kubeconfig" "test" {
+  cluster_names = [aws_eks_cluster.test.name]
+}
+`)
+}
+
+func testAccKubeconfigDataSourceConfig_Exec(rName string) string {
+	return acctest.ConfigCompose(testAccClusterConfig_Required(rName), `
+data "aws_eks_kubeconfig" "test" {
+  cluster_names = [aws_eks_cluster.test.name]
+
+  context_names = {
+    (aws_eks_cluster.test.name) = "test"
+  }
+
+  exec {
+    profile = "default"
+  }
+}
+`)
+}
@@ -0,0 +1,118 @@
+package eks
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestBuildKubeconfig(t *testing.T) {
+	entries := []kubeconfigEntry{
+		{
+			ClusterName:              "one",
+			ContextName:              "ctx-one",
+			Endpoint:                 "https://one.example.com",
+			CertificateAuthorityData: "Q0EtT05F",
+			User:                     kubeconfigUser{Token: "k8s-aws-v1.one"},
+		},
+		{
+			ClusterName: "two",
+			ContextName: "ctx-two",
+			Endpoint:    "https://two.example.com",
+			User: kubeconfigUser{Exec: &kubeconfigExec{
+				APIVersion: kubeconfigExecAPIVersionV1B1,
+				Command:    "aws",
+				Args:       kubeconfigExecArgs("us-west-2", "two", ""), //lintignore:AWSAT003
+				Env:        []kubeconfigExecEnvVar{{Name: "AWS_PROFILE", Value: "ops"}},
+			}},
+		},
+	}
+
+	output, err := buildKubeconfig(entries, "ctx-two")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var got kubeconfig
+	if err := yaml.Unmarshal([]byte(output), &got); err != nil {
+		t.Fatalf("error parsing kubeconfig: %s", err)
+	}
+
+	if got.APIVersion != "v1" || got.Kind != "Config" {
+		t.Errorf("unexpected header: %s/%s", got.APIVersion, got.Kind)
+	}
+
+	if got.CurrentContext != "ctx-two" {
+		t.Errorf("expected current context ctx-two, got %s", got.CurrentContext)
+	}
+
+	if len(got.Clusters) != 2 || len(got.Users) != 2 || len(got.Contexts) != 2 {
+		t.Fatalf("expected 2 clusters, users and contexts, got %d, %d, %d", len(got.Clusters), len(got.Users), len(got.Contexts))
+	}
+
+	if got.Clusters[0].Cluster.Server != "https://one.example.com" || got.Clusters[0].Cluster.CertificateAuthorityData != "Q0EtT05F" {
+		t.Errorf("unexpected cluster: %#v", got.Clusters[0])
+	}
+
+	if got.Users[0].User.Token != "k8s-aws-v1.one" || got.Users[0].User.Exec != nil {
+		t.Errorf("unexpected token user: %#v", got.Users[0])
+	}
+
+	if !reflect.DeepEqual(got.Users[1].User.Exec, entries[1].User.Exec) {
+		t.Errorf("unexpected exec user: %#v", got.Users[1].User.Exec)
+	}
+
+	if got.Contexts[1].Context.Cluster != "ctx-two" || got.Contexts[1].Context.User != "ctx-two" {
+		t.Errorf("unexpected context: %#v", got.Contexts[1])
+	}
+}
+
+func TestBuildKubeconfig_errors(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		Entries        []kubeconfigEntry
+		CurrentContext string
+		ExpectedError  string
+	}{
+		{
+			Name:          "no entries",
+			ExpectedError: "at least one cluster",
+		},
+		{
+			Name:          "duplicate context",
+			Entries:       []kubeconfigEntry{{ContextName: "a"}, {ContextName: "a"}},
+			ExpectedError: "duplicate context name",
+		},
+		{
+			Name:           "unknown current context",
+			Entries:        []kubeconfigEntry{{ContextName: "a"}},
+			CurrentContext: "b",
+			ExpectedError:  "does not match",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			_, err := buildKubeconfig(testCase.Entries, testCase.CurrentContext)
+
+			if err == nil || !strings.Contains(err.Error(), testCase.ExpectedError) {
+				t.Errorf("expected error containing %q, got %v", testCase.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestKubeconfigExecArgs(t *testing.T) {
+	roleARN := "arn:aws:iam::123456789012:role/test" //lintignore:AWSAT005
+
+	got := kubeconfigExecArgs("us-east-1", "test", roleARN) //lintignore:AWSAT003
+
+	expected := []string{"--region", "us-east-1", "eks", "get-token", "--cluster-name", "test", "--role-arn", roleARN} //lintignore:AWSAT003
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
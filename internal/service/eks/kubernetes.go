@@ -0,0 +1,225 @@
+package eks
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// kubernetesClient is a minimal client for the Kubernetes API server of an EKS cluster.
+// It only supports the ConfigMap operations required to manage the aws-auth ConfigMap.
+type kubernetesClient struct {
+	endpoint   string
+	httpClient *http.Client
+	token      string
+}
+
+// kubernetesStatusError is returned when the Kubernetes API server responds with a non-success status.
+type kubernetesStatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *kubernetesStatusError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("Kubernetes API error: %s", http.StatusText(e.StatusCode))
+	}
+
+	return fmt.Sprintf("Kubernetes API error (%s): %s", http.StatusText(e.StatusCode), e.Message)
+}
+
+func kubernetesErrStatusEquals(err error, statusCode int) bool {
+	var statusErr *kubernetesStatusError
+
+	return errors.As(err, &statusErr) && statusErr.StatusCode == statusCode
+}
+
+// newKubernetesClient returns a client for the API server at endpoint.
+// caData is the base64 encoded PEM certificate bundle of the cluster certificate authority.
+func newKubernetesClient(endpoint, caData, token string) (*kubernetesClient, error) {
+	httpClient := cleanhttp.DefaultPooledClient()
+	httpClient.Timeout = 1 * time.Minute
+
+	if caData != "" {
+		pem, err := base64.StdEncoding.DecodeString(caData)
+
+		if err != nil {
+			return nil, fmt.Errorf("error decoding certificate authority data: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("error parsing certificate authority data: no certificates found")
+		}
+
+		httpClient.Transport.(*http.Transport).TLSClientConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			RootCAs:    pool,
+		}
+	}
+
+	return &kubernetesClient{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		httpClient: httpClient,
+		token:      token,
+	}, nil
+}
+
+// kubernetesConfigMap is a ConfigMap object. The full object is retained so that
+// metadata (labels, annotations, resourceVersion) round-trips unchanged on update.
+type kubernetesConfigMap struct {
+	object map[string]interface{}
+}
+
+func (cm *kubernetesConfigMap) Data() map[string]string {
+	data := make(map[string]string)
+
+	if v, ok := cm.object["data"].(map[string]interface{}); ok {
+		for k, v := range v {
+			if v, ok := v.(string); ok {
+				data[k] = v
+			}
+		}
+	}
+
+	return data
+}
+
+func (cm *kubernetesConfigMap) SetData(data map[string]string) {
+	m := make(map[string]interface{}, len(data))
+
+	for k, v := range data {
+		m[k] = v
+	}
+
+	cm.object["data"] = m
+}
+
+func newKubernetesConfigMap(namespace, name string) *kubernetesConfigMap {
+	return &kubernetesConfigMap{
+		object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": namespace,
+			},
+		},
+	}
+}
+
+func (c *kubernetesClient) configMapPath(namespace, name string) string {
+	path := fmt.Sprintf("/api/v1/namespaces/%s/configmaps", url.PathEscape(namespace))
+
+	if name != "" {
+		path += "/" + url.PathEscape(name)
+	}
+
+	return path
+}
+
+// GetConfigMap returns the named ConfigMap or a resource.NotFoundError.
+func (c *kubernetesClient) GetConfigMap(ctx context.Context, namespace, name string) (*kubernetesConfigMap, error) {
+	var object map[string]interface{}
+
+	err := c.do(ctx, http.MethodGet, c.configMapPath(namespace, name), nil, &object)
+
+	if kubernetesErrStatusEquals(err, http.StatusNotFound) {
+		return nil, &resource.NotFoundError{
+			LastError: err,
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &kubernetesConfigMap{object: object}, nil
+}
+
+// CreateConfigMap creates the ConfigMap. An HTTP 409 error is returned if it already exists.
+func (c *kubernetesClient) CreateConfigMap(ctx context.Context, namespace string, cm *kubernetesConfigMap) error {
+	return c.do(ctx, http.MethodPost, c.configMapPath(namespace, ""), cm.object, nil)
+}
+
+// UpdateConfigMap replaces the ConfigMap. The object's resourceVersion is used for
+// optimistic concurrency, so an HTTP 409 error is returned if the ConfigMap was modified concurrently.
+func (c *kubernetesClient) UpdateConfigMap(ctx context.Context, namespace, name string, cm *kubernetesConfigMap) error {
+	return c.do(ctx, http.MethodPut, c.configMapPath(namespace, name), cm.object, nil)
+}
+
+func (c *kubernetesClient) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body io.Reader
+
+	if in != nil {
+		b, err := json.Marshal(in)
+
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, body)
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		statusErr := &kubernetesStatusError{StatusCode: resp.StatusCode}
+
+		// Errors are returned as a meta/v1 Status object.
+		var status struct {
+			Message string `json:"message"`
+		}
+
+		if err := json.Unmarshal(b, &status); err == nil {
+			statusErr.Message = status.Message
+		}
+
+		return statusErr
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.Unmarshal(b, out)
+}
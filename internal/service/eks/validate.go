@@ -3,6 +3,9 @@ package eks
 import (
 	"fmt"
 	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
 )
 
 func validClusterName(v interface{}, k string) (ws []string, errors []error) {
@@ -22,3 +25,30 @@ func validClusterName(v interface{}, k string) (ws []string, errors []error) {
 
 	return
 }
+
+// validIAMRoleARN validates that the value is the ARN of an IAM role, as role and user ARNs are mapped differently.
+func validIAMRoleARN(v interface{}, k string) (ws []string, errors []error) {
+	return validIAMPrincipalARN(v, k, "role")
+}
+
+// validIAMUserARN validates that the value is the ARN of an IAM user, as role and user ARNs are mapped differently.
+func validIAMUserARN(v interface{}, k string) (ws []string, errors []error) {
+	return validIAMPrincipalARN(v, k, "user")
+}
+
+func validIAMPrincipalARN(v interface{}, k string, resourceType string) (ws []string, errors []error) {
+	value := v.(string)
+
+	parsedARN, err := arn.Parse(value)
+
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q (%s) is an invalid ARN: %s", k, value, err))
+		return
+	}
+
+	if parsedARN.Service != "iam" || !strings.HasPrefix(parsedARN.Resource, resourceType+"/") {
+		errors = append(errors, fmt.Errorf("%q (%s) is not the ARN of an IAM %s", k, value, resourceType))
+	}
+
+	return
+}
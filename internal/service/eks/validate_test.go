@@ -57,3 +57,44 @@ func TestValidClusterName(t *testing.T) {
 		}
 	}
 }
+
+func TestValidIAMPrincipalARN(t *testing.T) {
+	cases := []struct {
+		Value      string
+		RoleErrors int
+		UserErrors int
+	}{
+		{
+			Value:      "arn:aws:iam::123456789012:role/test", //lintignore:AWSAT005
+			UserErrors: 1,
+		},
+		{
+			Value:      "arn:aws:iam::123456789012:role/path/test", //lintignore:AWSAT005
+			UserErrors: 1,
+		},
+		{
+			Value:      "arn:aws:iam::123456789012:user/test", //lintignore:AWSAT005
+			RoleErrors: 1,
+		},
+		{
+			Value:      "arn:aws:sts::123456789012:assumed-role/test/session", //lintignore:AWSAT005
+			RoleErrors: 1,
+			UserErrors: 1,
+		},
+		{
+			Value:      "test",
+			RoleErrors: 1,
+			UserErrors: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		if _, errors := validIAMRoleARN(tc.Value, "role_arn"); len(errors) != tc.RoleErrors {
+			t.Errorf("role_arn %s: expected %d errors, got %d: %v", tc.Value, tc.RoleErrors, len(errors), errors)
+		}
+
+		if _, errors := validIAMUserARN(tc.Value, "user_arn"); len(errors) != tc.UserErrors {
+			t.Errorf("user_arn %s: expected %d errors, got %d: %v", tc.Value, tc.UserErrors, len(errors), errors)
+		}
+	}
+}
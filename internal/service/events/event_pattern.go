@@ -0,0 +1,697 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+// Event pattern content filters.
+// https://docs.aws.amazon.com/eventbridge/latest/userguide/eb-event-patterns-content-based-filtering.html
+const (
+	eventPatternAnythingBut      = "anything-but"
+	eventPatternCIDR             = "cidr"
+	eventPatternEqualsIgnoreCase = "equals-ignore-case"
+	eventPatternExists           = "exists"
+	eventPatternNumeric          = "numeric"
+	eventPatternOr               = "$or"
+	eventPatternPrefix           = "prefix"
+	eventPatternSuffix           = "suffix"
+	eventPatternWildcard         = "wildcard"
+
+	// eventPatternMaxNumber is the maximum absolute value of numbers in numeric filters.
+	eventPatternMaxNumber = 5.0e9
+)
+
+// EventPattern is a parsed EventBridge event pattern.
+type EventPattern struct {
+	root map[string]interface{}
+}
+
+// ParseEventPattern parses and validates an EventBridge event pattern.
+func ParseEventPattern(pattern string) (*EventPattern, error) {
+	v, err := decodeEventJSON(pattern)
+
+	if err != nil {
+		return nil, fmt.Errorf("event pattern is not valid JSON: %w", err)
+	}
+
+	root, ok := v.(map[string]interface{})
+
+	if !ok {
+		return nil, fmt.Errorf("event pattern must be a JSON object")
+	}
+
+	if err := validateEventPatternObject("", root); err != nil {
+		return nil, err
+	}
+
+	return &EventPattern{root: root}, nil
+}
+
+// Match returns whether the event, a JSON object, matches the pattern.
+// As in EventBridge, arrays in the event are flattened: a field matches if any of its values matches,
+// and the fields of a pattern object may match different elements of an array of objects.
+func (p *EventPattern) Match(event string) (bool, error) {
+	v, err := decodeEventJSON(event)
+
+	if err != nil {
+		return false, fmt.Errorf("event is not valid JSON: %w", err)
+	}
+
+	object, ok := v.(map[string]interface{})
+
+	if !ok {
+		return false, fmt.Errorf("event must be a JSON object")
+	}
+
+	return matchEventPatternObject(p.root, []map[string]interface{}{object}), nil
+}
+
+func validateEventPatternObject(path string, pattern map[string]interface{}) error {
+	if len(pattern) == 0 {
+		return fmt.Errorf("%s: empty objects are not allowed", eventPatternPath(path))
+	}
+
+	for _, k := range sortedEventPatternKeys(pattern) {
+		fieldPath := joinEventPatternPath(path, k)
+
+		switch v := pattern[k].(type) {
+		case map[string]interface{}:
+			if k == eventPatternOr {
+				return fmt.Errorf("%s: must be an array of objects", fieldPath)
+			}
+
+			if err := validateEventPatternObject(fieldPath, v); err != nil {
+				return err
+			}
+		case []interface{}:
+			if len(v) == 0 {
+				return fmt.Errorf("%s: empty arrays are not allowed", fieldPath)
+			}
+
+			if k == eventPatternOr {
+				if len(v) < 2 {
+					return fmt.Errorf("%s: must contain at least 2 patterns", fieldPath)
+				}
+
+				for i, e := range v {
+					object, ok := e.(map[string]interface{})
+
+					if !ok {
+						return fmt.Errorf("%s[%d]: must be an object", fieldPath, i)
+					}
+
+					if err := validateEventPatternObject(fmt.Sprintf("%s[%d]", fieldPath, i), object); err != nil {
+						return err
+					}
+				}
+
+				continue
+			}
+
+			for i, e := range v {
+				if err := validateEventPatternMatcher(fmt.Sprintf("%s[%d]", fieldPath, i), e); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("%s: must be an object or an array", fieldPath)
+		}
+	}
+
+	return nil
+}
+
+func validateEventPatternMatcher(path string, matcher interface{}) error {
+	filter, ok := matcher.(map[string]interface{})
+
+	if !ok {
+		if _, ok := matcher.([]interface{}); ok {
+			return fmt.Errorf("%s: nested arrays are not allowed", path)
+		}
+
+		// Exact match of a string, number, boolean or null.
+		return nil
+	}
+
+	if len(filter) != 1 {
+		return fmt.Errorf("%s: content filters must have exactly one key", path)
+	}
+
+	for k, v := range filter {
+		filterPath := path + "." + k
+
+		switch k {
+		case eventPatternAnythingBut:
+			return validateEventPatternAnythingBut(filterPath, v)
+		case eventPatternCIDR:
+			s, ok := v.(string)
+
+			if !ok {
+				return fmt.Errorf("%s: must be a string", filterPath)
+			}
+
+			if _, _, err := net.ParseCIDR(s); err != nil {
+				return fmt.Errorf("%s: invalid CIDR block (%s)", filterPath, s)
+			}
+		case eventPatternEqualsIgnoreCase:
+			if _, ok := v.(string); !ok {
+				return fmt.Errorf("%s: must be a string", filterPath)
+			}
+		case eventPatternExists:
+			if _, ok := v.(bool); !ok {
+				return fmt.Errorf("%s: must be a boolean", filterPath)
+			}
+		case eventPatternNumeric:
+			_, err := parseEventPatternNumeric(v)
+
+			if err != nil {
+				return fmt.Errorf("%s: %w", filterPath, err)
+			}
+		case eventPatternPrefix, eventPatternSuffix:
+			if object, ok := v.(map[string]interface{}); ok {
+				if s, ok := object[eventPatternEqualsIgnoreCase].(string); !ok || len(object) != 1 || s == "" {
+					return fmt.Errorf("%s: must be a string or an object with a single %s string", filterPath, eventPatternEqualsIgnoreCase)
+				}
+			} else if s, ok := v.(string); !ok || s == "" {
+				return fmt.Errorf("%s: must be a non-empty string", filterPath)
+			}
+		case eventPatternWildcard:
+			s, ok := v.(string)
+
+			if !ok {
+				return fmt.Errorf("%s: must be a string", filterPath)
+			}
+
+			if strings.Contains(s, "**") {
+				return fmt.Errorf("%s: consecutive wildcard characters are not allowed", filterPath)
+			}
+		default:
+			return fmt.Errorf("%s: unsupported content filter", filterPath)
+		}
+	}
+
+	return nil
+}
+
+func validateEventPatternAnythingBut(path string, v interface{}) error {
+	switch v := v.(type) {
+	case string, json.Number:
+		return nil
+	case []interface{}:
+		if len(v) == 0 {
+			return fmt.Errorf("%s: empty arrays are not allowed", path)
+		}
+
+		for _, e := range v {
+			switch e.(type) {
+			case string, json.Number:
+			default:
+				return fmt.Errorf("%s: must contain only strings or numbers", path)
+			}
+		}
+
+		return nil
+	case map[string]interface{}:
+		if len(v) != 1 {
+			return fmt.Errorf("%s: must have exactly one of %s, %s, %s or %s", path, eventPatternPrefix, eventPatternSuffix, eventPatternEqualsIgnoreCase, eventPatternWildcard)
+		}
+
+		for k, e := range v {
+			switch k {
+			case eventPatternPrefix, eventPatternSuffix:
+				if s, ok := e.(string); !ok || s == "" {
+					return fmt.Errorf("%s.%s: must be a non-empty string", path, k)
+				}
+			case eventPatternEqualsIgnoreCase:
+				if _, ok := eventPatternStrings(e); !ok {
+					return fmt.Errorf("%s.%s: must be a string or an array of strings", path, k)
+				}
+			case eventPatternWildcard:
+				values, ok := eventPatternStrings(e)
+
+				if !ok {
+					return fmt.Errorf("%s.%s: must be a string or an array of strings", path, k)
+				}
+
+				for _, s := range values {
+					if strings.Contains(s, "**") {
+						return fmt.Errorf("%s.%s: consecutive wildcard characters are not allowed", path, k)
+					}
+				}
+			default:
+				return fmt.Errorf("%s.%s: unsupported filter", path, k)
+			}
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("%s: must be a string, a number, an array or an object", path)
+}
+
+// eventPatternNumericCondition is a numeric comparison, e.g. >= 5.
+type eventPatternNumericCondition struct {
+	operator string
+	value    float64
+}
+
+func parseEventPatternNumeric(v interface{}) ([]eventPatternNumericCondition, error) {
+	list, ok := v.([]interface{})
+
+	if !ok || (len(list) != 2 && len(list) != 4) {
+		return nil, fmt.Errorf("must be an array of one or two operator and number pairs")
+	}
+
+	var conditions []eventPatternNumericCondition
+
+	for i := 0; i < len(list); i += 2 {
+		operator, ok := list[i].(string)
+
+		if !ok {
+			return nil, fmt.Errorf("operator must be a string")
+		}
+
+		switch operator {
+		case "=", "<", "<=", ">", ">=":
+		default:
+			return nil, fmt.Errorf("unsupported operator (%s)", operator)
+		}
+
+		n, ok := list[i+1].(json.Number)
+
+		if !ok {
+			return nil, fmt.Errorf("value of operator (%s) must be a number", operator)
+		}
+
+		value, err := n.Float64()
+
+		if err != nil || value < -eventPatternMaxNumber || value > eventPatternMaxNumber {
+			return nil, fmt.Errorf("value (%s) must be a number between -%g and %g", n, eventPatternMaxNumber, eventPatternMaxNumber)
+		}
+
+		conditions = append(conditions, eventPatternNumericCondition{operator: operator, value: value})
+	}
+
+	if len(conditions) == 2 {
+		lower, upper := conditions[0].operator, conditions[1].operator
+
+		if (lower != ">" && lower != ">=") || (upper != "<" && upper != "<=") {
+			return nil, fmt.Errorf("ranges must be a lower bound (> or >=) followed by an upper bound (< or <=)")
+		}
+
+		if conditions[0].value >= conditions[1].value {
+			return nil, fmt.Errorf("lower bound must be smaller than upper bound")
+		}
+	}
+
+	return conditions, nil
+}
+
+// matchEventPatternObject returns whether the event objects at a path match a pattern object.
+func matchEventPatternObject(pattern map[string]interface{}, objects []map[string]interface{}) bool {
+	for _, k := range sortedEventPatternKeys(pattern) {
+		if k == eventPatternOr {
+			matched := false
+
+			for _, e := range pattern[k].([]interface{}) {
+				if matchEventPatternObject(e.(map[string]interface{}), objects) {
+					matched = true
+					break
+				}
+			}
+
+			if !matched {
+				return false
+			}
+
+			continue
+		}
+
+		switch v := pattern[k].(type) {
+		case map[string]interface{}:
+			var children []map[string]interface{}
+
+			for _, object := range objects {
+				children = append(children, eventObjects(object[k])...)
+			}
+
+			if !matchEventPatternObject(v, children) {
+				return false
+			}
+		case []interface{}:
+			present := false
+			var values []interface{}
+
+			for _, object := range objects {
+				if value, ok := object[k]; ok {
+					present = true
+					values = append(values, eventLeafValues(value)...)
+				}
+			}
+
+			if !matchEventPatternMatchers(v, present, values) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func matchEventPatternMatchers(matchers []interface{}, present bool, values []interface{}) bool {
+	for _, matcher := range matchers {
+		if filter, ok := matcher.(map[string]interface{}); ok {
+			if exists, ok := filter[eventPatternExists].(bool); ok {
+				if exists == present {
+					return true
+				}
+
+				continue
+			}
+		}
+
+		for _, value := range values {
+			if matchEventPatternValue(matcher, value) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func matchEventPatternValue(matcher interface{}, value interface{}) bool {
+	filter, ok := matcher.(map[string]interface{})
+
+	if !ok {
+		return eventValuesEqual(matcher, value)
+	}
+
+	s, isString := value.(string)
+
+	for k, v := range filter {
+		switch k {
+		case eventPatternAnythingBut:
+			return matchEventPatternAnythingBut(v, value)
+		case eventPatternCIDR:
+			_, network, _ := net.ParseCIDR(v.(string))
+			ip := net.ParseIP(s)
+
+			return isString && ip != nil && network.Contains(ip)
+		case eventPatternEqualsIgnoreCase:
+			return isString && strings.EqualFold(s, v.(string))
+		case eventPatternNumeric:
+			conditions, _ := parseEventPatternNumeric(v)
+
+			return matchEventPatternNumeric(conditions, value)
+		case eventPatternPrefix:
+			if object, ok := v.(map[string]interface{}); ok {
+				return isString && strings.HasPrefix(strings.ToLower(s), strings.ToLower(object[eventPatternEqualsIgnoreCase].(string)))
+			}
+
+			return isString && strings.HasPrefix(s, v.(string))
+		case eventPatternSuffix:
+			if object, ok := v.(map[string]interface{}); ok {
+				return isString && strings.HasSuffix(strings.ToLower(s), strings.ToLower(object[eventPatternEqualsIgnoreCase].(string)))
+			}
+
+			return isString && strings.HasSuffix(s, v.(string))
+		case eventPatternWildcard:
+			return isString && matchEventPatternWildcard(v.(string), s)
+		}
+	}
+
+	return false
+}
+
+func matchEventPatternAnythingBut(v interface{}, value interface{}) bool {
+	s, isString := value.(string)
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			switch k {
+			case eventPatternPrefix:
+				return isString && !strings.HasPrefix(s, e.(string))
+			case eventPatternSuffix:
+				return isString && !strings.HasSuffix(s, e.(string))
+			case eventPatternEqualsIgnoreCase:
+				if !isString {
+					return false
+				}
+
+				values, _ := eventPatternStrings(e)
+
+				for _, excluded := range values {
+					if strings.EqualFold(s, excluded) {
+						return false
+					}
+				}
+
+				return true
+			case eventPatternWildcard:
+				if !isString {
+					return false
+				}
+
+				values, _ := eventPatternStrings(e)
+
+				for _, excluded := range values {
+					if matchEventPatternWildcard(excluded, s) {
+						return false
+					}
+				}
+
+				return true
+			}
+		}
+
+		return false
+	case []interface{}:
+		for _, excluded := range v {
+			if eventValuesEqual(excluded, value) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return !eventValuesEqual(v, value)
+	}
+}
+
+func matchEventPatternNumeric(conditions []eventPatternNumericCondition, value interface{}) bool {
+	n, ok := value.(json.Number)
+
+	if !ok {
+		return false
+	}
+
+	f, err := n.Float64()
+
+	if err != nil {
+		return false
+	}
+
+	for _, c := range conditions {
+		var ok bool
+
+		switch c.operator {
+		case "=":
+			ok = f == c.value
+		case "<":
+			ok = f < c.value
+		case "<=":
+			ok = f <= c.value
+		case ">":
+			ok = f > c.value
+		case ">=":
+			ok = f >= c.value
+		}
+
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchEventPatternWildcard matches a string against a pattern where * matches any sequence of characters and \* a literal *.
+func matchEventPatternWildcard(pattern, s string) bool {
+	var parts []string
+	var sb strings.Builder
+
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case pattern[i] == '\\' && i+1 < len(pattern) && pattern[i+1] == '*':
+			sb.WriteByte('*')
+			i++
+		case pattern[i] == '*':
+			parts = append(parts, sb.String())
+			sb.Reset()
+		default:
+			sb.WriteByte(pattern[i])
+		}
+	}
+
+	parts = append(parts, sb.String())
+
+	if len(parts) == 1 {
+		return s == parts[0]
+	}
+
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+
+	s = s[len(parts[0]):]
+
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+
+		if i < 0 {
+			return false
+		}
+
+		s = s[i+len(part):]
+	}
+
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
+
+// eventValuesEqual returns whether a pattern value equals an event value. Strings are not equal to numbers.
+func eventValuesEqual(pattern, value interface{}) bool {
+	switch pattern := pattern.(type) {
+	case json.Number:
+		n, ok := value.(json.Number)
+
+		if !ok {
+			return false
+		}
+
+		a, err1 := pattern.Float64()
+		b, err2 := n.Float64()
+
+		return err1 == nil && err2 == nil && a == b
+	case string:
+		s, ok := value.(string)
+
+		return ok && s == pattern
+	case bool:
+		b, ok := value.(bool)
+
+		return ok && b == pattern
+	case nil:
+		return value == nil
+	}
+
+	return false
+}
+
+// eventObjects returns the objects of an event value, flattening arrays.
+func eventObjects(value interface{}) []map[string]interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		return []map[string]interface{}{value}
+	case []interface{}:
+		var objects []map[string]interface{}
+
+		for _, e := range value {
+			objects = append(objects, eventObjects(e)...)
+		}
+
+		return objects
+	}
+
+	return nil
+}
+
+// eventLeafValues returns the values of an event value, flattening arrays.
+func eventLeafValues(value interface{}) []interface{} {
+	list, ok := value.([]interface{})
+
+	if !ok {
+		return []interface{}{value}
+	}
+
+	var values []interface{}
+
+	for _, e := range list {
+		values = append(values, eventLeafValues(e)...)
+	}
+
+	return values
+}
+
+func eventPatternStrings(v interface{}) ([]string, bool) {
+	switch v := v.(type) {
+	case string:
+		return []string{v}, true
+	case []interface{}:
+		var values []string
+
+		for _, e := range v {
+			s, ok := e.(string)
+
+			if !ok {
+				return nil, false
+			}
+
+			values = append(values, s)
+		}
+
+		return values, len(values) > 0
+	}
+
+	return nil, false
+}
+
+func decodeEventJSON(s string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(s))
+	decoder.UseNumber()
+
+	var v interface{}
+
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after JSON value")
+	}
+
+	return v, nil
+}
+
+func sortedEventPatternKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func joinEventPatternPath(path, k string) string {
+	if path == "" {
+		return k
+	}
+
+	return path + "." + k
+}
+
+func eventPatternPath(path string) string {
+	if path == "" {
+		return "event pattern"
+	}
+
+	return path
+}
@@ -0,0 +1,203 @@
+package events
+
+import (
+	"testing"
+)
+
+func TestParseEventPattern(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		Pattern     string
+		ExpectError bool
+	}{
+		{Name: "source", Pattern: `{"source": ["aws.ec2"]}`},
+		{Name: "nested", Pattern: `{"detail": {"state": ["running", null, 5, true]}}`},
+		{Name: "prefix", Pattern: `{"source": [{"prefix": "aws."}]}`},
+		{Name: "prefix equals-ignore-case", Pattern: `{"source": [{"prefix": {"equals-ignore-case": "AWS."}}]}`},
+		{Name: "suffix", Pattern: `{"source": [{"suffix": ".png"}]}`},
+		{Name: "anything-but", Pattern: `{"source": [{"anything-but": ["aws.ec2", 5]}]}`},
+		{Name: "anything-but prefix", Pattern: `{"source": [{"anything-but": {"prefix": "aws."}}]}`},
+		{Name: "anything-but equals-ignore-case", Pattern: `{"source": [{"anything-but": {"equals-ignore-case": ["a", "b"]}}]}`},
+		{Name: "anything-but wildcard", Pattern: `{"detail": {"path": [{"anything-but": {"wildcard": "*/lib/*"}}]}}`},
+		{Name: "anything-but wildcards", Pattern: `{"detail": {"path": [{"anything-but": {"wildcard": ["*/lib/*", "*.tmp"]}}]}}`},
+		{Name: "numeric", Pattern: `{"detail": {"c": [{"numeric": [">", 0, "<=", 5]}, {"numeric": ["=", 10]}]}}`},
+		{Name: "exists", Pattern: `{"detail": {"c": [{"exists": false}]}}`},
+		{Name: "cidr", Pattern: `{"detail": {"ip": [{"cidr": "10.0.0.0/24"}, {"cidr": "2001:db8::/32"}]}}`},
+		{Name: "wildcard", Pattern: `{"detail": {"key": [{"wildcard": "dir/*.png"}]}}`},
+		{Name: "or", Pattern: `{"$or": [{"source": ["a"]}, {"detail": {"c": [1]}}]}`},
+		{Name: "invalid JSON", Pattern: `{"source": `, ExpectError: true},
+		{Name: "not an object", Pattern: `["aws.ec2"]`, ExpectError: true},
+		{Name: "empty object", Pattern: `{}`, ExpectError: true},
+		{Name: "empty nested object", Pattern: `{"detail": {}}`, ExpectError: true},
+		{Name: "scalar value", Pattern: `{"source": "aws.ec2"}`, ExpectError: true},
+		{Name: "empty array", Pattern: `{"source": []}`, ExpectError: true},
+		{Name: "nested array", Pattern: `{"source": [["aws.ec2"]]}`, ExpectError: true},
+		{Name: "unsupported filter", Pattern: `{"source": [{"regex": "a.*"}]}`, ExpectError: true},
+		{Name: "multiple filter keys", Pattern: `{"source": [{"prefix": "a", "suffix": "b"}]}`, ExpectError: true},
+		{Name: "empty prefix", Pattern: `{"source": [{"prefix": ""}]}`, ExpectError: true},
+		{Name: "numeric prefix", Pattern: `{"source": [{"prefix": 5}]}`, ExpectError: true},
+		{Name: "anything-but object", Pattern: `{"source": [{"anything-but": {"exists": true}}]}`, ExpectError: true},
+		{Name: "anything-but boolean", Pattern: `{"source": [{"anything-but": true}]}`, ExpectError: true},
+		{Name: "numeric operator", Pattern: `{"c": [{"numeric": ["!=", 5]}]}`, ExpectError: true},
+		{Name: "numeric string", Pattern: `{"c": [{"numeric": [">", "5"]}]}`, ExpectError: true},
+		{Name: "numeric range order", Pattern: `{"c": [{"numeric": ["<", 5, ">", 0]}]}`, ExpectError: true},
+		{Name: "numeric empty range", Pattern: `{"c": [{"numeric": [">", 5, "<", 0]}]}`, ExpectError: true},
+		{Name: "numeric out of range", Pattern: `{"c": [{"numeric": [">", 6e9]}]}`, ExpectError: true},
+		{Name: "exists string", Pattern: `{"c": [{"exists": "true"}]}`, ExpectError: true},
+		{Name: "cidr", Pattern: `{"c": [{"cidr": "10.0.0.0"}]}`, ExpectError: true},
+		{Name: "consecutive wildcards", Pattern: `{"c": [{"wildcard": "a**b"}]}`, ExpectError: true},
+		{Name: "anything-but consecutive wildcards", Pattern: `{"c": [{"anything-but": {"wildcard": ["a", "a**b"]}}]}`, ExpectError: true},
+		{Name: "or single pattern", Pattern: `{"$or": [{"source": ["a"]}]}`, ExpectError: true},
+		{Name: "or scalar", Pattern: `{"$or": ["a", "b"]}`, ExpectError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			_, err := ParseEventPattern(testCase.Pattern)
+
+			if err == nil && testCase.ExpectError {
+				t.Fatal("expected error, got none")
+			}
+
+			if err != nil && !testCase.ExpectError {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestEventPatternMatch(t *testing.T) {
+	event := `{
+  "source": "aws.ec2",
+  "detail-type": "EC2 Instance State-change Notification",
+  "detail": {
+    "instance-id": "i-1234567890abcdef0",
+    "state": "Running",
+    "count": 5,
+    "price": 10.5,
+    "ip": "10.0.0.17",
+    "ipv6": "2001:db8::1",
+    "key": "images/photo.PNG",
+    "empty": null,
+    "tags": ["a", "b"],
+    "volumes": [
+      {"id": "vol-1", "size": 8},
+      {"id": "vol-2", "size": 100}
+    ]
+  }
+}`
+
+	testCases := []struct {
+		Name    string
+		Pattern string
+		Match   bool
+	}{
+		{Name: "exact", Pattern: `{"source": ["aws.ec2"]}`, Match: true},
+		{Name: "exact no match", Pattern: `{"source": ["aws.s3"]}`, Match: false},
+		{Name: "exact case sensitive", Pattern: `{"detail": {"state": ["running"]}}`, Match: false},
+		{Name: "multiple values", Pattern: `{"source": ["aws.s3", "aws.ec2"]}`, Match: true},
+		{Name: "all fields", Pattern: `{"source": ["aws.ec2"], "detail-type": ["other"]}`, Match: false},
+		{Name: "missing field", Pattern: `{"account": ["123456789012"]}`, Match: false},
+		{Name: "number", Pattern: `{"detail": {"count": [5.0]}}`, Match: true},
+		{Name: "number is not string", Pattern: `{"detail": {"count": ["5"]}}`, Match: false},
+		{Name: "null", Pattern: `{"detail": {"empty": [null]}}`, Match: true},
+		{Name: "array", Pattern: `{"detail": {"tags": ["b", "c"]}}`, Match: true},
+		{Name: "array no match", Pattern: `{"detail": {"tags": ["c"]}}`, Match: false},
+		{Name: "array of objects", Pattern: `{"detail": {"volumes": {"size": [100]}}}`, Match: true},
+		{Name: "array of objects flattened", Pattern: `{"detail": {"volumes": {"id": ["vol-1"], "size": [100]}}}`, Match: true},
+		{Name: "prefix", Pattern: `{"source": [{"prefix": "aws."}]}`, Match: true},
+		{Name: "prefix no match", Pattern: `{"source": [{"prefix": "AWS."}]}`, Match: false},
+		{Name: "prefix equals-ignore-case", Pattern: `{"source": [{"prefix": {"equals-ignore-case": "AWS."}}]}`, Match: true},
+		{Name: "suffix", Pattern: `{"detail": {"key": [{"suffix": ".png"}]}}`, Match: false},
+		{Name: "suffix equals-ignore-case", Pattern: `{"detail": {"key": [{"suffix": {"equals-ignore-case": ".png"}}]}}`, Match: true},
+		{Name: "equals-ignore-case", Pattern: `{"detail": {"state": [{"equals-ignore-case": "RUNNING"}]}}`, Match: true},
+		{Name: "anything-but", Pattern: `{"detail": {"state": [{"anything-but": "Stopped"}]}}`, Match: true},
+		{Name: "anything-but no match", Pattern: `{"detail": {"state": [{"anything-but": ["Stopped", "Running"]}]}}`, Match: false},
+		{Name: "anything-but number", Pattern: `{"detail": {"count": [{"anything-but": [5]}]}}`, Match: false},
+		{Name: "anything-but missing", Pattern: `{"detail": {"missing": [{"anything-but": "a"}]}}`, Match: false},
+		{Name: "anything-but prefix", Pattern: `{"source": [{"anything-but": {"prefix": "aws."}}]}`, Match: false},
+		{Name: "anything-but suffix", Pattern: `{"detail": {"key": [{"anything-but": {"suffix": ".png"}}]}}`, Match: true},
+		{Name: "anything-but equals-ignore-case", Pattern: `{"detail": {"state": [{"anything-but": {"equals-ignore-case": ["running"]}}]}}`, Match: false},
+		{Name: "anything-but wildcard", Pattern: `{"detail": {"key": [{"anything-but": {"wildcard": "docs/*"}}]}}`, Match: true},
+		{Name: "anything-but wildcard no match", Pattern: `{"detail": {"key": [{"anything-but": {"wildcard": ["docs/*", "images/*"]}}]}}`, Match: false},
+		{Name: "numeric range", Pattern: `{"detail": {"count": [{"numeric": [">", 0, "<=", 5]}]}}`, Match: true},
+		{Name: "numeric range no match", Pattern: `{"detail": {"count": [{"numeric": [">", 5]}]}}`, Match: false},
+		{Name: "numeric equals", Pattern: `{"detail": {"price": [{"numeric": ["=", 10.5]}]}}`, Match: true},
+		{Name: "numeric string", Pattern: `{"detail": {"state": [{"numeric": [">", 0]}]}}`, Match: false},
+		{Name: "numeric array of objects", Pattern: `{"detail": {"volumes": {"size": [{"numeric": [">=", 100]}]}}}`, Match: true},
+		{Name: "exists", Pattern: `{"detail": {"state": [{"exists": true}]}}`, Match: true},
+		{Name: "exists null", Pattern: `{"detail": {"empty": [{"exists": true}]}}`, Match: true},
+		{Name: "exists missing", Pattern: `{"detail": {"missing": [{"exists": true}]}}`, Match: false},
+		{Name: "not exists", Pattern: `{"detail": {"missing": [{"exists": false}]}}`, Match: true},
+		{Name: "not exists present", Pattern: `{"detail": {"state": [{"exists": false}]}}`, Match: false},
+		{Name: "not exists nested", Pattern: `{"other": {"missing": [{"exists": false}]}}`, Match: true},
+		{Name: "cidr", Pattern: `{"detail": {"ip": [{"cidr": "10.0.0.0/24"}]}}`, Match: true},
+		{Name: "cidr no match", Pattern: `{"detail": {"ip": [{"cidr": "10.0.1.0/24"}]}}`, Match: false},
+		{Name: "cidr IPv6", Pattern: `{"detail": {"ipv6": [{"cidr": "2001:db8::/32"}]}}`, Match: true},
+		{Name: "cidr not an IP address", Pattern: `{"detail": {"state": [{"cidr": "0.0.0.0/0"}]}}`, Match: false},
+		{Name: "wildcard", Pattern: `{"detail": {"key": [{"wildcard": "images/*.PNG"}]}}`, Match: true},
+		{Name: "wildcard no match", Pattern: `{"detail": {"key": [{"wildcard": "docs/*"}]}}`, Match: false},
+		{Name: "or", Pattern: `{"$or": [{"source": ["aws.s3"]}, {"detail": {"count": [5]}}]}`, Match: true},
+		{Name: "or no match", Pattern: `{"$or": [{"source": ["aws.s3"]}, {"detail": {"count": [6]}}]}`, Match: false},
+		{Name: "or nested", Pattern: `{"source": ["aws.ec2"], "detail": {"$or": [{"state": ["Stopped"]}, {"tags": ["a"]}]}}`, Match: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			pattern, err := ParseEventPattern(testCase.Pattern)
+
+			if err != nil {
+				t.Fatalf("error parsing event pattern: %s", err)
+			}
+
+			got, err := pattern.Match(event)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != testCase.Match {
+				t.Errorf("got %t, expected %t", got, testCase.Match)
+			}
+		})
+	}
+}
+
+func TestEventPatternMatch_invalidEvent(t *testing.T) {
+	pattern, err := ParseEventPattern(`{"source": ["aws.ec2"]}`)
+
+	if err != nil {
+		t.Fatalf("error parsing event pattern: %s", err)
+	}
+
+	for _, event := range []string{`{"source": `, `["aws.ec2"]`} {
+		if _, err := pattern.Match(event); err == nil {
+			t.Errorf("expected error for event %s, got none", event)
+		}
+	}
+}
+
+func TestMatchEventPatternWildcard(t *testing.T) {
+	testCases := []struct {
+		Pattern string
+		Value   string
+		Match   bool
+	}{
+		{Pattern: "abc", Value: "abc", Match: true},
+		{Pattern: "abc", Value: "abcd", Match: false},
+		{Pattern: "*", Value: "", Match: true},
+		{Pattern: "a*", Value: "abc", Match: true},
+		{Pattern: "*c", Value: "abc", Match: true},
+		{Pattern: "a*c*e", Value: "abcde", Match: true},
+		{Pattern: "a*c*e", Value: "abcdef", Match: false},
+		{Pattern: "a*a", Value: "a", Match: false},
+		{Pattern: `a\*`, Value: "a*", Match: true},
+		{Pattern: `a\*`, Value: "ab", Match: false},
+	}
+
+	for _, testCase := range testCases {
+		if got := matchEventPatternWildcard(testCase.Pattern, testCase.Value); got != testCase.Match {
+			t.Errorf("matchEventPatternWildcard(%q, %q) = %t, expected %t", testCase.Pattern, testCase.Value, got, testCase.Match)
+		}
+	}
+}
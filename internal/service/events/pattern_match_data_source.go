@@ -0,0 +1,84 @@
+package events
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-aws/internal/create"
+)
+
+func DataSourcePatternMatch() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePatternMatchRead,
+
+		Schema: map[string]*schema.Schema{
+			"all_match": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"any_match": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"event_pattern": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateEventPatternValue(),
+			},
+			"events": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsJSON,
+				},
+			},
+			"matches": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeBool},
+			},
+		},
+	}
+}
+
+func dataSourcePatternMatchRead(d *schema.ResourceData, meta interface{}) error {
+	pattern, err := ParseEventPattern(d.Get("event_pattern").(string))
+
+	if err != nil {
+		return fmt.Errorf("error parsing EventBridge event pattern: %w", err)
+	}
+
+	var events []string
+	var matches []bool
+	allMatch, anyMatch := true, false
+
+	for i, v := range d.Get("events").([]interface{}) {
+		event := v.(string)
+
+		match, err := pattern.Match(event)
+
+		if err != nil {
+			return fmt.Errorf("error matching EventBridge event pattern against event %d: %w", i, err)
+		}
+
+		events = append(events, event)
+		matches = append(matches, match)
+		allMatch = allMatch && match
+		anyMatch = anyMatch || match
+	}
+
+	d.SetId(strconv.Itoa(create.StringHashcode(d.Get("event_pattern").(string) + strings.Join(events, ""))))
+	d.Set("all_match", allMatch)
+	d.Set("any_match", anyMatch)
+
+	if err := d.Set("matches", matches); err != nil {
+		return fmt.Errorf("error setting matches: %w", err)
+	}
+
+	return nil
+}
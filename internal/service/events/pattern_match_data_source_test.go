@@ -0,0 +1,92 @@
+package events_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+)
+
+func TestAccEventsPatternMatchDataSource_basic(t *testing.T) {
+	dataSourceName := "data.aws_cloudwatch_event_pattern_match.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { acctest.PreCheck(t) },
+		ErrorCheck: acctest.ErrorCheck(t, eventbridge.EndpointsID),
+		Providers:  acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPatternMatchDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "all_match", "false"),
+					resource.TestCheckResourceAttr(dataSourceName, "any_match", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "matches.#", "4"),
+					resource.TestCheckResourceAttr(dataSourceName, "matches.0", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "matches.1", "false"),
+					resource.TestCheckResourceAttr(dataSourceName, "matches.2", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "matches.3", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccEventsPatternMatchDataSource_invalid(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { acctest.PreCheck(t) },
+		ErrorCheck: acctest.ErrorCheck(t, eventbridge.EndpointsID),
+		Providers:  acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPatternMatchDataSourceConfig_invalid,
+				ExpectError: regexp.MustCompile(`unsupported content filter`),
+			},
+		},
+	})
+}
+
+const testAccPatternMatchDataSourceConfig = `
+data "aws_cloudwatch_event_pattern_match" "test" {
+  event_pattern = jsonencode({
+    source = [{ prefix = "aws." }]
+    detail = {
+      "$or" = [
+        { count = [{ numeric = [">", 0, "<=", 5] }] },
+        { ip = [{ cidr = "10.0.0.0/24" }] },
+      ]
+      state = [{ "anything-but" = { "equals-ignore-case" = ["stopped"] } }]
+    }
+  })
+
+  events = [
+    jsonencode({
+      source = "aws.ec2"
+      detail = { count = 5, state = "running" }
+    }),
+    jsonencode({
+      source = "aws.ec2"
+      detail = { count = 5, state = "STOPPED" }
+    }),
+    jsonencode({
+      source = "aws.ec2"
+      detail = { ip = "10.0.0.17", state = "pending" }
+    }),
+    jsonencode({
+      source = "custom.app"
+      detail = { count = 1, state = "running" }
+    }),
+  ]
+}
+`
+
+const testAccPatternMatchDataSourceConfig_invalid = `
+data "aws_cloudwatch_event_pattern_match" "test" {
+  event_pattern = jsonencode({
+    source = [{ regex = "aws.*" }]
+  })
+
+  events = [jsonencode({ source = "aws.ec2" })]
+}
+`
@@ -359,6 +359,11 @@ func validateEventPatternValue() schema.SchemaValidateFunc {
 		if len(json) > maxJsonLength {
 			errors = append(errors, fmt.Errorf("%q cannot be longer than %d characters: %q", k, maxJsonLength, json))
 		}
+
+		// EventBridge may support filters that are not known locally, so only warn.
+		if _, err := ParseEventPattern(json); err != nil {
+			ws = append(ws, fmt.Sprintf("%q may not be a valid event pattern: %s", k, err))
+		}
 		return
 	}
 }
@@ -253,6 +253,27 @@ func TestAccEventsRule_pattern(t *testing.T) {
 	})
 }
 
+func TestAccEventsRule_invalidPattern(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, eventbridge.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRulePatternConfig(rName, "{\"source\":\"aws.ec2\"}"),
+				ExpectError: regexp.MustCompile(`source: must be an object or an array`),
+			},
+			{
+				Config:      testAccRulePatternConfig(rName, "{\"detail\":{\"count\":[{\"numeric\":[\"<\",5,\">\",0]}]}}"),
+				ExpectError: regexp.MustCompile(`ranges must be a lower bound`),
+			},
+		},
+	})
+}
+
 func TestAccEventsRule_scheduleAndPattern(t *testing.T) {
 	var v eventbridge.DescribeRuleOutput
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
@@ -0,0 +1,756 @@
+package glue
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	schemaInferenceFormatAvro    = "avro"
+	schemaInferenceFormatCSV     = "csv"
+	schemaInferenceFormatJSON    = "json"
+	schemaInferenceFormatParquet = "parquet"
+)
+
+// schemaInferenceMaxMetadataSize is the maximum size of the metadata read from a file, e.g. a Parquet footer.
+const schemaInferenceMaxMetadataSize = 64 * 1024 * 1024
+
+func schemaInferenceFormat_Values() []string {
+	return []string{
+		schemaInferenceFormatAvro,
+		schemaInferenceFormatCSV,
+		schemaInferenceFormatJSON,
+		schemaInferenceFormatParquet,
+	}
+}
+
+// Glue Data Catalog (Hive) column types.
+const (
+	glueTypeBigint    = "bigint"
+	glueTypeBinary    = "binary"
+	glueTypeBoolean   = "boolean"
+	glueTypeDate      = "date"
+	glueTypeDouble    = "double"
+	glueTypeFloat     = "float"
+	glueTypeInt       = "int"
+	glueTypeSmallint  = "smallint"
+	glueTypeString    = "string"
+	glueTypeTimestamp = "timestamp"
+	glueTypeTinyint   = "tinyint"
+
+	glueTypeArray  = "array"
+	glueTypeMap    = "map"
+	glueTypeStruct = "struct"
+	glueTypeUnion  = "uniontype"
+)
+
+// glueType is a possibly nested column type.
+// A nil *glueType is the type of a value that is always null, which is not known yet.
+type glueType struct {
+	Name     string       // Primitive type, e.g. "bigint" or "decimal(10,2)", or one of the complex types.
+	Elements []*glueType  // Element type of an array, key and value types of a map, member types of a union.
+	Fields   []*glueField // Fields of a struct.
+}
+
+type glueField struct {
+	Name string
+	Type *glueType
+}
+
+func glueTypePrimitive(name string) *glueType {
+	return &glueType{Name: name}
+}
+
+func glueTypeArrayOf(element *glueType) *glueType {
+	return &glueType{Name: glueTypeArray, Elements: []*glueType{element}}
+}
+
+// String returns the type in the syntax of the Glue Data Catalog, e.g. array<struct<id:bigint>>.
+// Types that are not known, e.g. of fields that were always null, are string.
+func (t *glueType) String() string {
+	if t == nil {
+		return glueTypeString
+	}
+
+	switch t.Name {
+	case glueTypeArray, glueTypeMap, glueTypeUnion:
+		elements := make([]string, 0, len(t.Elements))
+
+		for _, v := range t.Elements {
+			elements = append(elements, v.String())
+		}
+
+		return fmt.Sprintf("%s<%s>", t.Name, strings.Join(elements, ","))
+	case glueTypeStruct:
+		fields := make([]string, 0, len(t.Fields))
+
+		for _, v := range t.Fields {
+			fields = append(fields, v.Name+":"+v.Type.String())
+		}
+
+		return fmt.Sprintf("%s<%s>", t.Name, strings.Join(fields, ","))
+	}
+
+	return t.Name
+}
+
+// mergeGlueTypes returns a type that holds the values of both types.
+// Integers are widened to doubles and dates to timestamps, struct fields are merged
+// and any other conflict results in a string.
+func mergeGlueTypes(a, b *glueType) *glueType {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case a.Name != b.Name:
+		switch {
+		case isGlueTypeOneOf(a, b, glueTypeBigint, glueTypeDouble):
+			return glueTypePrimitive(glueTypeDouble)
+		case isGlueTypeOneOf(a, b, glueTypeDate, glueTypeTimestamp):
+			return glueTypePrimitive(glueTypeTimestamp)
+		}
+
+		return glueTypePrimitive(glueTypeString)
+	case a.Name == glueTypeArray:
+		return glueTypeArrayOf(mergeGlueTypes(a.Elements[0], b.Elements[0]))
+	case a.Name == glueTypeStruct:
+		t := &glueType{Name: glueTypeStruct}
+		index := make(map[string]int)
+
+		for _, fields := range [][]*glueField{a.Fields, b.Fields} {
+			for _, v := range fields {
+				if i, ok := index[v.Name]; ok {
+					t.Fields[i] = &glueField{Name: v.Name, Type: mergeGlueTypes(t.Fields[i].Type, v.Type)}
+					continue
+				}
+
+				index[v.Name] = len(t.Fields)
+				t.Fields = append(t.Fields, v)
+			}
+		}
+
+		return t
+	}
+
+	return a
+}
+
+func isGlueTypeOneOf(a, b *glueType, x, y string) bool {
+	return (a.Name == x && b.Name == y) || (a.Name == y && b.Name == x)
+}
+
+// inferredSchema is the result of inferring the schema of a sample file.
+type inferredSchema struct {
+	Classification       string
+	Columns              []*glueField
+	InputFormat          string
+	OutputFormat         string
+	Parameters           map[string]string
+	SerDeParameters      map[string]string
+	SerializationLibrary string
+}
+
+func (s *inferredSchema) addColumn(name string, t *glueType) error {
+	name = strings.TrimSpace(name)
+
+	if name == "" {
+		return fmt.Errorf("column %d has no name", len(s.Columns)+1)
+	}
+
+	for _, v := range s.Columns {
+		if strings.EqualFold(v.Name, name) {
+			return fmt.Errorf("duplicate column (%s)", name)
+		}
+	}
+
+	s.Columns = append(s.Columns, &glueField{Name: name, Type: t})
+
+	return nil
+}
+
+var (
+	csvIntegerRegexp   = regexp.MustCompile(`^[-+]?\d+$`)
+	csvDoubleRegexp    = regexp.MustCompile(`^[-+]?(\d+\.?\d*|\.\d+)([eE][-+]?\d+)?$`)
+	csvDateRegexp      = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	csvTimestampRegexp = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}[ T]\d{2}:\d{2}:\d{2}(\.\d{1,9})?$`)
+)
+
+// inferCSVSchema infers the schema of CSV data with a header line from up to sampleSize records.
+func inferCSVSchema(r io.Reader, delimiter rune, sampleSize int) (*inferredSchema, error) {
+	var raw bytes.Buffer
+	reader := csv.NewReader(io.TeeReader(r, &raw))
+	reader.Comma = delimiter
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+
+	if err == io.EOF {
+		return nil, errors.New("no header line")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	names := append([]string(nil), header...)
+
+	if len(names) > 0 {
+		names[0] = strings.TrimPrefix(names[0], "\ufeff")
+	}
+
+	types := make([]*glueType, len(names))
+
+	for i := 0; i < sampleSize; i++ {
+		record, err := reader.Read()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		for j, v := range record {
+			types[j] = mergeGlueTypes(types[j], inferCSVValueType(v))
+		}
+	}
+
+	s := &inferredSchema{
+		Classification: schemaInferenceFormatCSV,
+		InputFormat:    "org.apache.hadoop.mapred.TextInputFormat",
+		OutputFormat:   "org.apache.hadoop.hive.ql.io.HiveIgnoreKeyTextOutputFormat",
+		Parameters: map[string]string{
+			"classification":         schemaInferenceFormatCSV,
+			"skip.header.line.count": "1",
+		},
+	}
+
+	// LazySimpleSerDe does not support quoted fields.
+	if bytes.ContainsRune(raw.Bytes(), '"') {
+		s.SerializationLibrary = "org.apache.hadoop.hive.serde2.OpenCSVSerde"
+		s.SerDeParameters = map[string]string{
+			"escapeChar":    `\`,
+			"quoteChar":     `"`,
+			"separatorChar": string(delimiter),
+		}
+	} else {
+		s.SerializationLibrary = "org.apache.hadoop.hive.serde2.lazy.LazySimpleSerDe"
+		s.SerDeParameters = map[string]string{
+			"field.delim": string(delimiter),
+		}
+	}
+
+	for i, name := range names {
+		if err := s.addColumn(name, types[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+func inferCSVValueType(v string) *glueType {
+	v = strings.TrimSpace(v)
+
+	switch {
+	case v == "":
+		return nil
+	case strings.EqualFold(v, "true"), strings.EqualFold(v, "false"):
+		return glueTypePrimitive(glueTypeBoolean)
+	case csvIntegerRegexp.MatchString(v):
+		if _, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return glueTypePrimitive(glueTypeBigint)
+		}
+
+		return glueTypePrimitive(glueTypeDouble)
+	case csvDoubleRegexp.MatchString(v):
+		return glueTypePrimitive(glueTypeDouble)
+	case csvDateRegexp.MatchString(v):
+		return glueTypePrimitive(glueTypeDate)
+	case csvTimestampRegexp.MatchString(v):
+		return glueTypePrimitive(glueTypeTimestamp)
+	}
+
+	return glueTypePrimitive(glueTypeString)
+}
+
+// inferJSONSchema infers the schema of JSON Lines data, one object per line, from up to sampleSize records.
+// Columns and struct fields are in the order in which they first appear.
+func inferJSONSchema(r io.Reader, sampleSize int) (*inferredSchema, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	var t *glueType
+
+	for line, records := 0, 0; records < sampleSize && scanner.Scan(); {
+		line++
+		b := bytes.TrimSpace(scanner.Bytes())
+
+		if len(b) == 0 {
+			continue
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(b))
+		decoder.UseNumber()
+
+		v, err := decodeJSONValueType(decoder)
+
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if v == nil || v.Name != glueTypeStruct {
+			return nil, fmt.Errorf("line %d: not a JSON object", line)
+		}
+
+		if _, err := decoder.Token(); err != io.EOF {
+			return nil, fmt.Errorf("line %d: more than one JSON value", line)
+		}
+
+		t = mergeGlueTypes(t, v)
+		records++
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if t == nil {
+		return nil, errors.New("no records")
+	}
+
+	s := &inferredSchema{
+		Classification: schemaInferenceFormatJSON,
+		InputFormat:    "org.apache.hadoop.mapred.TextInputFormat",
+		OutputFormat:   "org.apache.hadoop.hive.ql.io.HiveIgnoreKeyTextOutputFormat",
+		Parameters: map[string]string{
+			"classification": schemaInferenceFormatJSON,
+		},
+		SerializationLibrary: "org.openx.data.jsonserde.JsonSerDe",
+	}
+
+	for _, v := range t.Fields {
+		if err := s.addColumn(v.Name, v.Type); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// decodeJSONValueType returns the type of the next JSON value of the decoder.
+func decodeJSONValueType(decoder *json.Decoder) (*glueType, error) {
+	token, err := decoder.Token()
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := token.(type) {
+	case bool:
+		return glueTypePrimitive(glueTypeBoolean), nil
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return glueTypePrimitive(glueTypeBigint), nil
+		}
+
+		return glueTypePrimitive(glueTypeDouble), nil
+	case string:
+		return glueTypePrimitive(glueTypeString), nil
+	case json.Delim:
+		switch v {
+		case '[':
+			var element *glueType
+
+			for decoder.More() {
+				e, err := decodeJSONValueType(decoder)
+
+				if err != nil {
+					return nil, err
+				}
+
+				element = mergeGlueTypes(element, e)
+			}
+
+			if _, err := decoder.Token(); err != nil {
+				return nil, err
+			}
+
+			return glueTypeArrayOf(element), nil
+		case '{':
+			t := &glueType{Name: glueTypeStruct}
+
+			for decoder.More() {
+				k, err := decoder.Token()
+
+				if err != nil {
+					return nil, err
+				}
+
+				e, err := decodeJSONValueType(decoder)
+
+				if err != nil {
+					return nil, err
+				}
+
+				t = mergeGlueTypes(t, &glueType{Name: glueTypeStruct, Fields: []*glueField{{Name: k.(string), Type: e}}})
+			}
+
+			if _, err := decoder.Token(); err != nil {
+				return nil, err
+			}
+
+			return t, nil
+		}
+	}
+
+	return nil, nil
+}
+
+var avroMagic = []byte{'O', 'b', 'j', 1}
+
+// inferAvroSchema infers the schema of an Avro object container file from the writer schema in its header.
+func inferAvroSchema(r io.Reader) (*inferredSchema, error) {
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(avroMagic))
+
+	if _, err := io.ReadFull(br, magic); err != nil || !bytes.Equal(magic, avroMagic) {
+		return nil, errors.New("not an Avro object container file")
+	}
+
+	metadata, err := readAvroMetadata(br)
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading Avro file metadata: %w", err)
+	}
+
+	schemaJSON, ok := metadata["avro.schema"]
+
+	if !ok {
+		return nil, errors.New("Avro file metadata has no schema")
+	}
+
+	var v interface{}
+
+	if err := json.Unmarshal(schemaJSON, &v); err != nil {
+		return nil, fmt.Errorf("error decoding Avro schema: %w", err)
+	}
+
+	parser := &avroSchemaParser{
+		inProgress: make(map[string]bool),
+		named:      make(map[string]*glueType),
+	}
+	t, err := parser.parse(v, "")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if t == nil || t.Name != glueTypeStruct {
+		return nil, errors.New("Avro schema is not a record")
+	}
+
+	s := &inferredSchema{
+		Classification: schemaInferenceFormatAvro,
+		InputFormat:    "org.apache.hadoop.hive.ql.io.avro.AvroContainerInputFormat",
+		OutputFormat:   "org.apache.hadoop.hive.ql.io.avro.AvroContainerOutputFormat",
+		Parameters: map[string]string{
+			"classification": schemaInferenceFormatAvro,
+		},
+		SerDeParameters: map[string]string{
+			"avro.schema.literal": string(schemaJSON),
+		},
+		SerializationLibrary: "org.apache.hadoop.hive.serde2.avro.AvroSerDe",
+	}
+
+	for _, v := range t.Fields {
+		if err := s.addColumn(v.Name, v.Type); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// readAvroMetadata reads the file metadata map that follows the magic bytes of an Avro object container file.
+func readAvroMetadata(r *bufio.Reader) (map[string][]byte, error) {
+	metadata := make(map[string][]byte)
+
+	for {
+		n, err := readAvroLong(r)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if n == 0 {
+			return metadata, nil
+		}
+
+		// Negative block counts are followed by the size of the block in bytes.
+		if n < 0 {
+			n = -n
+
+			if _, err := readAvroLong(r); err != nil {
+				return nil, err
+			}
+		}
+
+		for i := int64(0); i < n; i++ {
+			k, err := readAvroBytes(r)
+
+			if err != nil {
+				return nil, err
+			}
+
+			v, err := readAvroBytes(r)
+
+			if err != nil {
+				return nil, err
+			}
+
+			metadata[string(k)] = v
+		}
+	}
+}
+
+// readAvroLong reads a zig-zag encoded variable length integer.
+func readAvroLong(r io.ByteReader) (int64, error) {
+	v, err := binary.ReadUvarint(r)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(v>>1) ^ -int64(v&1), nil
+}
+
+func readAvroBytes(r *bufio.Reader) ([]byte, error) {
+	n, err := readAvroLong(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if n < 0 || n > schemaInferenceMaxMetadataSize {
+		return nil, fmt.Errorf("invalid length (%d)", n)
+	}
+
+	b := make([]byte, n)
+
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// avroSchemaParser converts Avro schemas to Glue types, resolving references to named types.
+type avroSchemaParser struct {
+	inProgress map[string]bool
+	named      map[string]*glueType
+}
+
+func (p *avroSchemaParser) parse(v interface{}, namespace string) (*glueType, error) {
+	switch v := v.(type) {
+	case string:
+		switch v {
+		case "null":
+			return nil, nil
+		case "boolean":
+			return glueTypePrimitive(glueTypeBoolean), nil
+		case "int":
+			return glueTypePrimitive(glueTypeInt), nil
+		case "long":
+			return glueTypePrimitive(glueTypeBigint), nil
+		case "float":
+			return glueTypePrimitive(glueTypeFloat), nil
+		case "double":
+			return glueTypePrimitive(glueTypeDouble), nil
+		case "bytes":
+			return glueTypePrimitive(glueTypeBinary), nil
+		case "string":
+			return glueTypePrimitive(glueTypeString), nil
+		}
+
+		name := avroFullName(v, namespace)
+
+		if p.inProgress[name] {
+			return nil, fmt.Errorf("recursive Avro type (%s) is not supported", name)
+		}
+
+		if t, ok := p.named[name]; ok {
+			return t, nil
+		}
+
+		if t, ok := p.named[v]; ok {
+			return t, nil
+		}
+
+		return nil, fmt.Errorf("undefined Avro type (%s)", v)
+	case []interface{}:
+		var members []*glueType
+
+		for _, e := range v {
+			if e == "null" {
+				continue
+			}
+
+			t, err := p.parse(e, namespace)
+
+			if err != nil {
+				return nil, err
+			}
+
+			members = append(members, t)
+		}
+
+		switch len(members) {
+		case 0:
+			return nil, nil
+		case 1:
+			return members[0], nil
+		}
+
+		return &glueType{Name: glueTypeUnion, Elements: members}, nil
+	case map[string]interface{}:
+		return p.parseComplex(v, namespace)
+	}
+
+	return nil, fmt.Errorf("invalid Avro schema: %v", v)
+}
+
+func (p *avroSchemaParser) parseComplex(m map[string]interface{}, namespace string) (*glueType, error) {
+	typeName, _ := m["type"].(string)
+
+	switch logicalType, _ := m["logicalType"].(string); {
+	case logicalType == "date" && typeName == "int":
+		return glueTypePrimitive(glueTypeDate), nil
+	case (logicalType == "timestamp-millis" || logicalType == "timestamp-micros") && typeName == "long":
+		return glueTypePrimitive(glueTypeTimestamp), nil
+	case logicalType == "decimal" && (typeName == "bytes" || typeName == "fixed"):
+		precision, _ := m["precision"].(float64)
+		scale, _ := m["scale"].(float64)
+
+		if typeName == "fixed" {
+			if err := p.register(m, namespace, glueTypePrimitive(glueTypeBinary)); err != nil {
+				return nil, err
+			}
+		}
+
+		return glueTypePrimitive(fmt.Sprintf("decimal(%d,%d)", int(precision), int(scale))), nil
+	}
+
+	switch typeName {
+	case "record", "error":
+		if ns, ok := m["namespace"].(string); ok {
+			namespace = ns
+		}
+
+		recordName, ok := m["name"].(string)
+
+		if !ok {
+			return nil, errors.New("Avro record has no name")
+		}
+
+		name := avroFullName(recordName, namespace)
+		t := &glueType{Name: glueTypeStruct}
+
+		// A record's namespace is the default for the types that it defines.
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			namespace = name[:i]
+		}
+
+		p.inProgress[name] = true
+
+		fields, _ := m["fields"].([]interface{})
+
+		for _, f := range fields {
+			f, ok := f.(map[string]interface{})
+
+			if !ok {
+				return nil, fmt.Errorf("invalid field of Avro record (%s)", name)
+			}
+
+			fieldName, _ := f["name"].(string)
+			fieldType, err := p.parse(f["type"], namespace)
+
+			if err != nil {
+				return nil, err
+			}
+
+			t.Fields = append(t.Fields, &glueField{Name: fieldName, Type: fieldType})
+		}
+
+		delete(p.inProgress, name)
+
+		if err := p.register(m, namespace, t); err != nil {
+			return nil, err
+		}
+
+		return t, nil
+	case "enum":
+		t := glueTypePrimitive(glueTypeString)
+
+		return t, p.register(m, namespace, t)
+	case "fixed":
+		t := glueTypePrimitive(glueTypeBinary)
+
+		return t, p.register(m, namespace, t)
+	case "array":
+		t, err := p.parse(m["items"], namespace)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return glueTypeArrayOf(t), nil
+	case "map":
+		t, err := p.parse(m["values"], namespace)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &glueType{Name: glueTypeMap, Elements: []*glueType{glueTypePrimitive(glueTypeString), t}}, nil
+	}
+
+	// A primitive type or a reference to a named type, e.g. {"type": "string"}.
+	return p.parse(m["type"], namespace)
+}
+
+// register records a named type so that it can be referenced by later types.
+func (p *avroSchemaParser) register(m map[string]interface{}, namespace string, t *glueType) error {
+	name, ok := m["name"].(string)
+
+	if !ok {
+		return errors.New("Avro named type has no name")
+	}
+
+	if ns, ok := m["namespace"].(string); ok && !strings.Contains(name, ".") {
+		namespace = ns
+	}
+
+	p.named[avroFullName(name, namespace)] = t
+
+	return nil
+}
+
+func avroFullName(name, namespace string) string {
+	if strings.Contains(name, ".") || namespace == "" {
+		return name
+	}
+
+	return namespace + "." + name
+}
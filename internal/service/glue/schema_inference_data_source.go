@@ -0,0 +1,204 @@
+package glue
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mitchellh/go-homedir"
+)
+
+func DataSourceSchemaInference() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSchemaInferenceRead,
+		Schema: map[string]*schema.Schema{
+			"classification": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"columns": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"delimiter": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  ",",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					value := v.(string)
+					if utf8.RuneCountInString(value) != 1 || value == "\r" || value == "\n" || value == `"` {
+						errs = append(errs, fmt.Errorf("%q must be a single character other than a newline or double quote, got: %q", k, value))
+					}
+					return
+				},
+			},
+			"format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(schemaInferenceFormat_Values(), false),
+			},
+			"input_format": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"output_format": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"parameters": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"path": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"sample_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1000,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"ser_de_info": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"parameters": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"serialization_library": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSchemaInferenceRead(d *schema.ResourceData, meta interface{}) error {
+	path, err := homedir.Expand(d.Get("path").(string))
+
+	if err != nil {
+		return fmt.Errorf("error expanding path: %w", err)
+	}
+
+	format := d.Get("format").(string)
+
+	if format == "" {
+		format, err = schemaInferenceFormatFromPath(path)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	f, err := os.Open(path)
+
+	if err != nil {
+		return fmt.Errorf("error opening Glue schema inference sample (%s): %w", path, err)
+	}
+
+	defer f.Close()
+
+	var s *inferredSchema
+	sampleSize := d.Get("sample_size").(int)
+
+	switch format {
+	case schemaInferenceFormatAvro:
+		s, err = inferAvroSchema(bufio.NewReader(f))
+	case schemaInferenceFormatCSV:
+		delimiter, _ := utf8.DecodeRuneInString(d.Get("delimiter").(string))
+		s, err = inferCSVSchema(f, delimiter, sampleSize)
+	case schemaInferenceFormatJSON:
+		s, err = inferJSONSchema(f, sampleSize)
+	case schemaInferenceFormatParquet:
+		var fi os.FileInfo
+
+		if fi, err = f.Stat(); err == nil {
+			s, err = inferParquetSchema(f, fi.Size())
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("error inferring Glue schema of %s file (%s): %w", format, path, err)
+	}
+
+	d.SetId(path)
+	d.Set("classification", s.Classification)
+	d.Set("format", format)
+	d.Set("input_format", s.InputFormat)
+	d.Set("output_format", s.OutputFormat)
+
+	if err := d.Set("columns", flattenGlueInferredColumns(s.Columns)); err != nil {
+		return fmt.Errorf("error setting columns: %w", err)
+	}
+
+	if err := d.Set("parameters", s.Parameters); err != nil {
+		return fmt.Errorf("error setting parameters: %w", err)
+	}
+
+	serDeInfo := []interface{}{
+		map[string]interface{}{
+			"parameters":            s.SerDeParameters,
+			"serialization_library": s.SerializationLibrary,
+		},
+	}
+
+	if err := d.Set("ser_de_info", serDeInfo); err != nil {
+		return fmt.Errorf("error setting ser_de_info: %w", err)
+	}
+
+	return nil
+}
+
+func schemaInferenceFormatFromPath(path string) (string, error) {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".avro":
+		return schemaInferenceFormatAvro, nil
+	case ".csv":
+		return schemaInferenceFormatCSV, nil
+	case ".json", ".jsonl", ".ndjson":
+		return schemaInferenceFormatJSON, nil
+	case ".parquet":
+		return schemaInferenceFormatParquet, nil
+	}
+
+	return "", fmt.Errorf("cannot infer format of Glue schema inference sample (%s) from its extension, set format", path)
+}
+
+func flattenGlueInferredColumns(columns []*glueField) []interface{} {
+	tfList := make([]interface{}, 0, len(columns))
+
+	for _, v := range columns {
+		tfList = append(tfList, map[string]interface{}{
+			"name": v.Name,
+			"type": v.Type.String(),
+		})
+	}
+
+	return tfList
+}
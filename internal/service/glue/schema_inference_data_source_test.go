@@ -0,0 +1,166 @@
+package glue_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/glue"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+)
+
+func TestAccGlueSchemaInferenceDataSource_csv(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	dataSourceName := "data.aws_glue_schema_inference.test"
+	resourceName := "aws_glue_catalog_table.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, glue.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckGlueTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSchemaInferenceDataSourceConfig_csv(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "format", "csv"),
+					resource.TestCheckResourceAttr(dataSourceName, "classification", "csv"),
+					resource.TestCheckResourceAttr(dataSourceName, "columns.#", "5"),
+					resource.TestCheckResourceAttr(dataSourceName, "columns.0.name", "order_id"),
+					resource.TestCheckResourceAttr(dataSourceName, "columns.0.type", "bigint"),
+					resource.TestCheckResourceAttr(dataSourceName, "columns.1.name", "customer"),
+					resource.TestCheckResourceAttr(dataSourceName, "columns.1.type", "string"),
+					resource.TestCheckResourceAttr(dataSourceName, "columns.2.name", "amount"),
+					resource.TestCheckResourceAttr(dataSourceName, "columns.2.type", "double"),
+					resource.TestCheckResourceAttr(dataSourceName, "columns.3.name", "ordered_at"),
+					resource.TestCheckResourceAttr(dataSourceName, "columns.3.type", "timestamp"),
+					resource.TestCheckResourceAttr(dataSourceName, "columns.4.name", "shipped"),
+					resource.TestCheckResourceAttr(dataSourceName, "columns.4.type", "boolean"),
+					resource.TestCheckResourceAttr(dataSourceName, "input_format", "org.apache.hadoop.mapred.TextInputFormat"),
+					resource.TestCheckResourceAttr(dataSourceName, "output_format", "org.apache.hadoop.hive.ql.io.HiveIgnoreKeyTextOutputFormat"),
+					resource.TestCheckResourceAttr(dataSourceName, "parameters.%", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "parameters.skip.header.line.count", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "ser_de_info.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "ser_de_info.0.serialization_library", "org.apache.hadoop.hive.serde2.OpenCSVSerde"),
+					resource.TestCheckResourceAttr(dataSourceName, "ser_de_info.0.parameters.separatorChar", ","),
+					testAccCheckGlueCatalogTableExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "storage_descriptor.0.columns.#", "5"),
+					resource.TestCheckResourceAttrPair(resourceName, "storage_descriptor.0.columns.3.type", dataSourceName, "columns.3.type"),
+					resource.TestCheckResourceAttrPair(resourceName, "storage_descriptor.0.ser_de_info.0.serialization_library", dataSourceName, "ser_de_info.0.serialization_library"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccGlueSchemaInferenceDataSource_json(t *testing.T) {
+	dataSourceName := "data.aws_glue_schema_inference.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { acctest.PreCheck(t) },
+		ErrorCheck: acctest.ErrorCheck(t, glue.EndpointsID),
+		Providers:  acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSchemaInferenceDataSourceConfig_json,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "format", "json"),
+					resource.TestCheckResourceAttr(dataSourceName, "columns.#", "4"),
+					resource.TestCheckResourceAttr(dataSourceName, "columns.0.type", "bigint"),
+					resource.TestCheckResourceAttr(dataSourceName, "columns.1.type", "string"),
+					resource.TestCheckResourceAttr(dataSourceName, "columns.2.name", "user"),
+					resource.TestCheckResourceAttr(dataSourceName, "columns.2.type", "struct<id:string,tags:array<string>,country:string>"),
+					resource.TestCheckResourceAttr(dataSourceName, "columns.3.name", "duration"),
+					resource.TestCheckResourceAttr(dataSourceName, "columns.3.type", "double"),
+					resource.TestCheckResourceAttr(dataSourceName, "ser_de_info.0.serialization_library", "org.openx.data.jsonserde.JsonSerDe"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccGlueSchemaInferenceDataSource_errors(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { acctest.PreCheck(t) },
+		ErrorCheck: acctest.ErrorCheck(t, glue.EndpointsID),
+		Providers:  acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSchemaInferenceDataSourceConfig_path("testdata/missing.csv"),
+				ExpectError: regexp.MustCompile(`error opening Glue schema inference sample`),
+			},
+			{
+				Config:      testAccSchemaInferenceDataSourceConfig_path("testdata/schema_inference.txt"),
+				ExpectError: regexp.MustCompile(`cannot infer format of Glue schema inference sample`),
+			},
+			{
+				Config:      testAccSchemaInferenceDataSourceConfig_format("testdata/schema_inference.csv", "avro"),
+				ExpectError: regexp.MustCompile(`not an Avro object container file`),
+			},
+		},
+	})
+}
+
+func testAccSchemaInferenceDataSourceConfig_csv(rName string) string {
+	return fmt.Sprintf(`
+data "aws_glue_schema_inference" "test" {
+  path = "testdata/schema_inference.csv"
+}
+
+resource "aws_glue_catalog_database" "test" {
+  name = %[1]q
+}
+
+resource "aws_glue_catalog_table" "test" {
+  name          = %[1]q
+  database_name = aws_glue_catalog_database.test.name
+  table_type    = "EXTERNAL_TABLE"
+  parameters    = data.aws_glue_schema_inference.test.parameters
+
+  storage_descriptor {
+    location      = "s3://%[1]s/orders/"
+    input_format  = data.aws_glue_schema_inference.test.input_format
+    output_format = data.aws_glue_schema_inference.test.output_format
+
+    ser_de_info {
+      serialization_library = data.aws_glue_schema_inference.test.ser_de_info[0].serialization_library
+      parameters            = data.aws_glue_schema_inference.test.ser_de_info[0].parameters
+    }
+
+    dynamic "columns" {
+      for_each = data.aws_glue_schema_inference.test.columns
+
+      content {
+        name = columns.value.name
+        type = columns.value.type
+      }
+    }
+  }
+}
+`, rName)
+}
+
+const testAccSchemaInferenceDataSourceConfig_json = `
+data "aws_glue_schema_inference" "test" {
+  path = "testdata/schema_inference.jsonl"
+}
+`
+
+func testAccSchemaInferenceDataSourceConfig_path(path string) string {
+	return fmt.Sprintf(`
+data "aws_glue_schema_inference" "test" {
+  path = %[1]q
+}
+`, path)
+}
+
+func testAccSchemaInferenceDataSourceConfig_format(path, format string) string {
+	return fmt.Sprintf(`
+data "aws_glue_schema_inference" "test" {
+  path   = %[1]q
+  format = %[2]q
+}
+`, path, format)
+}
@@ -0,0 +1,708 @@
+package glue
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+var parquetMagic = []byte("PAR1")
+
+// Parquet physical types.
+const (
+	parquetTypeBoolean           = 0
+	parquetTypeInt32             = 1
+	parquetTypeInt64             = 2
+	parquetTypeInt96             = 3
+	parquetTypeFloat             = 4
+	parquetTypeDouble            = 5
+	parquetTypeByteArray         = 6
+	parquetTypeFixedLenByteArray = 7
+)
+
+// Parquet converted types.
+const (
+	parquetConvertedTypeUTF8            = 0
+	parquetConvertedTypeMap             = 1
+	parquetConvertedTypeMapKeyValue     = 2
+	parquetConvertedTypeList            = 3
+	parquetConvertedTypeEnum            = 4
+	parquetConvertedTypeDecimal         = 5
+	parquetConvertedTypeDate            = 6
+	parquetConvertedTypeTimestampMillis = 9
+	parquetConvertedTypeTimestampMicros = 10
+	parquetConvertedTypeUint8           = 11
+	parquetConvertedTypeUint16          = 12
+	parquetConvertedTypeUint32          = 13
+	parquetConvertedTypeUint64          = 14
+	parquetConvertedTypeInt8            = 15
+	parquetConvertedTypeInt16           = 16
+	parquetConvertedTypeInt32           = 17
+	parquetConvertedTypeInt64           = 18
+	parquetConvertedTypeJSON            = 19
+)
+
+// Parquet logical types, the field IDs of the members of the LogicalType union.
+const (
+	parquetLogicalTypeString    = 1
+	parquetLogicalTypeMap       = 2
+	parquetLogicalTypeList      = 3
+	parquetLogicalTypeEnum      = 4
+	parquetLogicalTypeDecimal   = 5
+	parquetLogicalTypeDate      = 6
+	parquetLogicalTypeTimestamp = 8
+	parquetLogicalTypeInteger   = 10
+	parquetLogicalTypeJSON      = 12
+)
+
+const (
+	parquetRepetitionTypeRepeated = 2
+)
+
+// parquetSchemaElement is a node of the schema in the footer of a Parquet file.
+type parquetSchemaElement struct {
+	Children       []*parquetSchemaElement
+	ConvertedType  int32 // -1 when unset.
+	Integer        parquetIntegerType
+	LogicalType    int16 // 0 when unset.
+	Name           string
+	NumChildren    int32
+	Precision      int32
+	RepetitionType int32
+	Scale          int32
+	Type           int32 // -1 for groups.
+}
+
+type parquetIntegerType struct {
+	BitWidth int8
+	IsSigned bool
+}
+
+// inferParquetSchema infers the schema of a Parquet file from the schema in its footer.
+func inferParquetSchema(r io.ReaderAt, size int64) (*inferredSchema, error) {
+	if size < int64(2*len(parquetMagic)+4) {
+		return nil, errors.New("not a Parquet file")
+	}
+
+	header := make([]byte, len(parquetMagic))
+	trailer := make([]byte, 4+len(parquetMagic))
+
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return nil, err
+	}
+
+	if _, err := r.ReadAt(trailer, size-int64(len(trailer))); err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(header, parquetMagic) || !bytes.Equal(trailer[4:], parquetMagic) {
+		return nil, errors.New("not a Parquet file")
+	}
+
+	footerSize := int64(binary.LittleEndian.Uint32(trailer))
+
+	if footerSize > size-int64(len(header)+len(trailer)) || footerSize > schemaInferenceMaxMetadataSize {
+		return nil, fmt.Errorf("invalid Parquet footer size (%d)", footerSize)
+	}
+
+	footer := make([]byte, footerSize)
+
+	if _, err := r.ReadAt(footer, size-int64(len(trailer))-footerSize); err != nil {
+		return nil, err
+	}
+
+	elements, err := readParquetFileMetaDataSchema(&thriftCompactReader{b: footer})
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading Parquet footer: %w", err)
+	}
+
+	if len(elements) == 0 {
+		return nil, errors.New("Parquet footer has no schema")
+	}
+
+	root, rest, err := buildParquetSchemaTree(elements)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rest) > 0 {
+		return nil, errors.New("invalid Parquet schema: elements after the root")
+	}
+
+	s := &inferredSchema{
+		Classification: schemaInferenceFormatParquet,
+		InputFormat:    "org.apache.hadoop.hive.ql.io.parquet.MapredParquetInputFormat",
+		OutputFormat:   "org.apache.hadoop.hive.ql.io.parquet.MapredParquetOutputFormat",
+		Parameters: map[string]string{
+			"classification": schemaInferenceFormatParquet,
+		},
+		SerDeParameters: map[string]string{
+			"serialization.format": "1",
+		},
+		SerializationLibrary: "org.apache.hadoop.hive.ql.io.parquet.serde.ParquetHiveSerDe",
+	}
+
+	for _, v := range root.Children {
+		t, err := parquetFieldType(v)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.addColumn(v.Name, t); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// buildParquetSchemaTree builds the tree of the depth-first list of schema elements,
+// returning the first element with its descendants and the remaining elements.
+func buildParquetSchemaTree(elements []*parquetSchemaElement) (*parquetSchemaElement, []*parquetSchemaElement, error) {
+	e, rest := elements[0], elements[1:]
+
+	for i := int32(0); i < e.NumChildren; i++ {
+		if len(rest) == 0 {
+			return nil, nil, fmt.Errorf("invalid Parquet schema: group (%s) is missing children", e.Name)
+		}
+
+		var child *parquetSchemaElement
+		var err error
+
+		child, rest, err = buildParquetSchemaTree(rest)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		e.Children = append(e.Children, child)
+	}
+
+	return e, rest, nil
+}
+
+// parquetFieldType returns the type of a field, which is an array for unannotated repeated fields.
+func parquetFieldType(e *parquetSchemaElement) (*glueType, error) {
+	t, err := parquetValueType(e)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if e.RepetitionType == parquetRepetitionTypeRepeated {
+		return glueTypeArrayOf(t), nil
+	}
+
+	return t, nil
+}
+
+func parquetValueType(e *parquetSchemaElement) (*glueType, error) {
+	if e.Type >= 0 {
+		return parquetPrimitiveType(e), nil
+	}
+
+	switch {
+	case e.ConvertedType == parquetConvertedTypeList || e.LogicalType == parquetLogicalTypeList:
+		return parquetListType(e)
+	case e.ConvertedType == parquetConvertedTypeMap || e.ConvertedType == parquetConvertedTypeMapKeyValue || e.LogicalType == parquetLogicalTypeMap:
+		return parquetMapType(e)
+	}
+
+	return parquetStructType(e)
+}
+
+func parquetStructType(e *parquetSchemaElement) (*glueType, error) {
+	t := &glueType{Name: glueTypeStruct}
+
+	for _, v := range e.Children {
+		field, err := parquetFieldType(v)
+
+		if err != nil {
+			return nil, err
+		}
+
+		t.Fields = append(t.Fields, &glueField{Name: v.Name, Type: field})
+	}
+
+	return t, nil
+}
+
+// parquetListType returns the type of a LIST annotated group, following the
+// backward-compatibility rules of the Parquet logical types specification.
+func parquetListType(e *parquetSchemaElement) (*glueType, error) {
+	if len(e.Children) != 1 || e.Children[0].RepetitionType != parquetRepetitionTypeRepeated {
+		return nil, fmt.Errorf("invalid Parquet LIST (%s): must have a single repeated field", e.Name)
+	}
+
+	repeated := e.Children[0]
+
+	if repeated.Type >= 0 {
+		return glueTypeArrayOf(parquetPrimitiveType(repeated)), nil
+	}
+
+	if len(repeated.Children) != 1 || repeated.Name == "array" || repeated.Name == e.Name+"_tuple" {
+		t, err := parquetStructType(repeated)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return glueTypeArrayOf(t), nil
+	}
+
+	t, err := parquetFieldType(repeated.Children[0])
+
+	if err != nil {
+		return nil, err
+	}
+
+	return glueTypeArrayOf(t), nil
+}
+
+func parquetMapType(e *parquetSchemaElement) (*glueType, error) {
+	if len(e.Children) != 1 || e.Children[0].RepetitionType != parquetRepetitionTypeRepeated || len(e.Children[0].Children) != 2 {
+		return nil, fmt.Errorf("invalid Parquet MAP (%s): must have a single repeated group of key and value", e.Name)
+	}
+
+	key, err := parquetValueType(e.Children[0].Children[0])
+
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := parquetFieldType(e.Children[0].Children[1])
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &glueType{Name: glueTypeMap, Elements: []*glueType{key, value}}, nil
+}
+
+func parquetPrimitiveType(e *parquetSchemaElement) *glueType {
+	switch {
+	case e.ConvertedType == parquetConvertedTypeUTF8, e.ConvertedType == parquetConvertedTypeEnum, e.ConvertedType == parquetConvertedTypeJSON,
+		e.LogicalType == parquetLogicalTypeString, e.LogicalType == parquetLogicalTypeEnum, e.LogicalType == parquetLogicalTypeJSON:
+		return glueTypePrimitive(glueTypeString)
+	case e.ConvertedType == parquetConvertedTypeDecimal, e.LogicalType == parquetLogicalTypeDecimal:
+		return glueTypePrimitive(fmt.Sprintf("decimal(%d,%d)", e.Precision, e.Scale))
+	case e.ConvertedType == parquetConvertedTypeDate, e.LogicalType == parquetLogicalTypeDate:
+		return glueTypePrimitive(glueTypeDate)
+	case e.ConvertedType == parquetConvertedTypeTimestampMillis, e.ConvertedType == parquetConvertedTypeTimestampMicros, e.LogicalType == parquetLogicalTypeTimestamp:
+		return glueTypePrimitive(glueTypeTimestamp)
+	case e.ConvertedType == parquetConvertedTypeInt8:
+		return glueTypePrimitive(glueTypeTinyint)
+	case e.ConvertedType == parquetConvertedTypeInt16, e.ConvertedType == parquetConvertedTypeUint8:
+		return glueTypePrimitive(glueTypeSmallint)
+	case e.ConvertedType == parquetConvertedTypeInt32, e.ConvertedType == parquetConvertedTypeUint16:
+		return glueTypePrimitive(glueTypeInt)
+	case e.ConvertedType == parquetConvertedTypeInt64, e.ConvertedType == parquetConvertedTypeUint32:
+		return glueTypePrimitive(glueTypeBigint)
+	case e.ConvertedType == parquetConvertedTypeUint64:
+		return glueTypePrimitive("decimal(20,0)")
+	case e.LogicalType == parquetLogicalTypeInteger:
+		return parquetIntegerGlueType(e.Integer)
+	}
+
+	switch e.Type {
+	case parquetTypeBoolean:
+		return glueTypePrimitive(glueTypeBoolean)
+	case parquetTypeInt32:
+		return glueTypePrimitive(glueTypeInt)
+	case parquetTypeInt64:
+		return glueTypePrimitive(glueTypeBigint)
+	case parquetTypeInt96:
+		return glueTypePrimitive(glueTypeTimestamp)
+	case parquetTypeFloat:
+		return glueTypePrimitive(glueTypeFloat)
+	case parquetTypeDouble:
+		return glueTypePrimitive(glueTypeDouble)
+	}
+
+	return glueTypePrimitive(glueTypeBinary)
+}
+
+// parquetIntegerGlueType returns the smallest Glue type that holds the values of an INTEGER logical type.
+func parquetIntegerGlueType(v parquetIntegerType) *glueType {
+	bitWidth := int(v.BitWidth)
+
+	if !v.IsSigned {
+		bitWidth *= 2
+	}
+
+	switch {
+	case bitWidth <= 8:
+		return glueTypePrimitive(glueTypeTinyint)
+	case bitWidth <= 16:
+		return glueTypePrimitive(glueTypeSmallint)
+	case bitWidth <= 32:
+		return glueTypePrimitive(glueTypeInt)
+	case bitWidth <= 64:
+		return glueTypePrimitive(glueTypeBigint)
+	}
+
+	return glueTypePrimitive("decimal(20,0)")
+}
+
+// readParquetFileMetaDataSchema reads the schema field of a Parquet FileMetaData structure, skipping the other fields.
+func readParquetFileMetaDataSchema(r *thriftCompactReader) ([]*parquetSchemaElement, error) {
+	var elements []*parquetSchemaElement
+
+	err := r.readStruct(func(id int16, typ byte) error {
+		if id != 2 || typ != thriftCompactTypeList {
+			return r.skip(typ)
+		}
+
+		elemType, n, err := r.readListHeader()
+
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i < n; i++ {
+			if elemType != thriftCompactTypeStruct {
+				if err := r.skip(elemType); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			e, err := readParquetSchemaElement(r)
+
+			if err != nil {
+				return err
+			}
+
+			elements = append(elements, e)
+		}
+
+		return nil
+	})
+
+	return elements, err
+}
+
+func readParquetSchemaElement(r *thriftCompactReader) (*parquetSchemaElement, error) {
+	e := &parquetSchemaElement{
+		ConvertedType: -1,
+		Type:          -1,
+	}
+
+	err := r.readStruct(func(id int16, typ byte) error {
+		var err error
+
+		switch {
+		case id == 1 && typ == thriftCompactTypeI32:
+			e.Type, err = r.readI32()
+		case id == 3 && typ == thriftCompactTypeI32:
+			e.RepetitionType, err = r.readI32()
+		case id == 4 && typ == thriftCompactTypeBinary:
+			var b []byte
+			b, err = r.readBinary()
+			e.Name = string(b)
+		case id == 5 && typ == thriftCompactTypeI32:
+			e.NumChildren, err = r.readI32()
+		case id == 6 && typ == thriftCompactTypeI32:
+			e.ConvertedType, err = r.readI32()
+		case id == 7 && typ == thriftCompactTypeI32:
+			e.Scale, err = r.readI32()
+		case id == 8 && typ == thriftCompactTypeI32:
+			e.Precision, err = r.readI32()
+		case id == 10 && typ == thriftCompactTypeStruct:
+			err = readParquetLogicalType(r, e)
+		default:
+			err = r.skip(typ)
+		}
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if e.NumChildren < 0 {
+		return nil, fmt.Errorf("invalid number of children (%d) of Parquet schema element (%s)", e.NumChildren, e.Name)
+	}
+
+	return e, nil
+}
+
+// readParquetLogicalType reads the LogicalType union, including the parameters of the DECIMAL and INTEGER members.
+func readParquetLogicalType(r *thriftCompactReader, e *parquetSchemaElement) error {
+	return r.readStruct(func(id int16, typ byte) error {
+		if typ != thriftCompactTypeStruct {
+			return r.skip(typ)
+		}
+
+		e.LogicalType = id
+
+		return r.readStruct(func(fieldID int16, fieldType byte) error {
+			var err error
+
+			switch {
+			case id == parquetLogicalTypeDecimal && fieldID == 1 && fieldType == thriftCompactTypeI32:
+				e.Scale, err = r.readI32()
+			case id == parquetLogicalTypeDecimal && fieldID == 2 && fieldType == thriftCompactTypeI32:
+				e.Precision, err = r.readI32()
+			case id == parquetLogicalTypeInteger && fieldID == 1 && fieldType == thriftCompactTypeByte:
+				var b byte
+				b, err = r.readByte()
+				e.Integer.BitWidth = int8(b)
+			case id == parquetLogicalTypeInteger && fieldID == 2 && (fieldType == thriftCompactTypeBooleanTrue || fieldType == thriftCompactTypeBooleanFalse):
+				e.Integer.IsSigned = fieldType == thriftCompactTypeBooleanTrue
+			default:
+				err = r.skip(fieldType)
+			}
+
+			return err
+		})
+	})
+}
+
+// Thrift compact protocol types.
+const (
+	thriftCompactTypeStop         = 0
+	thriftCompactTypeBooleanTrue  = 1
+	thriftCompactTypeBooleanFalse = 2
+	thriftCompactTypeByte         = 3
+	thriftCompactTypeI16          = 4
+	thriftCompactTypeI32          = 5
+	thriftCompactTypeI64          = 6
+	thriftCompactTypeDouble       = 7
+	thriftCompactTypeBinary       = 8
+	thriftCompactTypeList         = 9
+	thriftCompactTypeSet          = 10
+	thriftCompactTypeMap          = 11
+	thriftCompactTypeStruct       = 12
+
+	thriftCompactMaxDepth = 64
+)
+
+// thriftCompactReader reads the Thrift compact protocol encoding that Parquet uses for its metadata.
+type thriftCompactReader struct {
+	b     []byte
+	depth int
+	pos   int
+}
+
+func (r *thriftCompactReader) readByte() (byte, error) {
+	if r.pos >= len(r.b) {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	b := r.b[r.pos]
+	r.pos++
+
+	return b, nil
+}
+
+func (r *thriftCompactReader) readUvarint() (uint64, error) {
+	v, n := binary.Uvarint(r.b[r.pos:])
+
+	if n <= 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	r.pos += n
+
+	return v, nil
+}
+
+func (r *thriftCompactReader) readVarint() (int64, error) {
+	v, err := r.readUvarint()
+
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(v>>1) ^ -int64(v&1), nil
+}
+
+func (r *thriftCompactReader) readI32() (int32, error) {
+	v, err := r.readVarint()
+
+	if err != nil {
+		return 0, err
+	}
+
+	if v < math.MinInt32 || v > math.MaxInt32 {
+		return 0, fmt.Errorf("i32 out of range (%d)", v)
+	}
+
+	return int32(v), nil
+}
+
+func (r *thriftCompactReader) readBinary() ([]byte, error) {
+	n, err := r.readUvarint()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if n > uint64(len(r.b)-r.pos) {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	b := r.b[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+
+	return b, nil
+}
+
+func (r *thriftCompactReader) readListHeader() (byte, int, error) {
+	b, err := r.readByte()
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	n := uint64(b >> 4)
+
+	if n == 0x0f {
+		if n, err = r.readUvarint(); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	// Each element takes at least one byte.
+	if n > uint64(len(r.b)-r.pos) {
+		return 0, 0, io.ErrUnexpectedEOF
+	}
+
+	return b & 0x0f, int(n), nil
+}
+
+// readStruct calls f with the ID and type of each field of a struct, which must read or skip the field's value.
+func (r *thriftCompactReader) readStruct(f func(id int16, typ byte) error) error {
+	if r.depth++; r.depth > thriftCompactMaxDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+
+	defer func() { r.depth-- }()
+
+	var lastID int16
+
+	for {
+		b, err := r.readByte()
+
+		if err != nil {
+			return err
+		}
+
+		typ := b & 0x0f
+
+		if typ == thriftCompactTypeStop {
+			return nil
+		}
+
+		id := lastID + int16(b>>4)
+
+		if b>>4 == 0 {
+			v, err := r.readVarint()
+
+			if err != nil {
+				return err
+			}
+
+			id = int16(v)
+		}
+
+		if err := f(id, typ); err != nil {
+			return err
+		}
+
+		lastID = id
+	}
+}
+
+// skip skips a value of the specified type. Boolean field values are part of the field header,
+// but booleans in lists, sets and maps take a byte.
+func (r *thriftCompactReader) skip(typ byte) error {
+	switch typ {
+	case thriftCompactTypeBooleanTrue, thriftCompactTypeBooleanFalse:
+		return nil
+	case thriftCompactTypeByte:
+		_, err := r.readByte()
+		return err
+	case thriftCompactTypeI16, thriftCompactTypeI32, thriftCompactTypeI64:
+		_, err := r.readUvarint()
+		return err
+	case thriftCompactTypeDouble:
+		if len(r.b)-r.pos < 8 {
+			return io.ErrUnexpectedEOF
+		}
+		r.pos += 8
+		return nil
+	case thriftCompactTypeBinary:
+		_, err := r.readBinary()
+		return err
+	case thriftCompactTypeList, thriftCompactTypeSet:
+		elemType, n, err := r.readListHeader()
+
+		if err != nil {
+			return err
+		}
+
+		return r.skipElements(elemType, n)
+	case thriftCompactTypeMap:
+		n, err := r.readUvarint()
+
+		if err != nil || n == 0 {
+			return err
+		}
+
+		if n > uint64(len(r.b)-r.pos) {
+			return io.ErrUnexpectedEOF
+		}
+
+		b, err := r.readByte()
+
+		if err != nil {
+			return err
+		}
+
+		for i := uint64(0); i < n; i++ {
+			if err := r.skipElements(b>>4, 1); err != nil {
+				return err
+			}
+
+			if err := r.skipElements(b&0x0f, 1); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case thriftCompactTypeStruct:
+		return r.readStruct(func(_ int16, typ byte) error {
+			return r.skip(typ)
+		})
+	}
+
+	return fmt.Errorf("unknown Thrift compact type (%d)", typ)
+}
+
+func (r *thriftCompactReader) skipElements(typ byte, n int) error {
+	for i := 0; i < n; i++ {
+		var err error
+
+		if typ == thriftCompactTypeBooleanTrue || typ == thriftCompactTypeBooleanFalse {
+			_, err = r.readByte()
+		} else {
+			err = r.skip(typ)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
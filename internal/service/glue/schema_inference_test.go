@@ -0,0 +1,634 @@
+package glue
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+func TestInferCSVSchema(t *testing.T) {
+	testCases := []struct {
+		Name                 string
+		Input                string
+		Delimiter            rune
+		SampleSize           int
+		ExpectedColumns      []string
+		ExpectedSerDe        string
+		ExpectedSerDeParams  map[string]string
+		ExpectErrorSubstring string
+	}{
+		{
+			Name:      "types",
+			Input:     "\ufeffid,price,active,day,at,name,empty\n1,1.5,true,2021-01-02,2021-01-02 03:04:05,a,\n2,2,FALSE,2021-01-03,2021-01-03T03:04:05.123,b,\n",
+			Delimiter: ',',
+			ExpectedColumns: []string{
+				"id:bigint",
+				"price:double",
+				"active:boolean",
+				"day:date",
+				"at:timestamp",
+				"name:string",
+				"empty:string",
+			},
+			ExpectedSerDe:       "org.apache.hadoop.hive.serde2.lazy.LazySimpleSerDe",
+			ExpectedSerDeParams: map[string]string{"field.delim": ","},
+		},
+		{
+			Name:      "merged types",
+			Input:     "a|b|c\n1|2021-01-02|1\n1.5|2021-01-02 03:04:05|x\n",
+			Delimiter: '|',
+			ExpectedColumns: []string{
+				"a:double",
+				"b:timestamp",
+				"c:string",
+			},
+			ExpectedSerDe:       "org.apache.hadoop.hive.serde2.lazy.LazySimpleSerDe",
+			ExpectedSerDeParams: map[string]string{"field.delim": "|"},
+		},
+		{
+			Name:      "quoted",
+			Input:     "id,comment\n1,\"a, b\"\n",
+			Delimiter: ',',
+			ExpectedColumns: []string{
+				"id:bigint",
+				"comment:string",
+			},
+			ExpectedSerDe:       "org.apache.hadoop.hive.serde2.OpenCSVSerde",
+			ExpectedSerDeParams: map[string]string{"escapeChar": `\`, "quoteChar": `"`, "separatorChar": ","},
+		},
+		{
+			Name:       "sample size",
+			Input:      "a\n1\nx\n",
+			Delimiter:  ',',
+			SampleSize: 1,
+			ExpectedColumns: []string{
+				"a:bigint",
+			},
+			ExpectedSerDe:       "org.apache.hadoop.hive.serde2.lazy.LazySimpleSerDe",
+			ExpectedSerDeParams: map[string]string{"field.delim": ","},
+		},
+		{
+			Name:                 "empty",
+			Input:                "",
+			Delimiter:            ',',
+			ExpectErrorSubstring: "no header line",
+		},
+		{
+			Name:                 "duplicate column",
+			Input:                "a,A\n1,2\n",
+			Delimiter:            ',',
+			ExpectErrorSubstring: "duplicate column (A)",
+		},
+		{
+			Name:                 "missing column name",
+			Input:                "a,\n1,2\n",
+			Delimiter:            ',',
+			ExpectErrorSubstring: "column 2 has no name",
+		},
+		{
+			Name:                 "wrong number of fields",
+			Input:                "a,b\n1,2,3\n",
+			Delimiter:            ',',
+			ExpectErrorSubstring: "wrong number of fields",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			sampleSize := testCase.SampleSize
+
+			if sampleSize == 0 {
+				sampleSize = 1000
+			}
+
+			s, err := inferCSVSchema(strings.NewReader(testCase.Input), testCase.Delimiter, sampleSize)
+
+			if !testCheckSchemaInferenceError(t, err, testCase.ExpectErrorSubstring) {
+				return
+			}
+
+			testCheckInferredSchema(t, s, testCase.ExpectedColumns, testCase.ExpectedSerDe, testCase.ExpectedSerDeParams)
+
+			if got, want := s.Parameters["skip.header.line.count"], "1"; got != want {
+				t.Errorf("got skip.header.line.count %q, expected %q", got, want)
+			}
+		})
+	}
+}
+
+func TestInferJSONSchema(t *testing.T) {
+	testCases := []struct {
+		Name                 string
+		Input                string
+		ExpectedColumns      []string
+		ExpectErrorSubstring string
+	}{
+		{
+			Name: "nested",
+			Input: `{"id": 1, "name": "a", "tags": ["x"], "address": {"city": "b", "zip": 12345}, "scores": [1, 2.5], "missing": null}
+
+{"id": 2, "active": true, "address": {"street": "c", "zip": 67890}, "events": [{"at": "d"}, {"n": 1}], "missing": null}
+`,
+			ExpectedColumns: []string{
+				"id:bigint",
+				"name:string",
+				"tags:array<string>",
+				"address:struct<city:string,zip:bigint,street:string>",
+				"scores:array<double>",
+				"missing:string",
+				"active:boolean",
+				"events:array<struct<at:string,n:bigint>>",
+			},
+		},
+		{
+			Name:  "conflicting types",
+			Input: "{\"a\": 1, \"b\": {\"c\": 1}}\n{\"a\": \"x\", \"b\": [1]}\n",
+			ExpectedColumns: []string{
+				"a:string",
+				"b:string",
+			},
+		},
+		{
+			Name:                 "not an object",
+			Input:                "{\"a\": 1}\n[1]\n",
+			ExpectErrorSubstring: "line 2: not a JSON object",
+		},
+		{
+			Name:                 "multiple values",
+			Input:                "{\"a\": 1} {\"a\": 2}\n",
+			ExpectErrorSubstring: "line 1: more than one JSON value",
+		},
+		{
+			Name:                 "invalid",
+			Input:                "{\"a\"}\n",
+			ExpectErrorSubstring: "line 1: invalid character",
+		},
+		{
+			Name:                 "empty",
+			Input:                "\n\n",
+			ExpectErrorSubstring: "no records",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			s, err := inferJSONSchema(strings.NewReader(testCase.Input), 1000)
+
+			if !testCheckSchemaInferenceError(t, err, testCase.ExpectErrorSubstring) {
+				return
+			}
+
+			testCheckInferredSchema(t, s, testCase.ExpectedColumns, "org.openx.data.jsonserde.JsonSerDe", nil)
+		})
+	}
+}
+
+func TestInferAvroSchema(t *testing.T) {
+	testCases := []struct {
+		Name                 string
+		Schema               string
+		ExpectedColumns      []string
+		ExpectErrorSubstring string
+	}{
+		{
+			Name: "types",
+			Schema: `{
+  "type": "record",
+  "name": "Order",
+  "namespace": "com.example",
+  "fields": [
+    {"name": "id", "type": "long"},
+    {"name": "name", "type": ["null", "string"]},
+    {"name": "amount", "type": {"type": "bytes", "logicalType": "decimal", "precision": 10, "scale": 2}},
+    {"name": "created", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+    {"name": "day", "type": {"type": "int", "logicalType": "date"}},
+    {"name": "tags", "type": {"type": "array", "items": "string"}},
+    {"name": "attributes", "type": {"type": "map", "values": "long"}},
+    {"name": "shipping", "type": {"type": "record", "name": "Address", "fields": [{"name": "street", "type": "string"}]}},
+    {"name": "billing", "type": ["null", "com.example.Address"]},
+    {"name": "status", "type": {"type": "enum", "name": "Status", "symbols": ["NEW", "DONE"]}},
+    {"name": "hash", "type": {"type": "fixed", "name": "Hash", "size": 16}},
+    {"name": "value", "type": ["null", "int", "string"]},
+    {"name": "ratio", "type": {"type": "float"}}
+  ]
+}`,
+			ExpectedColumns: []string{
+				"id:bigint",
+				"name:string",
+				"amount:decimal(10,2)",
+				"created:timestamp",
+				"day:date",
+				"tags:array<string>",
+				"attributes:map<string,bigint>",
+				"shipping:struct<street:string>",
+				"billing:struct<street:string>",
+				"status:string",
+				"hash:binary",
+				"value:uniontype<int,string>",
+				"ratio:float",
+			},
+		},
+		{
+			Name:                 "recursive",
+			Schema:               `{"type": "record", "name": "Node", "fields": [{"name": "next", "type": ["null", "Node"]}]}`,
+			ExpectErrorSubstring: "recursive Avro type (Node) is not supported",
+		},
+		{
+			Name:                 "undefined",
+			Schema:               `{"type": "record", "name": "Node", "fields": [{"name": "x", "type": "Missing"}]}`,
+			ExpectErrorSubstring: "undefined Avro type (Missing)",
+		},
+		{
+			Name:                 "not a record",
+			Schema:               `"string"`,
+			ExpectErrorSubstring: "Avro schema is not a record",
+		},
+		{
+			Name:                 "unnamed record",
+			Schema:               `{"type": "record", "fields": []}`,
+			ExpectErrorSubstring: "Avro record has no name",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			s, err := inferAvroSchema(bytes.NewReader(testAvroHeader(testCase.Schema)))
+
+			if !testCheckSchemaInferenceError(t, err, testCase.ExpectErrorSubstring) {
+				return
+			}
+
+			testCheckInferredSchema(t, s, testCase.ExpectedColumns, "org.apache.hadoop.hive.serde2.avro.AvroSerDe", map[string]string{"avro.schema.literal": testCase.Schema})
+		})
+	}
+
+	if _, err := inferAvroSchema(strings.NewReader("PAR1")); err == nil {
+		t.Error("expected error for a file that is not an Avro object container file")
+	}
+}
+
+func TestInferParquetSchema(t *testing.T) {
+	// message schema {
+	//   required int64 id;
+	//   optional binary name (UTF8);
+	//   optional fixed_len_byte_array(5) price (DECIMAL(10,2));
+	//   optional int64 created (TIMESTAMP(MILLIS,true));
+	//   optional group tags (LIST) { repeated group list { optional binary element (STRING); } }
+	//   optional group attributes (MAP) { repeated group key_value { required binary key (UTF8); optional int32 value; } }
+	//   optional group point { required double x; required double y; }
+	//   repeated int32 legacy;
+	//   optional group pairs (LIST) { repeated group array { required int32 a; required int32 b; } }
+	//   optional group ids (LIST) { repeated int64 ids; }
+	//   optional int32 small (INTEGER(8,true));
+	//   optional int32 unsigned (UINT_32);
+	//   optional int96 legacy_timestamp;
+	// }
+	elements := []testParquetSchemaElement{
+		{Name: "schema", NumChildren: 13},
+		{Name: "id", Type: parquetTypeInt64, Repetition: 0},
+		{Name: "name", Type: parquetTypeByteArray, Repetition: 1, ConvertedType: testParquetConvertedType(parquetConvertedTypeUTF8)},
+		{Name: "price", Type: parquetTypeFixedLenByteArray, Repetition: 1, ConvertedType: testParquetConvertedType(parquetConvertedTypeDecimal), Precision: 10, Scale: 2},
+		{Name: "created", Type: parquetTypeInt64, Repetition: 1, LogicalType: parquetLogicalTypeTimestamp},
+		{Name: "tags", Repetition: 1, NumChildren: 1, ConvertedType: testParquetConvertedType(parquetConvertedTypeList), LogicalType: parquetLogicalTypeList},
+		{Name: "list", Repetition: 2, NumChildren: 1},
+		{Name: "element", Type: parquetTypeByteArray, Repetition: 1, LogicalType: parquetLogicalTypeString},
+		{Name: "attributes", Repetition: 1, NumChildren: 1, ConvertedType: testParquetConvertedType(parquetConvertedTypeMap)},
+		{Name: "key_value", Repetition: 2, NumChildren: 2, ConvertedType: testParquetConvertedType(parquetConvertedTypeMapKeyValue)},
+		{Name: "key", Type: parquetTypeByteArray, Repetition: 0, ConvertedType: testParquetConvertedType(parquetConvertedTypeUTF8)},
+		{Name: "value", Type: parquetTypeInt32, Repetition: 1},
+		{Name: "point", Repetition: 1, NumChildren: 2},
+		{Name: "x", Type: parquetTypeDouble, Repetition: 0},
+		{Name: "y", Type: parquetTypeDouble, Repetition: 0},
+		{Name: "legacy", Type: parquetTypeInt32, Repetition: 2},
+		{Name: "pairs", Repetition: 1, NumChildren: 1, ConvertedType: testParquetConvertedType(parquetConvertedTypeList)},
+		{Name: "array", Repetition: 2, NumChildren: 2},
+		{Name: "a", Type: parquetTypeInt32, Repetition: 0},
+		{Name: "b", Type: parquetTypeInt32, Repetition: 0},
+		{Name: "ids", Repetition: 1, NumChildren: 1, ConvertedType: testParquetConvertedType(parquetConvertedTypeList)},
+		{Name: "ids", Type: parquetTypeInt64, Repetition: 2},
+		{Name: "small", Type: parquetTypeInt32, Repetition: 1, LogicalType: parquetLogicalTypeInteger, BitWidth: 8, IsSigned: true},
+		{Name: "unsigned", Type: parquetTypeInt32, Repetition: 1, ConvertedType: testParquetConvertedType(parquetConvertedTypeUint32)},
+		{Name: "legacy_timestamp", Type: parquetTypeInt96, Repetition: 1},
+	}
+
+	s, err := inferParquetSchema(bytes.NewReader(testParquetFile(elements)), int64(len(testParquetFile(elements))))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	testCheckInferredSchema(t, s, []string{
+		"id:bigint",
+		"name:string",
+		"price:decimal(10,2)",
+		"created:timestamp",
+		"tags:array<string>",
+		"attributes:map<string,int>",
+		"point:struct<x:double,y:double>",
+		"legacy:array<int>",
+		"pairs:array<struct<a:int,b:int>>",
+		"ids:array<bigint>",
+		"small:tinyint",
+		"unsigned:bigint",
+		"legacy_timestamp:timestamp",
+	}, "org.apache.hadoop.hive.ql.io.parquet.serde.ParquetHiveSerDe", map[string]string{"serialization.format": "1"})
+
+	for _, testCase := range []struct {
+		Name                 string
+		Input                []byte
+		ExpectErrorSubstring string
+	}{
+		{
+			Name:                 "not a Parquet file",
+			Input:                []byte("PAR1 not a parquet file"),
+			ExpectErrorSubstring: "not a Parquet file",
+		},
+		{
+			Name:                 "footer size",
+			Input:                []byte("PAR1\xff\xff\x00\x00PAR1"),
+			ExpectErrorSubstring: "invalid Parquet footer size (65535)",
+		},
+		{
+			Name:                 "too small",
+			Input:                testParquetFile(elements)[:4],
+			ExpectErrorSubstring: "not a Parquet file",
+		},
+		{
+			Name:                 "truncated footer",
+			Input:                []byte("PAR1\x19\x01\x00\x00\x00PAR1"),
+			ExpectErrorSubstring: "error reading Parquet footer: unexpected EOF",
+		},
+		{
+			Name: "missing children",
+			Input: testParquetFile([]testParquetSchemaElement{
+				{Name: "schema", NumChildren: 2},
+				{Name: "id", Type: parquetTypeInt64},
+			}),
+			ExpectErrorSubstring: "group (schema) is missing children",
+		},
+		{
+			Name: "invalid list",
+			Input: testParquetFile([]testParquetSchemaElement{
+				{Name: "schema", NumChildren: 1},
+				{Name: "tags", Repetition: 1, NumChildren: 1, ConvertedType: testParquetConvertedType(parquetConvertedTypeList)},
+				{Name: "element", Type: parquetTypeInt32, Repetition: 1},
+			}),
+			ExpectErrorSubstring: "invalid Parquet LIST (tags)",
+		},
+	} {
+		t.Run(testCase.Name, func(t *testing.T) {
+			_, err := inferParquetSchema(bytes.NewReader(testCase.Input), int64(len(testCase.Input)))
+
+			testCheckSchemaInferenceError(t, err, testCase.ExpectErrorSubstring)
+		})
+	}
+}
+
+// testCheckSchemaInferenceError checks the error of a test case, returning whether the test case expects success.
+func testCheckSchemaInferenceError(t *testing.T, err error, expectErrorSubstring string) bool {
+	t.Helper()
+
+	if expectErrorSubstring == "" {
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		return true
+	}
+
+	if err == nil {
+		t.Fatalf("expected error containing %q, got no error", expectErrorSubstring)
+	}
+
+	if !strings.Contains(err.Error(), expectErrorSubstring) {
+		t.Fatalf("expected error containing %q, got: %s", expectErrorSubstring, err)
+	}
+
+	return false
+}
+
+func testCheckInferredSchema(t *testing.T, s *inferredSchema, expectedColumns []string, expectedSerDe string, expectedSerDeParams map[string]string) {
+	t.Helper()
+
+	var columns []string
+
+	for _, v := range s.Columns {
+		columns = append(columns, v.Name+":"+v.Type.String())
+	}
+
+	if got, want := strings.Join(columns, "\n"), strings.Join(expectedColumns, "\n"); got != want {
+		t.Errorf("got columns:\n%s\n\nexpected:\n%s", got, want)
+	}
+
+	if s.SerializationLibrary != expectedSerDe {
+		t.Errorf("got serialization library %q, expected %q", s.SerializationLibrary, expectedSerDe)
+	}
+
+	for k, want := range expectedSerDeParams {
+		if got := s.SerDeParameters[k]; got != want {
+			t.Errorf("got SerDe parameter %s %q, expected %q", k, got, want)
+		}
+	}
+
+	if got, want := s.Parameters["classification"], s.Classification; got != want {
+		t.Errorf("got classification parameter %q, expected %q", got, want)
+	}
+}
+
+func testAvroHeader(schema string) []byte {
+	var b bytes.Buffer
+
+	writeLong := func(v int64) {
+		buf := make([]byte, binary.MaxVarintLen64)
+		b.Write(buf[:binary.PutVarint(buf, v)])
+	}
+	writeBytes := func(v string) {
+		writeLong(int64(len(v)))
+		b.WriteString(v)
+	}
+
+	b.Write(avroMagic)
+	writeLong(2)
+	writeBytes("avro.codec")
+	writeBytes("null")
+	writeBytes("avro.schema")
+	writeBytes(schema)
+	writeLong(0)
+	b.Write(bytes.Repeat([]byte{0xab}, 16)) // Sync marker.
+
+	return b.Bytes()
+}
+
+type testParquetSchemaElement struct {
+	BitWidth      int8
+	ConvertedType *int32
+	IsSigned      bool
+	LogicalType   int16
+	Name          string
+	NumChildren   int32
+	Precision     int32
+	Repetition    int32
+	Scale         int32
+	Type          int32
+}
+
+func testParquetConvertedType(v int32) *int32 {
+	return &v
+}
+
+// testParquetFile returns a Parquet file without row groups whose footer contains the schema elements.
+func testParquetFile(elements []testParquetSchemaElement) []byte {
+	w := &testThriftCompactWriter{}
+
+	// FileMetaData.
+	w.fieldHeader(1, thriftCompactTypeI32)
+	w.varint(1)
+	w.fieldHeader(2, thriftCompactTypeList)
+	w.listHeader(thriftCompactTypeStruct, len(elements))
+
+	for _, e := range elements {
+		w.structBegin()
+
+		if e.NumChildren == 0 {
+			w.fieldHeader(1, thriftCompactTypeI32)
+			w.varint(int64(e.Type))
+		}
+
+		if e.Type == parquetTypeFixedLenByteArray {
+			w.fieldHeader(2, thriftCompactTypeI32)
+			w.varint(5)
+		}
+
+		w.fieldHeader(3, thriftCompactTypeI32)
+		w.varint(int64(e.Repetition))
+		w.fieldHeader(4, thriftCompactTypeBinary)
+		w.binary(e.Name)
+
+		if e.NumChildren > 0 {
+			w.fieldHeader(5, thriftCompactTypeI32)
+			w.varint(int64(e.NumChildren))
+		}
+
+		if e.ConvertedType != nil {
+			w.fieldHeader(6, thriftCompactTypeI32)
+			w.varint(int64(*e.ConvertedType))
+		}
+
+		if e.Precision > 0 {
+			w.fieldHeader(7, thriftCompactTypeI32)
+			w.varint(int64(e.Scale))
+			w.fieldHeader(8, thriftCompactTypeI32)
+			w.varint(int64(e.Precision))
+		}
+
+		// An unknown field, which must be skipped.
+		w.fieldHeader(100, thriftCompactTypeList)
+		w.listHeader(thriftCompactTypeBooleanTrue, 2)
+		w.b.Write([]byte{1, 0})
+
+		if e.LogicalType != 0 {
+			w.fieldHeader(10, thriftCompactTypeStruct)
+			w.structBegin()
+			w.fieldHeader(e.LogicalType, thriftCompactTypeStruct)
+			w.structBegin()
+
+			switch e.LogicalType {
+			case parquetLogicalTypeInteger:
+				w.fieldHeader(1, thriftCompactTypeByte)
+				w.b.WriteByte(byte(e.BitWidth))
+
+				if e.IsSigned {
+					w.fieldHeader(2, thriftCompactTypeBooleanTrue)
+				} else {
+					w.fieldHeader(2, thriftCompactTypeBooleanFalse)
+				}
+			case parquetLogicalTypeTimestamp:
+				w.fieldHeader(1, thriftCompactTypeBooleanTrue)
+				w.fieldHeader(2, thriftCompactTypeStruct)
+				w.structBegin()
+				w.fieldHeader(1, thriftCompactTypeStruct)
+				w.structBegin()
+				w.structEnd()
+				w.structEnd()
+			}
+
+			w.structEnd()
+			w.structEnd()
+		}
+
+		w.structEnd()
+	}
+
+	w.fieldHeader(3, thriftCompactTypeI64)
+	w.varint(0)
+	w.fieldHeader(4, thriftCompactTypeList)
+	w.listHeader(thriftCompactTypeStruct, 0)
+	w.fieldHeader(6, thriftCompactTypeBinary)
+	w.binary("test")
+	w.structEnd()
+
+	footer := w.b.Bytes()
+
+	var b bytes.Buffer
+
+	b.Write(parquetMagic)
+	b.Write(footer)
+	binary.Write(&b, binary.LittleEndian, uint32(len(footer))) //nolint:errcheck
+	b.Write(parquetMagic)
+
+	return b.Bytes()
+}
+
+type testThriftCompactWriter struct {
+	b       bytes.Buffer
+	lastIDs []int16
+	lastID  int16
+}
+
+func (w *testThriftCompactWriter) fieldHeader(id int16, typ byte) {
+	if delta := id - w.lastID; delta > 0 && delta <= 15 {
+		w.b.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.b.WriteByte(typ)
+		w.varint(int64(id))
+	}
+
+	w.lastID = id
+}
+
+func (w *testThriftCompactWriter) structBegin() {
+	w.lastIDs = append(w.lastIDs, w.lastID)
+	w.lastID = 0
+}
+
+func (w *testThriftCompactWriter) structEnd() {
+	w.b.WriteByte(thriftCompactTypeStop)
+
+	if n := len(w.lastIDs); n > 0 {
+		w.lastID = w.lastIDs[n-1]
+		w.lastIDs = w.lastIDs[:n-1]
+	}
+}
+
+func (w *testThriftCompactWriter) listHeader(elemType byte, n int) {
+	if n < 15 {
+		w.b.WriteByte(byte(n)<<4 | elemType)
+		return
+	}
+
+	w.b.WriteByte(0xf0 | elemType)
+	buf := make([]byte, binary.MaxVarintLen64)
+	w.b.Write(buf[:binary.PutUvarint(buf, uint64(n))])
+}
+
+func (w *testThriftCompactWriter) varint(v int64) {
+	buf := make([]byte, binary.MaxVarintLen64)
+	w.b.Write(buf[:binary.PutVarint(buf, v)])
+}
+
+func (w *testThriftCompactWriter) binary(v string) {
+	buf := make([]byte, binary.MaxVarintLen64)
+	w.b.Write(buf[:binary.PutUvarint(buf, uint64(len(v)))])
+	w.b.WriteString(v)
+}
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/networkfirewall"
 	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -396,7 +398,10 @@ func ResourceRuleGroup() *schema.Resource {
 			},
 		},
 
-		CustomizeDiff: verify.SetTagsDiff,
+		CustomizeDiff: customdiff.Sequence(
+			resourceRuleGroupRulesStringCustomizeDiff,
+			verify.SetTagsDiff,
+		),
 	}
 }
 
@@ -579,6 +584,76 @@ func resourceRuleGroupDelete(ctx context.Context, d *schema.ResourceData, meta i
 	return nil
 }
 
+// resourceRuleGroupRulesStringCustomizeDiff validates the Suricata compatible rules of a stateful rule group
+// and that its capacity is sufficient for them.
+func resourceRuleGroupRulesStringCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Get("type").(string) != networkfirewall.RuleGroupTypeStateful {
+		return nil
+	}
+
+	// Only validate new or changed rules.
+	if diff.Id() != "" && !diff.HasChange("capacity") && !diff.HasChange("rule_group") && !diff.HasChange("rules") {
+		return nil
+	}
+
+	var rulesString string
+	variables := &SuricataRuleVariables{}
+
+	if !diff.NewValueKnown("rules") {
+		return nil
+	} else if v := diff.Get("rules").(string); v != "" {
+		rulesString = v
+	} else {
+		if !diff.NewValueKnown("rule_group.0.rules_source.0.rules_string") {
+			return nil
+		}
+
+		rulesString = diff.Get("rule_group.0.rules_source.0.rules_string").(string)
+
+		if !diff.NewValueKnown("rule_group.0.rule_variables") {
+			variables = nil
+		} else {
+			if v, ok := diff.Get("rule_group.0.rule_variables.0.ip_sets").(*schema.Set); ok {
+				for _, tfMapRaw := range v.List() {
+					variables.IPSets = append(variables.IPSets, tfMapRaw.(map[string]interface{})["key"].(string))
+				}
+			}
+			if v, ok := diff.Get("rule_group.0.rule_variables.0.port_sets").(*schema.Set); ok {
+				for _, tfMapRaw := range v.List() {
+					variables.PortSets = append(variables.PortSets, tfMapRaw.(map[string]interface{})["key"].(string))
+				}
+			}
+		}
+	}
+
+	// rules_string may also be the name of a file in an S3 bucket.
+	if v := strings.TrimSpace(rulesString); v == "" || !strings.ContainsAny(v, " \t\n") {
+		return nil
+	}
+
+	rules, err := ParseSuricataRules(rulesString)
+
+	if err != nil {
+		return fmt.Errorf("error parsing NetworkFirewall Rule Group rules: %w", err)
+	}
+
+	warnings, err := ValidateSuricataRules(rules, variables)
+
+	if err != nil {
+		return fmt.Errorf("invalid NetworkFirewall Rule Group rules: %w", err)
+	}
+
+	for _, warning := range warnings {
+		log.Printf("[WARN] NetworkFirewall Rule Group rules: %s", warning)
+	}
+
+	if capacity := EstimateSuricataCapacity(rules); diff.NewValueKnown("capacity") && diff.Get("capacity").(int) < capacity {
+		return fmt.Errorf("capacity (%d) is less than the %d required by the rule group's rules", diff.Get("capacity").(int), capacity)
+	}
+
+	return nil
+}
+
 func expandNetworkFirewallStatefulRuleHeader(l []interface{}) *networkfirewall.Header {
 	if len(l) == 0 || l[0] == nil {
 		return nil
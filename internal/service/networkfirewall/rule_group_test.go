@@ -3,6 +3,7 @@ package networkfirewall_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/networkfirewall"
@@ -179,6 +180,31 @@ func TestAccNetworkFirewallRuleGroup_Basic_rules(t *testing.T) {
 	})
 }
 
+func TestAccNetworkFirewallRuleGroup_invalidRules(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t); testAccPreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, networkfirewall.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckRuleGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNetworkFirewallRuleGroup_basic_rules(rName, `alert tcp $CLIENTS any -> any 70000 (msg:"example"; sid:1;)`),
+				ExpectError: regexp.MustCompile(`IP set variable \(CLIENTS\) is not defined`),
+			},
+			{
+				Config:      testAccNetworkFirewallRuleGroup_basic_rules(rName, "alert tcp any any -> any any (sid:1;)\nalert tcp any any -> any any (sid:1;)"),
+				ExpectError: regexp.MustCompile(`sid \(1\) is already used on line 1`),
+			},
+			{
+				Config:      testAccNetworkFirewallRuleGroup_rulesStringCapacity(rName, 1),
+				ExpectError: regexp.MustCompile(`capacity \(1\) is less than the 2 required`),
+			},
+		},
+	})
+}
+
 func TestAccNetworkFirewallRuleGroup_statelessRuleWithCustomAction(t *testing.T) {
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
 	resourceName := "aws_networkfirewall_rule_group.test"
@@ -1145,6 +1171,34 @@ resource "aws_networkfirewall_rule_group" "test" {
 `, rName, rules)
 }
 
+func testAccNetworkFirewallRuleGroup_rulesStringCapacity(rName string, capacity int) string {
+	return fmt.Sprintf(`
+resource "aws_networkfirewall_rule_group" "test" {
+  capacity = %[2]d
+  name     = %[1]q
+  type     = "STATEFUL"
+
+  rule_group {
+    rule_variables {
+      ip_sets {
+        key = "CLIENTS"
+        ip_set {
+          definition = ["10.0.0.0/16"]
+        }
+      }
+    }
+
+    rules_source {
+      rules_string = <<EOF
+alert tcp $CLIENTS any -> any 22 (msg:"SSH"; sid:1;)
+alert tcp $CLIENTS any -> any 23 (msg:"Telnet"; sid:2;)
+EOF
+    }
+  }
+}
+`, rName, capacity)
+}
+
 func testAccNetworkFirewallRuleGroup_statelessRuleWithCustomAction(rName string) string {
 	return fmt.Sprintf(`
 resource "aws_networkfirewall_rule_group" "test" {
@@ -0,0 +1,620 @@
+package networkfirewall
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/networkfirewall"
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// Suricata compatible rules as supported by Network Firewall.
+// https://docs.aws.amazon.com/network-firewall/latest/developerguide/suricata-examples.html
+const (
+	suricataDirectionBidirectional = "<>"
+	suricataDirectionForward       = "->"
+	suricataVariablePrefix         = "$"
+	suricataAny                    = "any"
+	suricataMaxPort                = 65535
+	suricataMaxSID                 = 4294967295
+)
+
+var (
+	suricataKeywordRegexp      = regexp.MustCompile(`^[A-Za-z0-9_.\-]+$`)
+	suricataVariableNameRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+)
+
+func suricataAction_Values() []string {
+	return []string{
+		"alert",
+		"drop",
+		"pass",
+		"reject",
+	}
+}
+
+func suricataProtocol_Values() []string {
+	var values []string
+
+	for _, v := range networkfirewall.StatefulRuleProtocol_Values() {
+		values = append(values, strings.ToLower(v))
+	}
+
+	return values
+}
+
+// suricataPredefinedIPVariables are the IP set variables that Network Firewall defines for every rule group.
+func suricataPredefinedIPVariables() []string {
+	return []string{
+		"EXTERNAL_NET",
+		"HOME_NET",
+	}
+}
+
+func suricataFlowOption_Values() []string {
+	return []string{
+		"established",
+		"from_client",
+		"from_server",
+		"no_frag",
+		"no_stream",
+		"not_established",
+		"only_frag",
+		"only_stream",
+		"stateless",
+		"to_client",
+		"to_server",
+	}
+}
+
+func suricataFlowbitsCommand_Values() []string {
+	return []string{
+		"isnotset",
+		"isset",
+		"noalert",
+		"set",
+		"toggle",
+		"unset",
+	}
+}
+
+// SuricataRule is a single Suricata rule.
+type SuricataRule struct {
+	Line            int
+	Action          string
+	Protocol        string
+	Source          string
+	SourcePort      string
+	Direction       string
+	Destination     string
+	DestinationPort string
+	Options         []SuricataRuleOption
+}
+
+// SuricataRuleOption is a rule option, e.g. msg:"Example".
+// Options without settings, e.g. nocase, have an empty Value.
+type SuricataRuleOption struct {
+	Keyword string
+	Value   string
+}
+
+// SuricataRuleVariables are the names of the IP set and port set variables that rules may reference.
+type SuricataRuleVariables struct {
+	IPSets   []string
+	PortSets []string
+}
+
+// ParseSuricataRules parses Suricata rules, one per line.
+// Blank lines and comments are ignored and lines ending with a backslash are continued on the next line.
+func ParseSuricataRules(rules string) ([]*SuricataRule, error) {
+	var parsed []*SuricataRule
+	var errs []error
+	var continued strings.Builder
+	start := 0
+
+	for i, line := range strings.Split(rules, "\n") {
+		line = strings.TrimSpace(strings.TrimSuffix(line, "\r"))
+
+		if continued.Len() == 0 {
+			start = i + 1
+
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+		}
+
+		if strings.HasSuffix(line, "\\") {
+			continued.WriteString(strings.TrimSuffix(line, "\\"))
+			continue
+		}
+
+		continued.WriteString(line)
+		rule, err := parseSuricataRule(continued.String())
+		continued.Reset()
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", start, err))
+			continue
+		}
+
+		rule.Line = start
+		parsed = append(parsed, rule)
+	}
+
+	if continued.Len() > 0 {
+		errs = append(errs, fmt.Errorf("line %d: unterminated line continuation", start))
+	}
+
+	if len(errs) > 0 {
+		return nil, &multierror.Error{Errors: errs}
+	}
+
+	return parsed, nil
+}
+
+func parseSuricataRule(s string) (*SuricataRule, error) {
+	i := strings.Index(s, "(")
+
+	if i < 0 || !strings.HasSuffix(s, ")") {
+		return nil, fmt.Errorf("rule options must be enclosed in parentheses")
+	}
+
+	header := strings.Fields(s[:i])
+
+	if len(header) != 7 {
+		return nil, fmt.Errorf("rule header must be: action protocol source source_port direction destination destination_port")
+	}
+
+	options, err := parseSuricataRuleOptions(s[i+1 : len(s)-1])
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &SuricataRule{
+		Action:          header[0],
+		Protocol:        header[1],
+		Source:          header[2],
+		SourcePort:      header[3],
+		Direction:       header[4],
+		Destination:     header[5],
+		DestinationPort: header[6],
+		Options:         options,
+	}, nil
+}
+
+// parseSuricataRuleOptions splits rule options at semicolons that are neither escaped nor quoted.
+func parseSuricataRuleOptions(s string) ([]SuricataRuleOption, error) {
+	var options []SuricataRuleOption
+	var sb strings.Builder
+	quoted, escaped := false, false
+
+	for _, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case r == ';' && !quoted:
+			option, err := parseSuricataRuleOption(sb.String())
+
+			if err != nil {
+				return nil, err
+			}
+
+			options = append(options, option)
+			sb.Reset()
+
+			continue
+		}
+
+		sb.WriteRune(r)
+	}
+
+	if quoted {
+		return nil, fmt.Errorf("unterminated quoted string in rule options")
+	}
+
+	if strings.TrimSpace(sb.String()) != "" {
+		return nil, fmt.Errorf("rule option (%s) must be terminated by a semicolon", strings.TrimSpace(sb.String()))
+	}
+
+	return options, nil
+}
+
+func parseSuricataRuleOption(s string) (SuricataRuleOption, error) {
+	keyword, value := s, ""
+
+	if i := strings.Index(s, ":"); i >= 0 {
+		keyword, value = s[:i], strings.TrimSpace(s[i+1:])
+	}
+
+	keyword = strings.TrimSpace(keyword)
+
+	if !suricataKeywordRegexp.MatchString(keyword) {
+		return SuricataRuleOption{}, fmt.Errorf("invalid rule option keyword (%s)", keyword)
+	}
+
+	return SuricataRuleOption{Keyword: keyword, Value: value}, nil
+}
+
+// String returns the rule in Suricata syntax.
+func (r *SuricataRule) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(strings.Join([]string{r.Action, r.Protocol, r.Source, r.SourcePort, r.Direction, r.Destination, r.DestinationPort}, " "))
+	sb.WriteString(" (")
+
+	for i, option := range r.Options {
+		if i > 0 {
+			sb.WriteString(" ")
+		}
+
+		sb.WriteString(option.Keyword)
+
+		if option.Value != "" {
+			sb.WriteString(":")
+			sb.WriteString(option.Value)
+		}
+
+		sb.WriteString(";")
+	}
+
+	sb.WriteString(")")
+
+	return sb.String()
+}
+
+// SID returns the value of the rule's sid option.
+func (r *SuricataRule) SID() (string, bool) {
+	for _, option := range r.Options {
+		if strings.EqualFold(option.Keyword, "sid") {
+			return option.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// ValidateSuricataRules validates the actions, protocols, addresses, ports and options of rules,
+// and that their signature IDs are unique.
+// Variable references are not validated if variables is nil.
+// Protocols that Network Firewall's stateful rule headers do not list, such as http2, are accepted with a warning,
+// as Suricata rules support more application layer protocols.
+func ValidateSuricataRules(rules []*SuricataRule, variables *SuricataRuleVariables) ([]string, error) {
+	var warnings []string
+	var errs []error
+	sids := make(map[string]int)
+
+	for _, r := range rules {
+		prefix := fmt.Sprintf("line %d", r.Line)
+
+		if !stringInSlice(r.Action, suricataAction_Values()) {
+			errs = append(errs, fmt.Errorf("%s: unsupported action (%s), expected one of %s", prefix, r.Action, strings.Join(suricataAction_Values(), ", ")))
+		}
+
+		if !stringInSlice(r.Protocol, suricataProtocol_Values()) {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", prefix, unknownSuricataProtocolWarning(r.Protocol)))
+		}
+
+		if r.Direction != suricataDirectionForward && r.Direction != suricataDirectionBidirectional {
+			errs = append(errs, fmt.Errorf("%s: unsupported direction (%s), expected %s or %s", prefix, r.Direction, suricataDirectionForward, suricataDirectionBidirectional))
+		}
+
+		for _, v := range []struct {
+			name, value string
+		}{{"source", r.Source}, {"destination", r.Destination}} {
+			if err := validateSuricataAddress(v.value, variables); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %s: %w", prefix, v.name, err))
+			}
+		}
+
+		for _, v := range []struct {
+			name, value string
+		}{{"source port", r.SourcePort}, {"destination port", r.DestinationPort}} {
+			if err := validateSuricataPort(v.value, variables); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %s: %w", prefix, v.name, err))
+			}
+		}
+
+		for _, option := range r.Options {
+			if err := validateSuricataRuleOption(option); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %s: %w", prefix, option.Keyword, err))
+			}
+		}
+
+		sid, ok := r.SID()
+
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: sid option is required", prefix))
+			continue
+		}
+
+		if line, ok := sids[sid]; ok {
+			errs = append(errs, fmt.Errorf("%s: sid (%s) is already used on line %d", prefix, sid, line))
+			continue
+		}
+
+		sids[sid] = r.Line
+	}
+
+	if len(errs) > 0 {
+		return warnings, &multierror.Error{Errors: errs}
+	}
+
+	return warnings, nil
+}
+
+func unknownSuricataProtocolWarning(protocol string) string {
+	return fmt.Sprintf("protocol (%s) is not one of %s and may not be supported by Network Firewall", protocol, strings.Join(suricataProtocol_Values(), ", "))
+}
+
+// validSuricataProtocol warns of protocols that may not be supported by Network Firewall.
+func validSuricataProtocol(v interface{}, k string) (ws []string, errors []error) {
+	value, ok := v.(string)
+
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if !suricataKeywordRegexp.MatchString(value) {
+		errors = append(errors, fmt.Errorf("%s (%s) is not a valid protocol name", k, value))
+		return
+	}
+
+	if !stringInSlice(value, suricataProtocol_Values()) {
+		ws = append(ws, fmt.Sprintf("%s: %s", k, unknownSuricataProtocolWarning(value)))
+	}
+
+	return
+}
+
+func validateSuricataRuleOption(option SuricataRuleOption) error {
+	keyword, value := strings.ToLower(option.Keyword), option.Value
+
+	switch keyword {
+	case "sid":
+		return validateSuricataInteger(value, 1, suricataMaxSID)
+	case "gid", "priority", "rev":
+		return validateSuricataInteger(value, 0, suricataMaxSID)
+	case "msg":
+		if !isSuricataQuotedString(value) {
+			return fmt.Errorf("value must be a quoted string")
+		}
+	case "content", "pcre":
+		if !isSuricataQuotedString(strings.TrimPrefix(value, "!")) {
+			return fmt.Errorf("value must be a quoted string")
+		}
+
+		if keyword == "pcre" && !strings.HasPrefix(strings.TrimPrefix(strings.TrimPrefix(value, "!"), `"`), "/") {
+			return fmt.Errorf("regular expression must be enclosed in slashes")
+		}
+	case "classtype", "metadata", "reference", "threshold":
+		if value == "" {
+			return fmt.Errorf("value is required")
+		}
+	case "flow":
+		for _, v := range strings.Split(value, ",") {
+			if !stringInSlice(strings.TrimSpace(v), suricataFlowOption_Values()) {
+				return fmt.Errorf("unsupported value (%s)", strings.TrimSpace(v))
+			}
+		}
+	case "flowbits":
+		command := strings.TrimSpace(strings.SplitN(value, ",", 2)[0])
+
+		if !stringInSlice(command, suricataFlowbitsCommand_Values()) {
+			return fmt.Errorf("unsupported command (%s)", command)
+		}
+
+		if command != "noalert" && !strings.Contains(value, ",") {
+			return fmt.Errorf("command (%s) requires a flowbit name", command)
+		}
+	}
+
+	return nil
+}
+
+func validateSuricataInteger(value string, min, max int64) error {
+	n, err := strconv.ParseInt(value, 10, 64)
+
+	if err != nil || n < min || n > max {
+		return fmt.Errorf("value (%s) must be an integer between %d and %d", value, min, max)
+	}
+
+	return nil
+}
+
+func isSuricataQuotedString(value string) bool {
+	return len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) && !strings.HasSuffix(value, `\"`)
+}
+
+func validateSuricataAddress(value string, variables *SuricataRuleVariables) error {
+	return validateSuricataList(value, func(v string) error {
+		if name := strings.TrimPrefix(v, suricataVariablePrefix); name != v {
+			return validateSuricataVariable(name, variables, true)
+		}
+
+		if _, _, err := net.ParseCIDR(v); err == nil {
+			return nil
+		}
+
+		if net.ParseIP(v) != nil {
+			return nil
+		}
+
+		return fmt.Errorf("invalid IP address or CIDR block (%s)", v)
+	})
+}
+
+func validateSuricataPort(value string, variables *SuricataRuleVariables) error {
+	return validateSuricataList(value, func(v string) error {
+		if name := strings.TrimPrefix(v, suricataVariablePrefix); name != v {
+			return validateSuricataVariable(name, variables, false)
+		}
+
+		from, to := v, v
+
+		if i := strings.Index(v, ":"); i >= 0 {
+			from, to = v[:i], v[i+1:]
+
+			if from == "" && to == "" {
+				return fmt.Errorf("invalid port range (%s)", v)
+			}
+		}
+
+		var ports []int
+
+		for _, p := range []string{from, to} {
+			if p == "" {
+				continue
+			}
+
+			port, err := strconv.Atoi(p)
+
+			if err != nil || port < 0 || port > suricataMaxPort {
+				return fmt.Errorf("invalid port (%s)", p)
+			}
+
+			ports = append(ports, port)
+		}
+
+		if from != "" && to != "" && ports[0] > ports[1] {
+			return fmt.Errorf("invalid port range (%s)", v)
+		}
+
+		return nil
+	})
+}
+
+func validateSuricataVariable(name string, variables *SuricataRuleVariables, ip bool) error {
+	if !suricataVariableNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid variable name (%s)", name)
+	}
+
+	if variables == nil {
+		return nil
+	}
+
+	ipSets := append(suricataPredefinedIPVariables(), variables.IPSets...)
+
+	if ip {
+		if stringInSlice(name, ipSets) {
+			return nil
+		}
+
+		if stringInSlice(name, variables.PortSets) {
+			return fmt.Errorf("variable (%s) is a port set, not an IP set", name)
+		}
+
+		return fmt.Errorf("IP set variable (%s) is not defined", name)
+	}
+
+	if stringInSlice(name, variables.PortSets) {
+		return nil
+	}
+
+	if stringInSlice(name, ipSets) {
+		return fmt.Errorf("variable (%s) is an IP set, not a port set", name)
+	}
+
+	return fmt.Errorf("port set variable (%s) is not defined", name)
+}
+
+// validateSuricataList validates an address or port, which may be any, negated with ! or a bracketed, comma separated list.
+func validateSuricataList(value string, validateElement func(string) error) error {
+	if value == suricataAny {
+		return nil
+	}
+
+	value = strings.TrimPrefix(value, "!")
+
+	if !strings.HasPrefix(value, "[") {
+		if value == "" {
+			return fmt.Errorf("value is empty")
+		}
+
+		return validateElement(value)
+	}
+
+	if !strings.HasSuffix(value, "]") {
+		return fmt.Errorf("unterminated list (%s)", value)
+	}
+
+	elements, err := splitSuricataList(value[1 : len(value)-1])
+
+	if err != nil {
+		return err
+	}
+
+	for _, e := range elements {
+		if e == suricataAny {
+			return fmt.Errorf("any is not allowed in a list")
+		}
+
+		if err := validateSuricataList(e, validateElement); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// splitSuricataList splits a comma separated list at the top level, leaving nested lists intact.
+func splitSuricataList(s string) ([]string, error) {
+	var elements []string
+	depth, start := 0, 0
+
+	for i, r := range s {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			depth--
+
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced brackets in list")
+			}
+		case ',':
+			if depth == 0 {
+				elements = append(elements, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced brackets in list")
+	}
+
+	elements = append(elements, strings.TrimSpace(s[start:]))
+
+	for _, e := range elements {
+		if e == "" {
+			return nil, fmt.Errorf("empty element in list")
+		}
+	}
+
+	return elements, nil
+}
+
+// EstimateSuricataCapacity returns the capacity that a stateful rule group requires for rules.
+// Each Suricata rule requires one unit of capacity.
+func EstimateSuricataCapacity(rules []*SuricataRule) int {
+	return len(rules)
+}
+
+func stringInSlice(s string, values []string) bool {
+	for _, v := range values {
+		if s == v {
+			return true
+		}
+	}
+
+	return false
+}
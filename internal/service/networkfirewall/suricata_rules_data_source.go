@@ -0,0 +1,209 @@
+package networkfirewall
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-aws/internal/create"
+	"github.com/hashicorp/terraform-provider-aws/internal/flex"
+)
+
+func DataSourceSuricataRules() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSuricataRulesRead,
+
+		Schema: map[string]*schema.Schema{
+			"capacity": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"ip_set_keys": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"port_set_keys": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"rule": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(suricataAction_Values(), false),
+						},
+						"destination": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  suricataAny,
+						},
+						"destination_port": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  suricataAny,
+						},
+						"direction": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      suricataDirectionForward,
+							ValidateFunc: validation.StringInSlice([]string{suricataDirectionForward, suricataDirectionBidirectional}, false),
+						},
+						"message": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"option": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"keyword": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"settings": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+						"protocol": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validSuricataProtocol,
+						},
+						"rev": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"sid": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"source": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  suricataAny,
+						},
+						"source_port": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  suricataAny,
+						},
+					},
+				},
+			},
+			"rules_string": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceSuricataRulesRead(d *schema.ResourceData, meta interface{}) error {
+	var lines []string
+
+	for _, tfMapRaw := range d.Get("rule").([]interface{}) {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		lines = append(lines, expandSuricataRule(tfMap).String())
+	}
+
+	rulesString := strings.Join(lines, "\n")
+
+	rules, err := ParseSuricataRules(rulesString)
+
+	if err != nil {
+		return fmt.Errorf("error parsing Suricata rules: %w", err)
+	}
+
+	variables := &SuricataRuleVariables{
+		IPSets:   aws.StringValueSlice(flex.ExpandStringSet(d.Get("ip_set_keys").(*schema.Set))),
+		PortSets: aws.StringValueSlice(flex.ExpandStringSet(d.Get("port_set_keys").(*schema.Set))),
+	}
+
+	warnings, err := ValidateSuricataRules(rules, variables)
+
+	if err != nil {
+		return fmt.Errorf("invalid Suricata rules (lines are numbered in rule order): %w", err)
+	}
+
+	for _, warning := range warnings {
+		log.Printf("[WARN] Suricata rules (lines are numbered in rule order): %s", warning)
+	}
+
+	d.SetId(strconv.Itoa(create.StringHashcode(rulesString)))
+	d.Set("capacity", EstimateSuricataCapacity(rules))
+	d.Set("rules_string", rulesString)
+
+	return nil
+}
+
+func expandSuricataRule(tfMap map[string]interface{}) *SuricataRule {
+	rule := &SuricataRule{
+		Action:          tfMap["action"].(string),
+		Protocol:        tfMap["protocol"].(string),
+		Source:          tfMap["source"].(string),
+		SourcePort:      tfMap["source_port"].(string),
+		Direction:       tfMap["direction"].(string),
+		Destination:     tfMap["destination"].(string),
+		DestinationPort: tfMap["destination_port"].(string),
+	}
+
+	if v, ok := tfMap["message"].(string); ok && v != "" {
+		rule.Options = append(rule.Options, SuricataRuleOption{Keyword: "msg", Value: quoteSuricataString(v)})
+	}
+
+	if v, ok := tfMap["option"].([]interface{}); ok {
+		for _, tfMapRaw := range v {
+			tfMap, ok := tfMapRaw.(map[string]interface{})
+
+			if !ok {
+				continue
+			}
+
+			option := SuricataRuleOption{Keyword: tfMap["keyword"].(string)}
+
+			if v, ok := tfMap["settings"].([]interface{}); ok {
+				option.Value = strings.Join(aws.StringValueSlice(flex.ExpandStringList(v)), ",")
+			}
+
+			rule.Options = append(rule.Options, option)
+		}
+	}
+
+	rule.Options = append(rule.Options, SuricataRuleOption{Keyword: "sid", Value: strconv.Itoa(tfMap["sid"].(int))})
+
+	if v, ok := tfMap["rev"].(int); ok && v != 0 {
+		rule.Options = append(rule.Options, SuricataRuleOption{Keyword: "rev", Value: strconv.Itoa(v)})
+	}
+
+	return rule
+}
+
+// quoteSuricataString quotes a string, escaping the characters that Suricata requires to be escaped.
+func quoteSuricataString(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `;`, `\;`)
+
+	return `"` + r.Replace(s) + `"`
+}
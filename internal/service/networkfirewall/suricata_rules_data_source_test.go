@@ -0,0 +1,101 @@
+package networkfirewall_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/networkfirewall"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+)
+
+func TestAccNetworkFirewallSuricataRulesDataSource_basic(t *testing.T) {
+	dataSourceName := "data.aws_networkfirewall_suricata_rules.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { acctest.PreCheck(t) },
+		ErrorCheck: acctest.ErrorCheck(t, networkfirewall.EndpointsID),
+		Providers:  acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSuricataRulesDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "capacity", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "rules_string", `pass tls $HOME_NET any -> $EXTERNAL_NET 443 (msg:"Allow \"example\"\; TLS"; tls.sni; content:"example.com"; nocase; sid:1; rev:2;)
+drop tcp $CLIENTS any <> any [$BLOCKED_PORTS,23] (flow:established,to_server; sid:2;)`),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNetworkFirewallSuricataRulesDataSource_invalid(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { acctest.PreCheck(t) },
+		ErrorCheck: acctest.ErrorCheck(t, networkfirewall.EndpointsID),
+		Providers:  acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSuricataRulesDataSourceConfig_invalid,
+				ExpectError: regexp.MustCompile(`port set variable \(SSH_PORTS\) is not defined`),
+			},
+		},
+	})
+}
+
+const testAccSuricataRulesDataSourceConfig = `
+data "aws_networkfirewall_suricata_rules" "test" {
+  ip_set_keys   = ["CLIENTS"]
+  port_set_keys = ["BLOCKED_PORTS"]
+
+  rule {
+    action      = "pass"
+    protocol    = "tls"
+    source      = "$HOME_NET"
+    destination = "$EXTERNAL_NET"
+
+    destination_port = "443"
+    message          = "Allow \"example\"; TLS"
+    sid              = 1
+    rev              = 2
+
+    option {
+      keyword = "tls.sni"
+    }
+
+    option {
+      keyword  = "content"
+      settings = ["\"example.com\""]
+    }
+
+    option {
+      keyword = "nocase"
+    }
+  }
+
+  rule {
+    action           = "drop"
+    protocol         = "tcp"
+    source           = "$CLIENTS"
+    direction        = "<>"
+    destination_port = "[$BLOCKED_PORTS,23]"
+    sid              = 2
+
+    option {
+      keyword  = "flow"
+      settings = ["established", "to_server"]
+    }
+  }
+}
+`
+
+const testAccSuricataRulesDataSourceConfig_invalid = `
+data "aws_networkfirewall_suricata_rules" "test" {
+  rule {
+    action           = "alert"
+    protocol         = "tcp"
+    destination_port = "$SSH_PORTS"
+    sid              = 1
+  }
+}
+`
@@ -0,0 +1,232 @@
+package networkfirewall
+
+import (
+	"testing"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+func TestParseSuricataRules(t *testing.T) {
+	rules := `# Example rules
+
+alert http any any -> any any (http_response_line; content:"403 Forbidden"; sid:1;)
+pass tls $HOME_NET any -> $EXTERNAL_NET 443 (tls.sni; content:"example.com"; \
+  msg:"semicolon\; and \"quotes\""; sid:2; rev:1;)
+drop tcp [10.0.0.0/8,!10.1.0.0/16] !80 <> any [1024:,!8080] (msg:"a;b"; flow:established,to_server; sid:3;)
+`
+
+	got, err := ParseSuricataRules(rules)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("got %d rules, expected 3", len(got))
+	}
+
+	if got[1].Line != 4 {
+		t.Errorf("got line %d, expected 4", got[1].Line)
+	}
+
+	if got, expected := got[1].String(), `pass tls $HOME_NET any -> $EXTERNAL_NET 443 (tls.sni; content:"example.com"; msg:"semicolon\; and \"quotes\""; sid:2; rev:1;)`; got != expected {
+		t.Errorf("got %s, expected %s", got, expected)
+	}
+
+	rule := got[2]
+
+	if rule.Action != "drop" || rule.Protocol != "tcp" || rule.Source != "[10.0.0.0/8,!10.1.0.0/16]" || rule.SourcePort != "!80" ||
+		rule.Direction != "<>" || rule.Destination != "any" || rule.DestinationPort != "[1024:,!8080]" {
+		t.Errorf("unexpected rule header: %s", rule)
+	}
+
+	if len(rule.Options) != 3 || rule.Options[0].Value != `"a;b"` || rule.Options[1].Value != "established,to_server" {
+		t.Errorf("unexpected rule options: %v", rule.Options)
+	}
+
+	if sid, ok := rule.SID(); !ok || sid != "3" {
+		t.Errorf("got sid %q, expected 3", sid)
+	}
+}
+
+func TestParseSuricataRules_invalid(t *testing.T) {
+	testCases := []struct {
+		Name  string
+		Rules string
+	}{
+		{Name: "no options", Rules: `alert tcp any any -> any any`},
+		{Name: "short header", Rules: `alert tcp any any -> any (sid:1;)`},
+		{Name: "unterminated option", Rules: `alert tcp any any -> any any (sid:1)`},
+		{Name: "unterminated string", Rules: `alert tcp any any -> any any (msg:"a; sid:1;)`},
+		{Name: "invalid keyword", Rules: `alert tcp any any -> any any (bad keyword; sid:1;)`},
+		{Name: "unterminated continuation", Rules: `alert tcp any any -> any any (sid:1;) \`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			if _, err := ParseSuricataRules(testCase.Rules); err == nil {
+				t.Fatal("expected error, got none")
+			}
+		})
+	}
+}
+
+func TestValidateSuricataRules(t *testing.T) {
+	variables := &SuricataRuleVariables{
+		IPSets:   []string{"SERVERS"},
+		PortSets: []string{"WEB_PORTS"},
+	}
+
+	testCases := []struct {
+		Name         string
+		Rules        string
+		Variables    *SuricataRuleVariables
+		ErrorCount   int
+		WarningCount int
+	}{
+		{
+			Name: "valid",
+			Rules: `alert http any any -> any any (http_response_line; content:"403 Forbidden"; sid:1;)
+pass tls $HOME_NET any -> $EXTERNAL_NET 443 (tls.sni; content:"example.com"; nocase; msg:"FQDN"; sid:2; rev:3;)
+drop tcp [$SERVERS,!10.0.0.1] any <> [192.168.0.0/16,2001:db8::/32] [$WEB_PORTS,1024:2048,!8080] (flow:established,to_server; flowbits:set,seen; pcre:"/^GET/i"; sid:3;)
+reject tcp any :1023 -> any any (content:!"x"; priority:1; gid:1; sid:4;)`,
+			Variables: variables,
+		},
+		{
+			Name:       "action",
+			Rules:      `deny tcp any any -> any any (sid:1;)`,
+			ErrorCount: 1,
+		},
+		{
+			Name:         "protocol",
+			Rules:        `alert http2 any any -> any any (sid:1;)`,
+			WarningCount: 1,
+		},
+		{
+			Name: "protocols",
+			Rules: `alert quic any any -> any any (sid:1;)
+deny sctp any any -> any any (sid:2;)`,
+			ErrorCount:   1,
+			WarningCount: 2,
+		},
+		{
+			Name:       "direction",
+			Rules:      `alert tcp any any <- any any (sid:1;)`,
+			ErrorCount: 1,
+		},
+		{
+			Name:       "addresses",
+			Rules:      `alert tcp 10.0.0.0/33 any -> [10.0.0.1,any] any (sid:1;)`,
+			ErrorCount: 2,
+		},
+		{
+			Name:       "ports",
+			Rules:      `alert tcp any 65536 -> any [2048:1024] (sid:1;)`,
+			ErrorCount: 2,
+		},
+		{
+			Name:       "undefined variables",
+			Rules:      `alert tcp $CLIENTS any -> $SERVERS $SSH_PORTS (sid:1;)`,
+			Variables:  variables,
+			ErrorCount: 2,
+		},
+		{
+			Name:       "variable types",
+			Rules:      `alert tcp $WEB_PORTS $SERVERS -> any any (sid:1;)`,
+			Variables:  variables,
+			ErrorCount: 2,
+		},
+		{
+			Name:  "unvalidated variables",
+			Rules: `alert tcp $CLIENTS any -> any $SSH_PORTS (sid:1;)`,
+		},
+		{
+			Name:       "options",
+			Rules:      `alert tcp any any -> any any (msg:unquoted; flow:sideways; flowbits:set; pcre:"GET"; rev:x; sid:1;)`,
+			ErrorCount: 5,
+		},
+		{
+			Name: "sid",
+			Rules: `alert tcp any any -> any any (msg:"no sid";)
+alert tcp any any -> any any (sid:0;)
+alert tcp any any -> any any (sid:2;)
+alert tcp any any -> any any (sid:2;)`,
+			ErrorCount: 3,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			rules, err := ParseSuricataRules(testCase.Rules)
+
+			if err != nil {
+				t.Fatalf("error parsing rules: %s", err)
+			}
+
+			warnings, err := ValidateSuricataRules(rules, testCase.Variables)
+
+			if len(warnings) != testCase.WarningCount {
+				t.Errorf("expected %d warnings, got %d: %q", testCase.WarningCount, len(warnings), warnings)
+			}
+
+			if testCase.ErrorCount == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return
+			}
+
+			errs, ok := err.(*multierror.Error)
+
+			if !ok {
+				t.Fatalf("expected %d errors, got: %v", testCase.ErrorCount, err)
+			}
+
+			if len(errs.Errors) != testCase.ErrorCount {
+				t.Errorf("expected %d errors, got %d: %s", testCase.ErrorCount, len(errs.Errors), err)
+			}
+		})
+	}
+}
+
+func TestValidSuricataProtocol(t *testing.T) {
+	testCases := []struct {
+		Value        string
+		WarningCount int
+		ErrorCount   int
+	}{
+		{Value: "tcp"},
+		{Value: "http2", WarningCount: 1},
+		{Value: "tcp udp", ErrorCount: 1},
+		{Value: "", ErrorCount: 1},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Value, func(t *testing.T) {
+			ws, errs := validSuricataProtocol(testCase.Value, "protocol")
+
+			if len(ws) != testCase.WarningCount {
+				t.Errorf("expected %d warnings, got %d: %q", testCase.WarningCount, len(ws), ws)
+			}
+
+			if len(errs) != testCase.ErrorCount {
+				t.Errorf("expected %d errors, got %d: %v", testCase.ErrorCount, len(errs), errs)
+			}
+		})
+	}
+}
+
+func TestEstimateSuricataCapacity(t *testing.T) {
+	rules, err := ParseSuricataRules(`alert tcp any any -> any any (sid:1;)
+# comment
+alert tcp any any -> any any (sid:2;)`)
+
+	if err != nil {
+		t.Fatalf("error parsing rules: %s", err)
+	}
+
+	if got, expected := EstimateSuricataCapacity(rules), 2; got != expected {
+		t.Errorf("got %d, expected %d", got, expected)
+	}
+}
@@ -1,9 +1,12 @@
 package secretsmanager
 
 import (
+	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"log"
+	"reflect"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -11,6 +14,7 @@ import (
 	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-aws/internal/conns"
 	"github.com/hashicorp/terraform-provider-aws/internal/flex"
 	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
@@ -32,6 +36,84 @@ func ResourceSecretVersion() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"generated_field": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"secret_binary", "secret_string"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"exclude_characters": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"exclude_lowercase": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"exclude_numbers": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"exclude_punctuation": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"exclude_uppercase": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"include_space": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"keepers": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"length": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      32,
+							ValidateFunc: validation.IntBetween(1, 4096),
+						},
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringLenBetween(1, 256),
+						},
+						"require_each_included_type": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+					},
+				},
+			},
+			"secret_binary": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"secret_fields", "secret_string"},
+			},
+			"secret_field_revisions": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"secret_fields": {
+				Type:          schema.TypeMap,
+				Optional:      true,
+				Sensitive:     true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"secret_binary", "secret_string"},
+			},
 			"secret_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -40,17 +122,10 @@ func ResourceSecretVersion() *schema.Resource {
 			"secret_string": {
 				Type:          schema.TypeString,
 				Optional:      true,
-				ForceNew:      true,
+				Computed:      true,
 				Sensitive:     true,
 				ConflictsWith: []string{"secret_binary"},
 			},
-			"secret_binary": {
-				Type:          schema.TypeString,
-				Optional:      true,
-				ForceNew:      true,
-				Sensitive:     true,
-				ConflictsWith: []string{"secret_string"},
-			},
 			"version_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -62,6 +137,8 @@ func ResourceSecretVersion() *schema.Resource {
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 		},
+
+		CustomizeDiff: resourceSecretVersionCustomizeDiff,
 	}
 }
 
@@ -73,7 +150,22 @@ func resourceSecretVersionCreate(d *schema.ResourceData, meta interface{}) error
 		SecretId: aws.String(secretID),
 	}
 
-	if v, ok := d.GetOk("secret_string"); ok {
+	if secretFieldsMode(d) {
+		s, err := expandSecretFields(d, generateSecretField(conn))
+
+		if err != nil {
+			return err
+		}
+
+		v, err := flattenSecretFields(s.Values)
+
+		if err != nil {
+			return fmt.Errorf("error encoding Secrets Manager Secret (%s) fields: %w", secretID, err)
+		}
+
+		input.SecretString = aws.String(v)
+		d.Set("secret_field_revisions", s.Revisions)
+	} else if v, ok := d.GetOk("secret_string"); ok {
 		input.SecretString = aws.String(v.(string))
 	}
 
@@ -172,6 +264,20 @@ func resourceSecretVersionRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("version_id", output.VersionId)
 	d.Set("arn", output.ARN)
 
+	if secretFieldsMode(d) {
+		fields := make(map[string]string)
+
+		if err := json.Unmarshal([]byte(aws.StringValue(output.SecretString)), &fields); err != nil {
+			log.Printf("[WARN] Secrets Manager Secret Version (%s) value is not a JSON object of strings: %s", d.Id(), err)
+		} else {
+			for _, tfMapRaw := range d.Get("generated_field").(*schema.Set).List() {
+				delete(fields, tfMapRaw.(map[string]interface{})["name"].(string))
+			}
+
+			d.Set("secret_fields", fields)
+		}
+	}
+
 	if err := d.Set("version_stages", flex.FlattenStringList(output.VersionStages)); err != nil {
 		return fmt.Errorf("error setting version_stages: %s", err)
 	}
@@ -187,6 +293,46 @@ func resourceSecretVersionUpdate(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
+	if secretFieldsMode(d) {
+		s, err := expandSecretFields(d, generateSecretField(conn))
+
+		if err != nil {
+			return err
+		}
+
+		if s.Changed {
+			v, err := flattenSecretFields(s.Values)
+
+			if err != nil {
+				return fmt.Errorf("error encoding Secrets Manager Secret (%s) fields: %w", secretID, err)
+			}
+
+			input := &secretsmanager.PutSecretValueInput{
+				SecretId:     aws.String(secretID),
+				SecretString: aws.String(v),
+			}
+
+			// Configured stages are moved to the new version. AWSCURRENT is moved by default.
+			if config := d.GetRawConfig(); !config.IsNull() && !config.GetAttr("version_stages").IsNull() {
+				input.VersionStages = flex.ExpandStringSet(d.Get("version_stages").(*schema.Set))
+			}
+
+			log.Printf("[DEBUG] Putting Secrets Manager Secret %q value", secretID)
+			output, err := conn.PutSecretValue(input)
+
+			if err != nil {
+				return fmt.Errorf("error putting Secrets Manager Secret value: %w", err)
+			}
+
+			d.SetId(fmt.Sprintf("%s|%s", secretID, aws.StringValue(output.VersionId)))
+			d.Set("secret_field_revisions", s.Revisions)
+
+			return resourceSecretVersionRead(d, meta)
+		}
+
+		d.Set("secret_field_revisions", s.Revisions)
+	}
+
 	o, n := d.GetChange("version_stages")
 	os := o.(*schema.Set)
 	ns := n.(*schema.Set)
@@ -265,6 +411,70 @@ func resourceSecretVersionDelete(d *schema.ResourceData, meta interface{}) error
 	return nil
 }
 
+func resourceSecretVersionCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	known := diff.NewValueKnown("secret_fields") && diff.NewValueKnown("generated_field")
+
+	// The raw config is not set when the diff is recomputed for a replacement.
+	if config := diff.GetRawConfig(); !config.IsNull() {
+		known = config.GetAttr("secret_fields").IsWhollyKnown() && config.GetAttr("generated_field").IsWhollyKnown()
+	}
+
+	if !known {
+		for _, k := range []string{"secret_field_revisions", "secret_string", "version_id"} {
+			if err := diff.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if !secretFieldsMode(diff) {
+		// A secret_string can't be changed in place.
+		if diff.Id() != "" && diff.HasChange("secret_string") {
+			return diff.ForceNew("secret_string")
+		}
+
+		return nil
+	}
+
+	s, err := expandSecretFields(diff, func(map[string]interface{}) (*string, error) {
+		return nil, nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if o := diff.Get("secret_field_revisions").(map[string]interface{}); !reflect.DeepEqual(o, s.Revisions) {
+		if err := diff.SetNew("secret_field_revisions", s.Revisions); err != nil {
+			return err
+		}
+	}
+
+	if !s.Changed {
+		return nil
+	}
+
+	if err := diff.SetNewComputed("version_id"); err != nil {
+		return err
+	}
+
+	for _, v := range s.Values {
+		if v == nil {
+			return diff.SetNewComputed("secret_string")
+		}
+	}
+
+	v, err := flattenSecretFields(s.Values)
+
+	if err != nil {
+		return err
+	}
+
+	return diff.SetNew("secret_string", v)
+}
+
 func DecodeSecretVersionID(id string) (string, string, error) {
 	idParts := strings.Split(id, "|")
 	if len(idParts) != 2 {
@@ -0,0 +1,161 @@
+package secretsmanager
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// secretFieldGenerator returns a new value of a generated field, or nil if the value is not known until apply.
+type secretFieldGenerator func(tfMap map[string]interface{}) (*string, error)
+
+// secretFields is the desired value of a secret version that is configured with secret_fields or generated_field.
+type secretFields struct {
+	// Changed is whether the value differs from the current version, so that a new version must be put.
+	Changed bool
+	// Revisions is incremented for each field whose value changes.
+	Revisions map[string]interface{}
+	// Values is nil for generated fields whose value is not known yet.
+	Values map[string]*string
+}
+
+func secretFieldsMode(d interface{ Get(string) interface{} }) bool {
+	return len(d.Get("secret_fields").(map[string]interface{})) > 0 || d.Get("generated_field").(*schema.Set).Len() > 0
+}
+
+// expandSecretFields merges the configured fields with the generated fields.
+// A generated field keeps its current value until its settings or keepers change.
+func expandSecretFields(d interface {
+	GetChange(string) (interface{}, interface{})
+}, generate secretFieldGenerator) (*secretFields, error) {
+	oldSecretString, _ := d.GetChange("secret_string")
+	oldValues := make(map[string]string)
+
+	if v := oldSecretString.(string); v != "" {
+		// The current value is not a JSON object of strings, e.g. when switching from secret_string.
+		if err := json.Unmarshal([]byte(v), &oldValues); err != nil {
+			oldValues = make(map[string]string)
+		}
+	}
+
+	oldRevisions, _ := d.GetChange("secret_field_revisions")
+	oldGeneratedFields, newGeneratedFields := d.GetChange("generated_field")
+	_, newFields := d.GetChange("secret_fields")
+
+	oldSettings := make(map[string]map[string]interface{})
+
+	for _, tfMapRaw := range oldGeneratedFields.(*schema.Set).List() {
+		tfMap := tfMapRaw.(map[string]interface{})
+		oldSettings[tfMap["name"].(string)] = tfMap
+	}
+
+	s := &secretFields{
+		Revisions: make(map[string]interface{}),
+		Values:    make(map[string]*string),
+	}
+
+	for k, v := range newFields.(map[string]interface{}) {
+		s.Values[k] = aws.String(v.(string))
+	}
+
+	for _, tfMapRaw := range newGeneratedFields.(*schema.Set).List() {
+		tfMap := tfMapRaw.(map[string]interface{})
+		name := tfMap["name"].(string)
+
+		if _, ok := s.Values[name]; ok {
+			return nil, fmt.Errorf("generated field (%s) is also set in secret_fields", name)
+		}
+
+		if oldValue, ok := oldValues[name]; ok {
+			if settings, ok := oldSettings[name]; !ok || reflect.DeepEqual(settings, tfMap) {
+				s.Values[name] = aws.String(oldValue)
+
+				continue
+			}
+		}
+
+		v, err := generate(tfMap)
+
+		if err != nil {
+			return nil, err
+		}
+
+		s.Values[name] = v
+	}
+
+	for name, v := range s.Values {
+		oldValue, ok := oldValues[name]
+		revision, _ := oldRevisions.(map[string]interface{})[name].(int)
+
+		switch {
+		case v == nil || !ok || aws.StringValue(v) != oldValue:
+			revision++
+			s.Changed = true
+		case revision == 0:
+			// The field was not tracked yet, e.g. after import.
+			revision = 1
+		}
+
+		s.Revisions[name] = revision
+	}
+
+	if len(oldValues) != len(s.Values) {
+		s.Changed = true
+	}
+
+	return s, nil
+}
+
+// flattenSecretFields serializes the fields to a JSON object with sorted keys.
+func flattenSecretFields(values map[string]*string) (string, error) {
+	m := make(map[string]string, len(values))
+
+	for k, v := range values {
+		m[k] = aws.StringValue(v)
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(m); err != nil {
+		return "", err
+	}
+
+	return string(bytes.TrimSuffix(buf.Bytes(), []byte("\n"))), nil
+}
+
+func expandGetRandomPasswordInput(tfMap map[string]interface{}) *secretsmanager.GetRandomPasswordInput {
+	input := &secretsmanager.GetRandomPasswordInput{
+		ExcludeLowercase:        aws.Bool(tfMap["exclude_lowercase"].(bool)),
+		ExcludeNumbers:          aws.Bool(tfMap["exclude_numbers"].(bool)),
+		ExcludePunctuation:      aws.Bool(tfMap["exclude_punctuation"].(bool)),
+		ExcludeUppercase:        aws.Bool(tfMap["exclude_uppercase"].(bool)),
+		IncludeSpace:            aws.Bool(tfMap["include_space"].(bool)),
+		PasswordLength:          aws.Int64(int64(tfMap["length"].(int))),
+		RequireEachIncludedType: aws.Bool(tfMap["require_each_included_type"].(bool)),
+	}
+
+	if v, ok := tfMap["exclude_characters"].(string); ok && v != "" {
+		input.ExcludeCharacters = aws.String(v)
+	}
+
+	return input
+}
+
+func generateSecretField(conn *secretsmanager.SecretsManager) secretFieldGenerator {
+	return func(tfMap map[string]interface{}) (*string, error) {
+		output, err := conn.GetRandomPassword(expandGetRandomPasswordInput(tfMap))
+
+		if err != nil {
+			return nil, fmt.Errorf("error generating Secrets Manager Secret field (%s): %w", tfMap["name"].(string), err)
+		}
+
+		return output.RandomPassword, nil
+	}
+}
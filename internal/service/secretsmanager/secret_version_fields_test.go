@@ -0,0 +1,243 @@
+package secretsmanager
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceSecretVersionCustomizeDiff(t *testing.T) {
+	generatedField := map[string]interface{}{
+		"name":   "password",
+		"length": 16,
+	}
+
+	generatedFieldRotated := map[string]interface{}{
+		"name":    "password",
+		"length":  16,
+		"keepers": map[string]interface{}{"rotation": "2"},
+	}
+
+	currentState := map[string]interface{}{
+		"secret_id":       "secret",
+		"secret_fields":   map[string]interface{}{"user": "admin"},
+		"generated_field": []interface{}{generatedField},
+	}
+
+	testCases := []struct {
+		Name                 string
+		State                map[string]interface{}
+		StateSecretString    string
+		StateRevisions       map[string]interface{}
+		Config               map[string]interface{}
+		ExpectedSecretString string // "" if unchanged, "<computed>" if not known until apply.
+		ExpectedRevisions    map[string]string
+		ExpectRequiresNew    bool
+		ExpectErrorSubstring string
+	}{
+		{
+			Name: "create",
+			Config: map[string]interface{}{
+				"secret_id":       "secret",
+				"secret_fields":   map[string]interface{}{"user": "admin"},
+				"generated_field": []interface{}{generatedField},
+			},
+			ExpectedSecretString: "<computed>",
+			ExpectedRevisions:    map[string]string{"password": "1", "user": "1"},
+		},
+		{
+			Name: "create without generated fields",
+			Config: map[string]interface{}{
+				"secret_id":     "secret",
+				"secret_fields": map[string]interface{}{"user": "admin", "host": "<db>"},
+			},
+			ExpectedSecretString: `{"host":"<db>","user":"admin"}`,
+			ExpectedRevisions:    map[string]string{"host": "1", "user": "1"},
+		},
+		{
+			Name:              "unchanged",
+			State:             currentState,
+			StateSecretString: `{"password":"p1","user":"admin"}`,
+			StateRevisions:    map[string]interface{}{"password": 1, "user": 1},
+			Config: map[string]interface{}{
+				"secret_id":       "secret",
+				"secret_fields":   map[string]interface{}{"user": "admin"},
+				"generated_field": []interface{}{generatedField},
+			},
+		},
+		{
+			Name:              "changed field",
+			State:             currentState,
+			StateSecretString: `{"password":"p1","user":"admin"}`,
+			StateRevisions:    map[string]interface{}{"password": 1, "user": 1},
+			Config: map[string]interface{}{
+				"secret_id":       "secret",
+				"secret_fields":   map[string]interface{}{"user": "root"},
+				"generated_field": []interface{}{generatedField},
+			},
+			ExpectedSecretString: `{"password":"p1","user":"root"}`,
+			ExpectedRevisions:    map[string]string{"password": "1", "user": "2"},
+		},
+		{
+			Name:              "removed field",
+			State:             currentState,
+			StateSecretString: `{"password":"p1","user":"admin"}`,
+			StateRevisions:    map[string]interface{}{"password": 1, "user": 1},
+			Config: map[string]interface{}{
+				"secret_id":       "secret",
+				"generated_field": []interface{}{generatedField},
+			},
+			ExpectedSecretString: `{"password":"p1"}`,
+			ExpectedRevisions:    map[string]string{"password": "1"},
+		},
+		{
+			Name:              "rotated by keepers",
+			State:             currentState,
+			StateSecretString: `{"password":"p1","user":"admin"}`,
+			StateRevisions:    map[string]interface{}{"password": 1, "user": 1},
+			Config: map[string]interface{}{
+				"secret_id":       "secret",
+				"secret_fields":   map[string]interface{}{"user": "admin"},
+				"generated_field": []interface{}{generatedFieldRotated},
+			},
+			ExpectedSecretString: "<computed>",
+			ExpectedRevisions:    map[string]string{"password": "2", "user": "1"},
+		},
+		{
+			Name: "imported",
+			State: map[string]interface{}{
+				"secret_id": "secret",
+			},
+			StateSecretString: `{"password":"p1","user":"admin"}`,
+			Config: map[string]interface{}{
+				"secret_id":       "secret",
+				"secret_fields":   map[string]interface{}{"user": "admin"},
+				"generated_field": []interface{}{generatedField},
+			},
+			ExpectedRevisions: map[string]string{"password": "1", "user": "1"},
+		},
+		{
+			Name:              "switched from secret_string",
+			State:             map[string]interface{}{"secret_id": "secret", "secret_string": "plain"},
+			StateSecretString: "plain",
+			Config: map[string]interface{}{
+				"secret_id":     "secret",
+				"secret_fields": map[string]interface{}{"user": "admin"},
+			},
+			ExpectedSecretString: `{"user":"admin"}`,
+			ExpectedRevisions:    map[string]string{"user": "1"},
+		},
+		{
+			Name:              "changed secret_string",
+			State:             map[string]interface{}{"secret_id": "secret", "secret_string": "plain"},
+			StateSecretString: "plain",
+			Config: map[string]interface{}{
+				"secret_id":     "secret",
+				"secret_string": "changed",
+			},
+			ExpectedSecretString: "changed",
+			ExpectRequiresNew:    true,
+		},
+		{
+			Name: "duplicate field",
+			Config: map[string]interface{}{
+				"secret_id":       "secret",
+				"secret_fields":   map[string]interface{}{"password": "p1"},
+				"generated_field": []interface{}{generatedField},
+			},
+			ExpectErrorSubstring: "generated field (password) is also set in secret_fields",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := ResourceSecretVersion()
+
+			var state *terraform.InstanceState
+
+			if testCase.State != nil {
+				d := schema.TestResourceDataRaw(t, r.Schema, testCase.State)
+				d.SetId("secret|v1")
+				d.Set("secret_string", testCase.StateSecretString)
+				d.Set("secret_field_revisions", testCase.StateRevisions)
+				state = d.State()
+			}
+
+			diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(testCase.Config), nil)
+
+			if testCase.ExpectErrorSubstring != "" {
+				if err == nil {
+					t.Fatalf("expected error containing %q, got no error", testCase.ExpectErrorSubstring)
+				}
+
+				if !strings.Contains(err.Error(), testCase.ExpectErrorSubstring) {
+					t.Fatalf("expected error containing %q, got: %s", testCase.ExpectErrorSubstring, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			attributes := make(map[string]*terraform.ResourceAttrDiff)
+
+			if diff != nil {
+				attributes = diff.Attributes
+			}
+
+			switch v := attributes["secret_string"]; {
+			case testCase.ExpectedSecretString == "":
+				if v != nil && v.Old != v.New {
+					t.Errorf("unexpected secret_string diff: %+v", v)
+				}
+			case v == nil:
+				t.Errorf("missing secret_string diff, expected %q", testCase.ExpectedSecretString)
+			case testCase.ExpectedSecretString == "<computed>":
+				if !v.NewComputed {
+					t.Errorf("got secret_string %q, expected computed", v.New)
+				}
+			case v.New != testCase.ExpectedSecretString:
+				t.Errorf("got secret_string %q, expected %q", v.New, testCase.ExpectedSecretString)
+			}
+
+			if got := diff != nil && diff.RequiresNew(); state != nil && got != testCase.ExpectRequiresNew {
+				t.Errorf("got RequiresNew %t, expected %t", got, testCase.ExpectRequiresNew)
+			}
+
+			for k, want := range testCase.ExpectedRevisions {
+				v, ok := attributes["secret_field_revisions."+k]
+
+				if !ok {
+					if old, ok := state.Attributes["secret_field_revisions."+k]; ok && old == want {
+						continue
+					}
+
+					t.Errorf("missing secret_field_revisions.%s diff, expected %s", k, want)
+				} else if v.New != want {
+					t.Errorf("got secret_field_revisions.%s %s, expected %s", k, v.New, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFlattenSecretFields(t *testing.T) {
+	got, err := flattenSecretFields(map[string]*string{
+		"user":     aws.String("admin"),
+		"password": aws.String(`p&<"1>`),
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if want := `{"password":"p&<\"1>","user":"admin"}`; got != want {
+		t.Errorf("got %s, expected %s", got, want)
+	}
+}
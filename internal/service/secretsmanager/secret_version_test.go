@@ -1,6 +1,7 @@
 package secretsmanager_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -131,6 +132,65 @@ func TestAccSecretsManagerSecretVersion_versionStages(t *testing.T) {
 	})
 }
 
+func TestAccSecretsManagerSecretVersion_secretFields(t *testing.T) {
+	var version secretsmanager.GetSecretValueOutput
+	var password string
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_secretsmanager_secret_version.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t); testAccPreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, secretsmanager.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckSecretVersionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSecretVersionConfig_SecretFields(rName, "admin", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSecretVersionExists(resourceName, &version),
+					testAccCheckSecretVersionField(resourceName, "password", &password, true),
+					resource.TestCheckResourceAttr(resourceName, "secret_fields.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "secret_fields.user", "admin"),
+					resource.TestCheckResourceAttr(resourceName, "secret_field_revisions.%", "3"),
+					resource.TestCheckResourceAttr(resourceName, "secret_field_revisions.host", "1"),
+					resource.TestCheckResourceAttr(resourceName, "secret_field_revisions.password", "1"),
+					resource.TestCheckResourceAttr(resourceName, "secret_field_revisions.user", "1"),
+					resource.TestCheckResourceAttr(resourceName, "version_stages.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "version_stages.*", "AWSCURRENT"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"generated_field", "secret_field_revisions", "secret_fields"},
+			},
+			{
+				Config: testAccSecretVersionConfig_SecretFields(rName, "root", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSecretVersionExists(resourceName, &version),
+					testAccCheckSecretVersionField(resourceName, "password", &password, false),
+					resource.TestCheckResourceAttr(resourceName, "secret_fields.user", "root"),
+					resource.TestCheckResourceAttr(resourceName, "secret_field_revisions.host", "1"),
+					resource.TestCheckResourceAttr(resourceName, "secret_field_revisions.password", "1"),
+					resource.TestCheckResourceAttr(resourceName, "secret_field_revisions.user", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "version_stages.*", "AWSCURRENT"),
+				),
+			},
+			{
+				Config: testAccSecretVersionConfig_SecretFields(rName, "root", "2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSecretVersionExists(resourceName, &version),
+					testAccCheckSecretVersionField(resourceName, "password", &password, true),
+					resource.TestCheckResourceAttr(resourceName, "secret_field_revisions.host", "1"),
+					resource.TestCheckResourceAttr(resourceName, "secret_field_revisions.password", "2"),
+					resource.TestCheckResourceAttr(resourceName, "secret_field_revisions.user", "2"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckSecretVersionDestroy(s *terraform.State) error {
 	conn := acctest.Provider.Meta().(*conns.AWSClient).SecretsManagerConn
 
@@ -215,6 +275,36 @@ func testAccCheckSecretVersionExists(resourceName string, version *secretsmanage
 	}
 }
 
+// testAccCheckSecretVersionField checks whether a field of the secret_string JSON changed since the previous check.
+func testAccCheckSecretVersionField(resourceName, field string, value *string, expectChanged bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		var fields map[string]string
+
+		if err := json.Unmarshal([]byte(rs.Primary.Attributes["secret_string"]), &fields); err != nil {
+			return err
+		}
+
+		v, ok := fields[field]
+
+		if !ok || v == "" {
+			return fmt.Errorf("Secret Version %q has no field %q", rs.Primary.ID, field)
+		}
+
+		if changed := v != *value; changed != expectChanged {
+			return fmt.Errorf("Secret Version %q field %q changed: %t, expected %t", rs.Primary.ID, field, changed, expectChanged)
+		}
+
+		*value = v
+
+		return nil
+	}
+}
+
 func testAccSecretVersionConfig_SecretString(rName string) string {
 	return fmt.Sprintf(`
 resource "aws_secretsmanager_secret" "test" {
@@ -285,3 +375,30 @@ resource "aws_secretsmanager_secret_version" "test" {
 }
 `, rName)
 }
+
+func testAccSecretVersionConfig_SecretFields(rName, user, rotation string) string {
+	return fmt.Sprintf(`
+resource "aws_secretsmanager_secret" "test" {
+  name = %[1]q
+}
+
+resource "aws_secretsmanager_secret_version" "test" {
+  secret_id = aws_secretsmanager_secret.test.id
+
+  secret_fields = {
+    host = "db.example.com"
+    user = %[2]q
+  }
+
+  generated_field {
+    name                = "password"
+    length              = 24
+    exclude_punctuation = true
+
+    keepers = {
+      rotation = %[3]q
+    }
+  }
+}
+`, rName, user, rotation)
+}
@@ -0,0 +1,969 @@
+package sfn
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/aws/aws-sdk-go/service/sfn"
+	"github.com/hashicorp/go-multierror"
+)
+
+// Amazon States Language, https://states-language.net/spec.html.
+
+const (
+	stateTypeChoice   = "Choice"
+	stateTypeFail     = "Fail"
+	stateTypeMap      = "Map"
+	stateTypeParallel = "Parallel"
+	stateTypePass     = "Pass"
+	stateTypeSucceed  = "Succeed"
+	stateTypeTask     = "Task"
+	stateTypeWait     = "Wait"
+
+	// stateNameMaxLength is the maximum length of a state name.
+	stateNameMaxLength = 80
+
+	errorStatesAll = "States.ALL"
+
+	queryLanguageJSONPath = "JSONPath"
+)
+
+func stateType_Values() []string {
+	return []string{
+		stateTypeChoice,
+		stateTypeFail,
+		stateTypeMap,
+		stateTypeParallel,
+		stateTypePass,
+		stateTypeSucceed,
+		stateTypeTask,
+		stateTypeWait,
+	}
+}
+
+// stateFields are the fields allowed in each state type, other than Type and Comment.
+// Fields not listed for any state type are not validated, so that new fields are not rejected.
+var stateFields = map[string][]string{
+	stateTypeChoice:   {"Choices", "Default", "InputPath", "OutputPath"},
+	stateTypeFail:     {"Cause", "CausePath", "Error", "ErrorPath"},
+	stateTypeMap:      {"Catch", "End", "InputPath", "ItemProcessor", "ItemReader", "ItemSelector", "ItemsPath", "Iterator", "MaxConcurrency", "MaxConcurrencyPath", "Next", "OutputPath", "Parameters", "ResultPath", "ResultSelector", "Retry"},
+	stateTypeParallel: {"Branches", "Catch", "End", "InputPath", "Next", "OutputPath", "Parameters", "ResultPath", "ResultSelector", "Retry"},
+	stateTypePass:     {"End", "InputPath", "Next", "OutputPath", "Parameters", "Result", "ResultPath"},
+	stateTypeSucceed:  {"InputPath", "OutputPath"},
+	stateTypeTask:     {"Catch", "Credentials", "End", "HeartbeatSeconds", "HeartbeatSecondsPath", "InputPath", "Next", "OutputPath", "Parameters", "Resource", "ResultPath", "ResultSelector", "Retry", "TimeoutSeconds", "TimeoutSecondsPath"},
+	stateTypeWait:     {"End", "InputPath", "Next", "OutputPath", "Seconds", "SecondsPath", "Timestamp", "TimestampPath"},
+}
+
+// choiceComparisonOperators are the choice rule comparison operators and the type of their value.
+var choiceComparisonOperators = map[string]string{
+	"BooleanEquals":                  "boolean",
+	"BooleanEqualsPath":              "path",
+	"IsBoolean":                      "boolean",
+	"IsNull":                         "boolean",
+	"IsNumeric":                      "boolean",
+	"IsPresent":                      "boolean",
+	"IsString":                       "boolean",
+	"IsTimestamp":                    "boolean",
+	"NumericEquals":                  "number",
+	"NumericEqualsPath":              "path",
+	"NumericGreaterThan":             "number",
+	"NumericGreaterThanEquals":       "number",
+	"NumericGreaterThanEqualsPath":   "path",
+	"NumericGreaterThanPath":         "path",
+	"NumericLessThan":                "number",
+	"NumericLessThanEquals":          "number",
+	"NumericLessThanEqualsPath":      "path",
+	"NumericLessThanPath":            "path",
+	"StringEquals":                   "string",
+	"StringEqualsPath":               "path",
+	"StringGreaterThan":              "string",
+	"StringGreaterThanEquals":        "string",
+	"StringGreaterThanEqualsPath":    "path",
+	"StringGreaterThanPath":          "path",
+	"StringLessThan":                 "string",
+	"StringLessThanEquals":           "string",
+	"StringLessThanEqualsPath":       "path",
+	"StringLessThanPath":             "path",
+	"StringMatches":                  "string",
+	"TimestampEquals":                "timestamp",
+	"TimestampEqualsPath":            "path",
+	"TimestampGreaterThan":           "timestamp",
+	"TimestampGreaterThanEquals":     "timestamp",
+	"TimestampGreaterThanEqualsPath": "path",
+	"TimestampGreaterThanPath":       "path",
+	"TimestampLessThan":              "timestamp",
+	"TimestampLessThanEquals":        "timestamp",
+	"TimestampLessThanEqualsPath":    "path",
+	"TimestampLessThanPath":          "path",
+}
+
+// expressUnsupportedResourceSuffixes are the service integration patterns not supported by Express state machines.
+var expressUnsupportedResourceSuffixes = []string{".sync", ".sync:2", ".waitForTaskToken"}
+
+// ValidateDefinition validates an Amazon States Language state machine definition:
+// the state graph (transition targets, reachability and terminal states), the fields of each state type,
+// paths, choice rules, retriers and catchers, and Parallel branches and Map iterators, recursively.
+// Only the state graph is validated for states using a query language other than JSONPath, e.g. JSONata,
+// as their field values are expressions evaluated at runtime.
+func ValidateDefinition(definition, stateMachineType string) error {
+	decoder := json.NewDecoder(strings.NewReader(definition))
+	decoder.UseNumber()
+
+	var machine map[string]interface{}
+
+	if err := decoder.Decode(&machine); err != nil {
+		return fmt.Errorf("definition is not a valid JSON object: %w", err)
+	}
+
+	v := &definitionValidator{
+		express: stateMachineType == sfn.StateMachineTypeExpress,
+	}
+
+	v.validateMachine("", machine, true, true)
+
+	if len(v.errs) > 0 {
+		return &multierror.Error{Errors: v.errs}
+	}
+
+	return nil
+}
+
+type definitionValidator struct {
+	express bool
+	errs    []error
+}
+
+func (v *definitionValidator) errorf(path, format string, a ...interface{}) {
+	if path == "" {
+		v.errs = append(v.errs, fmt.Errorf(format, a...))
+		return
+	}
+
+	v.errs = append(v.errs, fmt.Errorf("%s: %s", path, fmt.Sprintf(format, a...)))
+}
+
+// validateMachine validates a state machine, Parallel branch or Map iterator.
+// jsonPath is whether its states use JSONPath unless they set their own QueryLanguage.
+func (v *definitionValidator) validateMachine(path string, machine map[string]interface{}, topLevel, jsonPath bool) {
+	if queryLanguage, ok := machine["QueryLanguage"].(string); ok {
+		jsonPath = queryLanguage == queryLanguageJSONPath
+	}
+
+	if !topLevel {
+		for _, k := range []string{"TimeoutSeconds", "Version"} {
+			if _, ok := machine[k]; ok {
+				v.errorf(joinPath(path, k), "only allowed at the top level of the definition")
+			}
+		}
+	} else if timeout, ok := machine["TimeoutSeconds"]; ok {
+		v.validatePositiveInteger(joinPath(path, "TimeoutSeconds"), timeout)
+	}
+
+	startAt, ok := machine["StartAt"].(string)
+
+	if !ok {
+		v.errorf(joinPath(path, "StartAt"), "required string")
+	}
+
+	states, ok := machine["States"].(map[string]interface{})
+
+	if !ok || len(states) == 0 {
+		v.errorf(joinPath(path, "States"), "required non-empty object")
+
+		return
+	}
+
+	if startAt != "" {
+		if _, ok := states[startAt]; !ok {
+			v.errorf(joinPath(path, "StartAt"), "state (%s) does not exist", startAt)
+		}
+	}
+
+	for _, name := range sortedKeys(states) {
+		statePath := joinPath(joinPath(path, "States"), name)
+
+		if len(name) > stateNameMaxLength {
+			v.errorf(statePath, "state name is longer than %d characters", stateNameMaxLength)
+		}
+
+		state, ok := states[name].(map[string]interface{})
+
+		if !ok {
+			v.errorf(statePath, "state is not an object")
+			continue
+		}
+
+		v.validateState(statePath, state, states, jsonPath)
+	}
+
+	if _, ok := states[startAt]; ok {
+		reachable := reachableStates(startAt, states)
+
+		for _, name := range sortedKeys(states) {
+			if !reachable[name] {
+				v.errorf(joinPath(joinPath(path, "States"), name), "state is unreachable from StartAt (%s)", startAt)
+			}
+		}
+	}
+}
+
+func (v *definitionValidator) validateState(path string, state map[string]interface{}, states map[string]interface{}, jsonPath bool) {
+	stateType, ok := state["Type"].(string)
+
+	if !ok {
+		v.errorf(joinPath(path, "Type"), "required string")
+		return
+	}
+
+	allowed, ok := stateFields[stateType]
+
+	if !ok {
+		v.errorf(joinPath(path, "Type"), "invalid state type (%s), expected one of %s", stateType, strings.Join(stateType_Values(), ", "))
+		return
+	}
+
+	if queryLanguage, ok := state["QueryLanguage"].(string); ok {
+		jsonPath = queryLanguage == queryLanguageJSONPath
+	}
+
+	// The field values of JSONata states are expressions, e.g. "Seconds": "{% $delay %}", and choice rules use Condition.
+	if !jsonPath {
+		v.validateTransitions(path, state, states, allowed)
+		v.validateNestedMachines(path, state, jsonPath)
+
+		return
+	}
+
+	for _, k := range sortedKeys(state) {
+		if isKnownStateField(k) && !stringInSlice(k, allowed) {
+			v.errorf(joinPath(path, k), "not allowed in %s states", stateType)
+		}
+	}
+
+	v.validateTransitions(path, state, states, allowed)
+
+	// Paths.
+	for _, k := range []string{"InputPath", "OutputPath"} {
+		if value, ok := state[k]; ok && value != nil {
+			v.validatePath(joinPath(path, k), value, false)
+		}
+	}
+
+	if value, ok := state["ResultPath"]; ok && value != nil {
+		v.validatePath(joinPath(path, "ResultPath"), value, true)
+	}
+
+	for _, k := range []string{"HeartbeatSecondsPath", "ItemsPath", "MaxConcurrencyPath", "SecondsPath", "TimeoutSecondsPath", "TimestampPath"} {
+		if value, ok := state[k]; ok {
+			v.validatePath(joinPath(path, k), value, false)
+		}
+	}
+
+	// The error and cause of Fail states can also be intrinsic functions.
+	for _, k := range []string{"CausePath", "ErrorPath"} {
+		if value, ok := state[k]; ok {
+			if s, ok := value.(string); !ok || !(strings.HasPrefix(s, "States.") || validJSONPath(s)) {
+				v.errorf(joinPath(path, k), "must be a JSONPath or intrinsic function")
+			}
+		}
+	}
+
+	for _, k := range []string{"ItemSelector", "Parameters", "ResultSelector"} {
+		if value, ok := state[k]; ok {
+			v.validatePayloadTemplate(joinPath(path, k), value)
+		}
+	}
+
+	if value, ok := state["Retry"]; ok {
+		v.validateRetriers(joinPath(path, "Retry"), value)
+	}
+
+	if value, ok := state["Catch"]; ok {
+		v.validateCatchers(joinPath(path, "Catch"), value, states)
+	}
+
+	switch stateType {
+	case stateTypeChoice:
+		v.validateChoice(path, state, states)
+	case stateTypeFail:
+		v.validateExclusive(path, state, false, "Error", "ErrorPath")
+		v.validateExclusive(path, state, false, "Cause", "CausePath")
+	case stateTypeMap:
+		v.validateMap(path, state)
+	case stateTypeParallel:
+		v.validateParallel(path, state)
+	case stateTypeTask:
+		v.validateTask(path, state)
+	case stateTypeWait:
+		v.validateWait(path, state)
+	}
+
+	v.validateNestedMachines(path, state, jsonPath)
+}
+
+// validateTransitions validates the Next and End fields of a state.
+func (v *definitionValidator) validateTransitions(path string, state map[string]interface{}, states map[string]interface{}, allowed []string) {
+	if stringInSlice("Next", allowed) {
+		next, hasNext := state["Next"]
+		end, hasEnd := state["End"]
+
+		if hasEnd {
+			if b, ok := end.(bool); !ok {
+				v.errorf(joinPath(path, "End"), "must be a boolean")
+			} else if !b {
+				hasEnd = false
+			}
+		}
+
+		switch {
+		case hasNext && hasEnd:
+			v.errorf(path, "only one of Next or End can be set")
+		case !hasNext && !hasEnd:
+			v.errorf(path, "one of Next or End (true) must be set")
+		case hasNext:
+			v.validateTarget(joinPath(path, "Next"), next, states)
+		}
+	}
+}
+
+// validateNestedMachines validates the Parallel branches and Map iterators of a state, which inherit its query language.
+func (v *definitionValidator) validateNestedMachines(path string, state map[string]interface{}, jsonPath bool) {
+	switch state["Type"] {
+	case stateTypeMap:
+		for _, k := range []string{"ItemProcessor", "Iterator"} {
+			if value, ok := state[k]; ok {
+				if iterator, ok := value.(map[string]interface{}); ok {
+					v.validateMachine(joinPath(path, k), iterator, false, jsonPath)
+				} else {
+					v.errorf(joinPath(path, k), "must be an object")
+				}
+			}
+		}
+	case stateTypeParallel:
+		branches, _ := state["Branches"].([]interface{})
+
+		for i, branch := range branches {
+			branchPath := fmt.Sprintf("%s[%d]", joinPath(path, "Branches"), i)
+
+			if branch, ok := branch.(map[string]interface{}); ok {
+				v.validateMachine(branchPath, branch, false, jsonPath)
+			} else {
+				v.errorf(branchPath, "must be an object")
+			}
+		}
+	}
+}
+
+func (v *definitionValidator) validateChoice(path string, state map[string]interface{}, states map[string]interface{}) {
+	choices, ok := state["Choices"].([]interface{})
+
+	if !ok || len(choices) == 0 {
+		v.errorf(joinPath(path, "Choices"), "required non-empty array")
+	}
+
+	for i, choice := range choices {
+		v.validateChoiceRule(fmt.Sprintf("%s[%d]", joinPath(path, "Choices"), i), choice, states, true)
+	}
+
+	if value, ok := state["Default"]; ok {
+		v.validateTarget(joinPath(path, "Default"), value, states)
+	}
+}
+
+func (v *definitionValidator) validateChoiceRule(path string, value interface{}, states map[string]interface{}, topLevel bool) {
+	rule, ok := value.(map[string]interface{})
+
+	if !ok {
+		v.errorf(path, "choice rule is not an object")
+		return
+	}
+
+	if next, ok := rule["Next"]; topLevel && ok {
+		v.validateTarget(joinPath(path, "Next"), next, states)
+	} else if topLevel {
+		v.errorf(path, "Next is required in top-level choice rules")
+	} else if ok {
+		v.errorf(joinPath(path, "Next"), "only allowed in top-level choice rules")
+	}
+
+	var operators []string
+
+	for _, k := range sortedKeys(rule) {
+		if _, ok := choiceComparisonOperators[k]; ok || k == "And" || k == "Or" || k == "Not" {
+			operators = append(operators, k)
+		}
+	}
+
+	if len(operators) != 1 {
+		v.errorf(path, "exactly one of And, Or, Not or a comparison operator must be set, got %d", len(operators))
+		return
+	}
+
+	operator := operators[0]
+	operatorPath := joinPath(path, operator)
+
+	switch operator {
+	case "And", "Or":
+		rules, ok := rule[operator].([]interface{})
+
+		if !ok || len(rules) == 0 {
+			v.errorf(operatorPath, "required non-empty array")
+		}
+
+		for i, r := range rules {
+			v.validateChoiceRule(fmt.Sprintf("%s[%d]", operatorPath, i), r, states, false)
+		}
+	case "Not":
+		v.validateChoiceRule(operatorPath, rule[operator], states, false)
+	default:
+		if variable, ok := rule["Variable"]; ok {
+			v.validatePath(joinPath(path, "Variable"), variable, false)
+		} else {
+			v.errorf(path, "Variable is required with comparison operator %s", operator)
+		}
+
+		switch value := rule[operator]; choiceComparisonOperators[operator] {
+		case "boolean":
+			if _, ok := value.(bool); !ok {
+				v.errorf(operatorPath, "must be a boolean")
+			}
+		case "number":
+			if _, ok := value.(json.Number); !ok {
+				v.errorf(operatorPath, "must be a number")
+			}
+		case "path":
+			v.validatePath(operatorPath, value, false)
+		case "string":
+			if _, ok := value.(string); !ok {
+				v.errorf(operatorPath, "must be a string")
+			}
+		case "timestamp":
+			if s, ok := value.(string); !ok {
+				v.errorf(operatorPath, "must be a string")
+			} else if _, err := time.Parse(time.RFC3339, s); err != nil {
+				v.errorf(operatorPath, "must be an RFC 3339 timestamp: %s", s)
+			}
+		}
+	}
+}
+
+func (v *definitionValidator) validateMap(path string, state map[string]interface{}) {
+	v.validateExclusive(path, state, true, "ItemProcessor", "Iterator")
+	v.validateExclusive(path, state, false, "MaxConcurrency", "MaxConcurrencyPath")
+
+	if value, ok := state["MaxConcurrency"]; ok {
+		v.validateNonNegativeInteger(joinPath(path, "MaxConcurrency"), value)
+	}
+}
+
+func (v *definitionValidator) validateParallel(path string, state map[string]interface{}) {
+	branches, ok := state["Branches"].([]interface{})
+
+	if !ok || len(branches) == 0 {
+		v.errorf(joinPath(path, "Branches"), "required non-empty array")
+	}
+}
+
+func (v *definitionValidator) validateTask(path string, state map[string]interface{}) {
+	resource, ok := state["Resource"].(string)
+
+	if !ok || resource == "" {
+		v.errorf(joinPath(path, "Resource"), "required string")
+	}
+
+	if v.express {
+		for _, suffix := range expressUnsupportedResourceSuffixes {
+			if strings.HasSuffix(resource, suffix) {
+				v.errorf(joinPath(path, "Resource"), "the %s integration pattern is not supported by %s state machines", suffix, sfn.StateMachineTypeExpress)
+			}
+		}
+	}
+
+	v.validateExclusive(path, state, false, "TimeoutSeconds", "TimeoutSecondsPath")
+	v.validateExclusive(path, state, false, "HeartbeatSeconds", "HeartbeatSecondsPath")
+
+	timeout, hasTimeout := state["TimeoutSeconds"]
+	heartbeat, hasHeartbeat := state["HeartbeatSeconds"]
+
+	if hasTimeout {
+		v.validatePositiveInteger(joinPath(path, "TimeoutSeconds"), timeout)
+	}
+
+	if hasHeartbeat {
+		v.validatePositiveInteger(joinPath(path, "HeartbeatSeconds"), heartbeat)
+	}
+
+	if hasTimeout && hasHeartbeat {
+		t, ok1 := integerValue(timeout)
+		h, ok2 := integerValue(heartbeat)
+
+		if ok1 && ok2 && h >= t {
+			v.errorf(joinPath(path, "HeartbeatSeconds"), "must be smaller than TimeoutSeconds")
+		}
+	}
+}
+
+func (v *definitionValidator) validateWait(path string, state map[string]interface{}) {
+	v.validateExclusive(path, state, true, "Seconds", "SecondsPath", "Timestamp", "TimestampPath")
+
+	if value, ok := state["Seconds"]; ok {
+		v.validateNonNegativeInteger(joinPath(path, "Seconds"), value)
+	}
+
+	if value, ok := state["Timestamp"]; ok {
+		if s, ok := value.(string); !ok {
+			v.errorf(joinPath(path, "Timestamp"), "must be a string")
+		} else if _, err := time.Parse(time.RFC3339, s); err != nil {
+			v.errorf(joinPath(path, "Timestamp"), "must be an RFC 3339 timestamp: %s", s)
+		}
+	}
+}
+
+func (v *definitionValidator) validateRetriers(path string, value interface{}) {
+	retriers, ok := value.([]interface{})
+
+	if !ok {
+		v.errorf(path, "must be an array")
+		return
+	}
+
+	for i, r := range retriers {
+		retrierPath := fmt.Sprintf("%s[%d]", path, i)
+		retrier, ok := r.(map[string]interface{})
+
+		if !ok {
+			v.errorf(retrierPath, "must be an object")
+			continue
+		}
+
+		v.validateErrorEquals(retrierPath, retrier, i == len(retriers)-1)
+
+		if value, ok := retrier["IntervalSeconds"]; ok {
+			v.validatePositiveInteger(joinPath(retrierPath, "IntervalSeconds"), value)
+		}
+
+		if value, ok := retrier["MaxAttempts"]; ok {
+			v.validateNonNegativeInteger(joinPath(retrierPath, "MaxAttempts"), value)
+		}
+
+		if value, ok := retrier["BackoffRate"]; ok {
+			if n, ok := value.(json.Number); !ok {
+				v.errorf(joinPath(retrierPath, "BackoffRate"), "must be a number")
+			} else if f, err := n.Float64(); err != nil || f < 1 {
+				v.errorf(joinPath(retrierPath, "BackoffRate"), "must be greater than or equal to 1.0")
+			}
+		}
+	}
+}
+
+func (v *definitionValidator) validateCatchers(path string, value interface{}, states map[string]interface{}) {
+	catchers, ok := value.([]interface{})
+
+	if !ok {
+		v.errorf(path, "must be an array")
+		return
+	}
+
+	for i, c := range catchers {
+		catcherPath := fmt.Sprintf("%s[%d]", path, i)
+		catcher, ok := c.(map[string]interface{})
+
+		if !ok {
+			v.errorf(catcherPath, "must be an object")
+			continue
+		}
+
+		v.validateErrorEquals(catcherPath, catcher, i == len(catchers)-1)
+
+		if next, ok := catcher["Next"]; ok {
+			v.validateTarget(joinPath(catcherPath, "Next"), next, states)
+		} else {
+			v.errorf(joinPath(catcherPath, "Next"), "required string")
+		}
+
+		if value, ok := catcher["ResultPath"]; ok && value != nil {
+			v.validatePath(joinPath(catcherPath, "ResultPath"), value, true)
+		}
+	}
+}
+
+// validateErrorEquals validates the error names of a retrier or catcher. States.ALL must be alone and in the last one.
+func (v *definitionValidator) validateErrorEquals(path string, m map[string]interface{}, last bool) {
+	errorEquals, ok := m["ErrorEquals"].([]interface{})
+
+	if !ok || len(errorEquals) == 0 {
+		v.errorf(joinPath(path, "ErrorEquals"), "required non-empty array")
+		return
+	}
+
+	for _, e := range errorEquals {
+		name, ok := e.(string)
+
+		if !ok {
+			v.errorf(joinPath(path, "ErrorEquals"), "error names must be strings")
+		} else if name == errorStatesAll && (len(errorEquals) > 1 || !last) {
+			v.errorf(joinPath(path, "ErrorEquals"), "%s must appear alone and in the last retrier or catcher", errorStatesAll)
+		}
+	}
+}
+
+func (v *definitionValidator) validateTarget(path string, value interface{}, states map[string]interface{}) {
+	name, ok := value.(string)
+
+	if !ok {
+		v.errorf(path, "must be a string")
+		return
+	}
+
+	if _, ok := states[name]; !ok {
+		v.errorf(path, "state (%s) does not exist", name)
+	}
+}
+
+// validateExclusive validates that at most, or if required exactly, one of the fields is set.
+func (v *definitionValidator) validateExclusive(path string, state map[string]interface{}, required bool, fields ...string) {
+	n := 0
+
+	for _, k := range fields {
+		if _, ok := state[k]; ok {
+			n++
+		}
+	}
+
+	if n > 1 || (required && n == 0) {
+		if required {
+			v.errorf(path, "exactly one of %s must be set", strings.Join(fields, ", "))
+		} else {
+			v.errorf(path, "only one of %s can be set", strings.Join(fields, ", "))
+		}
+	}
+}
+
+func (v *definitionValidator) validatePath(path string, value interface{}, reference bool) {
+	s, ok := value.(string)
+
+	switch {
+	case !ok:
+		v.errorf(path, "must be a string")
+	case reference && !validReferencePath(s):
+		v.errorf(path, "invalid reference path (%s)", s)
+	case !reference && !validJSONPath(s):
+		v.errorf(path, "invalid JSONPath (%s)", s)
+	}
+}
+
+// validatePayloadTemplate validates that fields whose name ends with .$ have a path or intrinsic function as value.
+func (v *definitionValidator) validatePayloadTemplate(path string, value interface{}) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for _, k := range sortedKeys(value) {
+			if strings.HasSuffix(k, ".$") {
+				s, ok := value[k].(string)
+
+				if !ok || !(strings.HasPrefix(s, "States.") || validJSONPath(s)) {
+					v.errorf(joinPath(path, k), "must be a JSONPath or intrinsic function")
+				}
+
+				continue
+			}
+
+			v.validatePayloadTemplate(joinPath(path, k), value[k])
+		}
+	case []interface{}:
+		for i, e := range value {
+			v.validatePayloadTemplate(fmt.Sprintf("%s[%d]", path, i), e)
+		}
+	}
+}
+
+func (v *definitionValidator) validatePositiveInteger(path string, value interface{}) {
+	if n, ok := integerValue(value); !ok || n <= 0 {
+		v.errorf(path, "must be a positive integer")
+	}
+}
+
+func (v *definitionValidator) validateNonNegativeInteger(path string, value interface{}) {
+	if n, ok := integerValue(value); !ok || n < 0 {
+		v.errorf(path, "must be a non-negative integer")
+	}
+}
+
+// reachableStates returns the states reachable from the start state through Next, Default, choice rule and catcher transitions.
+func reachableStates(startAt string, states map[string]interface{}) map[string]bool {
+	reachable := map[string]bool{startAt: true}
+	queue := []string{startAt}
+
+	for len(queue) > 0 {
+		state, _ := states[queue[0]].(map[string]interface{})
+		queue = queue[1:]
+
+		for _, next := range stateTransitions(state) {
+			if _, ok := states[next]; ok && !reachable[next] {
+				reachable[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return reachable
+}
+
+func stateTransitions(state map[string]interface{}) []string {
+	var transitions []string
+
+	add := func(v interface{}) {
+		if s, ok := v.(string); ok {
+			transitions = append(transitions, s)
+		}
+	}
+
+	add(state["Next"])
+	add(state["Default"])
+
+	for _, k := range []string{"Catch", "Choices"} {
+		if list, ok := state[k].([]interface{}); ok {
+			for _, e := range list {
+				if m, ok := e.(map[string]interface{}); ok {
+					add(m["Next"])
+				}
+			}
+		}
+	}
+
+	return transitions
+}
+
+// validJSONPath returns whether s is a JSONPath, starting with $, $$ for the context object,
+// or $ followed by the name of a workflow variable.
+func validJSONPath(s string) bool {
+	return validPath(s, false)
+}
+
+// validReferencePath returns whether s is a reference path, a JSONPath identifying a single node,
+// i.e. without wildcards, filters, slices or deep scans.
+func validReferencePath(s string) bool {
+	return validPath(s, true)
+}
+
+func validPath(s string, reference bool) bool {
+	if !strings.HasPrefix(s, "$") {
+		return false
+	}
+
+	rest := strings.TrimPrefix(s, "$")
+
+	switch {
+	case strings.HasPrefix(rest, "$"):
+		rest = rest[1:]
+	case !reference && rest != "" && rest[0] != '.' && rest[0] != '[':
+		// Variables can be read, but not written to by ResultPath.
+		i := strings.IndexAny(rest, ".[")
+
+		if i < 0 {
+			i = len(rest)
+		}
+
+		if !validVariableName(rest[:i]) {
+			return false
+		}
+
+		rest = rest[i:]
+	}
+
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+
+			if strings.HasPrefix(rest, ".") {
+				if reference {
+					return false
+				}
+
+				rest = rest[1:]
+			}
+
+			if strings.HasPrefix(rest, "*") {
+				if reference {
+					return false
+				}
+
+				rest = rest[1:]
+
+				continue
+			}
+
+			i := strings.IndexAny(rest, ".[")
+
+			if i < 0 {
+				i = len(rest)
+			}
+
+			if i == 0 {
+				return false
+			}
+
+			rest = rest[i:]
+		case '[':
+			i := closingBracket(rest)
+
+			if i < 0 {
+				return false
+			}
+
+			selector := rest[1:i]
+			rest = rest[i+1:]
+
+			if !validPathSelector(selector, reference) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// closingBracket returns the index of the bracket closing the bracket at the start of s, ignoring quoted brackets.
+func closingBracket(s string) int {
+	depth := 0
+	var quote byte
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+func validPathSelector(selector string, reference bool) bool {
+	switch {
+	case len(selector) >= 2 && (selector[0] == '\'' || selector[0] == '"') && selector[len(selector)-1] == selector[0]:
+		return true
+	case isDigits(selector):
+		return true
+	case reference:
+		return false
+	case selector == "*":
+		return true
+	case strings.HasPrefix(selector, "?(") && strings.HasSuffix(selector, ")"):
+		return true
+	case strings.Contains(selector, ":"):
+		for _, part := range strings.Split(selector, ":") {
+			if part != "" && !isDigits(strings.TrimPrefix(part, "-")) {
+				return false
+			}
+		}
+
+		return true
+	case strings.Contains(selector, ","):
+		for _, part := range strings.Split(selector, ",") {
+			if !validPathSelector(strings.TrimSpace(part), true) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// validVariableName returns whether s is a workflow variable name: letters, digits and underscores, not starting with a digit.
+func validVariableName(s string) bool {
+	for i, c := range s {
+		if !(unicode.IsLetter(c) || c == '_' || (i > 0 && unicode.IsDigit(c))) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func integerValue(value interface{}) (int64, bool) {
+	n, ok := value.(json.Number)
+
+	if !ok {
+		return 0, false
+	}
+
+	i, err := n.Int64()
+
+	return i, err == nil
+}
+
+func isKnownStateField(k string) bool {
+	for _, fields := range stateFields {
+		if stringInSlice(k, fields) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func joinPath(path, k string) string {
+	if path == "" {
+		return k
+	}
+
+	return path + "." + k
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func stringInSlice(s string, slice []string) bool {
+	for _, v := range slice {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,298 @@
+package sfn
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/sfn"
+	"github.com/hashicorp/go-multierror"
+)
+
+func TestValidateDefinition(t *testing.T) {
+	testCases := []struct {
+		Name             string
+		Definition       string
+		StateMachineType string
+		ExpectedErrors   []string
+	}{
+		{
+			Name: "valid",
+			Definition: `{
+  "Comment": "Order processing",
+  "StartAt": "Validate",
+  "TimeoutSeconds": 3600,
+  "States": {
+    "Validate": {
+      "Type": "Task",
+      "Resource": "arn:aws:states:::lambda:invoke",
+      "Parameters": {"FunctionName": "validate", "Payload.$": "$", "Execution.$": "$$.Execution.Id", "Message.$": "States.Format('Order {}', $.id)"},
+      "ResultSelector": {"valid.$": "$.Payload.valid"},
+      "ResultPath": "$.validation",
+      "TimeoutSeconds": 60,
+      "HeartbeatSeconds": 30,
+      "Retry": [
+        {"ErrorEquals": ["Lambda.ServiceException"], "IntervalSeconds": 2, "MaxAttempts": 3, "BackoffRate": 2.0},
+        {"ErrorEquals": ["States.ALL"]}
+      ],
+      "Catch": [{"ErrorEquals": ["States.ALL"], "ResultPath": "$.error", "Next": "Failed"}],
+      "Next": "IsValid"
+    },
+    "IsValid": {
+      "Type": "Choice",
+      "Choices": [
+        {"Variable": "$.validation.valid", "BooleanEquals": true, "Next": "Process"},
+        {"And": [{"Variable": "$.items[0].price", "NumericGreaterThan": 100}, {"Not": {"Variable": "$.coupon", "IsPresent": true}}], "Next": "Wait"}
+      ],
+      "Default": "Failed"
+    },
+    "Wait": {"Type": "Wait", "SecondsPath": "$.delay", "Next": "Process"},
+    "Process": {
+      "Type": "Parallel",
+      "Branches": [
+        {"StartAt": "Charge", "States": {"Charge": {"Type": "Pass", "Result": {"charged": true}, "End": true}}},
+        {
+          "StartAt": "Ship",
+          "States": {
+            "Ship": {
+              "Type": "Map",
+              "ItemsPath": "$.items[*]",
+              "MaxConcurrency": 2,
+              "Iterator": {"StartAt": "ShipItem", "States": {"ShipItem": {"Type": "Succeed"}}},
+              "End": true
+            }
+          }
+        }
+      ],
+      "OutputPath": "$[0]",
+      "Next": "Done"
+    },
+    "Done": {"Type": "Succeed"},
+    "Failed": {"Type": "Fail", "Error": "OrderFailed", "Cause": "Order could not be processed"}
+  }
+}`,
+		},
+		{
+			Name:           "invalid JSON",
+			Definition:     `{"StartAt": `,
+			ExpectedErrors: []string{"not a valid JSON object"},
+		},
+		{
+			Name:       "graph",
+			Definition: `{"StartAt": "Missing", "States": {"A": {"Type": "Pass", "Next": "Z"}, "B": {"Type": "Pass"}, "C": {"Type": "Pass", "Next": "A", "End": true}}}`,
+			ExpectedErrors: []string{
+				"StartAt: state (Missing) does not exist",
+				"States.A.Next: state (Z) does not exist",
+				"States.B: one of Next or End (true) must be set",
+				"States.C: only one of Next or End can be set",
+			},
+		},
+		{
+			Name:       "unreachable",
+			Definition: `{"StartAt": "A", "States": {"A": {"Type": "Succeed"}, "B": {"Type": "Pass", "Next": "C"}, "C": {"Type": "Succeed"}}}`,
+			ExpectedErrors: []string{
+				"States.B: state is unreachable from StartAt (A)",
+				"States.C: state is unreachable from StartAt (A)",
+			},
+		},
+		{
+			Name:       "fields per state type",
+			Definition: `{"StartAt": "A", "States": {"A": {"Type": "Succeed", "Next": "B", "ResultPath": "$.x"}, "B": {"Type": "Task", "End": true}, "C": {"Type": "Unknown"}}}`,
+			ExpectedErrors: []string{
+				"States.A.Next: not allowed in Succeed states",
+				"States.A.ResultPath: not allowed in Succeed states",
+				"States.B.Resource: required string",
+				"States.C.Type: invalid state type (Unknown)",
+				"States.C: state is unreachable from StartAt (A)",
+			},
+		},
+		{
+			Name:       "paths",
+			Definition: `{"StartAt": "A", "States": {"A": {"Type": "Pass", "InputPath": "input", "ResultPath": "$.items[*]", "OutputPath": "$.a[", "Parameters": {"x.$": "x", "nested": {"y.$": 1}}, "End": true}}}`,
+			ExpectedErrors: []string{
+				"States.A.InputPath: invalid JSONPath (input)",
+				"States.A.OutputPath: invalid JSONPath ($.a[)",
+				"States.A.ResultPath: invalid reference path ($.items[*])",
+				"States.A.Parameters.nested.y.$: must be a JSONPath or intrinsic function",
+				"States.A.Parameters.x.$: must be a JSONPath or intrinsic function",
+			},
+		},
+		{
+			Name: "choice rules",
+			Definition: `{"StartAt": "A", "States": {"A": {"Type": "Choice", "Choices": [
+  {"Variable": "$.x", "NumericEquals": "1", "Next": "B"},
+  {"Variable": "$.x", "StringEquals": "a", "StringMatches": "a*", "Next": "B"},
+  {"Not": {"Variable": "$.x", "IsNull": true, "Next": "B"}, "Next": "B"},
+  {"Variable": "$.t", "TimestampEquals": "yesterday"}
+], "Default": "C"}, "B": {"Type": "Succeed"}}}`,
+			ExpectedErrors: []string{
+				"States.A.Choices[0].NumericEquals: must be a number",
+				"States.A.Choices[1]: exactly one of And, Or, Not or a comparison operator must be set, got 2",
+				"States.A.Choices[2].Not.Next: only allowed in top-level choice rules",
+				"States.A.Choices[3]: Next is required in top-level choice rules",
+				"States.A.Choices[3].TimestampEquals: must be an RFC 3339 timestamp: yesterday",
+				"States.A.Default: state (C) does not exist",
+			},
+		},
+		{
+			Name:       "retriers and catchers",
+			Definition: `{"StartAt": "A", "States": {"A": {"Type": "Task", "Resource": "arn", "TimeoutSeconds": 10, "HeartbeatSeconds": 10, "Retry": [{"ErrorEquals": ["States.ALL"], "BackoffRate": 0.5}, {"ErrorEquals": []}], "Catch": [{"ErrorEquals": ["States.ALL", "Other"]}], "End": true}}}`,
+			ExpectedErrors: []string{
+				"States.A.Retry[0].ErrorEquals: States.ALL must appear alone and in the last retrier or catcher",
+				"States.A.Retry[0].BackoffRate: must be greater than or equal to 1.0",
+				"States.A.Retry[1].ErrorEquals: required non-empty array",
+				"States.A.Catch[0].ErrorEquals: States.ALL must appear alone and in the last retrier or catcher",
+				"States.A.Catch[0].Next: required string",
+				"States.A.HeartbeatSeconds: must be smaller than TimeoutSeconds",
+			},
+		},
+		{
+			Name:       "wait",
+			Definition: `{"StartAt": "A", "States": {"A": {"Type": "Wait", "Seconds": 5, "Timestamp": "2021-01-01T00:00:00Z", "Next": "B"}, "B": {"Type": "Wait", "Timestamp": "tomorrow", "End": true}}}`,
+			ExpectedErrors: []string{
+				"States.A: exactly one of Seconds, SecondsPath, Timestamp, TimestampPath must be set",
+				"States.B.Timestamp: must be an RFC 3339 timestamp: tomorrow",
+			},
+		},
+		{
+			Name: "nesting",
+			Definition: `{"StartAt": "P", "States": {
+  "P": {"Type": "Parallel", "Branches": [{"StartAt": "X", "Version": "1.0", "States": {"X": {"Type": "Pass", "Next": "Done"}}}], "Next": "M"},
+  "M": {"Type": "Map", "Iterator": {"StartAt": "Y", "States": {}}, "Next": "Done"},
+  "E": {"Type": "Parallel", "Branches": [], "Next": "Done"},
+  "Done": {"Type": "Succeed"}
+}}`,
+			ExpectedErrors: []string{
+				"States.P.Branches[0].Version: only allowed at the top level of the definition",
+				"States.P.Branches[0].States.X.Next: state (Done) does not exist",
+				"States.M.Iterator.States: required non-empty object",
+				"States.E.Branches: required non-empty array",
+				"States.E: state is unreachable from StartAt (P)",
+			},
+		},
+		{
+			Name: "JSONata",
+			Definition: `{"QueryLanguage": "JSONata", "StartAt": "A", "States": {
+  "A": {"Type": "Choice", "Choices": [{"Condition": "{% $states.input.ready %}", "Next": "B"}], "Default": "C"},
+  "B": {"Type": "Wait", "Seconds": "{% $states.input.delay %}", "Next": "C"},
+  "C": {"Type": "Task", "Resource": "arn:aws:states:::lambda:invoke", "Arguments": {"FunctionName": "f"}, "TimeoutSeconds": "{% $timeout %}", "Next": "Missing"}
+}}`,
+			ExpectedErrors: []string{
+				"States.C.Next: state (Missing) does not exist",
+			},
+		},
+		{
+			Name: "JSONata state",
+			Definition: `{"StartAt": "A", "States": {
+  "A": {"Type": "Wait", "QueryLanguage": "JSONata", "Seconds": "{% $states.input.delay %}", "Next": "B"},
+  "B": {"Type": "Map", "QueryLanguage": "JSONata", "Items": "{% $states.input.items %}", "ItemProcessor": {"StartAt": "X", "States": {"X": {"Type": "Wait", "Seconds": "{% $delay %}", "End": true}}}, "Next": "C"},
+  "C": {"Type": "Wait", "Seconds": "{% $states.input.delay %}", "End": true}
+}}`,
+			ExpectedErrors: []string{
+				"States.C.Seconds: must be a non-negative integer",
+			},
+		},
+		{
+			Name: "variables",
+			Definition: `{"StartAt": "A", "States": {
+  "A": {"Type": "Pass", "Assign": {"threshold": 10}, "Parameters": {"limit.$": "$threshold", "first.$": "$items[0].id"}, "Next": "B"},
+  "B": {"Type": "Choice", "Choices": [{"Variable": "$threshold", "NumericGreaterThanPath": "$.value", "Next": "C"}], "Default": "D"},
+  "C": {"Type": "Fail", "ErrorPath": "$errorName", "CausePath": "States.Format('Above {}', $threshold)"},
+  "D": {"Type": "Pass", "ResultPath": "$threshold", "End": true}
+}}`,
+			ExpectedErrors: []string{
+				"States.D.ResultPath: invalid reference path ($threshold)",
+			},
+		},
+		{
+			Name:             "express integration patterns",
+			Definition:       `{"StartAt": "A", "States": {"A": {"Type": "Task", "Resource": "arn:aws:states:::ecs:runTask.sync", "End": true}}}`,
+			StateMachineType: sfn.StateMachineTypeExpress,
+			ExpectedErrors: []string{
+				"States.A.Resource: the .sync integration pattern is not supported by EXPRESS state machines",
+			},
+		},
+		{
+			Name:             "standard integration patterns",
+			Definition:       `{"StartAt": "A", "States": {"A": {"Type": "Task", "Resource": "arn:aws:states:::ecs:runTask.sync", "End": true}}}`,
+			StateMachineType: sfn.StateMachineTypeStandard,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := ValidateDefinition(testCase.Definition, testCase.StateMachineType)
+
+			if len(testCase.ExpectedErrors) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatal("expected error")
+			}
+
+			for _, expected := range testCase.ExpectedErrors {
+				if !strings.Contains(err.Error(), expected) {
+					t.Errorf("expected error containing %q, got:\n%s", expected, err)
+				}
+			}
+
+			got := 1
+
+			if err, ok := err.(*multierror.Error); ok {
+				got = len(err.Errors)
+			}
+
+			if expected := len(testCase.ExpectedErrors); got != expected {
+				t.Errorf("got %d errors, expected %d:\n%s", got, expected, err)
+			}
+		})
+	}
+}
+
+func TestValidJSONPath(t *testing.T) {
+	testCases := []struct {
+		Path      string
+		Valid     bool
+		Reference bool
+	}{
+		{Path: "$", Valid: true, Reference: true},
+		{Path: "$$.Execution.Id", Valid: true, Reference: true},
+		{Path: "$.a.b_c", Valid: true, Reference: true},
+		{Path: "$.a[0]['b.c']", Valid: true, Reference: true},
+		{Path: `$["key"]`, Valid: true, Reference: true},
+		{Path: "$.a[*]", Valid: true},
+		{Path: "$..a", Valid: true},
+		{Path: "$.a.*", Valid: true},
+		{Path: "$.a[1:3]", Valid: true},
+		{Path: "$.a[-2:]", Valid: true},
+		{Path: "$.a[0,1]", Valid: true},
+		{Path: "$.a[?(@.price < 10)]", Valid: true},
+		{Path: "$.a[?(@.tags[0] == 'x]')]", Valid: true},
+		{Path: ""},
+		{Path: "a.b"},
+		{Path: "$."},
+		{Path: "$.a..."},
+		{Path: "$a", Valid: true},
+		{Path: "$a.b[0]", Valid: true},
+		{Path: "$1a"},
+		{Path: "$a-b"},
+		{Path: "$.a["},
+		{Path: "$.a[b]"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Path, func(t *testing.T) {
+			if got := validJSONPath(testCase.Path); got != testCase.Valid {
+				t.Errorf("validJSONPath: got %t, expected %t", got, testCase.Valid)
+			}
+
+			if got := validReferencePath(testCase.Path); got != testCase.Reference {
+				t.Errorf("validReferencePath: got %t, expected %t", got, testCase.Reference)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package sfn
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -8,6 +9,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/sfn"
 	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -115,10 +117,31 @@ func ResourceStateMachine() *schema.Resource {
 			},
 		},
 
-		CustomizeDiff: verify.SetTagsDiff,
+		CustomizeDiff: customdiff.Sequence(
+			resourceStateMachineDefinitionCustomizeDiff,
+			verify.SetTagsDiff,
+		),
 	}
 }
 
+// resourceStateMachineDefinitionCustomizeDiff validates new definitions locally,
+// as Amazon States Language errors are otherwise only reported by the API during apply.
+func resourceStateMachineDefinitionCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("definition") || !diff.NewValueKnown("type") {
+		return nil
+	}
+
+	if diff.Id() != "" && !diff.HasChange("definition") && !diff.HasChange("type") {
+		return nil
+	}
+
+	if err := ValidateDefinition(diff.Get("definition").(string), diff.Get("type").(string)); err != nil {
+		return fmt.Errorf("invalid Step Functions State Machine definition: %w", err)
+	}
+
+	return nil
+}
+
 func resourceStateMachineCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*conns.AWSClient).SFNConn
 	defaultTagsConfig := meta.(*conns.AWSClient).DefaultTagsConfig
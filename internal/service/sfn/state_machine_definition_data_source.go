@@ -0,0 +1,581 @@
+package sfn
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/sfn"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-aws/internal/create"
+)
+
+func DataSourceStateMachineDefinition() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceStateMachineDefinitionRead,
+
+		Schema: map[string]*schema.Schema{
+			"comment": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"start_at": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"state": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"branches": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringIsJSON,
+							},
+						},
+						"catch": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"error_equals": {
+										Type:     schema.TypeList,
+										Required: true,
+										MinItems: 1,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+									"next": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"result_path": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
+						"cause": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"choice": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"next": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"operator": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validation.StringInSlice(choiceComparisonOperator_Values(), false),
+									},
+									"rule": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validation.StringIsJSON,
+									},
+									"value": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"variable": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
+						"comment": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"default": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"end": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"error": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"heartbeat_seconds": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"input_path": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"items_path": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"iterator": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsJSON,
+						},
+						"max_concurrency": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringLenBetween(1, stateNameMaxLength),
+						},
+						"next": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"output_path": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"parameters": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsJSON,
+						},
+						"resource": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"result": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsJSON,
+						},
+						"result_path": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"result_selector": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsJSON,
+						},
+						"retry": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"backoff_rate": {
+										Type:         schema.TypeFloat,
+										Optional:     true,
+										ValidateFunc: validation.FloatAtLeast(1),
+									},
+									"error_equals": {
+										Type:     schema.TypeList,
+										Required: true,
+										MinItems: 1,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+									"interval_seconds": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+									"max_attempts": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validation.IntAtLeast(0),
+									},
+								},
+							},
+						},
+						"seconds": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"seconds_path": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"timeout_seconds": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"timestamp": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsRFC3339Time,
+						},
+						"timestamp_path": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(stateType_Values(), false),
+						},
+					},
+				},
+			},
+			"state_machine_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      sfn.StateMachineTypeStandard,
+				ValidateFunc: validation.StringInSlice(sfn.StateMachineType_Values(), false),
+			},
+			"timeout_seconds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func dataSourceStateMachineDefinitionRead(d *schema.ResourceData, meta interface{}) error {
+	tfList := d.Get("state").([]interface{})
+	definition := map[string]interface{}{}
+	states := map[string]interface{}{}
+
+	for i, v := range tfList {
+		tfMap, ok := v.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		name := tfMap["name"].(string)
+
+		if _, ok := states[name]; ok {
+			return fmt.Errorf("duplicate state name (%s)", name)
+		}
+
+		state, err := expandState(tfMap)
+
+		if err != nil {
+			return fmt.Errorf("error expanding state %d (%s): %w", i, name, err)
+		}
+
+		states[name] = state
+
+		if i == 0 {
+			definition["StartAt"] = name
+		}
+	}
+
+	definition["States"] = states
+
+	if v, ok := d.GetOk("start_at"); ok {
+		definition["StartAt"] = v.(string)
+	}
+
+	if v, ok := d.GetOk("comment"); ok {
+		definition["Comment"] = v.(string)
+	}
+
+	if v, ok := d.GetOk("timeout_seconds"); ok {
+		definition["TimeoutSeconds"] = v.(int)
+	}
+
+	if v, ok := d.GetOk("version"); ok {
+		definition["Version"] = v.(string)
+	}
+
+	jsonDoc, err := json.MarshalIndent(definition, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	jsonString := string(jsonDoc)
+
+	if err := ValidateDefinition(jsonString, d.Get("state_machine_type").(string)); err != nil {
+		return fmt.Errorf("invalid Step Functions State Machine definition: %w", err)
+	}
+
+	d.Set("json", jsonString)
+	d.SetId(strconv.Itoa(create.StringHashcode(jsonString)))
+
+	return nil
+}
+
+// stateFieldNames maps the state block's string and integer arguments to Amazon States Language fields.
+var stateFieldNames = map[string]string{
+	"cause":             "Cause",
+	"comment":           "Comment",
+	"default":           "Default",
+	"error":             "Error",
+	"heartbeat_seconds": "HeartbeatSeconds",
+	"input_path":        "InputPath",
+	"items_path":        "ItemsPath",
+	"max_concurrency":   "MaxConcurrency",
+	"next":              "Next",
+	"output_path":       "OutputPath",
+	"resource":          "Resource",
+	"result_path":       "ResultPath",
+	"seconds":           "Seconds",
+	"seconds_path":      "SecondsPath",
+	"timeout_seconds":   "TimeoutSeconds",
+	"timestamp":         "Timestamp",
+	"timestamp_path":    "TimestampPath",
+}
+
+// stateJSONFieldNames maps the state block's JSON arguments to Amazon States Language fields.
+var stateJSONFieldNames = map[string]string{
+	"iterator":        "Iterator",
+	"parameters":      "Parameters",
+	"result":          "Result",
+	"result_selector": "ResultSelector",
+}
+
+func expandState(tfMap map[string]interface{}) (map[string]interface{}, error) {
+	stateType := tfMap["type"].(string)
+	state := map[string]interface{}{
+		"Type": stateType,
+	}
+
+	for k, field := range stateFieldNames {
+		switch v := tfMap[k].(type) {
+		case string:
+			if v != "" {
+				state[field] = v
+			}
+		case int:
+			// Seconds is the only field where zero is meaningful.
+			if v != 0 || (k == "seconds" && stateType == stateTypeWait && !hasWaitField(tfMap)) {
+				state[field] = v
+			}
+		}
+	}
+
+	for k, field := range stateJSONFieldNames {
+		if v := tfMap[k].(string); v != "" {
+			value, err := decodeJSON(v)
+
+			if err != nil {
+				return nil, fmt.Errorf("error decoding %s: %w", k, err)
+			}
+
+			state[field] = value
+		}
+	}
+
+	if tfMap["end"].(bool) {
+		state["End"] = true
+	}
+
+	if v := tfMap["branches"].([]interface{}); len(v) > 0 {
+		var branches []interface{}
+
+		for i, branch := range v {
+			value, err := decodeJSON(branch.(string))
+
+			if err != nil {
+				return nil, fmt.Errorf("error decoding branches %d: %w", i, err)
+			}
+
+			// Branches only support the StartAt, States and Comment fields of a definition.
+			if m, ok := value.(map[string]interface{}); ok {
+				delete(m, "TimeoutSeconds")
+				delete(m, "Version")
+			}
+
+			branches = append(branches, value)
+		}
+
+		state["Branches"] = branches
+	}
+
+	if m, ok := state["Iterator"].(map[string]interface{}); ok {
+		delete(m, "TimeoutSeconds")
+		delete(m, "Version")
+	}
+
+	if v := tfMap["choice"].([]interface{}); len(v) > 0 {
+		var choices []interface{}
+
+		for i, tfMap := range v {
+			choice, err := expandChoiceRule(tfMap.(map[string]interface{}))
+
+			if err != nil {
+				return nil, fmt.Errorf("choice %d: %w", i, err)
+			}
+
+			choices = append(choices, choice)
+		}
+
+		state["Choices"] = choices
+	}
+
+	if v := tfMap["retry"].([]interface{}); len(v) > 0 {
+		var retriers []interface{}
+
+		for _, tfMap := range v {
+			tfMap := tfMap.(map[string]interface{})
+			retrier := map[string]interface{}{
+				"ErrorEquals": tfMap["error_equals"].([]interface{}),
+			}
+
+			if v := tfMap["backoff_rate"].(float64); v != 0 {
+				retrier["BackoffRate"] = v
+			}
+
+			if v := tfMap["interval_seconds"].(int); v != 0 {
+				retrier["IntervalSeconds"] = v
+			}
+
+			if v := tfMap["max_attempts"].(int); v != 0 {
+				retrier["MaxAttempts"] = v
+			}
+
+			retriers = append(retriers, retrier)
+		}
+
+		state["Retry"] = retriers
+	}
+
+	if v := tfMap["catch"].([]interface{}); len(v) > 0 {
+		var catchers []interface{}
+
+		for _, tfMap := range v {
+			tfMap := tfMap.(map[string]interface{})
+			catcher := map[string]interface{}{
+				"ErrorEquals": tfMap["error_equals"].([]interface{}),
+				"Next":        tfMap["next"].(string),
+			}
+
+			if v := tfMap["result_path"].(string); v != "" {
+				catcher["ResultPath"] = v
+			}
+
+			catchers = append(catchers, catcher)
+		}
+
+		state["Catch"] = catchers
+	}
+
+	return state, nil
+}
+
+// hasWaitField returns whether a Wait state sets a field other than seconds.
+func hasWaitField(tfMap map[string]interface{}) bool {
+	return tfMap["seconds_path"].(string) != "" || tfMap["timestamp"].(string) != "" || tfMap["timestamp_path"].(string) != ""
+}
+
+// expandChoiceRule returns a top-level choice rule, either from a JSON rule or a variable, comparison operator and value.
+func expandChoiceRule(tfMap map[string]interface{}) (map[string]interface{}, error) {
+	next := tfMap["next"].(string)
+
+	if v := tfMap["rule"].(string); v != "" {
+		if tfMap["variable"].(string) != "" || tfMap["operator"].(string) != "" || tfMap["value"].(string) != "" {
+			return nil, fmt.Errorf("rule conflicts with variable, operator and value")
+		}
+
+		value, err := decodeJSON(v)
+
+		if err != nil {
+			return nil, fmt.Errorf("error decoding rule: %w", err)
+		}
+
+		rule, ok := value.(map[string]interface{})
+
+		if !ok {
+			return nil, fmt.Errorf("rule must be a JSON object")
+		}
+
+		rule["Next"] = next
+
+		return rule, nil
+	}
+
+	variable, operator := tfMap["variable"].(string), tfMap["operator"].(string)
+
+	if variable == "" || operator == "" {
+		return nil, fmt.Errorf("one of rule, or variable and operator, must be set")
+	}
+
+	value, err := choiceRuleValue(operator, tfMap["value"].(string))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"Next":     next,
+		"Variable": variable,
+		operator:   value,
+	}, nil
+}
+
+// choiceRuleValue converts a comparison value to the type of the comparison operator.
+func choiceRuleValue(operator, value string) (interface{}, error) {
+	switch choiceComparisonOperators[operator] {
+	case "boolean":
+		if value == "" {
+			return true, nil
+		}
+
+		v, err := strconv.ParseBool(value)
+
+		if err != nil {
+			return nil, fmt.Errorf("value (%s) of %s must be a boolean", value, operator)
+		}
+
+		return v, nil
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return nil, fmt.Errorf("value (%s) of %s must be a number", value, operator)
+		}
+
+		return json.Number(value), nil
+	}
+
+	return value, nil
+}
+
+func choiceComparisonOperator_Values() []string {
+	values := make([]string, 0, len(choiceComparisonOperators))
+
+	for k := range choiceComparisonOperators {
+		values = append(values, k)
+	}
+
+	sort.Strings(values)
+
+	return values
+}
+
+func decodeJSON(s string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(s))
+	decoder.UseNumber()
+
+	var v interface{}
+
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
@@ -0,0 +1,176 @@
+package sfn_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/sfn"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+)
+
+func TestAccSFNStateMachineDefinitionDataSource_basic(t *testing.T) {
+	dataSourceName := "data.aws_sfn_state_machine_definition.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { acctest.PreCheck(t) },
+		ErrorCheck: acctest.ErrorCheck(t, sfn.EndpointsID),
+		Providers:  acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStateMachineDefinitionDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "json", testAccStateMachineDefinitionDataSourceExpectedJSON),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSFNStateMachineDefinitionDataSource_invalid(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { acctest.PreCheck(t) },
+		ErrorCheck: acctest.ErrorCheck(t, sfn.EndpointsID),
+		Providers:  acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccStateMachineDefinitionDataSourceConfig_unreachable,
+				ExpectError: regexp.MustCompile(`States.Orphan: state is unreachable from StartAt \(Start\)`),
+			},
+		},
+	})
+}
+
+const testAccStateMachineDefinitionDataSourceConfig = `
+data "aws_sfn_state_machine_definition" "branch" {
+  state {
+    name   = "Notify"
+    type   = "Pass"
+    result = jsonencode({ notified = true })
+    end    = true
+  }
+}
+
+data "aws_sfn_state_machine_definition" "test" {
+  comment = "Order processing"
+
+  state {
+    name    = "CheckAmount"
+    type    = "Choice"
+    default = "Process"
+
+    choice {
+      variable = "$.amount"
+      operator = "NumericGreaterThan"
+      value    = "1000"
+      next     = "Approve"
+    }
+  }
+
+  state {
+    name    = "Approve"
+    type    = "Wait"
+    seconds = 60
+    next    = "Process"
+  }
+
+  state {
+    name     = "Process"
+    type     = "Parallel"
+    branches = [data.aws_sfn_state_machine_definition.branch.json]
+    end      = true
+
+    retry {
+      error_equals = ["States.ALL"]
+      max_attempts = 2
+    }
+
+    catch {
+      error_equals = ["States.ALL"]
+      next         = "Failed"
+    }
+  }
+
+  state {
+    name  = "Failed"
+    type  = "Fail"
+    error = "OrderFailed"
+  }
+}
+`
+
+const testAccStateMachineDefinitionDataSourceExpectedJSON = `{
+  "Comment": "Order processing",
+  "StartAt": "CheckAmount",
+  "States": {
+    "Approve": {
+      "Next": "Process",
+      "Seconds": 60,
+      "Type": "Wait"
+    },
+    "CheckAmount": {
+      "Choices": [
+        {
+          "Next": "Approve",
+          "NumericGreaterThan": 1000,
+          "Variable": "$.amount"
+        }
+      ],
+      "Default": "Process",
+      "Type": "Choice"
+    },
+    "Failed": {
+      "Error": "OrderFailed",
+      "Type": "Fail"
+    },
+    "Process": {
+      "Branches": [
+        {
+          "StartAt": "Notify",
+          "States": {
+            "Notify": {
+              "End": true,
+              "Result": {
+                "notified": true
+              },
+              "Type": "Pass"
+            }
+          }
+        }
+      ],
+      "Catch": [
+        {
+          "ErrorEquals": [
+            "States.ALL"
+          ],
+          "Next": "Failed"
+        }
+      ],
+      "End": true,
+      "Retry": [
+        {
+          "ErrorEquals": [
+            "States.ALL"
+          ],
+          "MaxAttempts": 2
+        }
+      ],
+      "Type": "Parallel"
+    }
+  }
+}`
+
+const testAccStateMachineDefinitionDataSourceConfig_unreachable = `
+data "aws_sfn_state_machine_definition" "test" {
+  state {
+    name = "Start"
+    type = "Succeed"
+  }
+
+  state {
+    name = "Orphan"
+    type = "Pass"
+    end  = true
+  }
+}
+`
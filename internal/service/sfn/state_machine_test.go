@@ -261,6 +261,27 @@ func TestAccSFNStateMachine_disappears(t *testing.T) {
 	})
 }
 
+func TestAccSFNStateMachine_invalidDefinition(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, sfn.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckStateMachineDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccStateMachineConfig_invalidDefinition(rName, "STANDARD", `{"StartAt": "A", "States": {"A": {"Type": "Pass", "Next": "B"}}}`),
+				ExpectError: regexp.MustCompile(`States.A.Next: state \(B\) does not exist`),
+			},
+			{
+				Config:      testAccStateMachineConfig_invalidDefinition(rName, "EXPRESS", `{"StartAt": "A", "States": {"A": {"Type": "Task", "Resource": "arn:aws:states:::ecs:runTask.sync", "End": true}}}`),
+				ExpectError: regexp.MustCompile(`not supported by EXPRESS state machines`),
+			},
+		},
+	})
+}
+
 func TestAccSFNStateMachine_expressLogging(t *testing.T) {
 	var sm sfn.DescribeStateMachineOutput
 	resourceName := "aws_sfn_state_machine.test"
@@ -709,3 +730,18 @@ EOF
 }
 `, rName))
 }
+
+func testAccStateMachineConfig_invalidDefinition(rName, stateMachineType, definition string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+data "aws_caller_identity" "current" {}
+
+resource "aws_sfn_state_machine" "test" {
+  name       = %[1]q
+  role_arn   = "arn:${data.aws_partition.current.partition}:iam::${data.aws_caller_identity.current.account_id}:role/%[1]s"
+  type       = %[2]q
+  definition = %[3]q
+}
+`, rName, stateMachineType, definition)
+}
@@ -59,3 +59,31 @@ func FindPatchGroup(conn *ssm.SSM, patchGroup, baselineId string) (*ssm.PatchGro
 
 	return result, err
 }
+
+// FindParametersByPath returns the decrypted parameters under the specified path, at any level of the hierarchy, keyed by name.
+func FindParametersByPath(conn *ssm.SSM, path string) (map[string]*ssm.Parameter, error) {
+	input := &ssm.GetParametersByPathInput{
+		Path:           aws.String(path),
+		Recursive:      aws.Bool(true),
+		WithDecryption: aws.Bool(true),
+	}
+	parameters := make(map[string]*ssm.Parameter)
+
+	err := conn.GetParametersByPathPages(input, func(page *ssm.GetParametersByPathOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, parameter := range page.Parameters {
+			if parameter == nil {
+				continue
+			}
+
+			parameters[aws.StringValue(parameter.Name)] = parameter
+		}
+
+		return !lastPage
+	})
+
+	return parameters, err
+}
@@ -0,0 +1,538 @@
+package ssm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+)
+
+const (
+	// Maximum number of names in a DeleteParameters request.
+	parameterTreeDeleteBatchSize = 10
+	// Maximum number of concurrent PutParameter requests.
+	parameterTreePutConcurrency = 5
+)
+
+var parameterTreePathRegexp = regexp.MustCompile(`^(/[a-zA-Z0-9_.-]+)+$`)
+
+func ResourceParameterTree() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceParameterTreeCreate,
+		Read:   resourceParameterTreeRead,
+		Update: resourceParameterTreeUpdate,
+		Delete: resourceParameterTreeDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"document": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"document", "source"},
+			},
+			"key_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"key_ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"overwrite": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"parameter": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"key_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice(ssm.ParameterType_Values(), false),
+						},
+					},
+				},
+			},
+			"path": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.All(
+					validation.StringLenBetween(2, 1011),
+					validation.StringMatch(parameterTreePathRegexp, "must start with a slash and must not end with a slash, e.g. /app/prod"),
+				),
+			},
+			"secure_values": {
+				Type:      schema.TypeMap,
+				Computed:  true,
+				Sensitive: true,
+				Elem:      &schema.Schema{Type: schema.TypeString},
+			},
+			"source": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"document", "source"},
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(ssm.ParameterType_Values(), false),
+			},
+			"types": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"values": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"versions": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+		},
+
+		CustomizeDiff: resourceParameterTreeCustomizeDiff,
+	}
+}
+
+func resourceParameterTreeCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).SSMConn
+
+	path := d.Get("path").(string)
+	entries, err := expandParameterTree(d)
+
+	if err != nil {
+		return err
+	}
+
+	overwrite := make(map[string]bool)
+
+	for name := range entries {
+		overwrite[name] = d.Get("overwrite").(bool)
+	}
+
+	// Set the ID first so that the parameters of a partially created tree are deleted with the tainted resource.
+	d.SetId(path)
+
+	written, err := putParameterTreeEntries(conn, entries, overwrite)
+
+	if err != nil {
+		// Only the parameters that were written are managed, so that parameters that already existed are not deleted.
+		managed := make(map[string]*parameterTreeEntry)
+
+		for _, name := range written {
+			managed[name] = entries[name]
+		}
+
+		if err := setParameterTreeEntries(d, managed); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("error creating SSM Parameter Tree (%s): %w", path, err)
+	}
+
+	if err := setParameterTreeEntries(d, entries); err != nil {
+		return err
+	}
+
+	return resourceParameterTreeRead(d, meta)
+}
+
+func resourceParameterTreeRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).SSMConn
+
+	parameters, err := FindParametersByPath(conn, d.Id())
+
+	if err != nil {
+		return fmt.Errorf("error reading SSM Parameter Tree (%s): %w", d.Id(), err)
+	}
+
+	managed := d.Get("types").(map[string]interface{})
+
+	// An imported tree manages all the parameters under its path.
+	if len(managed) == 0 && d.Get("document").(string) == "" && d.Get("source").(string) == "" {
+		for name := range parameters {
+			managed[name] = ""
+		}
+	}
+
+	oldKeyIDs := d.Get("key_ids").(map[string]interface{})
+	keyIDs := make(map[string]interface{})
+	secureValues := make(map[string]interface{})
+	types := make(map[string]interface{})
+	values := make(map[string]interface{})
+	versions := make(map[string]interface{})
+
+	for name := range managed {
+		parameter, ok := parameters[name]
+
+		if !ok {
+			log.Printf("[WARN] SSM Parameter (%s) of SSM Parameter Tree (%s) not found, removing from state", name, d.Id())
+			continue
+		}
+
+		typ := aws.StringValue(parameter.Type)
+		types[name] = typ
+		versions[name] = int(aws.Int64Value(parameter.Version))
+
+		if typ == ssm.ParameterTypeSecureString {
+			secureValues[name] = aws.StringValue(parameter.Value)
+
+			if v, ok := oldKeyIDs[name]; ok {
+				keyIDs[name] = v
+			}
+		} else {
+			values[name] = aws.StringValue(parameter.Value)
+		}
+	}
+
+	d.Set("path", d.Id())
+
+	if err := d.Set("key_ids", keyIDs); err != nil {
+		return fmt.Errorf("error setting key_ids: %w", err)
+	}
+
+	if err := d.Set("secure_values", secureValues); err != nil {
+		return fmt.Errorf("error setting secure_values: %w", err)
+	}
+
+	if err := d.Set("types", types); err != nil {
+		return fmt.Errorf("error setting types: %w", err)
+	}
+
+	if err := d.Set("values", values); err != nil {
+		return fmt.Errorf("error setting values: %w", err)
+	}
+
+	if err := d.Set("versions", versions); err != nil {
+		return fmt.Errorf("error setting versions: %w", err)
+	}
+
+	return nil
+}
+
+func resourceParameterTreeUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).SSMConn
+
+	entries, err := expandParameterTree(d)
+
+	if err != nil {
+		return err
+	}
+
+	oldKeyIDs, _ := d.GetChange("key_ids")
+	oldSecureValues, _ := d.GetChange("secure_values")
+	oldTypes, _ := d.GetChange("types")
+	oldValues, _ := d.GetChange("values")
+	old := parameterTreeEntriesFromMaps(oldTypes.(map[string]interface{}), oldValues.(map[string]interface{}), oldSecureValues.(map[string]interface{}), oldKeyIDs.(map[string]interface{}))
+
+	var deletes []string
+
+	for name := range old {
+		if _, ok := entries[name]; !ok {
+			deletes = append(deletes, name)
+		}
+	}
+
+	if err := deleteParameterTreeParameters(conn, deletes); err != nil {
+		if err := setParameterTreeEntries(d, old); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("error updating SSM Parameter Tree (%s): %w", d.Id(), err)
+	}
+
+	puts := make(map[string]*parameterTreeEntry)
+	overwrite := make(map[string]bool)
+
+	for name, entry := range entries {
+		if v, ok := old[name]; ok && *v == *entry {
+			continue
+		}
+
+		puts[name] = entry
+		_, exists := old[name]
+		overwrite[name] = exists || d.Get("overwrite").(bool)
+	}
+
+	written, err := putParameterTreeEntries(conn, puts, overwrite)
+
+	if err != nil {
+		// The planned parameters are not stored, so that parameters that already existed are not managed.
+		for _, name := range deletes {
+			delete(old, name)
+		}
+
+		for _, name := range written {
+			old[name] = entries[name]
+		}
+
+		if err := setParameterTreeEntries(d, old); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("error updating SSM Parameter Tree (%s): %w", d.Id(), err)
+	}
+
+	if err := setParameterTreeEntries(d, entries); err != nil {
+		return err
+	}
+
+	return resourceParameterTreeRead(d, meta)
+}
+
+func resourceParameterTreeDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).SSMConn
+
+	var names []string
+
+	for name := range d.Get("types").(map[string]interface{}) {
+		names = append(names, name)
+	}
+
+	if err := deleteParameterTreeParameters(conn, names); err != nil {
+		return fmt.Errorf("error deleting SSM Parameter Tree (%s): %w", d.Id(), err)
+	}
+
+	return nil
+}
+
+// resourceParameterTreeCustomizeDiff plans the flattened parameters of the document, so that
+// parameters that are added, removed or changed out-of-band are shown per key.
+func resourceParameterTreeCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.GetRawConfig().IsWhollyKnown() {
+		for _, k := range []string{"key_ids", "secure_values", "types", "values", "versions"} {
+			if err := diff.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	entries, err := expandParameterTree(diff)
+
+	if err != nil {
+		return err
+	}
+
+	types, values, secureValues, keyIDs := flattenParameterTreeEntries(entries)
+	changed := false
+
+	for k, v := range map[string]map[string]interface{}{
+		"key_ids":       keyIDs,
+		"secure_values": secureValues,
+		"types":         types,
+		"values":        values,
+	} {
+		if parameterTreeMapsEqual(diff.Get(k).(map[string]interface{}), v) {
+			continue
+		}
+
+		changed = true
+
+		if err := diff.SetNew(k, v); err != nil {
+			return err
+		}
+	}
+
+	if changed {
+		return diff.SetNewComputed("versions")
+	}
+
+	return nil
+}
+
+func flattenParameterTreeEntries(entries map[string]*parameterTreeEntry) (types, values, secureValues, keyIDs map[string]interface{}) {
+	types = make(map[string]interface{})
+	values = make(map[string]interface{})
+	secureValues = make(map[string]interface{})
+	keyIDs = make(map[string]interface{})
+
+	for name, entry := range entries {
+		types[name] = entry.Type
+
+		if entry.Type == ssm.ParameterTypeSecureString {
+			secureValues[name] = entry.Value
+
+			if entry.KeyID != "" {
+				keyIDs[name] = entry.KeyID
+			}
+		} else {
+			values[name] = entry.Value
+		}
+	}
+
+	return types, values, secureValues, keyIDs
+}
+
+// setParameterTreeEntries sets the parameters that the tree manages, which may not have been known when it was planned.
+func setParameterTreeEntries(d *schema.ResourceData, entries map[string]*parameterTreeEntry) error {
+	types, values, secureValues, keyIDs := flattenParameterTreeEntries(entries)
+
+	if err := d.Set("key_ids", keyIDs); err != nil {
+		return fmt.Errorf("error setting key_ids: %w", err)
+	}
+
+	if err := d.Set("secure_values", secureValues); err != nil {
+		return fmt.Errorf("error setting secure_values: %w", err)
+	}
+
+	if err := d.Set("types", types); err != nil {
+		return fmt.Errorf("error setting types: %w", err)
+	}
+
+	if err := d.Set("values", values); err != nil {
+		return fmt.Errorf("error setting values: %w", err)
+	}
+
+	return nil
+}
+
+func parameterTreeEntriesFromMaps(types, values, secureValues, keyIDs map[string]interface{}) map[string]*parameterTreeEntry {
+	entries := make(map[string]*parameterTreeEntry)
+
+	for name, typ := range types {
+		entry := &parameterTreeEntry{Type: typ.(string)}
+
+		if entry.Type == ssm.ParameterTypeSecureString {
+			entry.Value, _ = secureValues[name].(string)
+			entry.KeyID, _ = keyIDs[name].(string)
+		} else {
+			entry.Value, _ = values[name].(string)
+		}
+
+		entries[name] = entry
+	}
+
+	return entries
+}
+
+func parameterTreeMapsEqual(a, b map[string]interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for k, v := range a {
+		if w, ok := b[k]; !ok || v != w {
+			return false
+		}
+	}
+
+	return true
+}
+
+// putParameterTreeEntries puts parameters concurrently, in batches of parameterTreePutConcurrency requests.
+// It returns the names of the parameters that were written, also if other parameters failed.
+func putParameterTreeEntries(conn *ssm.SSM, entries map[string]*parameterTreeEntry, overwrite map[string]bool) ([]string, error) {
+	names := make([]string, 0, len(entries))
+
+	for name := range entries {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var g multierror.Group
+	var mu sync.Mutex
+	var written []string
+	sem := make(chan struct{}, parameterTreePutConcurrency)
+
+	for _, name := range names {
+		name, entry := name, entries[name]
+
+		sem <- struct{}{}
+		g.Go(func() error {
+			defer func() { <-sem }()
+
+			input := &ssm.PutParameterInput{
+				Name:      aws.String(name),
+				Overwrite: aws.Bool(overwrite[name]),
+				Type:      aws.String(entry.Type),
+				Value:     aws.String(entry.Value),
+			}
+
+			if entry.KeyID != "" {
+				input.KeyId = aws.String(entry.KeyID)
+			}
+
+			log.Printf("[DEBUG] Putting SSM Parameter: %s", name)
+			if _, err := conn.PutParameter(input); err != nil {
+				return fmt.Errorf("error putting SSM Parameter (%s): %w", name, err)
+			}
+
+			mu.Lock()
+			written = append(written, name)
+			mu.Unlock()
+
+			return nil
+		})
+	}
+
+	err := g.Wait().ErrorOrNil()
+
+	sort.Strings(written)
+
+	return written, err
+}
+
+// deleteParameterTreeParameters deletes parameters in batches of parameterTreeDeleteBatchSize names.
+// Parameters that do not exist are ignored.
+func deleteParameterTreeParameters(conn *ssm.SSM, names []string) error {
+	sort.Strings(names)
+
+	for len(names) > 0 {
+		n := len(names)
+
+		if n > parameterTreeDeleteBatchSize {
+			n = parameterTreeDeleteBatchSize
+		}
+
+		batch := names[:n]
+		names = names[n:]
+
+		log.Printf("[DEBUG] Deleting SSM Parameters: %s", strings.Join(batch, ", "))
+		output, err := conn.DeleteParameters(&ssm.DeleteParametersInput{
+			Names: aws.StringSlice(batch),
+		})
+
+		if err != nil {
+			return fmt.Errorf("error deleting SSM Parameters (%s): %w", strings.Join(batch, ", "), err)
+		}
+
+		if output != nil && len(output.InvalidParameters) > 0 {
+			log.Printf("[DEBUG] SSM Parameters not found: %s", strings.Join(aws.StringValueSlice(output.InvalidParameters), ", "))
+		}
+	}
+
+	return nil
+}
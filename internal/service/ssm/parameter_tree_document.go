@@ -0,0 +1,223 @@
+package ssm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/mitchellh/go-homedir"
+	"gopkg.in/yaml.v2"
+)
+
+var parameterTreeKeyRegexp = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+
+// parameterTreeEntry is the desired state of a parameter of a tree.
+type parameterTreeEntry struct {
+	KeyID string
+	Type  string
+	Value string
+}
+
+// expandParameterTree flattens the document of a tree into its parameters, keyed by name.
+func expandParameterTree(d interface{ Get(string) interface{} }) (map[string]*parameterTreeEntry, error) {
+	path := d.Get("path").(string)
+	document := []byte(d.Get("document").(string))
+
+	if v := d.Get("source").(string); v != "" {
+		filename, err := homedir.Expand(v)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if document, err = os.ReadFile(filename); err != nil {
+			return nil, fmt.Errorf("error reading SSM Parameter Tree source (%s): %w", v, err)
+		}
+	}
+
+	v, err := decodeParameterTreeDocument(document)
+
+	if err != nil {
+		return nil, fmt.Errorf("error decoding SSM Parameter Tree (%s) document: %w", path, err)
+	}
+
+	switch v.(type) {
+	case map[string]interface{}, map[interface{}]interface{}:
+	default:
+		return nil, fmt.Errorf("invalid SSM Parameter Tree (%s) document: document must be a map", path)
+	}
+
+	entries := make(map[string]*parameterTreeEntry)
+
+	if err := flattenParameterTreeDocument(path, v, entries); err != nil {
+		return nil, fmt.Errorf("invalid SSM Parameter Tree (%s) document: %w", path, err)
+	}
+
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("invalid SSM Parameter Tree (%s) document: no parameters", path)
+	}
+
+	defaultType := d.Get("type").(string)
+
+	for _, entry := range entries {
+		if defaultType != "" {
+			entry.Type = defaultType
+		}
+	}
+
+	for _, tfMapRaw := range d.Get("parameter").([]interface{}) {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		key := strings.Trim(tfMap["key"].(string), "/")
+		entry, ok := entries[path+"/"+key]
+
+		if !ok {
+			return nil, fmt.Errorf("SSM Parameter Tree (%s) parameter (%s) is not in the document", path, key)
+		}
+
+		if v, ok := tfMap["type"].(string); ok && v != "" {
+			entry.Type = v
+		}
+
+		if v, ok := tfMap["key_id"].(string); ok && v != "" {
+			entry.KeyID = v
+		}
+	}
+
+	for _, entry := range entries {
+		if entry.Type != ssm.ParameterTypeSecureString {
+			entry.KeyID = ""
+		} else if entry.KeyID == "" {
+			entry.KeyID = d.Get("key_id").(string)
+		}
+	}
+
+	return entries, nil
+}
+
+// decodeParameterTreeDocument decodes a JSON or YAML document.
+// JSON numbers are decoded as written, e.g. 1.0 is not changed to 1.
+func decodeParameterTreeDocument(b []byte) (interface{}, error) {
+	if b = bytes.TrimSpace(b); bytes.HasPrefix(b, []byte("{")) {
+		var v interface{}
+		decoder := json.NewDecoder(bytes.NewReader(b))
+		decoder.UseNumber()
+
+		if err := decoder.Decode(&v); err == nil && !decoder.More() {
+			return v, nil
+		}
+	}
+
+	var v interface{}
+
+	if err := yaml.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// flattenParameterTreeDocument adds a parameter for each scalar or list of scalars in a decoded document.
+// Lists are StringList parameters and all other values are String parameters.
+func flattenParameterTreeDocument(name string, v interface{}, entries map[string]*parameterTreeEntry) error {
+	var m map[string]interface{}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m = v
+	case map[interface{}]interface{}:
+		m = make(map[string]interface{}, len(v))
+
+		for k, e := range v {
+			m[fmt.Sprint(k)] = e
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			return fmt.Errorf("%s: empty list", name)
+		}
+
+		items := make([]string, 0, len(v))
+
+		for _, e := range v {
+			item, err := parameterTreeScalarValue(e)
+
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+
+			if strings.Contains(item, ",") {
+				return fmt.Errorf("%s: list item (%s) contains a comma", name, item)
+			}
+
+			items = append(items, item)
+		}
+
+		return addParameterTreeEntry(entries, name, ssm.ParameterTypeStringList, strings.Join(items, ","))
+	default:
+		value, err := parameterTreeScalarValue(v)
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		return addParameterTreeEntry(entries, name, ssm.ParameterTypeString, value)
+	}
+
+	for k, e := range m {
+		if !parameterTreeKeyRegexp.MatchString(k) {
+			return fmt.Errorf("%s: invalid key (%s), keys can only contain letters, numbers, periods, hyphens and underscores", name, k)
+		}
+
+		if err := flattenParameterTreeDocument(name+"/"+k, e, entries); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func addParameterTreeEntry(entries map[string]*parameterTreeEntry, name, typ, value string) error {
+	if value == "" {
+		return fmt.Errorf("%s: empty value", name)
+	}
+
+	if _, ok := entries[name]; ok {
+		return fmt.Errorf("%s: duplicate key", name)
+	}
+
+	entries[name] = &parameterTreeEntry{Type: typ, Value: value}
+
+	return nil
+}
+
+func parameterTreeScalarValue(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case json.Number:
+		return v.String(), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case nil:
+		return "", fmt.Errorf("null value")
+	}
+
+	return "", fmt.Errorf("unsupported value (%v)", v)
+}
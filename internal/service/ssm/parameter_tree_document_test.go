@@ -0,0 +1,197 @@
+package ssm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestExpandParameterTree(t *testing.T) {
+	testCases := []struct {
+		Name                 string
+		Raw                  map[string]interface{}
+		Expected             map[string]parameterTreeEntry
+		ExpectErrorSubstring string
+	}{
+		{
+			Name: "JSON",
+			Raw: map[string]interface{}{
+				"path":     "/app/prod",
+				"document": `{"database": {"host": "db.example.com", "port": 5432, "ratio": 1.50}, "features": ["search", "export"], "debug": false}`,
+			},
+			Expected: map[string]parameterTreeEntry{
+				"/app/prod/database/host":  {Type: ssm.ParameterTypeString, Value: "db.example.com"},
+				"/app/prod/database/port":  {Type: ssm.ParameterTypeString, Value: "5432"},
+				"/app/prod/database/ratio": {Type: ssm.ParameterTypeString, Value: "1.50"},
+				"/app/prod/debug":          {Type: ssm.ParameterTypeString, Value: "false"},
+				"/app/prod/features":       {Type: ssm.ParameterTypeStringList, Value: "search,export"},
+			},
+		},
+		{
+			Name: "YAML source with overrides",
+			Raw: map[string]interface{}{
+				"path":   "/app/prod",
+				"source": "test-fixtures/parameter_tree.yaml",
+				"key_id": "alias/app",
+				"parameter": []interface{}{
+					map[string]interface{}{
+						"key":  "database/password",
+						"type": ssm.ParameterTypeSecureString,
+					},
+					map[string]interface{}{
+						"key":    "/database/host/",
+						"type":   ssm.ParameterTypeSecureString,
+						"key_id": "alias/database",
+					},
+					map[string]interface{}{
+						"key":    "debug",
+						"key_id": "alias/ignored",
+					},
+				},
+			},
+			Expected: map[string]parameterTreeEntry{
+				"/app/prod/database/host":     {KeyID: "alias/database", Type: ssm.ParameterTypeSecureString, Value: "db.example.com"},
+				"/app/prod/database/password": {KeyID: "alias/app", Type: ssm.ParameterTypeSecureString, Value: "correct-horse-battery-staple"},
+				"/app/prod/database/port":     {Type: ssm.ParameterTypeString, Value: "5432"},
+				"/app/prod/debug":             {Type: ssm.ParameterTypeString, Value: "false"},
+				"/app/prod/features":          {Type: ssm.ParameterTypeStringList, Value: "search,export"},
+			},
+		},
+		{
+			Name: "default type",
+			Raw: map[string]interface{}{
+				"path":     "/app",
+				"document": "a: x\nb: [p, q]\n",
+				"type":     ssm.ParameterTypeSecureString,
+			},
+			Expected: map[string]parameterTreeEntry{
+				"/app/a": {Type: ssm.ParameterTypeSecureString, Value: "x"},
+				"/app/b": {Type: ssm.ParameterTypeSecureString, Value: "p,q"},
+			},
+		},
+		{
+			Name: "undefined override",
+			Raw: map[string]interface{}{
+				"path":     "/app",
+				"document": `{"a": "x"}`,
+				"parameter": []interface{}{
+					map[string]interface{}{
+						"key":  "b",
+						"type": ssm.ParameterTypeSecureString,
+					},
+				},
+			},
+			ExpectErrorSubstring: "parameter (b) is not in the document",
+		},
+		{
+			Name: "comma in list",
+			Raw: map[string]interface{}{
+				"path":     "/app",
+				"document": `{"a": ["x,y"]}`,
+			},
+			ExpectErrorSubstring: "/app/a: list item (x,y) contains a comma",
+		},
+		{
+			Name: "nested list",
+			Raw: map[string]interface{}{
+				"path":     "/app",
+				"document": `{"a": [{"b": "c"}]}`,
+			},
+			ExpectErrorSubstring: "/app/a: unsupported value",
+		},
+		{
+			Name: "null",
+			Raw: map[string]interface{}{
+				"path":     "/app",
+				"document": "a:\n  b:\n",
+			},
+			ExpectErrorSubstring: "/app/a/b: null value",
+		},
+		{
+			Name: "empty value",
+			Raw: map[string]interface{}{
+				"path":     "/app",
+				"document": `{"a": ""}`,
+			},
+			ExpectErrorSubstring: "/app/a: empty value",
+		},
+		{
+			Name: "invalid key",
+			Raw: map[string]interface{}{
+				"path":     "/app",
+				"document": `{"a b": "x"}`,
+			},
+			ExpectErrorSubstring: "/app: invalid key (a b)",
+		},
+		{
+			Name: "no parameters",
+			Raw: map[string]interface{}{
+				"path":     "/app",
+				"document": `{}`,
+			},
+			ExpectErrorSubstring: "no parameters",
+		},
+		{
+			Name: "not a map",
+			Raw: map[string]interface{}{
+				"path":     "/app",
+				"document": `value`,
+			},
+			ExpectErrorSubstring: "document must be a map",
+		},
+		{
+			Name: "invalid YAML",
+			Raw: map[string]interface{}{
+				"path":     "/app",
+				"document": "a: [x\n",
+			},
+			ExpectErrorSubstring: "error decoding SSM Parameter Tree (/app) document",
+		},
+		{
+			Name: "missing source",
+			Raw: map[string]interface{}{
+				"path":   "/app",
+				"source": "test-fixtures/missing.yaml",
+			},
+			ExpectErrorSubstring: "error reading SSM Parameter Tree source (test-fixtures/missing.yaml)",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, ResourceParameterTree().Schema, testCase.Raw)
+
+			got, err := expandParameterTree(d)
+
+			if testCase.ExpectErrorSubstring != "" {
+				if err == nil {
+					t.Fatalf("expected error containing %q, got no error", testCase.ExpectErrorSubstring)
+				}
+
+				if !strings.Contains(err.Error(), testCase.ExpectErrorSubstring) {
+					t.Fatalf("expected error containing %q, got: %s", testCase.ExpectErrorSubstring, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if len(got) != len(testCase.Expected) {
+				t.Errorf("got %d parameters, expected %d", len(got), len(testCase.Expected))
+			}
+
+			for name, want := range testCase.Expected {
+				if v, ok := got[name]; !ok {
+					t.Errorf("missing parameter %s", name)
+				} else if *v != want {
+					t.Errorf("got parameter %s %+v, expected %+v", name, *v, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,378 @@
+package ssm_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	tfssm "github.com/hashicorp/terraform-provider-aws/internal/service/ssm"
+)
+
+func TestAccSSMParameterTree_basic(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_ssm_parameter_tree.test"
+	path := "/" + rName
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, ssm.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckParameterTreeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterTreeConfig(rName, `{
+    database = {
+      host     = "db.example.com"
+      port     = 5432
+      password = "correct-horse-battery-staple"
+    }
+    features = ["search", "export"]
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterTreeExists(resourceName, 4),
+					resource.TestCheckResourceAttr(resourceName, "path", path),
+					resource.TestCheckResourceAttr(resourceName, "types.%", "4"),
+					resource.TestCheckResourceAttr(resourceName, "types."+path+"/database/host", ssm.ParameterTypeString),
+					resource.TestCheckResourceAttr(resourceName, "types."+path+"/database/password", ssm.ParameterTypeSecureString),
+					resource.TestCheckResourceAttr(resourceName, "types."+path+"/features", ssm.ParameterTypeStringList),
+					resource.TestCheckResourceAttr(resourceName, "values.%", "3"),
+					resource.TestCheckResourceAttr(resourceName, "values."+path+"/database/port", "5432"),
+					resource.TestCheckResourceAttr(resourceName, "values."+path+"/features", "search,export"),
+					resource.TestCheckResourceAttr(resourceName, "secure_values.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "secure_values."+path+"/database/password", "correct-horse-battery-staple"),
+					resource.TestCheckResourceAttr(resourceName, "versions."+path+"/database/host", "1"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"document", "key_ids", "overwrite", "parameter"},
+			},
+			{
+				Config: testAccParameterTreeConfig(rName, `{
+    database = {
+      host     = "db2.example.com"
+      port     = 5432
+      password = "correct-horse-battery-staple"
+    }
+    debug = true
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterTreeExists(resourceName, 4),
+					resource.TestCheckResourceAttr(resourceName, "types.%", "4"),
+					resource.TestCheckNoResourceAttr(resourceName, "types."+path+"/features"),
+					resource.TestCheckResourceAttr(resourceName, "values."+path+"/database/host", "db2.example.com"),
+					resource.TestCheckResourceAttr(resourceName, "values."+path+"/debug", "true"),
+					resource.TestCheckResourceAttr(resourceName, "versions."+path+"/database/host", "2"),
+					resource.TestCheckResourceAttr(resourceName, "versions."+path+"/database/port", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSSMParameterTree_outOfBandChanges(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_ssm_parameter_tree.test"
+	path := "/" + rName
+	document := `{
+    a = "x"
+    b = "y"
+  }`
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, ssm.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckParameterTreeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterTreeConfig(rName, document),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterTreeExists(resourceName, 2),
+					testAccCheckParameterTreePutParameter(path+"/a", "changed"),
+					testAccCheckParameterTreeDeleteParameter(path+"/b"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccParameterTreeConfig(rName, document),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterTreeExists(resourceName, 2),
+					resource.TestCheckResourceAttr(resourceName, "values."+path+"/a", "x"),
+					resource.TestCheckResourceAttr(resourceName, "values."+path+"/b", "y"),
+					resource.TestCheckResourceAttr(resourceName, "versions."+path+"/a", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSSMParameterTree_source(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_ssm_parameter_tree.test"
+	path := "/" + rName
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, ssm.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckParameterTreeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterTreeSourceConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterTreeExists(resourceName, 5),
+					resource.TestCheckResourceAttr(resourceName, "types."+path+"/database/host", ssm.ParameterTypeSecureString),
+					resource.TestCheckResourceAttr(resourceName, "types."+path+"/database/password", ssm.ParameterTypeSecureString),
+					resource.TestCheckResourceAttrPair(resourceName, "key_ids."+path+"/database/password", "aws_kms_key.test", "arn"),
+					resource.TestCheckResourceAttr(resourceName, "key_ids."+path+"/database/host", "alias/aws/ssm"),
+					resource.TestCheckResourceAttr(resourceName, "values."+path+"/debug", "false"),
+					resource.TestCheckResourceAttr(resourceName, "values."+path+"/features", "search,export"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSSMParameterTree_invalidDocument(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { acctest.PreCheck(t) },
+		ErrorCheck: acctest.ErrorCheck(t, ssm.EndpointsID),
+		Providers:  acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterTreeConfig(rName, `{
+    features = ["a,b"]
+  }`),
+				ExpectError: regexp.MustCompile(`list item \(a,b\) contains a comma`),
+			},
+			{
+				Config: testAccParameterTreeConfig(rName, `{
+    "invalid key" = "x"
+  }`),
+				ExpectError: regexp.MustCompile(`invalid key \(invalid key\)`),
+			},
+		},
+	})
+}
+
+func TestAccSSMParameterTree_overwriteDisabled(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	path := "/" + rName
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, ssm.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckParameterTreeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterTreeExistingParameterConfig(rName),
+			},
+			{
+				Config:      testAccParameterTreeOverwriteDisabledConfig(rName),
+				ExpectError: regexp.MustCompile(ssm.ErrCodeParameterAlreadyExists),
+			},
+			{
+				// The tainted tree is destroyed, which must not delete the parameter that already existed.
+				Config: testAccParameterTreeExistingParameterConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterTreeParameterValue(path+"/a", "existing"),
+					testAccCheckParameterTreeParameterCount(path, 1),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckParameterTreeExists(n string, expectedCount int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No SSM Parameter Tree ID is set")
+		}
+
+		conn := acctest.Provider.Meta().(*conns.AWSClient).SSMConn
+
+		parameters, err := tfssm.FindParametersByPath(conn, rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if len(parameters) != expectedCount {
+			return fmt.Errorf("SSM Parameter Tree (%s) has %d parameters, expected %d", rs.Primary.ID, len(parameters), expectedCount)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckParameterTreeDestroy(s *terraform.State) error {
+	conn := acctest.Provider.Meta().(*conns.AWSClient).SSMConn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_ssm_parameter_tree" {
+			continue
+		}
+
+		parameters, err := tfssm.FindParametersByPath(conn, rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if len(parameters) > 0 {
+			return fmt.Errorf("SSM Parameter Tree %s still has %d parameters", rs.Primary.ID, len(parameters))
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckParameterTreeParameterCount(path string, expectedCount int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).SSMConn
+
+		parameters, err := tfssm.FindParametersByPath(conn, path)
+
+		if err != nil {
+			return err
+		}
+
+		if len(parameters) != expectedCount {
+			return fmt.Errorf("SSM Parameter path (%s) has %d parameters, expected %d", path, len(parameters), expectedCount)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckParameterTreeParameterValue(name, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).SSMConn
+
+		output, err := conn.GetParameter(&ssm.GetParameterInput{
+			Name: aws.String(name),
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if v := aws.StringValue(output.Parameter.Value); v != value {
+			return fmt.Errorf("SSM Parameter (%s) value is %q, expected %q", name, v, value)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckParameterTreePutParameter(name, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).SSMConn
+
+		_, err := conn.PutParameter(&ssm.PutParameterInput{
+			Name:      aws.String(name),
+			Overwrite: aws.Bool(true),
+			Type:      aws.String(ssm.ParameterTypeString),
+			Value:     aws.String(value),
+		})
+
+		return err
+	}
+}
+
+func testAccCheckParameterTreeDeleteParameter(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).SSMConn
+
+		_, err := conn.DeleteParameter(&ssm.DeleteParameterInput{
+			Name: aws.String(name),
+		})
+
+		return err
+	}
+}
+
+func testAccParameterTreeConfig(rName, document string) string {
+	return fmt.Sprintf(`
+resource "aws_ssm_parameter_tree" "test" {
+  path = "/%[1]s"
+
+  document = jsonencode(%[2]s)
+
+  parameter {
+    key  = "database/password"
+    type = "SecureString"
+  }
+}
+`, rName, document)
+}
+
+func testAccParameterTreeSourceConfig(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_kms_key" "test" {
+  description             = %[1]q
+  deletion_window_in_days = 7
+}
+
+resource "aws_ssm_parameter_tree" "test" {
+  path   = "/%[1]s"
+  source = "test-fixtures/parameter_tree.yaml"
+  key_id = "alias/aws/ssm"
+
+  parameter {
+    key    = "database/password"
+    type   = "SecureString"
+    key_id = aws_kms_key.test.arn
+  }
+
+  parameter {
+    key  = "database/host"
+    type = "SecureString"
+  }
+}
+`, rName)
+}
+
+func testAccParameterTreeExistingParameterConfig(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_ssm_parameter" "existing" {
+  name  = "/%[1]s/a"
+  type  = "String"
+  value = "existing"
+}
+`, rName)
+}
+
+func testAccParameterTreeOverwriteDisabledConfig(rName string) string {
+	return acctest.ConfigCompose(testAccParameterTreeExistingParameterConfig(rName), fmt.Sprintf(`
+resource "aws_ssm_parameter_tree" "test" {
+  path      = "/%[1]s"
+  overwrite = false
+
+  document = jsonencode({
+    a = "x"
+    b = "y"
+  })
+
+  depends_on = [aws_ssm_parameter.existing]
+}
+`, rName))
+}
@@ -0,0 +1,183 @@
+package wafv2
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+)
+
+// Web ACL Capacity Unit (WCU) costs of rule statements, as published in the
+// AWS WAF Developer Guide.
+const (
+	wcuByteMatchStatement                   = 2
+	wcuByteMatchStatementContains           = 10
+	wcuGeoMatchStatement                    = 1
+	wcuIPSetReferenceStatement              = 1
+	wcuLabelMatchStatement                  = 1
+	wcuRateBasedStatement                   = 2
+	wcuRegexPatternSetReferenceStatement    = 25
+	wcuSizeConstraintStatement              = 1
+	wcuSqliMatchStatement                   = 20
+	wcuXssMatchStatement                    = 40
+	wcuFieldToMatchAllQueryArguments        = 10
+	wcuTextTransformation                   = 10
+	wcuFieldToMatchJSONBodyBaseCostMultiple = 2
+)
+
+// managedRuleGroupCapacities are the published capacities of the AWS managed
+// rule groups, keyed by vendor name and rule group name.
+var managedRuleGroupCapacities = map[string]int64{
+	"AWS/AWSManagedRulesAdminProtectionRuleSet": 100,
+	"AWS/AWSManagedRulesAmazonIpReputationList": 25,
+	"AWS/AWSManagedRulesAnonymousIpList":        50,
+	"AWS/AWSManagedRulesBotControlRuleSet":      50,
+	"AWS/AWSManagedRulesCommonRuleSet":          700,
+	"AWS/AWSManagedRulesKnownBadInputsRuleSet":  200,
+	"AWS/AWSManagedRulesLinuxRuleSet":           200,
+	"AWS/AWSManagedRulesPHPRuleSet":             100,
+	"AWS/AWSManagedRulesSQLiRuleSet":            200,
+	"AWS/AWSManagedRulesUnixRuleSet":            100,
+	"AWS/AWSManagedRulesWindowsRuleSet":         200,
+	"AWS/AWSManagedRulesWordPressRuleSet":       100,
+}
+
+func managedRuleGroupCapacityKey(vendorName, name string) string {
+	return vendorName + "/" + name
+}
+
+// EstimateRulesCapacity returns the total WCU of the rules.
+// The capacities of managed rule groups and rule groups referenced by the rules
+// are looked up in groupCapacities, keyed by "vendor/name" for managed rule groups
+// and by ARN for rule groups, then in the published managed rule group capacities.
+func EstimateRulesCapacity(rules []*wafv2.Rule, groupCapacities map[string]int64) (int64, error) {
+	var capacity int64
+
+	for _, rule := range rules {
+		if rule == nil {
+			continue
+		}
+
+		v, err := EstimateStatementCapacity(rule.Statement, groupCapacities)
+
+		if err != nil {
+			return 0, fmt.Errorf("rule (%s): %w", aws.StringValue(rule.Name), err)
+		}
+
+		capacity += v
+	}
+
+	return capacity, nil
+}
+
+// EstimateStatementCapacity returns the WCU of a statement, including nested statements.
+func EstimateStatementCapacity(statement *wafv2.Statement, groupCapacities map[string]int64) (int64, error) {
+	if statement == nil {
+		return 0, nil
+	}
+
+	switch {
+	case statement.AndStatement != nil:
+		return estimateStatementsCapacity(statement.AndStatement.Statements, groupCapacities)
+	case statement.OrStatement != nil:
+		return estimateStatementsCapacity(statement.OrStatement.Statements, groupCapacities)
+	case statement.NotStatement != nil:
+		return EstimateStatementCapacity(statement.NotStatement.Statement, groupCapacities)
+	case statement.ByteMatchStatement != nil:
+		v := statement.ByteMatchStatement
+		base := int64(wcuByteMatchStatement)
+		switch aws.StringValue(v.PositionalConstraint) {
+		case wafv2.PositionalConstraintContains, wafv2.PositionalConstraintContainsWord:
+			base = wcuByteMatchStatementContains
+		}
+		return estimateFieldToMatchCapacity(base, v.FieldToMatch, v.TextTransformations), nil
+	case statement.GeoMatchStatement != nil:
+		return wcuGeoMatchStatement, nil
+	case statement.IPSetReferenceStatement != nil:
+		return wcuIPSetReferenceStatement, nil
+	case statement.LabelMatchStatement != nil:
+		return wcuLabelMatchStatement, nil
+	case statement.RegexPatternSetReferenceStatement != nil:
+		v := statement.RegexPatternSetReferenceStatement
+		return estimateFieldToMatchCapacity(wcuRegexPatternSetReferenceStatement, v.FieldToMatch, v.TextTransformations), nil
+	case statement.SizeConstraintStatement != nil:
+		v := statement.SizeConstraintStatement
+		return estimateFieldToMatchCapacity(wcuSizeConstraintStatement, v.FieldToMatch, v.TextTransformations), nil
+	case statement.SqliMatchStatement != nil:
+		v := statement.SqliMatchStatement
+		return estimateFieldToMatchCapacity(wcuSqliMatchStatement, v.FieldToMatch, v.TextTransformations), nil
+	case statement.XssMatchStatement != nil:
+		v := statement.XssMatchStatement
+		return estimateFieldToMatchCapacity(wcuXssMatchStatement, v.FieldToMatch, v.TextTransformations), nil
+	case statement.RateBasedStatement != nil:
+		scopeDown, err := EstimateStatementCapacity(statement.RateBasedStatement.ScopeDownStatement, groupCapacities)
+		if err != nil {
+			return 0, err
+		}
+		return wcuRateBasedStatement + scopeDown, nil
+	case statement.ManagedRuleGroupStatement != nil:
+		v := statement.ManagedRuleGroupStatement
+		key := managedRuleGroupCapacityKey(aws.StringValue(v.VendorName), aws.StringValue(v.Name))
+		capacity, ok := groupCapacities[key]
+		if !ok {
+			capacity, ok = managedRuleGroupCapacities[key]
+		}
+		if !ok {
+			return 0, fmt.Errorf("capacity of managed rule group (%s) is unknown", key)
+		}
+		scopeDown, err := EstimateStatementCapacity(v.ScopeDownStatement, groupCapacities)
+		if err != nil {
+			return 0, err
+		}
+		return capacity + scopeDown, nil
+	case statement.RuleGroupReferenceStatement != nil:
+		arn := aws.StringValue(statement.RuleGroupReferenceStatement.ARN)
+		capacity, ok := groupCapacities[arn]
+		if !ok {
+			return 0, fmt.Errorf("capacity of rule group (%s) is unknown", arn)
+		}
+		return capacity, nil
+	}
+
+	return 0, fmt.Errorf("unsupported statement")
+}
+
+func estimateStatementsCapacity(statements []*wafv2.Statement, groupCapacities map[string]int64) (int64, error) {
+	var capacity int64
+
+	for _, statement := range statements {
+		v, err := EstimateStatementCapacity(statement, groupCapacities)
+
+		if err != nil {
+			return 0, err
+		}
+
+		capacity += v
+	}
+
+	return capacity, nil
+}
+
+// estimateFieldToMatchCapacity adds the costs of the request component and text
+// transformations to the base cost of a match statement.
+func estimateFieldToMatchCapacity(base int64, fieldToMatch *wafv2.FieldToMatch, textTransformations []*wafv2.TextTransformation) int64 {
+	capacity := base
+
+	if fieldToMatch != nil {
+		if fieldToMatch.JsonBody != nil {
+			capacity *= wcuFieldToMatchJSONBodyBaseCostMultiple
+		}
+
+		if fieldToMatch.AllQueryArguments != nil {
+			capacity += wcuFieldToMatchAllQueryArguments
+		}
+	}
+
+	for _, v := range textTransformations {
+		if v != nil && aws.StringValue(v.Type) != wafv2.TextTransformationTypeNone {
+			capacity += wcuTextTransformation
+		}
+	}
+
+	return capacity
+}
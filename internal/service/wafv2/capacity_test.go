@@ -0,0 +1,293 @@
+package wafv2
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+)
+
+func TestEstimateStatementCapacity(t *testing.T) {
+	textTransformation := func(v string) []*wafv2.TextTransformation {
+		return []*wafv2.TextTransformation{{Priority: aws.Int64(0), Type: aws.String(v)}}
+	}
+	ipSetReference := &wafv2.Statement{
+		IPSetReferenceStatement: &wafv2.IPSetReferenceStatement{ARN: aws.String("arn:aws:wafv2:us-west-2:123456789012:regional/ipset/test/a1b2c3d4")},
+	}
+
+	testCases := []struct {
+		Name            string
+		Statement       *wafv2.Statement
+		GroupCapacities map[string]int64
+		Expected        int64
+		ExpectError     bool
+	}{
+		{
+			Name:      "geo match",
+			Statement: &wafv2.Statement{GeoMatchStatement: &wafv2.GeoMatchStatement{CountryCodes: aws.StringSlice([]string{"US"})}},
+			Expected:  1,
+		},
+		{
+			Name: "byte match exactly",
+			Statement: &wafv2.Statement{ByteMatchStatement: &wafv2.ByteMatchStatement{
+				FieldToMatch:         &wafv2.FieldToMatch{UriPath: &wafv2.UriPath{}},
+				PositionalConstraint: aws.String(wafv2.PositionalConstraintExactly),
+				TextTransformations:  textTransformation(wafv2.TextTransformationTypeNone),
+			}},
+			Expected: 2,
+		},
+		{
+			Name: "byte match contains with text transformation",
+			Statement: &wafv2.Statement{ByteMatchStatement: &wafv2.ByteMatchStatement{
+				FieldToMatch:         &wafv2.FieldToMatch{UriPath: &wafv2.UriPath{}},
+				PositionalConstraint: aws.String(wafv2.PositionalConstraintContains),
+				TextTransformations:  textTransformation(wafv2.TextTransformationTypeLowercase),
+			}},
+			Expected: 20,
+		},
+		{
+			Name: "sqli match all query arguments",
+			Statement: &wafv2.Statement{SqliMatchStatement: &wafv2.SqliMatchStatement{
+				FieldToMatch:        &wafv2.FieldToMatch{AllQueryArguments: &wafv2.AllQueryArguments{}},
+				TextTransformations: textTransformation(wafv2.TextTransformationTypeUrlDecode),
+			}},
+			Expected: 40,
+		},
+		{
+			Name: "xss match json body",
+			Statement: &wafv2.Statement{XssMatchStatement: &wafv2.XssMatchStatement{
+				FieldToMatch:        &wafv2.FieldToMatch{JsonBody: &wafv2.JsonBody{}},
+				TextTransformations: textTransformation(wafv2.TextTransformationTypeNone),
+			}},
+			Expected: 80,
+		},
+		{
+			Name: "and not",
+			Statement: &wafv2.Statement{AndStatement: &wafv2.AndStatement{Statements: []*wafv2.Statement{
+				{NotStatement: &wafv2.NotStatement{Statement: ipSetReference}},
+				{LabelMatchStatement: &wafv2.LabelMatchStatement{Key: aws.String("test"), Scope: aws.String(wafv2.LabelMatchScopeLabel)}},
+			}}},
+			Expected: 2,
+		},
+		{
+			Name: "rate based with scope-down",
+			Statement: &wafv2.Statement{RateBasedStatement: &wafv2.RateBasedStatement{
+				Limit:              aws.Int64(1000),
+				ScopeDownStatement: ipSetReference,
+			}},
+			Expected: 3,
+		},
+		{
+			Name: "published managed rule group",
+			Statement: &wafv2.Statement{ManagedRuleGroupStatement: &wafv2.ManagedRuleGroupStatement{
+				Name:       aws.String("AWSManagedRulesCommonRuleSet"),
+				VendorName: aws.String("AWS"),
+			}},
+			Expected: 700,
+		},
+		{
+			Name: "configured managed rule group",
+			Statement: &wafv2.Statement{ManagedRuleGroupStatement: &wafv2.ManagedRuleGroupStatement{
+				Name:       aws.String("Example"),
+				VendorName: aws.String("Vendor"),
+			}},
+			GroupCapacities: map[string]int64{"Vendor/Example": 150},
+			Expected:        150,
+		},
+		{
+			Name: "unknown managed rule group",
+			Statement: &wafv2.Statement{ManagedRuleGroupStatement: &wafv2.ManagedRuleGroupStatement{
+				Name:       aws.String("Example"),
+				VendorName: aws.String("Vendor"),
+			}},
+			ExpectError: true,
+		},
+		{
+			Name:            "rule group reference",
+			Statement:       &wafv2.Statement{RuleGroupReferenceStatement: &wafv2.RuleGroupReferenceStatement{ARN: aws.String("arn:aws:wafv2:us-west-2:123456789012:regional/rulegroup/test/a1b2c3d4")}},
+			GroupCapacities: map[string]int64{"arn:aws:wafv2:us-west-2:123456789012:regional/rulegroup/test/a1b2c3d4": 25},
+			Expected:        25,
+		},
+		{
+			Name:        "unknown rule group reference",
+			Statement:   &wafv2.Statement{RuleGroupReferenceStatement: &wafv2.RuleGroupReferenceStatement{ARN: aws.String("arn:aws:wafv2:us-west-2:123456789012:regional/rulegroup/test/a1b2c3d4")}},
+			ExpectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got, err := EstimateStatementCapacity(testCase.Statement, testCase.GroupCapacities)
+
+			if err == nil && testCase.ExpectError {
+				t.Fatalf("expected error")
+			}
+
+			if err != nil && !testCase.ExpectError {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != testCase.Expected {
+				t.Errorf("got %d, expected %d", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestEstimateRulesCapacity(t *testing.T) {
+	rules := []*wafv2.Rule{
+		{
+			Name:      aws.String("geo"),
+			Statement: &wafv2.Statement{GeoMatchStatement: &wafv2.GeoMatchStatement{CountryCodes: aws.StringSlice([]string{"US"})}},
+		},
+		{
+			Name: aws.String("regex"),
+			Statement: &wafv2.Statement{RegexPatternSetReferenceStatement: &wafv2.RegexPatternSetReferenceStatement{
+				ARN:                 aws.String("arn:aws:wafv2:us-west-2:123456789012:regional/regexpatternset/test/a1b2c3d4"),
+				FieldToMatch:        &wafv2.FieldToMatch{SingleHeader: &wafv2.SingleHeader{Name: aws.String("user-agent")}},
+				TextTransformations: []*wafv2.TextTransformation{{Priority: aws.Int64(0), Type: aws.String(wafv2.TextTransformationTypeNone)}},
+			}},
+		},
+	}
+
+	got, err := EstimateRulesCapacity(rules, nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := int64(26); got != expected {
+		t.Errorf("got %d, expected %d", got, expected)
+	}
+}
+
+func TestResourceRuleGroupCapacityCustomizeDiff(t *testing.T) {
+	visibilityConfig := []interface{}{map[string]interface{}{
+		"cloudwatch_metrics_enabled": false,
+		"metric_name":                "test",
+		"sampled_requests_enabled":   false,
+	}}
+
+	// 50 capacity units.
+	xssRule := map[string]interface{}{
+		"name":     "xss",
+		"priority": 1,
+		"action":   []interface{}{map[string]interface{}{"block": []interface{}{map[string]interface{}{}}}},
+		"statement": []interface{}{map[string]interface{}{
+			"xss_match_statement": []interface{}{map[string]interface{}{
+				"field_to_match":      []interface{}{map[string]interface{}{"body": []interface{}{map[string]interface{}{}}}},
+				"text_transformation": []interface{}{map[string]interface{}{"priority": 1, "type": "URL_DECODE"}},
+			}},
+		}},
+		"visibility_config": visibilityConfig,
+	}
+
+	// 1 capacity unit.
+	geoRule := map[string]interface{}{
+		"name":     "geo",
+		"priority": 2,
+		"action":   []interface{}{map[string]interface{}{"block": []interface{}{map[string]interface{}{}}}},
+		"statement": []interface{}{map[string]interface{}{
+			"geo_match_statement": []interface{}{map[string]interface{}{"country_codes": []interface{}{"US"}}},
+		}},
+		"visibility_config": visibilityConfig,
+	}
+
+	otherGeoRule := make(map[string]interface{}, len(geoRule))
+
+	for k, v := range geoRule {
+		otherGeoRule[k] = v
+	}
+
+	otherGeoRule["name"] = "other-geo"
+
+	ruleGroup := func(capacity int, rules ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"capacity":          capacity,
+			"name":              "test",
+			"scope":             wafv2.ScopeRegional,
+			"rule":              rules,
+			"visibility_config": visibilityConfig,
+		}
+	}
+
+	testCases := []struct {
+		Name                 string
+		State                map[string]interface{}
+		Config               map[string]interface{}
+		ExpectErrorSubstring string
+	}{
+		{
+			Name:   "create",
+			Config: ruleGroup(51, xssRule, geoRule),
+		},
+		{
+			Name:                 "create exceeded",
+			Config:               ruleGroup(50, xssRule, geoRule),
+			ExpectErrorSubstring: "estimated 51 capacity units, which exceeds the capacity of 50",
+		},
+		{
+			Name:   "update",
+			State:  ruleGroup(51, xssRule),
+			Config: ruleGroup(51, xssRule, geoRule),
+		},
+		{
+			Name:                 "update exceeded",
+			State:                ruleGroup(50, xssRule),
+			Config:               ruleGroup(50, xssRule, geoRule),
+			ExpectErrorSubstring: "estimated 51 capacity units, which exceeds the capacity of 50",
+		},
+		{
+			Name:   "update replaced",
+			State:  ruleGroup(51, xssRule, geoRule),
+			Config: ruleGroup(51, xssRule, otherGeoRule),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := ResourceRuleGroup()
+
+			meta := &conns.AWSClient{}
+
+			var state *terraform.InstanceState
+
+			// The state is that of the created rule group, so that unchanged rules have the same set hash code.
+			if testCase.State != nil {
+				diff, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(testCase.State), meta)
+
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				state = &terraform.InstanceState{ID: "test", Attributes: map[string]string{"id": "test"}}
+
+				for k, v := range diff.Attributes {
+					state.Attributes[k] = v.New
+				}
+			}
+
+			_, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(testCase.Config), meta)
+
+			if testCase.ExpectErrorSubstring == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected error containing %q, got no error", testCase.ExpectErrorSubstring)
+			}
+
+			if !strings.Contains(err.Error(), testCase.ExpectErrorSubstring) {
+				t.Fatalf("expected error containing %q, got: %s", testCase.ExpectErrorSubstring, err)
+			}
+		})
+	}
+}
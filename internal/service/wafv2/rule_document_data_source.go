@@ -0,0 +1,835 @@
+package wafv2
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/private/protocol/json/jsonutil"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-aws/internal/create"
+	"github.com/hashicorp/terraform-provider-aws/internal/flex"
+	"github.com/hashicorp/terraform-provider-aws/internal/verify"
+)
+
+const (
+	ruleDocumentActionAllow = "allow"
+	ruleDocumentActionBlock = "block"
+	ruleDocumentActionCount = "count"
+	ruleDocumentActionNone  = "none"
+
+	ruleDocumentFieldToMatchAllQueryArguments   = "ALL_QUERY_ARGUMENTS"
+	ruleDocumentFieldToMatchBody                = "BODY"
+	ruleDocumentFieldToMatchMethod              = "METHOD"
+	ruleDocumentFieldToMatchQueryString         = "QUERY_STRING"
+	ruleDocumentFieldToMatchSingleHeader        = "SINGLE_HEADER"
+	ruleDocumentFieldToMatchSingleQueryArgument = "SINGLE_QUERY_ARGUMENT"
+	ruleDocumentFieldToMatchURIPath             = "URI_PATH"
+)
+
+// ruleDocumentStatementTypes are the typed blocks of a statement, exactly one of which must be set.
+var ruleDocumentStatementTypes = []string{
+	"and_statement",
+	"byte_match_statement",
+	"geo_match_statement",
+	"ip_set_reference_statement",
+	"label_match_statement",
+	"managed_rule_group_statement",
+	"not_statement",
+	"or_statement",
+	"rate_based_statement",
+	"regex_pattern_set_reference_statement",
+	"rule_group_reference_statement",
+	"size_constraint_statement",
+	"sqli_match_statement",
+	"xss_match_statement",
+}
+
+func ruleDocumentFieldToMatch_Values() []string {
+	return []string{
+		ruleDocumentFieldToMatchAllQueryArguments,
+		ruleDocumentFieldToMatchBody,
+		ruleDocumentFieldToMatchMethod,
+		ruleDocumentFieldToMatchQueryString,
+		ruleDocumentFieldToMatchSingleHeader,
+		ruleDocumentFieldToMatchSingleQueryArgument,
+		ruleDocumentFieldToMatchURIPath,
+	}
+}
+
+func DataSourceRuleDocument() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRuleDocumentRead,
+
+		Schema: map[string]*schema.Schema{
+			"capacity": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"max_capacity": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"rule": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								ruleDocumentActionAllow,
+								ruleDocumentActionBlock,
+								ruleDocumentActionCount,
+							}, false),
+						},
+						"capacity": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"cloudwatch_metrics_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"metric_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ValidateFunc: validation.All(
+								validation.StringLenBetween(1, 128),
+								validation.StringMatch(regexp.MustCompile(`^[a-zA-Z0-9-_]+$`), "must contain only alphanumeric hyphen and underscore characters"),
+							),
+						},
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringLenBetween(1, 128),
+						},
+						"override_action": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								ruleDocumentActionCount,
+								ruleDocumentActionNone,
+							}, false),
+						},
+						"priority": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"rule_labels": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringLenBetween(1, 1024),
+							},
+						},
+						"sampled_requests_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"statement_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"statement": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"and_statement": ruleDocumentStatementIDsSchema(),
+						"byte_match_statement": ruleDocumentFieldToMatchStatementSchema(map[string]*schema.Schema{
+							"positional_constraint": {
+								Type:         schema.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringInSlice(wafv2.PositionalConstraint_Values(), false),
+							},
+							"search_string": {
+								Type:         schema.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringLenBetween(1, 50),
+							},
+						}),
+						"geo_match_statement": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"country_codes": {
+										Type:     schema.TypeList,
+										Required: true,
+										MinItems: 1,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+						"id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"ip_set_reference_statement": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"arn": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: verify.ValidARN,
+									},
+								},
+							},
+						},
+						"label_match_statement": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"key": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringLenBetween(1, 1024),
+									},
+									"scope": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice(wafv2.LabelMatchScope_Values(), false),
+									},
+								},
+							},
+						},
+						"managed_rule_group_statement": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"capacity": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+									"excluded_rules": ruleDocumentExcludedRulesSchema(),
+									"name": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringLenBetween(1, 128),
+									},
+									"scope_down_statement_id": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"vendor_name": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringLenBetween(1, 128),
+									},
+									"version": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validation.StringLenBetween(1, 64),
+									},
+								},
+							},
+						},
+						"not_statement": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"statement_id": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+						"or_statement": ruleDocumentStatementIDsSchema(),
+						"rate_based_statement": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"aggregate_key_type": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Default:      wafv2.RateBasedStatementAggregateKeyTypeIp,
+										ValidateFunc: validation.StringInSlice(wafv2.RateBasedStatementAggregateKeyType_Values(), false),
+									},
+									"limit": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntBetween(100, 2000000000),
+									},
+									"scope_down_statement_id": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
+						"regex_pattern_set_reference_statement": ruleDocumentFieldToMatchStatementSchema(map[string]*schema.Schema{
+							"arn": {
+								Type:         schema.TypeString,
+								Required:     true,
+								ValidateFunc: verify.ValidARN,
+							},
+						}),
+						"rule_group_reference_statement": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"arn": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: verify.ValidARN,
+									},
+									"capacity": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+									"excluded_rules": ruleDocumentExcludedRulesSchema(),
+								},
+							},
+						},
+						"size_constraint_statement": ruleDocumentFieldToMatchStatementSchema(map[string]*schema.Schema{
+							"comparison_operator": {
+								Type:         schema.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringInSlice(wafv2.ComparisonOperator_Values(), false),
+							},
+							"size": {
+								Type:         schema.TypeInt,
+								Required:     true,
+								ValidateFunc: validation.IntBetween(0, math.MaxInt32),
+							},
+						}),
+						"sqli_match_statement": ruleDocumentFieldToMatchStatementSchema(nil),
+						"xss_match_statement":  ruleDocumentFieldToMatchStatementSchema(nil),
+					},
+				},
+			},
+		},
+	}
+}
+
+func ruleDocumentStatementIDsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"statement_ids": {
+					Type:     schema.TypeList,
+					Required: true,
+					MinItems: 2,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+func ruleDocumentExcludedRulesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 100,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringLenBetween(1, 128),
+		},
+	}
+}
+
+// ruleDocumentFieldToMatchStatementSchema returns the schema of a statement that inspects
+// a request component, with the statement specific arguments in s.
+func ruleDocumentFieldToMatchStatementSchema(s map[string]*schema.Schema) *schema.Schema {
+	m := map[string]*schema.Schema{
+		"field_name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringLenBetween(1, 40),
+		},
+		"field_to_match": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice(ruleDocumentFieldToMatch_Values(), false),
+		},
+		"text_transformations": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice(wafv2.TextTransformationType_Values(), false),
+			},
+		},
+	}
+
+	for k, v := range s {
+		m[k] = v
+	}
+
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: m,
+		},
+	}
+}
+
+func dataSourceRuleDocumentRead(d *schema.ResourceData, meta interface{}) error {
+	statements := make(map[string]map[string]interface{})
+
+	for _, tfMapRaw := range d.Get("statement").([]interface{}) {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		id := tfMap["id"].(string)
+
+		if _, ok := statements[id]; ok {
+			return fmt.Errorf("duplicate WAFv2 rule document statement (%s)", id)
+		}
+
+		statements[id] = tfMap
+	}
+
+	var rules []*wafv2.Rule
+	var capacity int64
+	tfList := d.Get("rule").([]interface{})
+	names := make(map[string]struct{})
+	priorities := make(map[int]struct{})
+
+	for i, tfMapRaw := range tfList {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		name := tfMap["name"].(string)
+
+		if _, ok := names[name]; ok {
+			return fmt.Errorf("duplicate WAFv2 rule document rule name (%s)", name)
+		}
+
+		names[name] = struct{}{}
+
+		if _, ok := priorities[tfMap["priority"].(int)]; ok {
+			return fmt.Errorf("WAFv2 rule document rule (%s): duplicate priority (%d)", name, tfMap["priority"].(int))
+		}
+
+		priorities[tfMap["priority"].(int)] = struct{}{}
+
+		rule, err := expandRuleDocumentRule(tfMap, statements)
+
+		if err != nil {
+			return fmt.Errorf("WAFv2 rule document rule (%s): %w", name, err)
+		}
+
+		ruleCapacity, err := EstimateStatementCapacity(rule.Statement, ruleDocumentGroupCapacities(statements))
+
+		if err != nil {
+			return fmt.Errorf("error estimating WAFv2 rule document rule (%s) capacity: %w", name, err)
+		}
+
+		tfMap["capacity"] = int(ruleCapacity)
+		tfList[i] = tfMap
+		capacity += ruleCapacity
+		rules = append(rules, rule)
+	}
+
+	if v, ok := d.GetOk("max_capacity"); ok && capacity > int64(v.(int)) {
+		return fmt.Errorf("WAFv2 rule document rules require an estimated %d capacity units, which exceeds the maximum capacity of %d", capacity, v.(int))
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return aws.Int64Value(rules[i].Priority) < aws.Int64Value(rules[j].Priority)
+	})
+
+	b, err := jsonutil.BuildJSON(rules)
+
+	if err != nil {
+		return fmt.Errorf("error encoding WAFv2 rule document: %w", err)
+	}
+
+	var jsonDoc bytes.Buffer
+
+	if err := json.Indent(&jsonDoc, b, "", "  "); err != nil {
+		return fmt.Errorf("error encoding WAFv2 rule document: %w", err)
+	}
+
+	jsonString := jsonDoc.String()
+
+	d.SetId(strconv.Itoa(create.StringHashcode(jsonString)))
+	d.Set("capacity", capacity)
+	d.Set("json", jsonString)
+
+	if err := d.Set("rule", tfList); err != nil {
+		return fmt.Errorf("error setting rule: %w", err)
+	}
+
+	return nil
+}
+
+// ruleDocumentGroupCapacities returns the capacities configured for the managed rule groups
+// and rule groups referenced by the statements.
+func ruleDocumentGroupCapacities(statements map[string]map[string]interface{}) map[string]int64 {
+	capacities := make(map[string]int64)
+
+	for _, tfMap := range statements {
+		if v, ok := tfMap["managed_rule_group_statement"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			m := v[0].(map[string]interface{})
+
+			if v, ok := m["capacity"].(int); ok && v > 0 {
+				capacities[managedRuleGroupCapacityKey(m["vendor_name"].(string), m["name"].(string))] = int64(v)
+			}
+		}
+
+		if v, ok := tfMap["rule_group_reference_statement"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			m := v[0].(map[string]interface{})
+
+			capacities[m["arn"].(string)] = int64(m["capacity"].(int))
+		}
+	}
+
+	return capacities
+}
+
+func expandRuleDocumentRule(tfMap map[string]interface{}, statements map[string]map[string]interface{}) (*wafv2.Rule, error) {
+	statement, err := expandRuleDocumentStatement(statements, tfMap["statement_id"].(string), true, make(map[string]bool))
+
+	if err != nil {
+		return nil, err
+	}
+
+	name := tfMap["name"].(string)
+	metricName := name
+
+	if v, ok := tfMap["metric_name"].(string); ok && v != "" {
+		metricName = v
+	}
+
+	apiObject := &wafv2.Rule{
+		Name:      aws.String(name),
+		Priority:  aws.Int64(int64(tfMap["priority"].(int))),
+		Statement: statement,
+		VisibilityConfig: &wafv2.VisibilityConfig{
+			CloudWatchMetricsEnabled: aws.Bool(tfMap["cloudwatch_metrics_enabled"].(bool)),
+			MetricName:               aws.String(metricName),
+			SampledRequestsEnabled:   aws.Bool(tfMap["sampled_requests_enabled"].(bool)),
+		},
+	}
+
+	action := tfMap["action"].(string)
+	overrideAction := tfMap["override_action"].(string)
+
+	// Rule groups are referenced with an override action, all other statements with an action.
+	if statement.ManagedRuleGroupStatement != nil || statement.RuleGroupReferenceStatement != nil {
+		if action != "" {
+			return nil, fmt.Errorf("action cannot be set for a rule group statement, use override_action")
+		}
+
+		switch overrideAction {
+		case ruleDocumentActionCount:
+			apiObject.OverrideAction = &wafv2.OverrideAction{Count: &wafv2.CountAction{}}
+		default:
+			apiObject.OverrideAction = &wafv2.OverrideAction{None: &wafv2.NoneAction{}}
+		}
+	} else {
+		if overrideAction != "" {
+			return nil, fmt.Errorf("override_action can only be set for a rule group statement")
+		}
+
+		switch action {
+		case ruleDocumentActionAllow:
+			apiObject.Action = &wafv2.RuleAction{Allow: &wafv2.AllowAction{}}
+		case ruleDocumentActionBlock:
+			apiObject.Action = &wafv2.RuleAction{Block: &wafv2.BlockAction{}}
+		case ruleDocumentActionCount:
+			apiObject.Action = &wafv2.RuleAction{Count: &wafv2.CountAction{}}
+		default:
+			return nil, fmt.Errorf("action is required")
+		}
+	}
+
+	for _, v := range tfMap["rule_labels"].([]interface{}) {
+		apiObject.RuleLabels = append(apiObject.RuleLabels, &wafv2.Label{Name: aws.String(v.(string))})
+	}
+
+	return apiObject, nil
+}
+
+// expandRuleDocumentStatement returns the statement with the specified ID, with the statements
+// that it references expanded in place. Rate-based, managed rule group and rule group reference
+// statements can only be the root statement of a rule.
+func expandRuleDocumentStatement(statements map[string]map[string]interface{}, id string, root bool, visiting map[string]bool) (*wafv2.Statement, error) {
+	tfMap, ok := statements[id]
+
+	if !ok {
+		return nil, fmt.Errorf("reference to undefined statement (%s)", id)
+	}
+
+	if visiting[id] {
+		return nil, fmt.Errorf("statement (%s) is part of a reference cycle", id)
+	}
+
+	visiting[id] = true
+	defer delete(visiting, id)
+
+	var statementType string
+	var m map[string]interface{}
+
+	for _, k := range ruleDocumentStatementTypes {
+		if v, ok := tfMap[k].([]interface{}); ok && len(v) > 0 {
+			if statementType != "" {
+				return nil, fmt.Errorf("statement (%s) sets both %s and %s", id, statementType, k)
+			}
+
+			statementType = k
+			m, _ = v[0].(map[string]interface{})
+		}
+	}
+
+	if statementType == "" {
+		return nil, fmt.Errorf("statement (%s) must set one of %v", id, ruleDocumentStatementTypes)
+	}
+
+	if m == nil {
+		m = make(map[string]interface{})
+	}
+
+	apiObject := &wafv2.Statement{}
+
+	switch statementType {
+	case "and_statement":
+		v, err := expandRuleDocumentStatements(statements, m["statement_ids"].([]interface{}), visiting)
+		if err != nil {
+			return nil, err
+		}
+		apiObject.AndStatement = &wafv2.AndStatement{Statements: v}
+	case "byte_match_statement":
+		apiObject.ByteMatchStatement = &wafv2.ByteMatchStatement{
+			FieldToMatch:         expandRuleDocumentFieldToMatch(m),
+			PositionalConstraint: aws.String(m["positional_constraint"].(string)),
+			SearchString:         []byte(m["search_string"].(string)),
+			TextTransformations:  expandRuleDocumentTextTransformations(m["text_transformations"].([]interface{})),
+		}
+	case "geo_match_statement":
+		apiObject.GeoMatchStatement = &wafv2.GeoMatchStatement{
+			CountryCodes: flex.ExpandStringList(m["country_codes"].([]interface{})),
+		}
+	case "ip_set_reference_statement":
+		apiObject.IPSetReferenceStatement = &wafv2.IPSetReferenceStatement{
+			ARN: aws.String(m["arn"].(string)),
+		}
+	case "label_match_statement":
+		apiObject.LabelMatchStatement = &wafv2.LabelMatchStatement{
+			Key:   aws.String(m["key"].(string)),
+			Scope: aws.String(m["scope"].(string)),
+		}
+	case "managed_rule_group_statement":
+		if !root {
+			return nil, fmt.Errorf("statement (%s): %s can only be the statement of a rule", id, statementType)
+		}
+		v, err := expandRuleDocumentScopeDownStatement(statements, m, visiting)
+		if err != nil {
+			return nil, err
+		}
+		apiObject.ManagedRuleGroupStatement = &wafv2.ManagedRuleGroupStatement{
+			ExcludedRules:      expandRuleDocumentExcludedRules(m["excluded_rules"].([]interface{})),
+			Name:               aws.String(m["name"].(string)),
+			ScopeDownStatement: v,
+			VendorName:         aws.String(m["vendor_name"].(string)),
+		}
+		if v, ok := m["version"].(string); ok && v != "" {
+			apiObject.ManagedRuleGroupStatement.Version = aws.String(v)
+		}
+	case "not_statement":
+		v, err := expandRuleDocumentStatement(statements, m["statement_id"].(string), false, visiting)
+		if err != nil {
+			return nil, err
+		}
+		apiObject.NotStatement = &wafv2.NotStatement{Statement: v}
+	case "or_statement":
+		v, err := expandRuleDocumentStatements(statements, m["statement_ids"].([]interface{}), visiting)
+		if err != nil {
+			return nil, err
+		}
+		apiObject.OrStatement = &wafv2.OrStatement{Statements: v}
+	case "rate_based_statement":
+		if !root {
+			return nil, fmt.Errorf("statement (%s): %s can only be the statement of a rule", id, statementType)
+		}
+		v, err := expandRuleDocumentScopeDownStatement(statements, m, visiting)
+		if err != nil {
+			return nil, err
+		}
+		apiObject.RateBasedStatement = &wafv2.RateBasedStatement{
+			AggregateKeyType:   aws.String(m["aggregate_key_type"].(string)),
+			Limit:              aws.Int64(int64(m["limit"].(int))),
+			ScopeDownStatement: v,
+		}
+	case "regex_pattern_set_reference_statement":
+		apiObject.RegexPatternSetReferenceStatement = &wafv2.RegexPatternSetReferenceStatement{
+			ARN:                 aws.String(m["arn"].(string)),
+			FieldToMatch:        expandRuleDocumentFieldToMatch(m),
+			TextTransformations: expandRuleDocumentTextTransformations(m["text_transformations"].([]interface{})),
+		}
+	case "rule_group_reference_statement":
+		if !root {
+			return nil, fmt.Errorf("statement (%s): %s can only be the statement of a rule", id, statementType)
+		}
+		apiObject.RuleGroupReferenceStatement = &wafv2.RuleGroupReferenceStatement{
+			ARN:           aws.String(m["arn"].(string)),
+			ExcludedRules: expandRuleDocumentExcludedRules(m["excluded_rules"].([]interface{})),
+		}
+	case "size_constraint_statement":
+		apiObject.SizeConstraintStatement = &wafv2.SizeConstraintStatement{
+			ComparisonOperator:  aws.String(m["comparison_operator"].(string)),
+			FieldToMatch:        expandRuleDocumentFieldToMatch(m),
+			Size:                aws.Int64(int64(m["size"].(int))),
+			TextTransformations: expandRuleDocumentTextTransformations(m["text_transformations"].([]interface{})),
+		}
+	case "sqli_match_statement":
+		apiObject.SqliMatchStatement = &wafv2.SqliMatchStatement{
+			FieldToMatch:        expandRuleDocumentFieldToMatch(m),
+			TextTransformations: expandRuleDocumentTextTransformations(m["text_transformations"].([]interface{})),
+		}
+	case "xss_match_statement":
+		apiObject.XssMatchStatement = &wafv2.XssMatchStatement{
+			FieldToMatch:        expandRuleDocumentFieldToMatch(m),
+			TextTransformations: expandRuleDocumentTextTransformations(m["text_transformations"].([]interface{})),
+		}
+	}
+
+	if v, ok := m["field_to_match"].(string); ok {
+		switch v {
+		case ruleDocumentFieldToMatchSingleHeader, ruleDocumentFieldToMatchSingleQueryArgument:
+			if m["field_name"].(string) == "" {
+				return nil, fmt.Errorf("statement (%s): field_name is required when field_to_match is %s", id, v)
+			}
+		default:
+			if m["field_name"].(string) != "" {
+				return nil, fmt.Errorf("statement (%s): field_name can only be set when field_to_match is %s or %s", id, ruleDocumentFieldToMatchSingleHeader, ruleDocumentFieldToMatchSingleQueryArgument)
+			}
+		}
+	}
+
+	return apiObject, nil
+}
+
+func expandRuleDocumentStatements(statements map[string]map[string]interface{}, ids []interface{}, visiting map[string]bool) ([]*wafv2.Statement, error) {
+	var apiObjects []*wafv2.Statement
+
+	for _, id := range ids {
+		v, err := expandRuleDocumentStatement(statements, id.(string), false, visiting)
+
+		if err != nil {
+			return nil, err
+		}
+
+		apiObjects = append(apiObjects, v)
+	}
+
+	return apiObjects, nil
+}
+
+func expandRuleDocumentScopeDownStatement(statements map[string]map[string]interface{}, m map[string]interface{}, visiting map[string]bool) (*wafv2.Statement, error) {
+	id, ok := m["scope_down_statement_id"].(string)
+
+	if !ok || id == "" {
+		return nil, nil
+	}
+
+	return expandRuleDocumentStatement(statements, id, false, visiting)
+}
+
+func expandRuleDocumentFieldToMatch(m map[string]interface{}) *wafv2.FieldToMatch {
+	apiObject := &wafv2.FieldToMatch{}
+	name := m["field_name"].(string)
+
+	switch m["field_to_match"].(string) {
+	case ruleDocumentFieldToMatchAllQueryArguments:
+		apiObject.AllQueryArguments = &wafv2.AllQueryArguments{}
+	case ruleDocumentFieldToMatchBody:
+		apiObject.Body = &wafv2.Body{}
+	case ruleDocumentFieldToMatchMethod:
+		apiObject.Method = &wafv2.Method{}
+	case ruleDocumentFieldToMatchQueryString:
+		apiObject.QueryString = &wafv2.QueryString{}
+	case ruleDocumentFieldToMatchSingleHeader:
+		apiObject.SingleHeader = &wafv2.SingleHeader{Name: aws.String(name)}
+	case ruleDocumentFieldToMatchSingleQueryArgument:
+		apiObject.SingleQueryArgument = &wafv2.SingleQueryArgument{Name: aws.String(name)}
+	case ruleDocumentFieldToMatchURIPath:
+		apiObject.UriPath = &wafv2.UriPath{}
+	}
+
+	return apiObject
+}
+
+// expandRuleDocumentTextTransformations returns the text transformations in the order that they are applied.
+// WAF requires at least one text transformation, so NONE is used when there are none.
+func expandRuleDocumentTextTransformations(tfList []interface{}) []*wafv2.TextTransformation {
+	if len(tfList) == 0 {
+		tfList = []interface{}{wafv2.TextTransformationTypeNone}
+	}
+
+	apiObjects := make([]*wafv2.TextTransformation, 0, len(tfList))
+
+	for i, v := range tfList {
+		apiObjects = append(apiObjects, &wafv2.TextTransformation{
+			Priority: aws.Int64(int64(i)),
+			Type:     aws.String(v.(string)),
+		})
+	}
+
+	return apiObjects
+}
+
+func expandRuleDocumentExcludedRules(tfList []interface{}) []*wafv2.ExcludedRule {
+	var apiObjects []*wafv2.ExcludedRule
+
+	for _, v := range tfList {
+		apiObjects = append(apiObjects, &wafv2.ExcludedRule{Name: aws.String(v.(string))})
+	}
+
+	return apiObjects
+}
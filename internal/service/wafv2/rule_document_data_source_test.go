@@ -0,0 +1,321 @@
+package wafv2_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+)
+
+func TestAccWAFV2RuleDocumentDataSource_basic(t *testing.T) {
+	var v wafv2.WebACL
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	dataSourceName := "data.aws_wafv2_rule_document.test"
+	resourceName := "aws_wafv2_web_acl.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t); testAccPreCheckScopeRegional(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, wafv2.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckWebACLDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRuleDocumentDataSourceConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "capacity", "723"),
+					resource.TestCheckResourceAttr(dataSourceName, "rule.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "rule.0.capacity", "700"),
+					resource.TestCheckResourceAttr(dataSourceName, "rule.1.capacity", "23"),
+					resource.TestCheckResourceAttr(dataSourceName, "json", testAccRuleDocumentExpectedJSON),
+					testAccCheckWebACLExists(resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "rule.#", "0"),
+					resource.TestCheckResourceAttrPair(resourceName, "rule_json", dataSourceName, "json"),
+				),
+			},
+			{
+				Config:   testAccRuleDocumentDataSourceConfig(rName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccWAFV2RuleDocumentDataSource_maxCapacity(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { acctest.PreCheck(t) },
+		ErrorCheck: acctest.ErrorCheck(t, wafv2.EndpointsID),
+		Providers:  acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRuleDocumentDataSourceConfig_maxCapacity,
+				ExpectError: regexp.MustCompile(`require an estimated 40 capacity units, which exceeds the maximum capacity of 30`),
+			},
+		},
+	})
+}
+
+func TestAccWAFV2RuleDocumentDataSource_invalid(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { acctest.PreCheck(t) },
+		ErrorCheck: acctest.ErrorCheck(t, wafv2.EndpointsID),
+		Providers:  acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRuleDocumentDataSourceConfig_undefinedStatement,
+				ExpectError: regexp.MustCompile(`reference to undefined statement \(missing\)`),
+			},
+			{
+				Config:      testAccRuleDocumentDataSourceConfig_cycle,
+				ExpectError: regexp.MustCompile(`statement \(first\) is part of a reference cycle`),
+			},
+		},
+	})
+}
+
+const testAccRuleDocumentExpectedJSON = `[
+  {
+    "Action": {
+      "Block": {}
+    },
+    "Name": "block-sqli",
+    "Priority": 1,
+    "Statement": {
+      "AndStatement": {
+        "Statements": [
+          {
+            "NotStatement": {
+              "Statement": {
+                "GeoMatchStatement": {
+                  "CountryCodes": [
+                    "US"
+                  ]
+                }
+              }
+            }
+          },
+          {
+            "SqliMatchStatement": {
+              "FieldToMatch": {
+                "QueryString": {}
+              },
+              "TextTransformations": [
+                {
+                  "Priority": 0,
+                  "Type": "NONE"
+                }
+              ]
+            }
+          },
+          {
+            "ByteMatchStatement": {
+              "FieldToMatch": {
+                "UriPath": {}
+              },
+              "PositionalConstraint": "STARTS_WITH",
+              "SearchString": "L2FwaQ==",
+              "TextTransformations": [
+                {
+                  "Priority": 0,
+                  "Type": "NONE"
+                }
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "VisibilityConfig": {
+      "CloudWatchMetricsEnabled": false,
+      "MetricName": "block-sqli",
+      "SampledRequestsEnabled": false
+    }
+  },
+  {
+    "Name": "common",
+    "OverrideAction": {
+      "None": {}
+    },
+    "Priority": 2,
+    "Statement": {
+      "ManagedRuleGroupStatement": {
+        "ExcludedRules": [
+          {
+            "Name": "SizeRestrictions_QUERYSTRING"
+          }
+        ],
+        "Name": "AWSManagedRulesCommonRuleSet",
+        "VendorName": "AWS"
+      }
+    },
+    "VisibilityConfig": {
+      "CloudWatchMetricsEnabled": false,
+      "MetricName": "common-metric",
+      "SampledRequestsEnabled": false
+    }
+  }
+]`
+
+func testAccRuleDocumentDataSourceConfig(rName string) string {
+	return fmt.Sprintf(`
+data "aws_wafv2_rule_document" "test" {
+  max_capacity = 1500
+
+  statement {
+    id = "outside_us"
+
+    not_statement {
+      statement_id = "us"
+    }
+  }
+
+  statement {
+    id = "us"
+
+    geo_match_statement {
+      country_codes = ["US"]
+    }
+  }
+
+  statement {
+    id = "sqli"
+
+    sqli_match_statement {
+      field_to_match = "QUERY_STRING"
+    }
+  }
+
+  statement {
+    id = "api"
+
+    byte_match_statement {
+      field_to_match        = "URI_PATH"
+      positional_constraint = "STARTS_WITH"
+      search_string         = "/api"
+    }
+  }
+
+  statement {
+    id = "block_sqli"
+
+    and_statement {
+      statement_ids = ["outside_us", "sqli", "api"]
+    }
+  }
+
+  statement {
+    id = "common"
+
+    managed_rule_group_statement {
+      name           = "AWSManagedRulesCommonRuleSet"
+      vendor_name    = "AWS"
+      excluded_rules = ["SizeRestrictions_QUERYSTRING"]
+    }
+  }
+
+  rule {
+    name                       = "common"
+    priority                   = 2
+    statement_id               = "common"
+    metric_name                = "common-metric"
+    cloudwatch_metrics_enabled = false
+    sampled_requests_enabled   = false
+  }
+
+  rule {
+    name                       = "block-sqli"
+    priority                   = 1
+    statement_id               = "block_sqli"
+    action                     = "block"
+    cloudwatch_metrics_enabled = false
+    sampled_requests_enabled   = false
+  }
+}
+
+resource "aws_wafv2_web_acl" "test" {
+  name      = %[1]q
+  scope     = "REGIONAL"
+  rule_json = data.aws_wafv2_rule_document.test.json
+
+  default_action {
+    allow {}
+  }
+
+  visibility_config {
+    cloudwatch_metrics_enabled = false
+    metric_name                = "friendly-metric-name"
+    sampled_requests_enabled   = false
+  }
+}
+`, rName)
+}
+
+const testAccRuleDocumentDataSourceConfig_maxCapacity = `
+data "aws_wafv2_rule_document" "test" {
+  max_capacity = 30
+
+  statement {
+    id = "xss"
+
+    xss_match_statement {
+      field_to_match = "BODY"
+    }
+  }
+
+  rule {
+    name         = "xss"
+    priority     = 1
+    statement_id = "xss"
+    action       = "block"
+  }
+}
+`
+
+const testAccRuleDocumentDataSourceConfig_undefinedStatement = `
+data "aws_wafv2_rule_document" "test" {
+  statement {
+    id = "not_missing"
+
+    not_statement {
+      statement_id = "missing"
+    }
+  }
+
+  rule {
+    name         = "test"
+    priority     = 1
+    statement_id = "not_missing"
+    action       = "block"
+  }
+}
+`
+
+const testAccRuleDocumentDataSourceConfig_cycle = `
+data "aws_wafv2_rule_document" "test" {
+  statement {
+    id = "first"
+
+    not_statement {
+      statement_id = "second"
+    }
+  }
+
+  statement {
+    id = "second"
+
+    not_statement {
+      statement_id = "first"
+    }
+  }
+
+  rule {
+    name         = "test"
+    priority     = 1
+    statement_id = "first"
+    action       = "block"
+  }
+}
+`
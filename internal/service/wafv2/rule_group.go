@@ -1,15 +1,18 @@
 package wafv2
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/wafv2"
 	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -117,10 +120,83 @@ func ResourceRuleGroup() *schema.Resource {
 			"visibility_config": wafv2VisibilityConfigSchema(),
 		},
 
-		CustomizeDiff: verify.SetTagsDiff,
+		CustomizeDiff: customdiff.Sequence(
+			resourceRuleGroupCapacityCustomizeDiff,
+			verify.SetTagsDiff,
+		),
 	}
 }
 
+// resourceRuleGroupCapacityCustomizeDiff fails the plan when the estimated
+// capacity of the rules exceeds the capacity of the rule group.
+func resourceRuleGroupCapacityCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() != "" && !diff.HasChange("capacity") && !diff.HasChange("rule") {
+		return nil
+	}
+
+	if !diff.NewValueKnown("capacity") || !diff.NewValueKnown("rule") {
+		return nil
+	}
+
+	estimate, err := EstimateRulesCapacity(expandWafv2RuleGroupDiffRules(diff), nil)
+
+	if err != nil {
+		return fmt.Errorf("error estimating WAFv2 RuleGroup capacity: %w", err)
+	}
+
+	if capacity := int64(diff.Get("capacity").(int)); estimate > capacity {
+		return fmt.Errorf("WAFv2 RuleGroup rules require an estimated %d capacity units, which exceeds the capacity of %d", estimate, capacity)
+	}
+
+	return nil
+}
+
+// expandWafv2RuleGroupDiffRules returns all the planned rules of a rule group.
+// Reading the whole rule set from a ResourceDiff drops the nested blocks of changed rules,
+// so the rules are read one at a time by their set hash code: the codes of the current rules,
+// which are unchanged unless they are in the diff, and the codes of the changed rules.
+func expandWafv2RuleGroupDiffRules(diff *schema.ResourceDiff) []*wafv2.Rule {
+	if !diff.HasChange("rule") {
+		return expandWafv2Rules(diff.Get("rule").(*schema.Set).List())
+	}
+
+	codes := make(map[string]struct{})
+
+	o, _ := diff.GetChange("rule")
+	os := o.(*schema.Set)
+
+	for _, tfMapRaw := range os.List() {
+		code := os.F(tfMapRaw)
+
+		if code < 0 {
+			code = -code
+		}
+
+		codes[strconv.Itoa(code)] = struct{}{}
+	}
+
+	for _, k := range diff.GetChangedKeysPrefix("rule") {
+		if parts := strings.Split(k, "."); len(parts) > 2 {
+			codes[parts[1]] = struct{}{}
+		}
+	}
+
+	tfList := make([]interface{}, 0, len(codes))
+
+	for code := range codes {
+		tfMap, ok := diff.Get("rule." + code).(map[string]interface{})
+
+		// Rules removed from the set are still in the diff, without a name.
+		if !ok || tfMap["name"].(string) == "" {
+			continue
+		}
+
+		tfList = append(tfList, tfMap)
+	}
+
+	return expandWafv2Rules(tfList)
+}
+
 func resourceRuleGroupCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*conns.AWSClient).WAFV2Conn
 	defaultTagsConfig := meta.(*conns.AWSClient).DefaultTagsConfig
@@ -1,13 +1,16 @@
 package wafv2
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/private/protocol/json/jsonutil"
 	"github.com/aws/aws-sdk-go/service/wafv2"
 	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -96,8 +99,9 @@ func ResourceWebACL() *schema.Resource {
 				}, false),
 			},
 			"rule": {
-				Type:     schema.TypeSet,
-				Optional: true,
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"rule_json"},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"action": {
@@ -138,6 +142,13 @@ func ResourceWebACL() *schema.Resource {
 					},
 				},
 			},
+			"rule_json": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ConflictsWith:    []string{"rule"},
+				ValidateFunc:     validWebACLRulesJSON,
+				DiffSuppressFunc: suppressEquivalentWebACLRulesJSON,
+			},
 			"tags":              tftags.TagsSchema(),
 			"tags_all":          tftags.TagsSchemaComputed(),
 			"visibility_config": wafv2VisibilityConfigSchema(),
@@ -153,11 +164,17 @@ func resourceWebACLCreate(d *schema.ResourceData, meta interface{}) error {
 	tags := defaultTagsConfig.MergeTags(tftags.New(d.Get("tags").(map[string]interface{})))
 	var resp *wafv2.CreateWebACLOutput
 
+	rules, err := expandWafv2WebACLRulesFromConfig(d)
+
+	if err != nil {
+		return err
+	}
+
 	params := &wafv2.CreateWebACLInput{
 		Name:             aws.String(d.Get("name").(string)),
 		Scope:            aws.String(d.Get("scope").(string)),
 		DefaultAction:    expandWafv2DefaultAction(d.Get("default_action").([]interface{})),
-		Rules:            expandWafv2WebACLRules(d.Get("rule").(*schema.Set).List()),
+		Rules:            rules,
 		VisibilityConfig: expandWafv2VisibilityConfig(d.Get("visibility_config").([]interface{})),
 	}
 
@@ -173,7 +190,7 @@ func resourceWebACLCreate(d *schema.ResourceData, meta interface{}) error {
 		params.Tags = Tags(tags.IgnoreAWS())
 	}
 
-	err := resource.Retry(Wafv2WebACLCreateTimeout, func() *resource.RetryError {
+	err = resource.Retry(Wafv2WebACLCreateTimeout, func() *resource.RetryError {
 		var err error
 		resp, err = conn.CreateWebACL(params)
 		if err != nil {
@@ -241,7 +258,19 @@ func resourceWebACLRead(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error setting default_action: %w", err)
 	}
 
-	if err := d.Set("rule", flattenWafv2WebACLRules(resp.WebACL.Rules)); err != nil {
+	// Rules configured as JSON are only stored in rule_json, as they would otherwise show as a difference to rule.
+	if v, ok := d.GetOk("rule_json"); ok {
+		rulesJSON, err := normalizeWafv2WebACLRulesJSON(resp.WebACL.Rules)
+
+		if err != nil {
+			return fmt.Errorf("Error encoding WAFv2 WebACL rules: %w", err)
+		}
+
+		// Keep the configured formatting when the rules are unchanged.
+		if !suppressEquivalentWebACLRulesJSON("rule_json", v.(string), rulesJSON, d) {
+			d.Set("rule_json", rulesJSON)
+		}
+	} else if err := d.Set("rule", flattenWafv2WebACLRules(resp.WebACL.Rules)); err != nil {
 		return fmt.Errorf("Error setting rule: %w", err)
 	}
 
@@ -272,14 +301,20 @@ func resourceWebACLRead(d *schema.ResourceData, meta interface{}) error {
 func resourceWebACLUpdate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*conns.AWSClient).WAFV2Conn
 
-	if d.HasChanges("custom_response_body", "default_action", "description", "rule", "visibility_config") {
+	if d.HasChanges("custom_response_body", "default_action", "description", "rule", "rule_json", "visibility_config") {
+		rules, err := expandWafv2WebACLRulesFromConfig(d)
+
+		if err != nil {
+			return err
+		}
+
 		u := &wafv2.UpdateWebACLInput{
 			Id:               aws.String(d.Id()),
 			Name:             aws.String(d.Get("name").(string)),
 			Scope:            aws.String(d.Get("scope").(string)),
 			LockToken:        aws.String(d.Get("lock_token").(string)),
 			DefaultAction:    expandWafv2DefaultAction(d.Get("default_action").([]interface{})),
-			Rules:            expandWafv2WebACLRules(d.Get("rule").(*schema.Set).List()),
+			Rules:            rules,
 			VisibilityConfig: expandWafv2VisibilityConfig(d.Get("visibility_config").([]interface{})),
 		}
 
@@ -291,7 +326,7 @@ func resourceWebACLUpdate(d *schema.ResourceData, meta interface{}) error {
 			u.Description = aws.String(v.(string))
 		}
 
-		err := resource.Retry(Wafv2WebACLUpdateTimeout, func() *resource.RetryError {
+		err = resource.Retry(Wafv2WebACLUpdateTimeout, func() *resource.RetryError {
 			_, err := conn.UpdateWebACL(u)
 			if err != nil {
 				if tfawserr.ErrMessageContains(err, wafv2.ErrCodeWAFUnavailableEntityException, "") {
@@ -498,6 +533,129 @@ func wafv2RuleGroupReferenceStatementSchema() *schema.Schema {
 	}
 }
 
+// expandWafv2WebACLRulesFromConfig returns the rules configured in either rule or rule_json.
+func expandWafv2WebACLRulesFromConfig(d *schema.ResourceData) ([]*wafv2.Rule, error) {
+	if v, ok := d.GetOk("rule_json"); ok {
+		rules, err := expandWafv2WebACLRulesJSON(v.(string))
+
+		if err != nil {
+			return nil, fmt.Errorf("error decoding rule_json: %w", err)
+		}
+
+		return rules, nil
+	}
+
+	return expandWafv2WebACLRules(d.Get("rule").(*schema.Set).List()), nil
+}
+
+// expandWafv2WebACLRulesJSON decodes rules in the JSON form of the WAFv2 API,
+// e.g. as generated by the aws_wafv2_rule_document data source.
+// As in the API, blob fields such as ByteMatchStatement.SearchString are base64-encoded.
+func expandWafv2WebACLRulesJSON(s string) ([]*wafv2.Rule, error) {
+	// The API's JSON protocol ignores unknown fields, so these are checked first.
+	decoder := json.NewDecoder(strings.NewReader(s))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&[]*wafv2.Rule{}); err != nil {
+		return nil, err
+	}
+
+	var rules []*wafv2.Rule
+
+	if err := jsonutil.UnmarshalJSON(&rules, strings.NewReader(s)); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// normalizeWafv2WebACLRulesJSON returns the JSON form of rules sorted by priority,
+// without the empty lists that the API returns for unset arguments.
+func normalizeWafv2WebACLRulesJSON(rules []*wafv2.Rule) (string, error) {
+	sorted := make([]*wafv2.Rule, len(rules))
+	copy(sorted, rules)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return aws.Int64Value(sorted[i].Priority) < aws.Int64Value(sorted[j].Priority)
+	})
+
+	b, err := jsonutil.BuildJSON(sorted)
+
+	if err != nil {
+		return "", err
+	}
+
+	var v interface{}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return "", err
+	}
+
+	b, err = json.Marshal(removeWafv2EmptyJSONLists(v))
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+func removeWafv2EmptyJSONLists(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if l, ok := e.([]interface{}); ok && len(l) == 0 {
+				delete(v, k)
+				continue
+			}
+
+			v[k] = removeWafv2EmptyJSONLists(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = removeWafv2EmptyJSONLists(e)
+		}
+	}
+
+	return v
+}
+
+func validWebACLRulesJSON(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := expandWafv2WebACLRulesJSON(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q contains invalid WAFv2 rules JSON: %w", k, err))
+	}
+
+	return
+}
+
+func suppressEquivalentWebACLRulesJSON(k, old, new string, d *schema.ResourceData) bool {
+	oldRules, err := expandWafv2WebACLRulesJSON(old)
+
+	if err != nil {
+		return false
+	}
+
+	newRules, err := expandWafv2WebACLRulesJSON(new)
+
+	if err != nil {
+		return false
+	}
+
+	oldJSON, err := normalizeWafv2WebACLRulesJSON(oldRules)
+
+	if err != nil {
+		return false
+	}
+
+	newJSON, err := normalizeWafv2WebACLRulesJSON(newRules)
+
+	if err != nil {
+		return false
+	}
+
+	return oldJSON == newJSON
+}
+
 func expandWafv2WebACLRules(l []interface{}) []*wafv2.Rule {
 	if len(l) == 0 || l[0] == nil {
 		return nil
@@ -1518,6 +1518,81 @@ func TestAccWAFV2WebACL_Operators_maxNested(t *testing.T) {
 	})
 }
 
+func TestAccWAFV2WebACL_ruleJSON(t *testing.T) {
+	var v wafv2.WebACL
+	webACLName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_wafv2_web_acl.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t); testAccPreCheckScopeRegional(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, wafv2.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckWebACLDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWebACLConfig_RuleJSON(webACLName, "US"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebACLExists(resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "rule.#", "0"),
+					resource.TestMatchResourceAttr(resourceName, "rule_json", regexp.MustCompile(`"CountryCodes":\["US"\]`)),
+				),
+			},
+			{
+				Config: testAccWebACLConfig_RuleJSON(webACLName, "NL"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebACLExists(resourceName, &v),
+					resource.TestMatchResourceAttr(resourceName, "rule_json", regexp.MustCompile(`"CountryCodes":\["NL"\]`)),
+				),
+			},
+			{
+				Config:      testAccWebACLConfig_RuleJSONInvalid(webACLName),
+				ExpectError: regexp.MustCompile(`contains invalid WAFv2 rules JSON`),
+			},
+		},
+	})
+}
+
+func TestAccWAFV2WebACL_ruleJSONSearchString(t *testing.T) {
+	var v wafv2.WebACL
+	webACLName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_wafv2_web_acl.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t); testAccPreCheckScopeRegional(t) },
+		ErrorCheck:   acctest.ErrorCheck(t, wafv2.EndpointsID),
+		Providers:    acctest.Providers,
+		CheckDestroy: testAccCheckWebACLDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWebACLConfig_RuleJSONSearchString(webACLName, `base64encode("badbot")`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebACLExists(resourceName, &v),
+					testAccCheckWebACLByteMatchSearchString(&v, "badbot"),
+				),
+			},
+			// Blobs are base64-encoded, as in the WAFv2 API.
+			{
+				Config:      testAccWebACLConfig_RuleJSONSearchString(webACLName, `"bad bot"`),
+				ExpectError: regexp.MustCompile(`illegal base64 data`),
+			},
+		},
+	})
+}
+
+func testAccCheckWebACLByteMatchSearchString(v *wafv2.WebACL, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if len(v.Rules) != 1 || v.Rules[0].Statement == nil || v.Rules[0].Statement.ByteMatchStatement == nil {
+			return fmt.Errorf("expected a single WAFv2 WebACL byte match rule, got: %s", v.Rules)
+		}
+
+		if got := string(v.Rules[0].Statement.ByteMatchStatement.SearchString); got != expected {
+			return fmt.Errorf("got search string %q, expected %q", got, expected)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckWebACLDestroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "aws_wafv2_web_acl" {
@@ -2729,6 +2804,115 @@ resource "aws_wafv2_web_acl" "test" {
 `, name)
 }
 
+func testAccWebACLConfig_RuleJSON(name, countryCode string) string {
+	return fmt.Sprintf(`
+resource "aws_wafv2_web_acl" "test" {
+  name  = %[1]q
+  scope = "REGIONAL"
+
+  rule_json = jsonencode([{
+    Name     = "rule-1"
+    Priority = 1
+    Action = {
+      Block = {}
+    }
+    Statement = {
+      GeoMatchStatement = {
+        CountryCodes = [%[2]q]
+      }
+    }
+    VisibilityConfig = {
+      CloudWatchMetricsEnabled = false
+      MetricName               = "friendly-rule-metric-name"
+      SampledRequestsEnabled   = false
+    }
+  }])
+
+  default_action {
+    allow {}
+  }
+
+  visibility_config {
+    cloudwatch_metrics_enabled = false
+    metric_name                = "friendly-metric-name"
+    sampled_requests_enabled   = false
+  }
+}
+`, name, countryCode)
+}
+
+func testAccWebACLConfig_RuleJSONSearchString(name, searchString string) string {
+	return fmt.Sprintf(`
+resource "aws_wafv2_web_acl" "test" {
+  name  = %[1]q
+  scope = "REGIONAL"
+
+  rule_json = jsonencode([{
+    Name     = "rule-1"
+    Priority = 1
+    Action = {
+      Block = {}
+    }
+    Statement = {
+      ByteMatchStatement = {
+        FieldToMatch = {
+          SingleHeader = {
+            Name = "user-agent"
+          }
+        }
+        PositionalConstraint = "CONTAINS"
+        SearchString         = %[2]s
+        TextTransformations = [{
+          Priority = 0
+          Type     = "LOWERCASE"
+        }]
+      }
+    }
+    VisibilityConfig = {
+      CloudWatchMetricsEnabled = false
+      MetricName               = "friendly-rule-metric-name"
+      SampledRequestsEnabled   = false
+    }
+  }])
+
+  default_action {
+    allow {}
+  }
+
+  visibility_config {
+    cloudwatch_metrics_enabled = false
+    metric_name                = "friendly-metric-name"
+    sampled_requests_enabled   = false
+  }
+}
+`, name, searchString)
+}
+
+func testAccWebACLConfig_RuleJSONInvalid(name string) string {
+	return fmt.Sprintf(`
+resource "aws_wafv2_web_acl" "test" {
+  name  = %[1]q
+  scope = "REGIONAL"
+
+  rule_json = jsonencode([{
+    Name          = "rule-1"
+    Priority      = 1
+    UnknownAction = {}
+  }])
+
+  default_action {
+    allow {}
+  }
+
+  visibility_config {
+    cloudwatch_metrics_enabled = false
+    metric_name                = "friendly-metric-name"
+    sampled_requests_enabled   = false
+  }
+}
+`, name)
+}
+
 func testAccWebACLConfig_OneTag(name, tagKey, tagValue string) string {
 	return fmt.Sprintf(`
 resource "aws_wafv2_web_acl" "test" {
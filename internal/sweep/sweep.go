@@ -59,24 +59,28 @@ func SharedRegionalSweepClient(region string) (interface{}, error) {
 	}
 
 	if role := os.Getenv(conns.EnvVarAssumeRoleARN); role != "" {
-		conf.AssumeRoleARN = role
+		assumeRole := conns.AssumeRole{
+			DurationSeconds: defaultSweeperAssumeRoleDurationSeconds,
+			RoleARN:         role,
+		}
 
-		conf.AssumeRoleDurationSeconds = defaultSweeperAssumeRoleDurationSeconds
 		if v := os.Getenv(conns.EnvVarAssumeRoleDuration); v != "" {
 			d, err := strconv.Atoi(v)
 			if err != nil {
 				return nil, fmt.Errorf("environment variable %s: %w", conns.EnvVarAssumeRoleDuration, err)
 			}
-			conf.AssumeRoleDurationSeconds = d
+			assumeRole.DurationSeconds = d
 		}
 
 		if v := os.Getenv(conns.EnvVarAssumeRoleExternalID); v != "" {
-			conf.AssumeRoleExternalID = v
+			assumeRole.ExternalID = v
 		}
 
 		if v := os.Getenv(conns.EnvVarAssumeRoleSessionName); v != "" {
-			conf.AssumeRoleSessionName = v
+			assumeRole.SessionName = v
 		}
+
+		conf.AssumeRole = []conns.AssumeRole{assumeRole}
 	}
 
 	// configures a default client for the region, using the above env vars